@@ -46,9 +46,53 @@ func main() {
 	// Product routes
 	r.Post("/products", hdl.CreateProduct)
 	r.Get("/products", hdl.ListProducts)
+	r.Get("/products/search", hdl.SearchProducts)
+	r.Get("/products/deleted", hdl.ListDeletedProducts)
+	r.Get("/products/by-barcode/{gtin}", hdl.GetProductByBarcode)
+	r.Get("/products/sku/{sku}", hdl.GetProductBySKU)
 	r.Get("/products/{id}", hdl.GetProduct)
 	r.Put("/products/{id}", hdl.UpdateProduct)
+	r.Patch("/products/{id}", hdl.PatchProduct)
 	r.Delete("/products/{id}", hdl.DeleteProduct)
+	r.Post("/products/{id}:restore", hdl.RestoreProduct)
+	r.Post("/products/{id}/movements", hdl.RecordStockMovement)
+	r.Get("/products/{id}/movements", hdl.ListStockMovements)
+	r.Post("/products/{id}/reservations", hdl.ReserveStock)
+	r.Post("/reservations/{id}/commit", hdl.CommitReservation)
+	r.Delete("/reservations/{id}", hdl.ReleaseReservation)
+	r.Post("/products/{id}/transfers", hdl.TransferStock)
+	r.Get("/stock/expiring", hdl.ListExpiringStock)
+	r.Post("/warehouses", hdl.CreateWarehouse)
+	r.Get("/warehouses", hdl.ListWarehouses)
+	r.Get("/warehouses/{id}", hdl.GetWarehouse)
+	r.Put("/warehouses/{id}", hdl.UpdateWarehouse)
+	r.Delete("/warehouses/{id}", hdl.DeleteWarehouse)
+	r.Post("/categories", hdl.CreateCategory)
+	r.Get("/categories", hdl.ListCategories)
+	r.Get("/categories/{id}", hdl.GetCategory)
+	r.Post("/categories/{id}/move", hdl.MoveCategory)
+	r.Put("/categories/{id}/attributes", hdl.SetCategoryAttributes)
+	r.Put("/categories/{id}/sku-prefix", hdl.SetCategorySKUPrefix)
+	r.Post("/suppliers", hdl.CreateSupplier)
+	r.Get("/suppliers", hdl.ListSuppliers)
+	r.Get("/suppliers/{id}", hdl.GetSupplier)
+	r.Put("/suppliers/{id}", hdl.UpdateSupplier)
+	r.Delete("/suppliers/{id}", hdl.DeleteSupplier)
+	r.Get("/products/{id}/suppliers", hdl.ListProductSuppliers)
+	r.Put("/products/{id}/suppliers/{supplierId}", hdl.LinkProductSupplier)
+	r.Delete("/products/{id}/suppliers/{supplierId}", hdl.UnlinkProductSupplier)
+	r.Post("/pricelists", hdl.CreatePriceList)
+	r.Get("/pricelists", hdl.ListPriceLists)
+	r.Get("/pricelists/{id}", hdl.GetPriceList)
+	r.Put("/pricelists/{id}", hdl.UpdatePriceList)
+	r.Get("/pricelists/{id}/products/{productId}", hdl.GetPriceTiers)
+	r.Put("/pricelists/{id}/products/{productId}", hdl.SetPriceTiers)
+	r.Get("/products/{id}/price", hdl.QuotePrice)
+	r.Post("/auctions", hdl.CreateAuction)
+	r.Get("/auctions", hdl.ListAuctions)
+	r.Get("/auctions/{id}", hdl.GetAuction)
+	r.Post("/auctions/{id}/bids", hdl.SubmitBid)
+	r.Get("/auctions/{id}/bids", hdl.ListBids)
 
 	// Health check
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
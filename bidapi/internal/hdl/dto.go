@@ -1,49 +1,752 @@
 package hdl
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"time"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type ProductDTO struct {
+	ID          string              `json:"id"`
+	SKU         string              `json:"sku,omitempty"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Price       MoneyDTO            `json:"price"`
+	Quantity    int32               `json:"quantity"`
+	Reserved    int32               `json:"reserved"`
+	Available   int32               `json:"available"`
+	Stock       []WarehouseStockDTO `json:"stock"`
+	CategoryID  string              `json:"category_id"`
+	Units       UnitsOfMeasureDTO   `json:"units"`
+	Batches     []BatchDTO          `json:"batches"`
+	ParentID    string              `json:"parent_id,omitempty"`
+	Attributes  map[string]string   `json:"attributes,omitempty"`
+	// Allergens maps the declared allergens to contains, may_contain or
+	// free_from, an allergen left out is unknown
+	Allergens   map[string]string `json:"allergens,omitempty"`
+	Nutrition   *NutritionDTO     `json:"nutrition,omitempty"`
+	DietaryTags []string          `json:"dietary_tags,omitempty"`
+	Barcodes    []BarcodeDTO      `json:"barcodes,omitempty"`
+	// Variants is only filled in for a single parent product
+	Variants  []ProductDTO `json:"variants,omitempty"`
+	Version   int64        `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	// DeletedAt is set while the product is in the trash
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// MoneyDTO is an exact amount in the minor units of an ISO-4217 currency,
+// 999.99 USD is {"currency_code": "USD", "amount_minor": 99999}
+type MoneyDTO struct {
+	CurrencyCode string `json:"currency_code"`
+	AmountMinor  int64  `json:"amount_minor"`
+}
+
+// proto returns the amount as a protobuf Money, nil when absent
+func (m *MoneyDTO) proto() *pb.Money {
+	if m == nil {
+		return nil
+	}
+	return &pb.Money{CurrencyCode: m.CurrencyCode, AmountMinor: m.AmountMinor}
+}
+
+// PriceInput is an amount in a request body, a MoneyDTO or a bare number in
+// USD as sent by clients written before amounts had a currency
+type PriceInput struct {
+	Money  *MoneyDTO
+	Legacy float64
+}
+
+func (p *PriceInput) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		p.Money = new(MoneyDTO)
+		return json.Unmarshal(b, p.Money)
+	}
+	return json.Unmarshal(b, &p.Legacy)
+}
+
+// positive reports whether the amount is greater than zero
+func (p PriceInput) positive() bool {
+	if p.Money != nil {
+		return p.Money.AmountMinor > 0
+	}
+	return p.Legacy > 0
+}
+
+// proto returns the amount as a protobuf Money, nil for a legacy number,
+// which travels in the deprecated double field
+func (p PriceInput) proto() *pb.Money {
+	if p.Money == nil {
+		return nil
+	}
+	return &pb.Money{CurrencyCode: p.Money.CurrencyCode, AmountMinor: p.Money.AmountMinor}
+}
+
+// UnitsOfMeasureDTO is how the stock of a product is counted: quantities
+// are whole numbers of base_unit, conversions define the other units
+type UnitsOfMeasureDTO struct {
+	BaseUnit    string              `json:"base_unit"`
+	Conversions []UnitConversionDTO `json:"conversions"`
+}
+
+// UnitConversionDTO defines a unit as factor base units, a fractional unit
+// such as kg takes quantities with a fraction
+type UnitConversionDTO struct {
+	Code       string  `json:"code"`
+	Factor     float64 `json:"factor"`
+	Fractional bool    `json:"fractional"`
+}
+
+// proto returns the units as protobuf units of measure, nil when absent
+func (u *UnitsOfMeasureDTO) proto() *pb.UnitsOfMeasure {
+	if u == nil {
+		return nil
+	}
+	out := &pb.UnitsOfMeasure{BaseUnit: u.BaseUnit}
+	for _, c := range u.Conversions {
+		out.Conversions = append(out.Conversions, &pb.UnitConversion{Code: c.Code, Factor: c.Factor, Fractional: c.Fractional})
+	}
+	return out
+}
+
+// MeasureDTO is a quantity in a unit of a product, 1.5 kg is
+// {"value": 1.5, "unit": "kg"}
+type MeasureDTO struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// QuantityInput is a quantity in a request body, a bare number of base
+// units of the product or a MeasureDTO in any of its units
+type QuantityInput struct {
+	Measure *MeasureDTO
+	Count   int32
+}
+
+func (q *QuantityInput) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		q.Measure = new(MeasureDTO)
+		return json.Unmarshal(b, q.Measure)
+	}
+	return json.Unmarshal(b, &q.Count)
+}
+
+// measure returns the quantity as a protobuf Measure, nil for a number of
+// base units, which travels in the quantity field
+func (q QuantityInput) measure() *pb.Measure {
+	if q.Measure == nil {
+		return nil
+	}
+	return &pb.Measure{Value: q.Measure.Value, Unit: q.Measure.Unit}
+}
+
+// AttributesInput are attribute values in a request body by name, each a
+// string, number or boolean, as in {"size_g": 200, "vegan": false}
+type AttributesInput map[string]string
+
+func (a *AttributesInput) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*a = make(AttributesInput, len(raw))
+	for name, v := range raw {
+		var value any
+		if err := json.Unmarshal(v, &value); err != nil {
+			return err
+		}
+		switch value := value.(type) {
+		case string:
+			(*a)[name] = value
+		case float64, bool:
+			(*a)[name] = string(v)
+		default:
+			return fmt.Errorf("attribute %s must be a string, number or boolean", name)
+		}
+	}
+	return nil
+}
+
+// proto returns the values as protobuf attribute values ordered by name
+func (a AttributesInput) proto() []*pb.AttributeValue {
+	var out []*pb.AttributeValue
+	for _, name := range slices.Sorted(maps.Keys(a)) {
+		out = append(out, &pb.AttributeValue{Name: name, Value: a[name]})
+	}
+	return out
+}
+
+// AttributeDefinitionDTO defines an attribute of the products in a
+// category, Type is text, number, boolean or enum
+type AttributeDefinitionDTO struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	Required      bool     `json:"required"`
+}
+
+// AllergensInput are allergen declarations in a request body, as in
+// {"milk": "contains", "peanut": "free_from"}
+type AllergensInput map[string]string
+
+// proto returns the declarations as protobuf allergen declarations ordered
+// by allergen
+func (a AllergensInput) proto() []*pb.AllergenDeclaration {
+	var out []*pb.AllergenDeclaration
+	for _, al := range slices.Sorted(maps.Keys(a)) {
+		out = append(out, &pb.AllergenDeclaration{Allergen: al, Status: a[al]})
+	}
+	return out
+}
+
+// NutritionDTO are the nutrition facts per 100 g, or 100 ml of a drink, in
+// grams except for the energy
+type NutritionDTO struct {
+	EnergyKJ     float64 `json:"energy_kj"`
+	EnergyKcal   float64 `json:"energy_kcal"`
+	Fat          float64 `json:"fat"`
+	SaturatedFat float64 `json:"saturated_fat"`
+	Carbohydrate float64 `json:"carbohydrate"`
+	Sugars       float64 `json:"sugars"`
+	Fibre        float64 `json:"fibre"`
+	Protein      float64 `json:"protein"`
+	Salt         float64 `json:"salt"`
+}
+
+// proto returns the facts as protobuf nutrition, nil when absent
+func (n *NutritionDTO) proto() *pb.Nutrition {
+	if n == nil {
+		return nil
+	}
+	return &pb.Nutrition{
+		EnergyKj:     n.EnergyKJ,
+		EnergyKcal:   n.EnergyKcal,
+		Fat:          n.Fat,
+		SaturatedFat: n.SaturatedFat,
+		Carbohydrate: n.Carbohydrate,
+		Sugars:       n.Sugars,
+		Fibre:        n.Fibre,
+		Protein:      n.Protein,
+		Salt:         n.Salt,
+	}
+}
+
+// BarcodeDTO is a GTIN on a product or one of its packs, a unit such as
+// case for a pack-level GTIN-14 and the base unit when unit is left out
+type BarcodeDTO struct {
+	GTIN string `json:"gtin"`
+	Unit string `json:"unit,omitempty"`
+}
+
+// barcodesProto converts barcodes in a request body to their protobuf form
+func barcodesProto(barcodes []BarcodeDTO) []*pb.Barcode {
+	var out []*pb.Barcode
+	for _, b := range barcodes {
+		out = append(out, &pb.Barcode{Gtin: b.GTIN, Unit: b.Unit})
+	}
+	return out
+}
+
+// ProductByBarcodeDTO is a product found by barcode with the barcode that
+// matched, whose unit tells a scanned case from a single item
+type ProductByBarcodeDTO struct {
+	Product ProductDTO `json:"product"`
+	Barcode BarcodeDTO `json:"barcode"`
+}
+
+type WarehouseStockDTO struct {
+	WarehouseID string `json:"warehouse_id"`
+	Quantity    int32  `json:"quantity"`
+	Reserved    int32  `json:"reserved"`
+	Available   int32  `json:"available"`
+}
+
+// BatchDTO is the stock of one lot in a warehouse, a lot without
+// expires_at does not expire
+type BatchDTO struct {
+	WarehouseID string     `json:"warehouse_id"`
+	LotCode     string     `json:"lot_code"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ReceivedAt  time.Time  `json:"received_at"`
+	Quantity    int32      `json:"quantity"`
+	Reserved    int32      `json:"reserved"`
+	Available   int32      `json:"available"`
+}
+
+// LotQuantityDTO is the part of a movement or reservation in one lot
+type LotQuantityDTO struct {
+	LotCode  string `json:"lot_code"`
+	Quantity int32  `json:"quantity"`
+}
+
+// CreateProductRequest is the body of POST /products and PUT /products/{id},
+// a product without units is counted in pieces. ParentID makes a new
+// product a variant of that product and SKU chooses its SKU, PUT ignores
+// both as well as the quantity.
+type CreateProductRequest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Price       PriceInput         `json:"price"`
+	Quantity    QuantityInput      `json:"quantity"`
+	CategoryID  string             `json:"category_id"`
+	Units       *UnitsOfMeasureDTO `json:"units"`
+	ParentID    string             `json:"parent_id"`
+	Attributes  AttributesInput    `json:"attributes"`
+	Allergens   AllergensInput     `json:"allergens"`
+	Nutrition   *NutritionDTO      `json:"nutrition"`
+	DietaryTags []string           `json:"dietary_tags"`
+	Barcodes    []BarcodeDTO       `json:"barcodes"`
+	SKU         string             `json:"sku"`
+}
+
+// ProductMergePatch is the body of PATCH /products/{id}. A member that is
+// absent stays as it is, a null member is removed.
+type ProductMergePatch map[string]json.RawMessage
+
+type ListProductsResponse struct {
+	Products      []ProductDTO `json:"products"`
+	Total         int32        `json:"total"`
+	Page          int32        `json:"page"`
+	PageSize      int32        `json:"page_size"`
+	NextPageToken string       `json:"next_page_token,omitempty"`
+}
+
+type SearchResultDTO struct {
+	Product              ProductDTO `json:"product"`
+	Score                float64    `json:"score"`
+	NameHighlight        string     `json:"name_highlight,omitempty"`
+	DescriptionHighlight string     `json:"description_highlight,omitempty"`
+}
+
+type SearchProductsResponse struct {
+	Query   string            `json:"query"`
+	Results []SearchResultDTO `json:"results"`
+}
+
+type StockMovementDTO struct {
+	ID            string           `json:"id"`
+	ProductID     string           `json:"product_id"`
+	WarehouseID   string           `json:"warehouse_id"`
+	ToWarehouseID string           `json:"to_warehouse_id,omitempty"`
+	Kind          string           `json:"kind"`
+	Delta         int32            `json:"delta"`
+	Lots          []LotQuantityDTO `json:"lots,omitempty"`
+	Balance       int32            `json:"balance"`
+	Reason        string           `json:"reason,omitempty"`
+	Actor         string           `json:"actor"`
+	Version       int64            `json:"version"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// RecordStockMovementRequest is the body of POST /products/{id}/movements.
+// Quantity is the amount received, dispatched or written off, or the signed
+// delta of an adjustment. An empty WarehouseID books it in warehouse main.
+// A receipt adds to the lot LotCode, with ExpiresAt when the lot is new, and
+// stock is taken out of it instead of first expiry first out.
+type RecordStockMovementRequest struct {
+	Kind        string        `json:"kind"`
+	Quantity    QuantityInput `json:"quantity"`
+	Reason      string        `json:"reason"`
+	Actor       string        `json:"actor"`
+	WarehouseID string        `json:"warehouse_id"`
+	LotCode     string        `json:"lot_code"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+}
+
+// TransferStockRequest is the body of POST /products/{id}/transfers, a
+// LotCode moves that lot instead of the first expiring ones
+type TransferStockRequest struct {
+	FromWarehouseID string        `json:"from_warehouse_id"`
+	ToWarehouseID   string        `json:"to_warehouse_id"`
+	Quantity        QuantityInput `json:"quantity"`
+	Reason          string        `json:"reason"`
+	Actor           string        `json:"actor"`
+	LotCode         string        `json:"lot_code"`
+}
+
+type RecordStockMovementResponse struct {
+	Movement StockMovementDTO `json:"movement"`
+	Product  ProductDTO       `json:"product"`
+}
+
+type ListStockMovementsResponse struct {
+	Movements     []StockMovementDTO `json:"movements"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+}
+
+// ExpiringStockDTO is a row of the expiring stock report, days_left is
+// negative once the lot has expired
+type ExpiringStockDTO struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	DaysLeft    int32  `json:"days_left"`
+	BatchDTO
+}
+
+type ListExpiringStockResponse struct {
+	Stock []ExpiringStockDTO `json:"stock"`
+}
+
+type ReservationDTO struct {
+	ID          string           `json:"id"`
+	ProductID   string           `json:"product_id"`
+	WarehouseID string           `json:"warehouse_id"`
+	Quantity    int32            `json:"quantity"`
+	Lots        []LotQuantityDTO `json:"lots,omitempty"`
+	Status      string           `json:"status"`
+	Actor       string           `json:"actor,omitempty"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// ReserveStockRequest is the body of POST /products/{id}/reservations,
+// a zero TTL holds the stock for 15 minutes
+type ReserveStockRequest struct {
+	Quantity    QuantityInput `json:"quantity"`
+	TTLSeconds  int64         `json:"ttl_seconds"`
+	Actor       string        `json:"actor"`
+	WarehouseID string        `json:"warehouse_id"`
+}
+
+type ReservationResponse struct {
+	Reservation ReservationDTO `json:"reservation"`
+	// Movement is the dispatch a commit recorded
+	Movement *StockMovementDTO `json:"movement,omitempty"`
+	Product  ProductDTO        `json:"product"`
+}
+
+type WarehouseDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseRequest is the body of POST /warehouses and PUT /warehouses/{id}
+type WarehouseRequest struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type ListWarehousesResponse struct {
+	Warehouses []WarehouseDTO `json:"warehouses"`
+}
+
+// CategoryDTO is a node of the category tree, Children is only filled in
+// tree views
+type CategoryDTO struct {
+	ID         string                   `json:"id"`
+	Name       string                   `json:"name"`
+	ParentID   string                   `json:"parent_id"`
+	SKUPrefix  string                   `json:"sku_prefix,omitempty"`
+	Attributes []AttributeDefinitionDTO `json:"attributes,omitempty"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+	Children   []*CategoryDTO           `json:"children,omitempty"`
+}
+
+// CreateCategoryRequest is the body of POST /categories, an empty ParentID
+// creates a root category
+type CreateCategoryRequest struct {
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id"`
+}
+
+// MoveCategoryRequest is the body of POST /categories/{id}/move
+type MoveCategoryRequest struct {
+	ParentID string `json:"parent_id"`
+}
+
+// SetCategoryAttributesRequest is the body of PUT /categories/{id}/attributes,
+// it replaces every attribute definition of the category
+type SetCategoryAttributesRequest struct {
+	Attributes []AttributeDefinitionDTO `json:"attributes"`
+}
+
+// SetCategorySKUPrefixRequest is the body of PUT /categories/{id}/sku-prefix,
+// an empty prefix inherits the one of the parent
+type SetCategorySKUPrefixRequest struct {
+	SKUPrefix string `json:"sku_prefix"`
+}
+
+type ListCategoriesResponse struct {
+	Categories []*CategoryDTO `json:"categories"`
+}
+
+type SupplierDTO struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	ContactName  string    `json:"contact_name"`
+	Email        string    `json:"email"`
+	Phone        string    `json:"phone"`
+	LeadTimeDays int32     `json:"lead_time_days"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SupplierRequest is the body of POST /suppliers and PUT /suppliers/{id}
+type SupplierRequest struct {
+	Name         string `json:"name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	LeadTimeDays int32  `json:"lead_time_days"`
+}
+
+type ListSuppliersResponse struct {
+	Suppliers []SupplierDTO `json:"suppliers"`
+}
+
+type ProductSupplierDTO struct {
+	ProductID   string       `json:"product_id"`
+	SupplierID  string       `json:"supplier_id"`
+	SupplierSKU string       `json:"supplier_sku"`
+	CostPrice   MoneyDTO     `json:"cost_price"`
+	Preferred   bool         `json:"preferred"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Supplier    *SupplierDTO `json:"supplier,omitempty"`
+}
+
+// LinkProductSupplierRequest is the body of
+// PUT /products/{id}/suppliers/{supplierId}
+type LinkProductSupplierRequest struct {
+	SupplierSKU string     `json:"supplier_sku"`
+	CostPrice   PriceInput `json:"cost_price"`
+	Preferred   bool       `json:"preferred"`
+}
+
+type ListProductSuppliersResponse struct {
+	Suppliers []ProductSupplierDTO `json:"suppliers"`
+}
+
+type PriceListDTO struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Quantity    int32     `json:"quantity"`
+	CustomerIDs []string  `json:"customer_ids"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-type CreateProductRequest struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int32   `json:"quantity"`
+// PriceListRequest is the body of POST /pricelists and PUT /pricelists/{id}
+type PriceListRequest struct {
+	Name        string   `json:"name"`
+	CustomerIDs []string `json:"customer_ids"`
 }
 
-type ListProductsResponse struct {
-	Products []ProductDTO `json:"products"`
-	Total    int32        `json:"total"`
-	Page     int32        `json:"page"`
-	PageSize int32        `json:"page_size"`
+type ListPriceListsResponse struct {
+	PriceLists []PriceListDTO `json:"price_lists"`
+}
+
+type PriceTierDTO struct {
+	MinQuantity int32     `json:"min_quantity"`
+	Price       *MoneyDTO `json:"price"`
+}
+
+// PriceTiersDTO is the body of PUT /pricelists/{id}/products/{productId}, an
+// empty list removes the product from the price list
+type PriceTiersDTO struct {
+	Tiers []PriceTierDTO `json:"tiers"`
+}
+
+type PriceQuoteDTO struct {
+	ProductID   string   `json:"product_id"`
+	PriceListID string   `json:"price_list_id,omitempty"`
+	Quantity    int32    `json:"quantity"`
+	UnitPrice   MoneyDTO `json:"unit_price"`
+	LinePrice   MoneyDTO `json:"line_price"`
+	Rule        string   `json:"rule"`
+	MinQuantity int32    `json:"min_quantity,omitempty"`
+	Explanation string   `json:"explanation"`
+}
+
+type AuctionDTO struct {
+	ID            string    `json:"id"`
+	ProductID     string    `json:"product_id"`
+	WarehouseID   string    `json:"warehouse_id"`
+	Quantity      int32     `json:"quantity"`
+	ReservePrice  MoneyDTO  `json:"reserve_price"`
+	OpensAt       time.Time `json:"opens_at"`
+	ClosesAt      time.Time `json:"closes_at"`
+	Status        string    `json:"status"`
+	ReservationID string    `json:"reservation_id"`
+	WinningBidID  string    `json:"winning_bid_id,omitempty"`
+	WinnerID      string    `json:"winner_id,omitempty"`
+	WinningPrice  *MoneyDTO `json:"winning_price,omitempty"`
+	Outcome       string    `json:"outcome,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
+// CreateAuctionRequest is the body of POST /auctions, an auction without
+// opens_at opens right away
+type CreateAuctionRequest struct {
+	ProductID    string    `json:"product_id"`
+	WarehouseID  string    `json:"warehouse_id"`
+	Quantity     int32     `json:"quantity"`
+	ReservePrice *MoneyDTO `json:"reserve_price"`
+	OpensAt      time.Time `json:"opens_at"`
+	ClosesAt     time.Time `json:"closes_at"`
+}
+
+type ListAuctionsResponse struct {
+	Auctions []AuctionDTO `json:"auctions"`
+}
+
+type BidDTO struct {
+	ID          string    `json:"id"`
+	AuctionID   string    `json:"auction_id"`
+	BidderID    string    `json:"bidder_id"`
+	Amount      MoneyDTO  `json:"amount"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// SubmitBidRequest is the body of POST /auctions/{id}/bids, it replaces the
+// bidder's earlier bid
+type SubmitBidRequest struct {
+	BidderID string    `json:"bidder_id"`
+	Amount   *MoneyDTO `json:"amount"`
+}
+
+type ListBidsResponse struct {
+	Bids []BidDTO `json:"bids"`
+}
+
+// ErrorResponse describes a failed RPC, Error is the gRPC status code name
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Error           string              `json:"error"`
+	Message         string              `json:"message"`
+	Code            int                 `json:"code"`
+	FieldViolations []FieldViolationDTO `json:"field_violations,omitempty"`
+}
+
+type FieldViolationDTO struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
 }
 
 func (p *CreateProductRequest) Validate() error {
 	if p.Name == "" {
 		return errors.New("Name is required")
 	}
-	if p.Price <= 0 {
+	if !p.Price.positive() {
 		return errors.New("Price must be greater than zero")
 	}
-	if p.Quantity < 0 {
+	if p.Quantity.Count < 0 {
 		return errors.New("Quantity must be greater than zero")
 	}
 	return nil
 }
+
+// ToRequest turns the patch into an update of the present members only
+func (p ProductMergePatch) ToRequest() (*pb.UpdateProductRequest, error) {
+	req := &pb.UpdateProductRequest{UpdateMask: &fieldmaskpb.FieldMask{}}
+	for field, raw := range p {
+		null := string(raw) == "null"
+		var err error
+		switch field {
+		case "name":
+			err = decodeMember(raw, null, &req.Name)
+			if err == nil && req.GetName() == "" {
+				err = errors.New("Name is required")
+			}
+		case "description":
+			// removing the description empties it
+			req.Description = new(string)
+			if !null {
+				err = json.Unmarshal(raw, req.Description)
+			}
+		case "price":
+			var price *PriceInput
+			err = decodeMember(raw, null, &price)
+			if err == nil && !price.positive() {
+				err = errors.New("Price must be greater than zero")
+			}
+			if err == nil {
+				req.Price, req.PriceMoney = &price.Legacy, price.proto()
+			}
+		case "quantity":
+			var quantity *QuantityInput
+			err = decodeMember(raw, null, &quantity)
+			if err == nil && quantity.Count < 0 {
+				err = errors.New("Quantity must be greater than zero")
+			}
+			if err == nil {
+				req.Quantity, req.Measure = &quantity.Count, quantity.measure()
+			}
+		case "units":
+			var units *UnitsOfMeasureDTO
+			err = decodeMember(raw, null, &units)
+			req.Units = units.proto()
+		case "category_id":
+			// removing the category leaves the product uncategorized
+			req.CategoryId = new(string)
+			if !null {
+				err = json.Unmarshal(raw, req.CategoryId)
+			}
+		case "attributes":
+			// the attributes are replaced as a whole, removing them clears
+			// them all
+			var attrs AttributesInput
+			if !null {
+				err = json.Unmarshal(raw, &attrs)
+			}
+			req.Attributes = attrs.proto()
+		case "allergens":
+			// like the attributes the allergens and dietary tags are replaced
+			// as a whole
+			var allergens AllergensInput
+			if !null {
+				err = json.Unmarshal(raw, &allergens)
+			}
+			req.Allergens = allergens.proto()
+		case "nutrition":
+			var nutrition *NutritionDTO
+			if !null {
+				err = json.Unmarshal(raw, &nutrition)
+			}
+			req.Nutrition = nutrition.proto()
+		case "dietary_tags":
+			if !null {
+				err = json.Unmarshal(raw, &req.DietaryTags)
+			}
+		case "barcodes":
+			// replaced as a whole too, removing them clears them all
+			var barcodes []BarcodeDTO
+			if !null {
+				err = json.Unmarshal(raw, &barcodes)
+			}
+			req.Barcodes = barcodesProto(barcodes)
+		default:
+			err = errors.New("unknown or read-only member")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, field)
+	}
+	if len(req.UpdateMask.Paths) == 0 {
+		return nil, errors.New("patch changes nothing")
+	}
+	slices.Sort(req.UpdateMask.Paths)
+	return req, nil
+}
+
+// decodeMember decodes a member that cannot be removed
+func decodeMember[T any](raw json.RawMessage, null bool, dst **T) error {
+	if null {
+		return errors.New("cannot be removed")
+	}
+	*dst = new(T)
+	return json.Unmarshal(raw, *dst)
+}
@@ -0,0 +1,115 @@
+package hdl
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+)
+
+func TestPriceInput(t *testing.T) {
+	tests := []struct {
+		body     string
+		money    *pb.Money
+		legacy   float64
+		positive bool
+	}{
+		{`{"currency_code": "EUR", "amount_minor": 450}`, &pb.Money{CurrencyCode: "EUR", AmountMinor: 450}, 0, true},
+		{`{"currency_code": "EUR", "amount_minor": 0}`, &pb.Money{CurrencyCode: "EUR"}, 0, false},
+		{`4.5`, nil, 4.5, true},
+		{`0`, nil, 0, false},
+	}
+	for _, tt := range tests {
+		var p PriceInput
+		if err := json.Unmarshal([]byte(tt.body), &p); err != nil {
+			t.Fatalf("decoding %s failed: %v", tt.body, err)
+		}
+		got := p.proto()
+		if (got == nil) != (tt.money == nil) || got.GetCurrencyCode() != tt.money.GetCurrencyCode() || got.GetAmountMinor() != tt.money.GetAmountMinor() {
+			t.Errorf("%s: proto() = %v, want %v", tt.body, got, tt.money)
+		}
+		if p.Legacy != tt.legacy || p.positive() != tt.positive {
+			t.Errorf("%s: legacy %v positive %v, want %v %v", tt.body, p.Legacy, p.positive(), tt.legacy, tt.positive)
+		}
+	}
+	var p PriceInput
+	if err := json.Unmarshal([]byte(`"4.5"`), &p); err == nil {
+		t.Error("a price given as a string should not decode")
+	}
+}
+
+func TestProductMergePatch_ToRequest(t *testing.T) {
+	tests := []struct {
+		body  string
+		paths []string
+		check func(req *pb.UpdateProductRequest) bool
+	}{
+		// an absent member is left out of the mask and the request
+		{`{"name": "Brie"}`, []string{"name"}, func(req *pb.UpdateProductRequest) bool {
+			return req.GetName() == "Brie" && req.Description == nil && req.CategoryId == nil && req.Price == nil
+		}},
+		// null removes what can be removed
+		{`{"description": null}`, []string{"description"}, func(req *pb.UpdateProductRequest) bool {
+			return req.Description != nil && *req.Description == ""
+		}},
+		{`{"description": "Soft cheese"}`, []string{"description"}, func(req *pb.UpdateProductRequest) bool {
+			return req.GetDescription() == "Soft cheese"
+		}},
+		{`{"category_id": null}`, []string{"category_id"}, func(req *pb.UpdateProductRequest) bool {
+			return req.CategoryId != nil && *req.CategoryId == ""
+		}},
+		{`{"attributes": null, "allergens": null, "nutrition": null, "dietary_tags": null, "barcodes": null}`,
+			[]string{"allergens", "attributes", "barcodes", "dietary_tags", "nutrition"}, func(req *pb.UpdateProductRequest) bool {
+				return req.Attributes == nil && req.Allergens == nil && req.Nutrition == nil && req.DietaryTags == nil && req.Barcodes == nil
+			}},
+		{`{"quantity": 3, "price": {"currency_code": "EUR", "amount_minor": 450}}`, []string{"price", "quantity"}, func(req *pb.UpdateProductRequest) bool {
+			return req.GetQuantity() == 3 && req.GetPriceMoney().GetAmountMinor() == 450 && req.GetPriceMoney().GetCurrencyCode() == "EUR"
+		}},
+		{`{"price": 4.5}`, []string{"price"}, func(req *pb.UpdateProductRequest) bool {
+			return req.GetPrice() == 4.5 && req.PriceMoney == nil
+		}},
+		{`{"units": {"base_unit": "kg"}}`, []string{"units"}, func(req *pb.UpdateProductRequest) bool {
+			return req.GetUnits().GetBaseUnit() == "kg"
+		}},
+	}
+	for _, tt := range tests {
+		var patch ProductMergePatch
+		if err := json.Unmarshal([]byte(tt.body), &patch); err != nil {
+			t.Fatalf("decoding %s failed: %v", tt.body, err)
+		}
+		req, err := patch.ToRequest()
+		if err != nil {
+			t.Errorf("ToRequest(%s) failed: %v", tt.body, err)
+			continue
+		}
+		if !slices.Equal(req.GetUpdateMask().GetPaths(), tt.paths) {
+			t.Errorf("ToRequest(%s) masks %v, want %v", tt.body, req.GetUpdateMask().GetPaths(), tt.paths)
+		}
+		if !tt.check(req) {
+			t.Errorf("ToRequest(%s) = %v", tt.body, req)
+		}
+	}
+
+	// members that are required, unknown or read-only fail the patch
+	for _, body := range []string{
+		`{}`,
+		`{"name": null}`,
+		`{"name": ""}`,
+		`{"price": null}`,
+		`{"price": 0}`,
+		`{"units": null}`,
+		`{"quantity": -1}`,
+		`{"sku": "SKU-00001-7"}`,
+		`{"version": 3}`,
+		`{"description": 7}`,
+	} {
+		var patch ProductMergePatch
+		if err := json.Unmarshal([]byte(body), &patch); err != nil {
+			t.Fatalf("decoding %s failed: %v", body, err)
+		}
+		if req, err := patch.ToRequest(); err == nil {
+			t.Errorf("ToRequest(%s) = %v, want an error", body, req)
+		}
+	}
+}
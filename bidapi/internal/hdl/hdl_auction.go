@@ -0,0 +1,163 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toAuctionDTO converts a protobuf auction to its JSON form
+func toAuctionDTO(a *pb.Auction) AuctionDTO {
+	dto := AuctionDTO{
+		ID:            a.Id,
+		ProductID:     a.ProductId,
+		WarehouseID:   a.WarehouseId,
+		Quantity:      a.Quantity,
+		ReservePrice:  toMoneyDTO(a.ReservePrice),
+		OpensAt:       time.Unix(a.OpensAt, 0),
+		ClosesAt:      time.Unix(a.ClosesAt, 0),
+		Status:        a.Status,
+		ReservationID: a.ReservationId,
+		WinningBidID:  a.WinningBidId,
+		WinnerID:      a.WinnerId,
+		Outcome:       a.Outcome,
+		CreatedAt:     time.Unix(a.CreatedAt, 0),
+		UpdatedAt:     time.Unix(a.UpdatedAt, 0),
+	}
+	if a.WinningPrice != nil {
+		price := toMoneyDTO(a.WinningPrice)
+		dto.WinningPrice = &price
+	}
+	return dto
+}
+
+// toBidDTO converts a protobuf bid to its JSON form
+func toBidDTO(b *pb.Bid) BidDTO {
+	return BidDTO{
+		ID:          b.Id,
+		AuctionID:   b.AuctionId,
+		BidderID:    b.BidderId,
+		Amount:      toMoneyDTO(b.Amount),
+		SubmittedAt: time.Unix(b.SubmittedAt, 0),
+	}
+}
+
+// unixOrZero returns t in unix seconds, 0 for the zero time
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// CreateAuction puts a lot of a product up for auction
+func CreateAuction(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args CreateAuctionRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.CreateAuctionRequest{
+		ProductId:    args.ProductID,
+		WarehouseId:  args.WarehouseID,
+		Quantity:     args.Quantity,
+		ReservePrice: args.ReservePrice.proto(),
+		OpensAt:      unixOrZero(args.OpensAt),
+		ClosesAt:     unixOrZero(args.ClosesAt),
+	}
+
+	rsp, err := rpc.RpcClientProduct.AuctionClt.CreateAuction(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to create auction", err)
+		return
+	}
+
+	Ok(w, http.StatusCreated, toAuctionDTO(rsp.Auction))
+}
+
+func GetAuction(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.AuctionClt.GetAuction(ctx, &pb.GetAuctionRequest{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to get auction", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toAuctionDTO(rsp.Auction))
+}
+
+// ListAuctions lists the auctions by closing time, only those with the
+// status given by ?status= when set
+func ListAuctions(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.AuctionClt.ListAuctions(ctx, &pb.ListAuctionsRequest{Status: r.URL.Query().Get("status")})
+	if err != nil {
+		RpcErr(w, "failed to list auctions", err)
+		return
+	}
+
+	auctions := make([]AuctionDTO, len(rsp.Auctions))
+	for i, a := range rsp.Auctions {
+		auctions[i] = toAuctionDTO(a)
+	}
+
+	Ok(w, http.StatusOK, ListAuctionsResponse{Auctions: auctions})
+}
+
+// SubmitBid places a sealed bid on an open auction
+func SubmitBid(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args SubmitBidRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.SubmitBidRequest{
+		AuctionId: chi.URLParam(r, "id"),
+		BidderId:  args.BidderID,
+		Amount:    args.Amount.proto(),
+	}
+
+	rsp, err := rpc.RpcClientProduct.AuctionClt.SubmitBid(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to submit bid", err)
+		return
+	}
+
+	Ok(w, http.StatusCreated, toBidDTO(rsp.Bid))
+}
+
+// ListBids lists the bids of a closed auction from best to worst, it fails
+// with 409 while bids are sealed
+func ListBids(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.AuctionClt.ListBids(ctx, &pb.ListBidsRequest{AuctionId: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to list bids", err)
+		return
+	}
+
+	bids := make([]BidDTO, len(rsp.Bids))
+	for i, b := range rsp.Bids {
+		bids[i] = toBidDTO(b)
+	}
+
+	Ok(w, http.StatusOK, ListBidsResponse{Bids: bids})
+}
@@ -0,0 +1,177 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toCategoryDTO converts a protobuf category to its JSON form, without children
+func toCategoryDTO(c *pb.Category) CategoryDTO {
+	dto := CategoryDTO{
+		ID:        c.Id,
+		Name:      c.Name,
+		ParentID:  c.ParentId,
+		SKUPrefix: c.SkuPrefix,
+		CreatedAt: time.Unix(c.CreatedAt, 0),
+		UpdatedAt: time.Unix(c.UpdatedAt, 0),
+	}
+	for _, d := range c.Attributes {
+		dto.Attributes = append(dto.Attributes, AttributeDefinitionDTO{Name: d.Name, Type: d.Type, AllowedValues: d.AllowedValues, Required: d.Required})
+	}
+	return dto
+}
+
+// categoryTree nests the flat category list by parent ID. It returns the
+// roots, a category whose parent is unknown counts as one, and the nodes by
+// ID. Children keep the order of the list.
+func categoryTree(categories []*pb.Category) ([]*CategoryDTO, map[string]*CategoryDTO) {
+	nodes := make(map[string]*CategoryDTO, len(categories))
+	for _, c := range categories {
+		dto := toCategoryDTO(c)
+		dto.Children = []*CategoryDTO{}
+		nodes[c.Id] = &dto
+	}
+	roots := []*CategoryDTO{}
+	for _, c := range categories {
+		if parent, ok := nodes[c.ParentId]; ok && c.ParentId != c.Id {
+			parent.Children = append(parent.Children, nodes[c.Id])
+		} else {
+			roots = append(roots, nodes[c.Id])
+		}
+	}
+	return roots, nodes
+}
+
+func CreateCategory(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.CreateCategory(ctx, &pb.CreateCategoryRequest{Name: args.Name, ParentId: args.ParentID})
+	if err != nil {
+		RpcErr(w, "failed to create category", err)
+		return
+	}
+
+	Ok(w, http.StatusCreated, toCategoryDTO(rsp.Category))
+}
+
+// ListCategories returns the whole taxonomy as a tree, siblings ordered by name
+func ListCategories(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.Clt.ListCategories(ctx, &pb.ListCategoriesRequest{})
+	if err != nil {
+		RpcErr(w, "failed to list categories", err)
+		return
+	}
+
+	roots, _ := categoryTree(rsp.Categories)
+	Ok(w, http.StatusOK, ListCategoriesResponse{Categories: roots})
+}
+
+// GetCategory returns a category with its subtree
+func GetCategory(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.Clt.ListCategories(ctx, &pb.ListCategoriesRequest{})
+	if err != nil {
+		RpcErr(w, "failed to get category", err)
+		return
+	}
+
+	_, nodes := categoryTree(rsp.Categories)
+	node, ok := nodes[chi.URLParam(r, "id")]
+	if !ok {
+		Err(w, http.StatusNotFound, "category not found", errors.New("category not found"))
+		return
+	}
+	Ok(w, http.StatusOK, node)
+}
+
+// MoveCategory moves a category with its subtree under another parent, an
+// empty parent_id makes it a root
+func MoveCategory(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args MoveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.MoveCategoryRequest{
+		Id:       chi.URLParam(r, "id"),
+		ParentId: args.ParentID,
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.MoveCategory(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to move category", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toCategoryDTO(rsp.Category))
+}
+
+// SetCategoryAttributes replaces the attribute definitions of a category,
+// products are checked against them when they are next written
+func SetCategoryAttributes(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args SetCategoryAttributesRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.SetCategoryAttributesRequest{Id: chi.URLParam(r, "id")}
+	for _, d := range args.Attributes {
+		req.Attributes = append(req.Attributes, &pb.AttributeDefinition{Name: d.Name, Type: d.Type, AllowedValues: d.AllowedValues, Required: d.Required})
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.SetCategoryAttributes(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to set category attributes", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toCategoryDTO(rsp.Category))
+}
+
+// SetCategorySKUPrefix sets the prefix of the SKUs generated for the products
+// of a category and of its subcategories that set none
+func SetCategorySKUPrefix(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args SetCategorySKUPrefixRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.SetCategorySKUPrefix(ctx, &pb.SetCategorySKUPrefixRequest{
+		Id:        chi.URLParam(r, "id"),
+		SkuPrefix: args.SKUPrefix,
+	})
+	if err != nil {
+		RpcErr(w, "failed to set category SKU prefix", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toCategoryDTO(rsp.Category))
+}
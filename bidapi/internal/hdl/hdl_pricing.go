@@ -0,0 +1,207 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toPriceListDTO converts a protobuf price list to its JSON form
+func toPriceListDTO(l *pb.PriceList) PriceListDTO {
+	customers := l.CustomerIds
+	if customers == nil {
+		customers = []string{}
+	}
+	return PriceListDTO{
+		ID:          l.Id,
+		Name:        l.Name,
+		CustomerIDs: customers,
+		CreatedAt:   time.Unix(l.CreatedAt, 0),
+		UpdatedAt:   time.Unix(l.UpdatedAt, 0),
+	}
+}
+
+// toPriceTiersDTO converts protobuf price tiers to their JSON form
+func toPriceTiersDTO(tiers []*pb.PriceTier) PriceTiersDTO {
+	dto := PriceTiersDTO{Tiers: make([]PriceTierDTO, len(tiers))}
+	for i, t := range tiers {
+		price := toMoneyDTO(t.Price)
+		dto.Tiers[i] = PriceTierDTO{MinQuantity: t.MinQuantity, Price: &price}
+	}
+	return dto
+}
+
+func CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args PriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.CreatePriceListRequest{
+		Name:        args.Name,
+		CustomerIds: args.CustomerIDs,
+	}
+
+	rsp, err := rpc.RpcClientProduct.PricingClt.CreatePriceList(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to create price list", err)
+		return
+	}
+
+	Ok(w, http.StatusCreated, toPriceListDTO(rsp.PriceList))
+}
+
+func GetPriceList(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.PricingClt.GetPriceList(ctx, &pb.GetPriceListRequest{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to get price list", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toPriceListDTO(rsp.PriceList))
+}
+
+func ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.PricingClt.ListPriceLists(ctx, &pb.ListPriceListsRequest{})
+	if err != nil {
+		RpcErr(w, "failed to list price lists", err)
+		return
+	}
+
+	lists := make([]PriceListDTO, len(rsp.PriceLists))
+	for i, l := range rsp.PriceLists {
+		lists[i] = toPriceListDTO(l)
+	}
+
+	Ok(w, http.StatusOK, ListPriceListsResponse{PriceLists: lists})
+}
+
+// UpdatePriceList replaces the name and customers of a price list
+func UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args PriceListRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.UpdatePriceListRequest{
+		Id:          chi.URLParam(r, "id"),
+		Name:        args.Name,
+		CustomerIds: args.CustomerIDs,
+	}
+
+	rsp, err := rpc.RpcClientProduct.PricingClt.UpdatePriceList(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to update price list", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toPriceListDTO(rsp.PriceList))
+}
+
+// GetPriceTiers lists the tiers of a product on a price list
+func GetPriceTiers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	req := &pb.GetPriceTiersRequest{
+		PriceListId: chi.URLParam(r, "id"),
+		ProductId:   chi.URLParam(r, "productId"),
+	}
+	rsp, err := rpc.RpcClientProduct.PricingClt.GetPriceTiers(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to get price tiers", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toPriceTiersDTO(rsp.Tiers))
+}
+
+// SetPriceTiers replaces the tiers of a product on a price list
+func SetPriceTiers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args PriceTiersDTO
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.SetPriceTiersRequest{
+		PriceListId: chi.URLParam(r, "id"),
+		ProductId:   chi.URLParam(r, "productId"),
+		Tiers:       make([]*pb.PriceTier, len(args.Tiers)),
+	}
+	for i, t := range args.Tiers {
+		req.Tiers[i] = &pb.PriceTier{MinQuantity: t.MinQuantity}
+		if t.Price != nil {
+			req.Tiers[i].Price = &pb.Money{CurrencyCode: t.Price.CurrencyCode, AmountMinor: t.Price.AmountMinor}
+		}
+	}
+
+	rsp, err := rpc.RpcClientProduct.PricingClt.SetPriceTiers(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to set price tiers", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toPriceTiersDTO(rsp.Tiers))
+}
+
+// QuotePrice prices qty units of a product, 1 by default, on the price list
+// given by pricelist or the one of customer
+func QuotePrice(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	qty := int64(1)
+	if s := r.URL.Query().Get("qty"); s != "" {
+		var err error
+		if qty, err = strconv.ParseInt(s, 10, 32); err != nil {
+			Err(w, http.StatusBadRequest, "invalid qty", err)
+			return
+		}
+	}
+
+	req := &pb.QuotePriceRequest{
+		ProductId:   chi.URLParam(r, "id"),
+		PriceListId: r.URL.Query().Get("pricelist"),
+		CustomerId:  r.URL.Query().Get("customer"),
+		Quantity:    int32(qty),
+	}
+	rsp, err := rpc.RpcClientProduct.PricingClt.QuotePrice(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to quote price", err)
+		return
+	}
+
+	q := rsp.Quote
+	Ok(w, http.StatusOK, PriceQuoteDTO{
+		ProductID:   q.ProductId,
+		PriceListID: q.PriceListId,
+		Quantity:    q.Quantity,
+		UnitPrice:   toMoneyDTO(q.UnitPrice),
+		LinePrice:   toMoneyDTO(q.LinePrice),
+		Rule:        q.Rule,
+		MinQuantity: q.MinQuantity,
+		Explanation: q.Explanation,
+	})
+}
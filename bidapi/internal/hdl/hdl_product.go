@@ -3,16 +3,135 @@ package hdl
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/athxx/bidfood/bidapi/internal/rpc"
 	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
 
 	chi "github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// toProductDTO converts a protobuf product to its JSON form
+func toProductDTO(p *pb.Product) ProductDTO {
+	dto := ProductDTO{
+		ID:          p.Id,
+		SKU:         p.Sku,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       toMoneyDTO(p.PriceMoney),
+		Quantity:    p.Quantity,
+		Reserved:    p.Reserved,
+		Available:   p.Available,
+		Stock:       toWarehouseStockDTOs(p.Stock),
+		CategoryID:  p.CategoryId,
+		Units:       toUnitsDTO(p.Units),
+		Batches:     toBatchDTOs(p.Batches),
+		ParentID:    p.ParentId,
+		Attributes:  toAttributesDTO(p.Attributes),
+		Allergens:   toAllergensDTO(p.Allergens),
+		Nutrition:   toNutritionDTO(p.Nutrition),
+		DietaryTags: p.DietaryTags,
+		Barcodes:    toBarcodeDTOs(p.Barcodes),
+		Variants:    toProductDTOs(p.Variants),
+		Version:     p.Version,
+		CreatedAt:   time.Unix(p.CreatedAt, 0),
+		UpdatedAt:   time.Unix(p.UpdatedAt, 0),
+	}
+	if p.DeletedAt != 0 {
+		deletedAt := time.Unix(p.DeletedAt, 0)
+		dto.DeletedAt = &deletedAt
+	}
+	return dto
+}
+
+// toProductDTOs converts protobuf products to their JSON form, nil when
+// there are none
+func toProductDTOs(products []*pb.Product) []ProductDTO {
+	var out []ProductDTO
+	for _, p := range products {
+		out = append(out, toProductDTO(p))
+	}
+	return out
+}
+
+// toAttributesDTO converts protobuf attribute values to a JSON object, nil
+// when there are none
+func toAttributesDTO(values []*pb.AttributeValue) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for _, v := range values {
+		out[v.Name] = v.Value
+	}
+	return out
+}
+
+// toAllergensDTO converts protobuf allergen declarations to a JSON object,
+// nil when there are none
+func toAllergensDTO(decls []*pb.AllergenDeclaration) map[string]string {
+	if len(decls) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(decls))
+	for _, d := range decls {
+		out[d.Allergen] = d.Status
+	}
+	return out
+}
+
+// toNutritionDTO converts protobuf nutrition facts to their JSON form, nil
+// when the product declares none
+func toNutritionDTO(n *pb.Nutrition) *NutritionDTO {
+	if n == nil {
+		return nil
+	}
+	return &NutritionDTO{
+		EnergyKJ:     n.EnergyKj,
+		EnergyKcal:   n.EnergyKcal,
+		Fat:          n.Fat,
+		SaturatedFat: n.SaturatedFat,
+		Carbohydrate: n.Carbohydrate,
+		Sugars:       n.Sugars,
+		Fibre:        n.Fibre,
+		Protein:      n.Protein,
+		Salt:         n.Salt,
+	}
+}
+
+// toBarcodeDTOs converts protobuf barcodes to their JSON form, nil when
+// there are none
+func toBarcodeDTOs(barcodes []*pb.Barcode) []BarcodeDTO {
+	var out []BarcodeDTO
+	for _, b := range barcodes {
+		out = append(out, BarcodeDTO{GTIN: b.Gtin, Unit: b.Unit})
+	}
+	return out
+}
+
+// toUnitsDTO converts protobuf units of measure to their JSON form
+func toUnitsDTO(u *pb.UnitsOfMeasure) UnitsOfMeasureDTO {
+	dto := UnitsOfMeasureDTO{BaseUnit: u.GetBaseUnit(), Conversions: make([]UnitConversionDTO, len(u.GetConversions()))}
+	for i, c := range u.GetConversions() {
+		dto.Conversions[i] = UnitConversionDTO{Code: c.Code, Factor: c.Factor, Fractional: c.Fractional}
+	}
+	return dto
+}
+
+// toMoneyDTO converts a protobuf amount to its JSON form
+func toMoneyDTO(m *pb.Money) MoneyDTO {
+	return MoneyDTO{
+		CurrencyCode: m.GetCurrencyCode(),
+		AmountMinor:  m.GetAmountMinor(),
+	}
+}
+
 func CreateProduct(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
@@ -29,26 +148,30 @@ func CreateProduct(w http.ResponseWriter, r *http.Request) {
 	req := &pb.CreateProductRequest{
 		Name:        args.Name,
 		Description: args.Description,
-		Price:       args.Price,
-		Quantity:    args.Quantity,
+		Price:       args.Price.Legacy,
+		PriceMoney:  args.Price.proto(),
+		Quantity:    args.Quantity.Count,
+		Measure:     args.Quantity.measure(),
+		CategoryId:  args.CategoryID,
+		Units:       args.Units.proto(),
+		ParentId:    args.ParentID,
+		Attributes:  args.Attributes.proto(),
+		Allergens:   args.Allergens.proto(),
+		Nutrition:   args.Nutrition.proto(),
+		DietaryTags: args.DietaryTags,
+		Barcodes:    barcodesProto(args.Barcodes),
+		Sku:         args.SKU,
 	}
 
 	rsp, err := rpc.RpcClientProduct.Clt.CreateProduct(ctx, req)
 	if err != nil {
-		Err(w, http.StatusInternalServerError, "failed to create product", err)
+		RpcErr(w, "failed to create product", err)
 		return
 	}
 
-	response := ProductDTO{
-		ID:          rsp.Product.Id,
-		Name:        rsp.Product.Name,
-		Description: rsp.Product.Description,
-		Price:       rsp.Product.Price,
-		Quantity:    rsp.Product.Quantity,
-		CreatedAt:   time.Unix(rsp.Product.CreatedAt, 0),
-		UpdatedAt:   time.Unix(rsp.Product.UpdatedAt, 0),
-	}
+	response := toProductDTO(rsp.Product)
 
+	w.Header().Set("ETag", etag(rsp.Product.Version))
 	Ok(w, http.StatusCreated, response)
 }
 
@@ -62,60 +185,146 @@ func GetProduct(w http.ResponseWriter, r *http.Request) {
 
 	rsp, err := rpc.RpcClientProduct.Clt.GetProduct(ctx, req)
 	if err != nil {
-		Err(w, http.StatusNotFound, "product not found", err)
+		RpcErr(w, "failed to get product", err)
 		return
 	}
 
-	response := ProductDTO{
-		ID:          rsp.Product.Id,
-		Name:        rsp.Product.Name,
-		Description: rsp.Product.Description,
-		Price:       rsp.Product.Price,
-		Quantity:    rsp.Product.Quantity,
-		CreatedAt:   time.Unix(rsp.Product.CreatedAt, 0),
-		UpdatedAt:   time.Unix(rsp.Product.UpdatedAt, 0),
+	response := toProductDTO(rsp.Product)
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusOK, response)
+}
+
+// GetProductBySKU finds a product by SKU, regardless of case
+func GetProductBySKU(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	req := &pb.GetProductRequest{
+		Sku: chi.URLParam(r, "sku"),
 	}
 
+	rsp, err := rpc.RpcClientProduct.Clt.GetProduct(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to get product by SKU", err)
+		return
+	}
+
+	response := toProductDTO(rsp.Product)
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
 	Ok(w, http.StatusOK, response)
 }
 
-func UpdateProduct(w http.ResponseWriter, r *http.Request) {
+// GetProductByBarcode finds the product carrying a scanned GTIN-8, 12, 13
+// or 14
+func GetProductByBarcode(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
-	id := chi.URLParam(r, "id")
 
+	req := &pb.GetProductByBarcodeRequest{
+		Gtin: chi.URLParam(r, "gtin"),
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.GetProductByBarcode(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to get product by barcode", err)
+		return
+	}
+
+	response := ProductByBarcodeDTO{
+		Product: toProductDTO(rsp.Product),
+		Barcode: BarcodeDTO{GTIN: rsp.Barcode.GetGtin(), Unit: rsp.Barcode.GetUnit()},
+	}
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusOK, response)
+}
+
+// replacedPaths are the fields PUT /products/{id} sets from the body
+var replacedPaths = []string{"name", "description", "price", "category_id", "units", "attributes", "allergens",
+	"nutrition", "dietary_tags", "barcodes"}
+
+// UpdateProduct replaces all editable fields of a product, fields missing
+// from the body are reset: the units to pieces, the attributes, labelling
+// and barcodes to none. The quantity is not one of them, it is the balance
+// of the stock ledger and changes through PATCH or the stock endpoints, so
+// a body that leaves it out does not wipe the stock.
+func UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	var args CreateProductRequest
 	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
 		Err(w, http.StatusBadRequest, "invalid request body", err)
 		return
 	}
+	if err := args.Validate(); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
 
 	req := &pb.UpdateProductRequest{
-		Id:          id,
-		Name:        args.Name,
-		Description: args.Description,
-		Price:       args.Price,
-		Quantity:    args.Quantity,
+		Name:        &args.Name,
+		Description: &args.Description,
+		Price:       &args.Price.Legacy,
+		PriceMoney:  args.Price.proto(),
+		CategoryId:  &args.CategoryID,
+		Units:       args.Units.proto(),
+		Attributes:  args.Attributes.proto(),
+		Allergens:   args.Allergens.proto(),
+		Nutrition:   args.Nutrition.proto(),
+		DietaryTags: args.DietaryTags,
+		Barcodes:    barcodesProto(args.Barcodes),
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: replacedPaths},
+	}
+	updateProduct(w, r, req)
+}
+
+// PatchProduct applies a JSON Merge Patch (RFC 7396) to a product, only the
+// members present in the body change and null clears the description, the
+// category, the attributes, the labelling or the barcodes
+func PatchProduct(w http.ResponseWriter, r *http.Request) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mt, _, _ := mime.ParseMediaType(ct); mt != "application/merge-patch+json" && mt != "application/json" {
+			Err(w, http.StatusUnsupportedMediaType, "unsupported content type", errors.New("use application/merge-patch+json"))
+			return
+		}
 	}
 
-	rsp, err := rpc.RpcClientProduct.Clt.UpdateProduct(ctx, req)
+	var args ProductMergePatch
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	req, err := args.ToRequest()
 	if err != nil {
-		Err(w, http.StatusInternalServerError, "failed to update product", err)
+		Err(w, http.StatusBadRequest, "invalid request body", err)
 		return
 	}
 
-	response := ProductDTO{
-		ID:          rsp.Product.Id,
-		Name:        rsp.Product.Name,
-		Description: rsp.Product.Description,
-		Price:       rsp.Product.Price,
-		Quantity:    rsp.Product.Quantity,
-		CreatedAt:   time.Unix(rsp.Product.CreatedAt, 0),
-		UpdatedAt:   time.Unix(rsp.Product.UpdatedAt, 0),
+	updateProduct(w, r, req)
+}
+
+// updateProduct sends req for the product in the URL, honouring If-Match
+func updateProduct(w http.ResponseWriter, r *http.Request, req *pb.UpdateProductRequest) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		Err(w, http.StatusPreconditionFailed, "precondition failed", errors.New("If-Match must be \"*\" or a product ETag"))
+		return
 	}
+	req.Id = chi.URLParam(r, "id")
+	req.ExpectedVersion = version
 
-	Ok(w, http.StatusOK, response)
+	rsp, err := rpc.RpcClientProduct.Clt.UpdateProduct(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to update product", err)
+		return
+	}
 
+	response := toProductDTO(rsp.Product)
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusOK, response)
 }
 
 func DeleteProduct(w http.ResponseWriter, r *http.Request) {
@@ -123,14 +332,20 @@ func DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	id := chi.URLParam(r, "id")
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		Err(w, http.StatusPreconditionFailed, "precondition failed", errors.New("If-Match must be \"*\" or a product ETag"))
+		return
+	}
 
 	req := &pb.DeleteProductRequest{
-		Id: id,
+		Id:              id,
+		ExpectedVersion: version,
 	}
 
 	rsp, err := rpc.RpcClientProduct.Clt.DeleteProduct(ctx, req)
 	if err != nil {
-		Err(w, http.StatusInternalServerError, "failed to delete product", err)
+		RpcErr(w, "failed to delete product", err)
 		return
 	}
 	if !rsp.Success {
@@ -145,12 +360,94 @@ func DeleteProduct(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// RestoreProduct moves a deleted product out of the trash
+func RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		Err(w, http.StatusPreconditionFailed, "precondition failed", errors.New("If-Match must be \"*\" or a product ETag"))
+		return
+	}
+
+	req := &pb.RestoreProductRequest{
+		Id:              chi.URLParam(r, "id"),
+		ExpectedVersion: version,
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.RestoreProduct(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to restore product", err)
+		return
+	}
+
+	response := toProductDTO(rsp.Product)
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusOK, response)
+}
+
+// ListDeletedProducts pages through the trash, taking the query parameters
+// of ListProducts but the warehouse and category
+func ListDeletedProducts(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	page, _ := strconv.ParseInt(r.URL.Query().Get("page"), 10, 32)
+	pageSize, _ := strconv.ParseInt(r.URL.Query().Get("page_size"), 10, 32)
+
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	req := &pb.ListDeletedProductsRequest{
+		Page:       int32(page),
+		PageSize:   int32(pageSize),
+		NameFilter: r.URL.Query().Get("name_filter"),
+		SortBy:     r.URL.Query().Get("sort_by"),
+		SortOrder:  r.URL.Query().Get("sort_order"),
+		PageToken:  r.URL.Query().Get("page_token"),
+		Filter:     r.URL.Query().Get("filter"),
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.ListDeletedProducts(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to list deleted products", err)
+		return
+	}
+
+	productDTOs := make([]ProductDTO, len(rsp.Products))
+	for i, product := range rsp.Products {
+		productDTOs[i] = toProductDTO(product)
+	}
+
+	Ok(w, http.StatusOK, ListProductsResponse{
+		Products:      productDTOs,
+		Total:         rsp.Total,
+		Page:          int32(page),
+		PageSize:      int32(pageSize),
+		NextPageToken: rsp.NextPageToken,
+	})
+}
+
 func ListProducts(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 	page, _ := strconv.ParseInt(r.URL.Query().Get("page"), 10, 32)
 	pageSize, _ := strconv.ParseInt(r.URL.Query().Get("page_size"), 10, 32)
 	nameFilter := r.URL.Query().Get("name_filter")
+	sortBy := r.URL.Query().Get("sort_by")
+	sortOrder := r.URL.Query().Get("sort_order")
+	pageToken := r.URL.Query().Get("page_token")
+	filter := r.URL.Query().Get("filter")
+	warehouseID := r.URL.Query().Get("warehouse_id")
+	categoryID := r.URL.Query().Get("category_id")
 
 	if page <= 0 {
 		page = 1
@@ -163,36 +460,79 @@ func ListProducts(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req := &pb.ListProductsRequest{
-		Page:       int32(page),
-		PageSize:   int32(pageSize),
-		NameFilter: nameFilter,
+		Page:        int32(page),
+		PageSize:    int32(pageSize),
+		NameFilter:  nameFilter,
+		SortBy:      sortBy,
+		SortOrder:   sortOrder,
+		PageToken:   pageToken,
+		Filter:      filter,
+		WarehouseId: warehouseID,
+		CategoryId:  categoryID,
 	}
 
 	rsp, err := rpc.RpcClientProduct.Clt.ListProducts(ctx, req)
 	if err != nil {
-		Err(w, http.StatusInternalServerError, "failed to list products", err)
+		RpcErr(w, "failed to list products", err)
 		return
 	}
 
 	productDTOs := make([]ProductDTO, len(rsp.Products))
 	for i, product := range rsp.Products {
-		productDTOs[i] = ProductDTO{
-			ID:          product.Id,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
-			CreatedAt:   time.Unix(product.CreatedAt, 0),
-			UpdatedAt:   time.Unix(product.UpdatedAt, 0),
-		}
+		productDTOs[i] = toProductDTO(product)
 	}
 
 	response := ListProductsResponse{
-		Products: productDTOs,
-		Total:    rsp.Total,
-		Page:     int32(page),
-		PageSize: int32(pageSize),
+		Products:      productDTOs,
+		Total:         rsp.Total,
+		Page:          int32(page),
+		PageSize:      int32(pageSize),
+		NextPageToken: rsp.NextPageToken,
 	}
 
 	Ok(w, http.StatusOK, response)
 }
+
+func SearchProducts(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	limit, _ := strconv.ParseInt(r.URL.Query().Get("limit"), 10, 32)
+
+	if query == "" {
+		Err(w, http.StatusBadRequest, "invalid query", errors.New("q is required"))
+		return
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	req := &pb.SearchProductsRequest{
+		Query:    query,
+		PageSize: int32(limit),
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.SearchProducts(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to search products", err)
+		return
+	}
+
+	results := make([]SearchResultDTO, len(rsp.Results))
+	for i, result := range rsp.Results {
+		results[i] = SearchResultDTO{
+			Product:              toProductDTO(result.Product),
+			Score:                result.Score,
+			NameHighlight:        result.NameHighlight,
+			DescriptionHighlight: result.DescriptionHighlight,
+		}
+	}
+
+	Ok(w, http.StatusOK, SearchProductsResponse{
+		Query:   query,
+		Results: results,
+	})
+}
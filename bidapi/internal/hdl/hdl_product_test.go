@@ -0,0 +1,118 @@
+package hdl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeProductService records the update it is sent and answers with err or
+// the product at the next version
+type fakeProductService struct {
+	pb.ProductServiceClient
+	update *pb.UpdateProductRequest
+	err    error
+}
+
+func (f *fakeProductService) UpdateProduct(ctx context.Context, in *pb.UpdateProductRequest, opts ...grpc.CallOption) (*pb.UpdateProductResponse, error) {
+	f.update = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.UpdateProductResponse{Product: &pb.Product{Id: in.Id, Name: in.GetName(), Version: in.ExpectedVersion + 1}}, nil
+}
+
+// newTestRouter routes the product updates to a fake product service
+func newTestRouter(t *testing.T, svc *fakeProductService) http.Handler {
+	t.Helper()
+	prev := rpc.RpcClientProduct
+	rpc.RpcClientProduct = &rpc.ProductClient{Clt: svc}
+	t.Cleanup(func() { rpc.RpcClientProduct = prev })
+
+	r := chi.NewRouter()
+	r.Put("/products/{id}", UpdateProduct)
+	return r
+}
+
+func TestUpdateProduct_ReplacesEveryField(t *testing.T) {
+	tests := []struct {
+		body  string
+		check func(req *pb.UpdateProductRequest) bool
+	}{
+		// absent fields are sent empty, so they are reset
+		{`{"name": "Brie", "price": {"currency_code": "EUR", "amount_minor": 450}}`, func(req *pb.UpdateProductRequest) bool {
+			return req.GetName() == "Brie" && req.GetDescription() == "" && req.GetCategoryId() == "" && req.Units == nil &&
+				req.Attributes == nil && req.Allergens == nil && req.Nutrition == nil && req.DietaryTags == nil && req.Barcodes == nil
+		}},
+		{`{"name": "Brie", "price": 4.5, "units": {"base_unit": "kg"}, "attributes": {"size_g": "200"},
+			"allergens": {"milk": "contains"}, "dietary_tags": ["vegetarian"], "barcodes": [{"gtin": "4006381333931"}], "quantity": 0}`,
+			func(req *pb.UpdateProductRequest) bool {
+				return req.GetUnits().GetBaseUnit() == "kg" && len(req.Attributes) == 1 && len(req.Allergens) == 1 &&
+					slices.Equal(req.DietaryTags, []string{"vegetarian"}) && len(req.Barcodes) == 1 && req.Quantity == nil
+			}},
+	}
+	for _, tt := range tests {
+		svc := &fakeProductService{}
+		rec := httptest.NewRecorder()
+		newTestRouter(t, svc).ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/products/p1", strings.NewReader(tt.body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("PUT %s = %d %s, want 200", tt.body, rec.Code, rec.Body)
+		}
+		if !slices.Equal(svc.update.GetUpdateMask().GetPaths(), replacedPaths) {
+			t.Errorf("PUT %s sent the mask %v, want %v", tt.body, svc.update.GetUpdateMask().GetPaths(), replacedPaths)
+		}
+		if svc.update.Id != "p1" || !tt.check(svc.update) {
+			t.Errorf("PUT %s sent %v", tt.body, svc.update)
+		}
+	}
+}
+
+func TestUpdateProduct_IfMatch(t *testing.T) {
+	stale := statusErr(t, codes.FailedPrecondition, precondition(versionViolation))
+	tests := []struct {
+		ifMatch string
+		err     error
+		status  int
+		// sent is the expected version the service is asked for, -1 when
+		// the request must not reach it
+		sent int64
+	}{
+		{"", nil, http.StatusOK, 0},
+		{"*", nil, http.StatusOK, 0},
+		{`"4"`, nil, http.StatusOK, 4},
+		{`"4"`, stale, http.StatusPreconditionFailed, 4},
+		{`W/"4"`, nil, http.StatusPreconditionFailed, -1},
+		{`"four"`, nil, http.StatusPreconditionFailed, -1},
+	}
+	for _, tt := range tests {
+		svc := &fakeProductService{err: tt.err}
+		r := httptest.NewRequest(http.MethodPut, "/products/p1", strings.NewReader(`{"name": "Brie", "price": 4.5}`))
+		if tt.ifMatch != "" {
+			r.Header.Set("If-Match", tt.ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		newTestRouter(t, svc).ServeHTTP(rec, r)
+		if rec.Code != tt.status {
+			t.Errorf("If-Match %q: status %d, want %d", tt.ifMatch, rec.Code, tt.status)
+		}
+		switch {
+		case tt.sent < 0 && svc.update != nil:
+			t.Errorf("If-Match %q should not reach the service", tt.ifMatch)
+		case tt.sent >= 0 && svc.update.GetExpectedVersion() != tt.sent:
+			t.Errorf("If-Match %q sent the expected version %d, want %d", tt.ifMatch, svc.update.GetExpectedVersion(), tt.sent)
+		}
+		if want := etag(tt.sent + 1); rec.Code == http.StatusOK && rec.Header().Get("ETag") != want {
+			t.Errorf("If-Match %q: ETag %q, want %q", tt.ifMatch, rec.Header().Get("ETag"), want)
+		}
+	}
+}
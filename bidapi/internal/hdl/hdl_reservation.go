@@ -0,0 +1,99 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toReservationDTO converts a protobuf reservation to its JSON form
+func toReservationDTO(res *pb.Reservation) ReservationDTO {
+	return ReservationDTO{
+		ID:          res.Id,
+		ProductID:   res.ProductId,
+		WarehouseID: res.WarehouseId,
+		Quantity:    res.Quantity,
+		Lots:        toLotQuantityDTOs(res.Lots),
+		Status:      res.Status,
+		Actor:       res.Actor,
+		ExpiresAt:   time.Unix(res.ExpiresAt, 0),
+		CreatedAt:   time.Unix(res.CreatedAt, 0),
+		UpdatedAt:   time.Unix(res.UpdatedAt, 0),
+	}
+}
+
+// ReserveStock holds available stock of a product for a checkout
+func ReserveStock(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args ReserveStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.ReserveStockRequest{
+		ProductId:   chi.URLParam(r, "id"),
+		Quantity:    args.Quantity.Count,
+		Measure:     args.Quantity.measure(),
+		TtlSeconds:  args.TTLSeconds,
+		Actor:       args.Actor,
+		WarehouseId: args.WarehouseID,
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.ReserveStock(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to reserve stock", err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusCreated, ReservationResponse{
+		Reservation: toReservationDTO(rsp.Reservation),
+		Product:     toProductDTO(rsp.Product),
+	})
+}
+
+// CommitReservation dispatches the stock held by a reservation
+func CommitReservation(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.Clt.CommitReservation(ctx, &pb.CommitReservationRequest{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to commit reservation", err)
+		return
+	}
+
+	movement := toStockMovementDTO(rsp.Movement)
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusOK, ReservationResponse{
+		Reservation: toReservationDTO(rsp.Reservation),
+		Movement:    &movement,
+		Product:     toProductDTO(rsp.Product),
+	})
+}
+
+// ReleaseReservation gives the stock held by a reservation back
+func ReleaseReservation(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.Clt.ReleaseReservation(ctx, &pb.ReleaseReservationRequest{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to release reservation", err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusOK, ReservationResponse{
+		Reservation: toReservationDTO(rsp.Reservation),
+		Product:     toProductDTO(rsp.Product),
+	})
+}
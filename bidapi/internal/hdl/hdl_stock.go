@@ -0,0 +1,218 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toStockMovementDTO converts a protobuf stock movement to its JSON form
+func toStockMovementDTO(m *pb.StockMovement) StockMovementDTO {
+	return StockMovementDTO{
+		ID:            m.Id,
+		ProductID:     m.ProductId,
+		WarehouseID:   m.WarehouseId,
+		ToWarehouseID: m.ToWarehouseId,
+		Kind:          m.Kind,
+		Delta:         m.Delta,
+		Lots:          toLotQuantityDTOs(m.Lots),
+		Balance:       m.Balance,
+		Reason:        m.Reason,
+		Actor:         m.Actor,
+		Version:       m.Version,
+		CreatedAt:     time.Unix(m.CreatedAt, 0),
+	}
+}
+
+// toBatchDTOs converts the protobuf batches of a product to their JSON form
+func toBatchDTOs(batches []*pb.Batch) []BatchDTO {
+	dtos := make([]BatchDTO, len(batches))
+	for i, b := range batches {
+		dtos[i] = toBatchDTO(b)
+	}
+	return dtos
+}
+
+// toBatchDTO converts a protobuf batch to its JSON form
+func toBatchDTO(b *pb.Batch) BatchDTO {
+	dto := BatchDTO{
+		WarehouseID: b.GetWarehouseId(),
+		LotCode:     b.GetLotCode(),
+		ReceivedAt:  time.Unix(b.GetReceivedAt(), 0),
+		Quantity:    b.GetQuantity(),
+		Reserved:    b.GetReserved(),
+		Available:   b.GetAvailable(),
+	}
+	if b.GetExpiresAt() != 0 {
+		expiresAt := time.Unix(b.GetExpiresAt(), 0)
+		dto.ExpiresAt = &expiresAt
+	}
+	return dto
+}
+
+// toLotQuantityDTOs converts the split by lot of a movement or reservation,
+// nil when it has none
+func toLotQuantityDTOs(lots []*pb.LotQuantity) []LotQuantityDTO {
+	var dtos []LotQuantityDTO
+	for _, l := range lots {
+		dtos = append(dtos, LotQuantityDTO{LotCode: l.LotCode, Quantity: l.Quantity})
+	}
+	return dtos
+}
+
+// RecordStockMovement books a receipt, adjustment, dispatch or write-off
+// against the stock of a product, honouring If-Match
+func RecordStockMovement(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args RecordStockMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		Err(w, http.StatusPreconditionFailed, "precondition failed", errors.New("If-Match must be \"*\" or a product ETag"))
+		return
+	}
+
+	req := &pb.RecordStockMovementRequest{
+		ProductId:       chi.URLParam(r, "id"),
+		Kind:            args.Kind,
+		Quantity:        args.Quantity.Count,
+		Measure:         args.Quantity.measure(),
+		Reason:          args.Reason,
+		Actor:           args.Actor,
+		ExpectedVersion: version,
+		WarehouseId:     args.WarehouseID,
+		LotCode:         args.LotCode,
+		ExpiresAt:       unixOrZero(args.ExpiresAt),
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.RecordStockMovement(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to record stock movement", err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusCreated, RecordStockMovementResponse{
+		Movement: toStockMovementDTO(rsp.Movement),
+		Product:  toProductDTO(rsp.Product),
+	})
+}
+
+// TransferStock moves stock of a product between warehouses, honouring
+// If-Match
+func TransferStock(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args TransferStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		Err(w, http.StatusPreconditionFailed, "precondition failed", errors.New("If-Match must be \"*\" or a product ETag"))
+		return
+	}
+
+	req := &pb.TransferStockRequest{
+		ProductId:       chi.URLParam(r, "id"),
+		FromWarehouseId: args.FromWarehouseID,
+		ToWarehouseId:   args.ToWarehouseID,
+		Quantity:        args.Quantity.Count,
+		Measure:         args.Quantity.measure(),
+		Reason:          args.Reason,
+		Actor:           args.Actor,
+		ExpectedVersion: version,
+		LotCode:         args.LotCode,
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.TransferStock(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to transfer stock", err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(rsp.Product.Version))
+	Ok(w, http.StatusCreated, RecordStockMovementResponse{
+		Movement: toStockMovementDTO(rsp.Movement),
+		Product:  toProductDTO(rsp.Product),
+	})
+}
+
+func ListStockMovements(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	pageSize, _ := strconv.ParseInt(r.URL.Query().Get("page_size"), 10, 32)
+
+	req := &pb.ListStockMovementsRequest{
+		ProductId: chi.URLParam(r, "id"),
+		PageSize:  int32(pageSize),
+		PageToken: r.URL.Query().Get("page_token"),
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.ListStockMovements(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to list stock movements", err)
+		return
+	}
+
+	movements := make([]StockMovementDTO, len(rsp.Movements))
+	for i, m := range rsp.Movements {
+		movements[i] = toStockMovementDTO(m)
+	}
+
+	Ok(w, http.StatusOK, ListStockMovementsResponse{
+		Movements:     movements,
+		NextPageToken: rsp.NextPageToken,
+	})
+}
+
+// ListExpiringStock reports the lots expiring within within_days, 7 by
+// default, in warehouse_id or every warehouse, soonest first
+func ListExpiringStock(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	days := int64(7)
+	if s := r.URL.Query().Get("within_days"); s != "" {
+		var err error
+		if days, err = strconv.ParseInt(s, 10, 32); err != nil {
+			Err(w, http.StatusBadRequest, "invalid within_days", err)
+			return
+		}
+	}
+
+	req := &pb.ListExpiringStockRequest{
+		WithinDays:  int32(days),
+		WarehouseId: r.URL.Query().Get("warehouse_id"),
+	}
+	rsp, err := rpc.RpcClientProduct.Clt.ListExpiringStock(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to list expiring stock", err)
+		return
+	}
+
+	stock := make([]ExpiringStockDTO, len(rsp.Stock))
+	for i, s := range rsp.Stock {
+		stock[i] = ExpiringStockDTO{
+			ProductID:   s.ProductId,
+			ProductName: s.ProductName,
+			DaysLeft:    s.DaysLeft,
+			BatchDTO:    toBatchDTO(s.Batch),
+		}
+	}
+
+	Ok(w, http.StatusOK, ListExpiringStockResponse{Stock: stock})
+}
@@ -0,0 +1,217 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toSupplierDTO converts a protobuf supplier to its JSON form
+func toSupplierDTO(s *pb.Supplier) SupplierDTO {
+	return SupplierDTO{
+		ID:           s.Id,
+		Name:         s.Name,
+		ContactName:  s.ContactName,
+		Email:        s.Email,
+		Phone:        s.Phone,
+		LeadTimeDays: s.LeadTimeDays,
+		CreatedAt:    time.Unix(s.CreatedAt, 0),
+		UpdatedAt:    time.Unix(s.UpdatedAt, 0),
+	}
+}
+
+// toProductSupplierDTO converts a protobuf supplier link to its JSON form
+func toProductSupplierDTO(l *pb.ProductSupplier) ProductSupplierDTO {
+	dto := ProductSupplierDTO{
+		ProductID:   l.ProductId,
+		SupplierID:  l.SupplierId,
+		SupplierSKU: l.SupplierSku,
+		CostPrice:   toMoneyDTO(l.CostPriceMoney),
+		Preferred:   l.Preferred,
+		CreatedAt:   time.Unix(l.CreatedAt, 0),
+		UpdatedAt:   time.Unix(l.UpdatedAt, 0),
+	}
+	if l.Supplier != nil {
+		s := toSupplierDTO(l.Supplier)
+		dto.Supplier = &s
+	}
+	return dto
+}
+
+func CreateSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args SupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.CreateSupplierRequest{
+		Name:         args.Name,
+		ContactName:  args.ContactName,
+		Email:        args.Email,
+		Phone:        args.Phone,
+		LeadTimeDays: args.LeadTimeDays,
+	}
+
+	rsp, err := rpc.RpcClientProduct.SupplierClt.CreateSupplier(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to create supplier", err)
+		return
+	}
+
+	Ok(w, http.StatusCreated, toSupplierDTO(rsp.Supplier))
+}
+
+func GetSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.SupplierClt.GetSupplier(ctx, &pb.GetSupplierRequest{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to get supplier", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toSupplierDTO(rsp.Supplier))
+}
+
+func ListSuppliers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.SupplierClt.ListSuppliers(ctx, &pb.ListSuppliersRequest{})
+	if err != nil {
+		RpcErr(w, "failed to list suppliers", err)
+		return
+	}
+
+	suppliers := make([]SupplierDTO, len(rsp.Suppliers))
+	for i, s := range rsp.Suppliers {
+		suppliers[i] = toSupplierDTO(s)
+	}
+
+	Ok(w, http.StatusOK, ListSuppliersResponse{Suppliers: suppliers})
+}
+
+// UpdateSupplier replaces all details of a supplier
+func UpdateSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args SupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.UpdateSupplierRequest{
+		Id:           chi.URLParam(r, "id"),
+		Name:         args.Name,
+		ContactName:  args.ContactName,
+		Email:        args.Email,
+		Phone:        args.Phone,
+		LeadTimeDays: args.LeadTimeDays,
+	}
+
+	rsp, err := rpc.RpcClientProduct.SupplierClt.UpdateSupplier(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to update supplier", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toSupplierDTO(rsp.Supplier))
+}
+
+// DeleteSupplier deletes a supplier, answering 409 while products are linked
+// to it
+func DeleteSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if _, err := rpc.RpcClientProduct.SupplierClt.DeleteSupplier(ctx, &pb.DeleteSupplierRequest{Id: chi.URLParam(r, "id")}); err != nil {
+		RpcErr(w, "failed to delete supplier", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "supplier deleted successfully",
+	})
+}
+
+// ListProductSuppliers lists where a product is sourced from, the preferred
+// supplier first
+func ListProductSuppliers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	req := &pb.ListProductSuppliersRequest{ProductId: chi.URLParam(r, "id")}
+	rsp, err := rpc.RpcClientProduct.SupplierClt.ListProductSuppliers(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to list product suppliers", err)
+		return
+	}
+
+	links := make([]ProductSupplierDTO, len(rsp.ProductSuppliers))
+	for i, l := range rsp.ProductSuppliers {
+		links[i] = toProductSupplierDTO(l)
+	}
+
+	Ok(w, http.StatusOK, ListProductSuppliersResponse{Suppliers: links})
+}
+
+// LinkProductSupplier links a product to a supplier, replacing the SKU, cost
+// price and preference of an existing link
+func LinkProductSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args LinkProductSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.LinkProductSupplierRequest{
+		ProductId:      chi.URLParam(r, "id"),
+		SupplierId:     chi.URLParam(r, "supplierId"),
+		SupplierSku:    args.SupplierSKU,
+		CostPrice:      args.CostPrice.Legacy,
+		CostPriceMoney: args.CostPrice.proto(),
+		Preferred:      args.Preferred,
+	}
+
+	rsp, err := rpc.RpcClientProduct.SupplierClt.LinkProductSupplier(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to link product supplier", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toProductSupplierDTO(rsp.ProductSupplier))
+}
+
+// UnlinkProductSupplier removes a supplier from the sources of a product
+func UnlinkProductSupplier(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	req := &pb.UnlinkProductSupplierRequest{
+		ProductId:  chi.URLParam(r, "id"),
+		SupplierId: chi.URLParam(r, "supplierId"),
+	}
+	if _, err := rpc.RpcClientProduct.SupplierClt.UnlinkProductSupplier(ctx, req); err != nil {
+		RpcErr(w, "failed to unlink product supplier", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "product supplier unlinked successfully",
+	})
+}
@@ -0,0 +1,128 @@
+package hdl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/athxx/bidfood/bidapi/internal/rpc"
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+
+	chi "github.com/go-chi/chi/v5"
+)
+
+// toWarehouseDTO converts a protobuf warehouse to its JSON form
+func toWarehouseDTO(wh *pb.Warehouse) WarehouseDTO {
+	return WarehouseDTO{
+		ID:        wh.Id,
+		Name:      wh.Name,
+		Address:   wh.Address,
+		CreatedAt: time.Unix(wh.CreatedAt, 0),
+		UpdatedAt: time.Unix(wh.UpdatedAt, 0),
+	}
+}
+
+// toWarehouseStockDTOs converts the stock of a product by warehouse
+func toWarehouseStockDTOs(stock []*pb.WarehouseStock) []WarehouseStockDTO {
+	out := make([]WarehouseStockDTO, len(stock))
+	for i, s := range stock {
+		out[i] = WarehouseStockDTO{
+			WarehouseID: s.WarehouseId,
+			Quantity:    s.Quantity,
+			Reserved:    s.Reserved,
+			Available:   s.Available,
+		}
+	}
+	return out
+}
+
+func CreateWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args WarehouseRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.CreateWarehouse(ctx, &pb.CreateWarehouseRequest{Name: args.Name, Address: args.Address})
+	if err != nil {
+		RpcErr(w, "failed to create warehouse", err)
+		return
+	}
+
+	Ok(w, http.StatusCreated, toWarehouseDTO(rsp.Warehouse))
+}
+
+func GetWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.Clt.GetWarehouse(ctx, &pb.GetWarehouseRequest{Id: chi.URLParam(r, "id")})
+	if err != nil {
+		RpcErr(w, "failed to get warehouse", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toWarehouseDTO(rsp.Warehouse))
+}
+
+func ListWarehouses(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	rsp, err := rpc.RpcClientProduct.Clt.ListWarehouses(ctx, &pb.ListWarehousesRequest{})
+	if err != nil {
+		RpcErr(w, "failed to list warehouses", err)
+		return
+	}
+
+	warehouses := make([]WarehouseDTO, len(rsp.Warehouses))
+	for i, wh := range rsp.Warehouses {
+		warehouses[i] = toWarehouseDTO(wh)
+	}
+
+	Ok(w, http.StatusOK, ListWarehousesResponse{Warehouses: warehouses})
+}
+
+// UpdateWarehouse replaces the name and address of a warehouse
+func UpdateWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	var args WarehouseRequest
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		Err(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	req := &pb.UpdateWarehouseRequest{
+		Id:      chi.URLParam(r, "id"),
+		Name:    args.Name,
+		Address: args.Address,
+	}
+
+	rsp, err := rpc.RpcClientProduct.Clt.UpdateWarehouse(ctx, req)
+	if err != nil {
+		RpcErr(w, "failed to update warehouse", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, toWarehouseDTO(rsp.Warehouse))
+}
+
+// DeleteWarehouse deletes a warehouse, answering 409 while it holds stock
+func DeleteWarehouse(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if _, err := rpc.RpcClientProduct.Clt.DeleteWarehouse(ctx, &pb.DeleteWarehouseRequest{Id: chi.URLParam(r, "id")}); err != nil {
+		RpcErr(w, "failed to delete warehouse", err)
+		return
+	}
+
+	Ok(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "warehouse deleted successfully",
+	})
+}
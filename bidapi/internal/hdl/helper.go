@@ -4,6 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Response struct {
@@ -35,3 +42,96 @@ func Err(w http.ResponseWriter, status int, message string, err error) {
 		Data: err.Error(),
 	})
 }
+
+// versionViolation is the PreconditionFailure violation type the service
+// marks a stale expected version with
+const versionViolation = "VERSION"
+
+// RpcErr writes a failed RPC with the HTTP status matching its gRPC code,
+// the field violations of an invalid argument are passed on. A failed
+// precondition is a 409 unless it is a stale expected version, a 412.
+func RpcErr(w http.ResponseWriter, message string, err error) {
+	st := status.Convert(err)
+	code := httpStatus(st.Code())
+	rsp := ErrorResponse{
+		Error:   st.Code().String(),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				rsp.FieldViolations = append(rsp.FieldViolations, FieldViolationDTO{
+					Field:       v.GetField(),
+					Description: v.GetDescription(),
+				})
+			}
+		case *errdetails.PreconditionFailure:
+			if slices.ContainsFunc(d.GetViolations(), func(v *errdetails.PreconditionFailure_Violation) bool {
+				return v.GetType() == versionViolation
+			}) {
+				code = http.StatusPreconditionFailed
+			}
+		}
+	}
+	rsp.Code = code
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(Response{
+		Code: code,
+		Msg:  message,
+		Data: rsp,
+	})
+}
+
+// httpStatus maps a gRPC status code to the HTTP status a client expects
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // client closed request
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// etag formats a product version as a strong entity tag
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion reads the If-Match header as the product version the
+// caller expects, 0 when the header is absent or "*". ok is false when the
+// header cannot match any version, such as a weak or malformed tag.
+func ifMatchVersion(r *http.Request) (version int64, ok bool) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, true
+	}
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(v[1:len(v)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
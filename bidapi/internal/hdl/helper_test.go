@@ -0,0 +1,108 @@
+package hdl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// statusErr returns a gRPC status error carrying details
+func statusErr(t *testing.T, code codes.Code, details ...protoadapt.MessageV1) error {
+	t.Helper()
+	st, err := status.New(code, code.String()).WithDetails(details...)
+	if err != nil {
+		t.Fatalf("WithDetails failed: %v", err)
+	}
+	return st.Err()
+}
+
+// precondition returns a PreconditionFailure with one violation of typ
+func precondition(typ string) *errdetails.PreconditionFailure {
+	return &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{Type: typ}}}
+}
+
+func TestRpcErr(t *testing.T) {
+	badRequest := &errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+		{Field: "price", Description: "must not be negative"},
+	}}
+	tests := []struct {
+		err    error
+		status int
+		fields int
+	}{
+		{statusErr(t, codes.InvalidArgument, badRequest), http.StatusBadRequest, 1},
+		{statusErr(t, codes.OutOfRange), http.StatusBadRequest, 0},
+		{statusErr(t, codes.NotFound), http.StatusNotFound, 0},
+		{statusErr(t, codes.AlreadyExists), http.StatusConflict, 0},
+		{statusErr(t, codes.Aborted), http.StatusConflict, 0},
+		// only a stale expected version fails the If-Match precondition,
+		// other failed preconditions are state conflicts
+		{statusErr(t, codes.FailedPrecondition, precondition(versionViolation)), http.StatusPreconditionFailed, 0},
+		{statusErr(t, codes.FailedPrecondition, precondition("STOCK")), http.StatusConflict, 0},
+		{statusErr(t, codes.FailedPrecondition), http.StatusConflict, 0},
+		{statusErr(t, codes.Unavailable), http.StatusServiceUnavailable, 0},
+		{statusErr(t, codes.DeadlineExceeded), http.StatusGatewayTimeout, 0},
+		{statusErr(t, codes.Canceled), 499, 0},
+		{statusErr(t, codes.Internal), http.StatusInternalServerError, 0},
+		{errors.New("connection reset"), http.StatusInternalServerError, 0},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		RpcErr(rec, "failed", tt.err)
+		if rec.Code != tt.status {
+			t.Errorf("RpcErr(%v) wrote %d, want %d", tt.err, rec.Code, tt.status)
+		}
+		var rsp struct {
+			Code int           `json:"code"`
+			Data ErrorResponse `json:"data"`
+		}
+		if err := json.NewDecoder(rec.Body).Decode(&rsp); err != nil {
+			t.Fatalf("decoding the response failed: %v", err)
+		}
+		if rsp.Code != tt.status || rsp.Data.Code != tt.status || rsp.Data.Error != status.Code(tt.err).String() {
+			t.Errorf("RpcErr(%v) = %+v, want code %d", tt.err, rsp, tt.status)
+		}
+		if len(rsp.Data.FieldViolations) != tt.fields {
+			t.Errorf("RpcErr(%v) passed on %v, want %d field violations", tt.err, rsp.Data.FieldViolations, tt.fields)
+		}
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int64
+		ok      bool
+	}{
+		{"", 0, true},
+		{"*", 0, true},
+		{` "7" `, 7, true},
+		{etag(42), 42, true},
+		{`W/"7"`, 0, false},
+		{`7`, 0, false},
+		{`"seven"`, 0, false},
+		{`"0"`, 0, false},
+		{`"-3"`, 0, false},
+		{`"`, 0, false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodDelete, "/products/p1", nil)
+		if tt.header != "" {
+			r.Header.Set("If-Match", tt.header)
+		}
+		version, ok := ifMatchVersion(r)
+		if version != tt.version || ok != tt.ok {
+			t.Errorf("ifMatchVersion(%q) = %d, %v, want %d, %v", tt.header, version, ok, tt.version, tt.ok)
+		}
+	}
+	if got := etag(3); got != `"3"` {
+		t.Errorf(`etag(3) = %s, want "3"`, got)
+	}
+}
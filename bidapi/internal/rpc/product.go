@@ -7,10 +7,14 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// ProductClient wraps the gRPC client for product service
+// ProductClient wraps the gRPC clients for the product, supplier, pricing
+// and auction services, all served by bidrpc over one connection
 type ProductClient struct {
-	Clt  pb.ProductServiceClient
-	conn *grpc.ClientConn
+	Clt         pb.ProductServiceClient
+	SupplierClt pb.SupplierServiceClient
+	PricingClt  pb.PricingServiceClient
+	AuctionClt  pb.AuctionServiceClient
+	conn        *grpc.ClientConn
 }
 
 var RpcClientProduct *ProductClient
@@ -27,8 +31,11 @@ func NewProductClient(addr string) (*ProductClient, error) {
 		return nil, err
 	}
 	return &ProductClient{
-		Clt:  pb.NewProductServiceClient(conn),
-		conn: conn,
+		Clt:         pb.NewProductServiceClient(conn),
+		SupplierClt: pb.NewSupplierServiceClient(conn),
+		PricingClt:  pb.NewPricingServiceClient(conn),
+		AuctionClt:  pb.NewAuctionServiceClient(conn),
+		conn:        conn,
 	}, nil
 }
 
@@ -9,6 +9,7 @@ package bidrpcproto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,14 +24,54 @@ const (
 
 // Product represents a product in the inventory
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: use price_money, this is its amount as a double
+	Price float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	// stock on hand across all warehouses
+	Quantity  int32 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt int64 `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// starts at 1 and increases with every update
+	Version int64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	// part of quantity held by open reservations
+	Reserved int32 `protobuf:"varint,9,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	// quantity less reserved, what can still be reserved or dispatched
+	Available int32 `protobuf:"varint,10,opt,name=available,proto3" json:"available,omitempty"`
+	// stock by warehouse, ordered by warehouse id, warehouses without stock
+	// are left out
+	Stock []*WarehouseStock `protobuf:"bytes,11,rep,name=stock,proto3" json:"stock,omitempty"`
+	// category the product is filed under, empty when uncategorized
+	CategoryId string `protobuf:"bytes,12,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// exact unit price
+	PriceMoney *Money `protobuf:"bytes,13,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`
+	// how the stock is counted, quantity, reserved and available are in the
+	// base unit
+	Units *UnitsOfMeasure `protobuf:"bytes,14,opt,name=units,proto3" json:"units,omitempty"`
+	// stock tracked by lot, by warehouse id and then first expiry first out
+	Batches []*Batch `protobuf:"bytes,15,rep,name=batches,proto3" json:"batches,omitempty"`
+	// the product this one is a variant of, empty for a standalone product or
+	// a parent
+	ParentId string `protobuf:"bytes,16,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// values of the attributes the category defines, ordered by name
+	Attributes []*AttributeValue `protobuf:"bytes,17,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// the variants of a parent ordered by name, only filled in by GetProduct
+	Variants []*Product `protobuf:"bytes,18,rep,name=variants,proto3" json:"variants,omitempty"`
+	// declared allergens in label order, an allergen left out is unknown
+	Allergens []*AllergenDeclaration `protobuf:"bytes,19,rep,name=allergens,proto3" json:"allergens,omitempty"`
+	// not set when the product declares no nutrition facts
+	Nutrition *Nutrition `protobuf:"bytes,20,opt,name=nutrition,proto3" json:"nutrition,omitempty"`
+	// sorted dietary claims: vegan, vegetarian, halal, kosher, gluten_free
+	DietaryTags []string `protobuf:"bytes,21,rep,name=dietary_tags,json=dietaryTags,proto3" json:"dietary_tags,omitempty"`
+	// GTIN-14 barcodes of the product and its packs ordered by gtin
+	Barcodes []*Barcode `protobuf:"bytes,22,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	// short unique code for pick lists and the ERP, e.g. CHE-00042-4; empty
+	// for products created before SKUs existed
+	Sku string `protobuf:"bytes,23,opt,name=sku,proto3" json:"sku,omitempty"`
+	// when the product was moved to the trash, 0 while it is live
+	DeletedAt     int64 `protobuf:"varint,24,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -114,31 +155,151 @@ func (x *Product) GetUpdatedAt() int64 {
 	return 0
 }
 
-// Request messages
-type CreateProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+func (x *Product) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Product) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Product) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Product) GetStock() []*WarehouseStock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+func (x *Product) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Product) GetPriceMoney() *Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
+}
+
+func (x *Product) GetUnits() *UnitsOfMeasure {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *Product) GetBatches() []*Batch {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
+}
+
+func (x *Product) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Product) GetAttributes() []*AttributeValue {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Product) GetVariants() []*Product {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Product) GetAllergens() []*AllergenDeclaration {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *Product) GetNutrition() *Nutrition {
+	if x != nil {
+		return x.Nutrition
+	}
+	return nil
+}
+
+func (x *Product) GetDietaryTags() []string {
+	if x != nil {
+		return x.DietaryTags
+	}
+	return nil
+}
+
+func (x *Product) GetBarcodes() []*Barcode {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+func (x *Product) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *Product) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+// Barcode is a GTIN printed on a product or one of its packs. A GTIN-8, 12
+// or 13 is zero padded to a GTIN-14.
+type Barcode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Gtin  string                 `protobuf:"bytes,1,opt,name=gtin,proto3" json:"gtin,omitempty"`
+	// the unit of the product carrying the barcode, e.g. a case; empty is
+	// the base unit
+	Unit          string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
+func (x *Barcode) Reset() {
+	*x = Barcode{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductRequest) String() string {
+func (x *Barcode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
+func (*Barcode) ProtoMessage() {}
 
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+func (x *Barcode) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -150,60 +311,51 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Barcode.ProtoReflect.Descriptor instead.
+func (*Barcode) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateProductRequest) GetName() string {
+func (x *Barcode) GetGtin() string {
 	if x != nil {
-		return x.Name
+		return x.Gtin
 	}
 	return ""
 }
 
-func (x *CreateProductRequest) GetDescription() string {
+func (x *Barcode) GetUnit() string {
 	if x != nil {
-		return x.Description
+		return x.Unit
 	}
 	return ""
 }
 
-func (x *CreateProductRequest) GetPrice() float64 {
-	if x != nil {
-		return x.Price
-	}
-	return 0
-}
-
-func (x *CreateProductRequest) GetQuantity() int32 {
-	if x != nil {
-		return x.Quantity
-	}
-	return 0
-}
-
-type GetProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+// AllergenDeclaration is what a product declares about one of the 14 major
+// allergens: celery, gluten, crustacean, egg, fish, lupin, milk, mollusc,
+// mustard, tree_nut, peanut, sesame, soy and sulphite
+type AllergenDeclaration struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Allergen string                 `protobuf:"bytes,1,opt,name=allergen,proto3" json:"allergen,omitempty"`
+	// contains, may_contain or free_from
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
+func (x *AllergenDeclaration) Reset() {
+	*x = AllergenDeclaration{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *AllergenDeclaration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*AllergenDeclaration) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+func (x *AllergenDeclaration) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -215,43 +367,56 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AllergenDeclaration.ProtoReflect.Descriptor instead.
+func (*AllergenDeclaration) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *AllergenDeclaration) GetAllergen() string {
 	if x != nil {
-		return x.Id
+		return x.Allergen
 	}
 	return ""
 }
 
-type UpdateProductRequest struct {
+func (x *AllergenDeclaration) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Nutrition are the nutrition facts per 100 g, or 100 ml of a drink, in
+// grams except for the energy
+type Nutrition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      int32                  `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	EnergyKj      float64                `protobuf:"fixed64,1,opt,name=energy_kj,json=energyKj,proto3" json:"energy_kj,omitempty"`
+	EnergyKcal    float64                `protobuf:"fixed64,2,opt,name=energy_kcal,json=energyKcal,proto3" json:"energy_kcal,omitempty"`
+	Fat           float64                `protobuf:"fixed64,3,opt,name=fat,proto3" json:"fat,omitempty"`
+	SaturatedFat  float64                `protobuf:"fixed64,4,opt,name=saturated_fat,json=saturatedFat,proto3" json:"saturated_fat,omitempty"`
+	Carbohydrate  float64                `protobuf:"fixed64,5,opt,name=carbohydrate,proto3" json:"carbohydrate,omitempty"`
+	Sugars        float64                `protobuf:"fixed64,6,opt,name=sugars,proto3" json:"sugars,omitempty"`
+	Fibre         float64                `protobuf:"fixed64,7,opt,name=fibre,proto3" json:"fibre,omitempty"`
+	Protein       float64                `protobuf:"fixed64,8,opt,name=protein,proto3" json:"protein,omitempty"`
+	Salt          float64                `protobuf:"fixed64,9,opt,name=salt,proto3" json:"salt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
+func (x *Nutrition) Reset() {
+	*x = Nutrition{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *Nutrition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*Nutrition) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+func (x *Nutrition) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -263,67 +428,98 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Nutrition.ProtoReflect.Descriptor instead.
+func (*Nutrition) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *UpdateProductRequest) GetId() string {
+func (x *Nutrition) GetEnergyKj() float64 {
 	if x != nil {
-		return x.Id
+		return x.EnergyKj
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetName() string {
+func (x *Nutrition) GetEnergyKcal() float64 {
 	if x != nil {
-		return x.Name
+		return x.EnergyKcal
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetDescription() string {
+func (x *Nutrition) GetFat() float64 {
 	if x != nil {
-		return x.Description
+		return x.Fat
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateProductRequest) GetPrice() float64 {
+func (x *Nutrition) GetSaturatedFat() float64 {
 	if x != nil {
-		return x.Price
+		return x.SaturatedFat
 	}
 	return 0
 }
 
-func (x *UpdateProductRequest) GetQuantity() int32 {
+func (x *Nutrition) GetCarbohydrate() float64 {
 	if x != nil {
-		return x.Quantity
+		return x.Carbohydrate
 	}
 	return 0
 }
 
-type DeleteProductRequest struct {
+func (x *Nutrition) GetSugars() float64 {
+	if x != nil {
+		return x.Sugars
+	}
+	return 0
+}
+
+func (x *Nutrition) GetFibre() float64 {
+	if x != nil {
+		return x.Fibre
+	}
+	return 0
+}
+
+func (x *Nutrition) GetProtein() float64 {
+	if x != nil {
+		return x.Protein
+	}
+	return 0
+}
+
+func (x *Nutrition) GetSalt() float64 {
+	if x != nil {
+		return x.Salt
+	}
+	return 0
+}
+
+// AttributeValue is the value of a product attribute, in the canonical form
+// of its type: numbers as in 1.5, booleans as true or false
+type AttributeValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
+func (x *AttributeValue) Reset() {
+	*x = AttributeValue{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *AttributeValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*AttributeValue) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+func (x *AttributeValue) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -335,41 +531,54 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttributeValue.ProtoReflect.Descriptor instead.
+func (*AttributeValue) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DeleteProductRequest) GetId() string {
+func (x *AttributeValue) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-type ListProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	NameFilter    string                 `protobuf:"bytes,3,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+func (x *AttributeValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// AttributeDefinition defines an attribute of the products in a category
+type AttributeDefinition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 1 to 32 lowercase letters, digits or underscores, e.g. size_g
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// text, number, boolean or enum
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// the values an enum takes
+	AllowedValues []string `protobuf:"bytes,3,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	// every product of the category must set a required attribute
+	Required      bool `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
+func (x *AttributeDefinition) Reset() {
+	*x = AttributeDefinition{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *AttributeDefinition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*AttributeDefinition) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+func (x *AttributeDefinition) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,54 +590,65 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AttributeDefinition.ProtoReflect.Descriptor instead.
+func (*AttributeDefinition) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListProductsRequest) GetPage() int32 {
+func (x *AttributeDefinition) GetName() string {
 	if x != nil {
-		return x.Page
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsRequest) GetPageSize() int32 {
+func (x *AttributeDefinition) GetType() string {
 	if x != nil {
-		return x.PageSize
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsRequest) GetNameFilter() string {
+func (x *AttributeDefinition) GetAllowedValues() []string {
 	if x != nil {
-		return x.NameFilter
+		return x.AllowedValues
 	}
-	return ""
+	return nil
 }
 
-// Response messages
-type CreateProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+func (x *AttributeDefinition) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// UnitsOfMeasure is how the stock of a product is counted: whole numbers of
+// base_unit, with alternate units converted to it
+type UnitsOfMeasure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. each or g, each when empty
+	BaseUnit string `protobuf:"bytes,1,opt,name=base_unit,json=baseUnit,proto3" json:"base_unit,omitempty"`
+	// ordered by factor
+	Conversions   []*UnitConversion `protobuf:"bytes,2,rep,name=conversions,proto3" json:"conversions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductResponse) Reset() {
-	*x = CreateProductResponse{}
+func (x *UnitsOfMeasure) Reset() {
+	*x = UnitsOfMeasure{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductResponse) String() string {
+func (x *UnitsOfMeasure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductResponse) ProtoMessage() {}
+func (*UnitsOfMeasure) ProtoMessage() {}
 
-func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+func (x *UnitsOfMeasure) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -440,39 +660,53 @@ func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnitsOfMeasure.ProtoReflect.Descriptor instead.
+func (*UnitsOfMeasure) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateProductResponse) GetProduct() *Product {
+func (x *UnitsOfMeasure) GetBaseUnit() string {
 	if x != nil {
-		return x.Product
+		return x.BaseUnit
+	}
+	return ""
+}
+
+func (x *UnitsOfMeasure) GetConversions() []*UnitConversion {
+	if x != nil {
+		return x.Conversions
 	}
 	return nil
 }
 
-type GetProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+// UnitConversion defines an alternate unit of a product as factor base
+// units, e.g. a case of 12 each or a kg of 1000 g
+type UnitConversion struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Code   string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Factor float64                `protobuf:"fixed64,2,opt,name=factor,proto3" json:"factor,omitempty"`
+	// a fractional unit, such as kg, takes quantities with a fraction that
+	// are rounded to the nearest base unit, other units are counted in whole
+	// numbers
+	Fractional    bool `protobuf:"varint,3,opt,name=fractional,proto3" json:"fractional,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductResponse) Reset() {
-	*x = GetProductResponse{}
+func (x *UnitConversion) Reset() {
+	*x = UnitConversion{}
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductResponse) String() string {
+func (x *UnitConversion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductResponse) ProtoMessage() {}
+func (*UnitConversion) ProtoMessage() {}
 
-func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+func (x *UnitConversion) ProtoReflect() protoreflect.Message {
 	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -484,40 +718,6472 @@ func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
-func (*GetProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnitConversion.ProtoReflect.Descriptor instead.
+func (*UnitConversion) Descriptor() ([]byte, []int) {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetProductResponse) GetProduct() *Product {
+func (x *UnitConversion) GetCode() string {
 	if x != nil {
-		return x.Product
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UnitConversion) GetFactor() float64 {
+	if x != nil {
+		return x.Factor
+	}
+	return 0
+}
+
+func (x *UnitConversion) GetFractional() bool {
+	if x != nil {
+		return x.Fractional
+	}
+	return false
+}
+
+// Measure is a quantity in a unit of a product, an empty unit is the base
+// unit. A request that takes a quantity in base units also takes a measure,
+// which replaces the quantity when set.
+type Measure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Measure) Reset() {
+	*x = Measure{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Measure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Measure) ProtoMessage() {}
+
+func (x *Measure) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Measure.ProtoReflect.Descriptor instead.
+func (*Measure) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Measure) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Measure) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// Money is an exact amount in the minor units of an ISO-4217 currency,
+// 999.99 USD is {currency_code: "USD", amount_minor: 99999}
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyCode  string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	AmountMinor   int64                  `protobuf:"varint,2,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+// WarehouseStock is the stock of a product in one warehouse
+type WarehouseStock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId   string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32                  `protobuf:"varint,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32                  `protobuf:"varint,4,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WarehouseStock) Reset() {
+	*x = WarehouseStock{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WarehouseStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WarehouseStock) ProtoMessage() {}
+
+func (x *WarehouseStock) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WarehouseStock.ProtoReflect.Descriptor instead.
+func (*WarehouseStock) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WarehouseStock) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *WarehouseStock) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *WarehouseStock) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *WarehouseStock) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+// Batch is the stock of one lot of a product in a warehouse. Stock of the
+// warehouse not covered by its batches was received without a lot.
+type Batch struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	WarehouseId string                 `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	LotCode     string                 `protobuf:"bytes,2,opt,name=lot_code,json=lotCode,proto3" json:"lot_code,omitempty"`
+	// best-before date in unix seconds, 0 for a lot that does not expire
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// when the lot first arrived in the warehouse
+	ReceivedAt    int64 `protobuf:"varint,4,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	Quantity      int32 `protobuf:"varint,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reserved      int32 `protobuf:"varint,6,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	Available     int32 `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Batch) Reset() {
+	*x = Batch{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batch) ProtoMessage() {}
+
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batch.ProtoReflect.Descriptor instead.
+func (*Batch) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Batch) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *Batch) GetLotCode() string {
+	if x != nil {
+		return x.LotCode
+	}
+	return ""
+}
+
+func (x *Batch) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Batch) GetReceivedAt() int64 {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return 0
+}
+
+func (x *Batch) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Batch) GetReserved() int32 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+func (x *Batch) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+// LotQuantity is the part of a movement or reservation in one lot
+type LotQuantity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LotCode       string                 `protobuf:"bytes,1,opt,name=lot_code,json=lotCode,proto3" json:"lot_code,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LotQuantity) Reset() {
+	*x = LotQuantity{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LotQuantity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LotQuantity) ProtoMessage() {}
+
+func (x *LotQuantity) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LotQuantity.ProtoReflect.Descriptor instead.
+func (*LotQuantity) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LotQuantity) GetLotCode() string {
+	if x != nil {
+		return x.LotCode
+	}
+	return ""
+}
+
+func (x *LotQuantity) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Warehouse is a depot holding stock, the warehouse "main" always exists
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Warehouse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Warehouse) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Warehouse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Warehouse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Category is a node of the product taxonomy, e.g. Cheese in
+// Chilled > Dairy > Cheese
+type Category struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// empty for a root category
+	ParentId  string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	CreatedAt int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// the attributes of the products filed directly under the category,
+	// subcategories do not inherit them
+	Attributes []*AttributeDefinition `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// prefix of the SKUs generated for its products, empty inherits the
+	// prefix of the parent
+	SkuPrefix     string `protobuf:"bytes,7,opt,name=sku_prefix,json=skuPrefix,proto3" json:"sku_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Category) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Category) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Category) GetAttributes() []*AttributeDefinition {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Category) GetSkuPrefix() string {
+	if x != nil {
+		return x.SkuPrefix
+	}
+	return ""
+}
+
+// Request messages
+type CreateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: use price_money. Read as USD when price_money is not set,
+	// it must not be more precise than a cent.
+	Price    float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity int32   `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// optional, the category to file the product under
+	CategoryId string `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	PriceMoney *Money `protobuf:"bytes,6,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`
+	// each with no alternate units when not set
+	Units *UnitsOfMeasure `protobuf:"bytes,7,opt,name=units,proto3" json:"units,omitempty"`
+	// the opening stock in a unit of units, replaces quantity
+	Measure *Measure `protobuf:"bytes,8,opt,name=measure,proto3" json:"measure,omitempty"`
+	// optional, makes the product a variant of this product, in its category
+	ParentId string `protobuf:"bytes,9,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// values of the attributes the category defines
+	Attributes  []*AttributeValue      `protobuf:"bytes,10,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Allergens   []*AllergenDeclaration `protobuf:"bytes,11,rep,name=allergens,proto3" json:"allergens,omitempty"`
+	Nutrition   *Nutrition             `protobuf:"bytes,12,opt,name=nutrition,proto3" json:"nutrition,omitempty"`
+	DietaryTags []string               `protobuf:"bytes,13,rep,name=dietary_tags,json=dietaryTags,proto3" json:"dietary_tags,omitempty"`
+	// distinct GTINs that no other product carries
+	Barcodes []*Barcode `protobuf:"bytes,14,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	// optional, a SKU no other product has; generated when not set
+	Sku           string `protobuf:"bytes,15,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateProductRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetPriceMoney() *Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetUnits() *UnitsOfMeasure {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetMeasure() *Measure {
+	if x != nil {
+		return x.Measure
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateProductRequest) GetAttributes() []*AttributeValue {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetAllergens() []*AllergenDeclaration {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetNutrition() *Nutrition {
+	if x != nil {
+		return x.Nutrition
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetDietaryTags() []string {
+	if x != nil {
+		return x.DietaryTags
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetBarcodes() []*Barcode {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+func (x *CreateProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+// GetProductRequest looks a product up by id, or by SKU when id is empty.
+// An id that matches no product is tried as a SKU.
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku           string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductRequest) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+// GetProductByBarcodeRequest looks up a scanned GTIN-8, 12, 13 or 14
+type GetProductByBarcodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Gtin          string                 `protobuf:"bytes,1,opt,name=gtin,proto3" json:"gtin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByBarcodeRequest) Reset() {
+	*x = GetProductByBarcodeRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByBarcodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByBarcodeRequest) ProtoMessage() {}
+
+func (x *GetProductByBarcodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByBarcodeRequest.ProtoReflect.Descriptor instead.
+func (*GetProductByBarcodeRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetProductByBarcodeRequest) GetGtin() string {
+	if x != nil {
+		return x.Gtin
+	}
+	return ""
+}
+
+type GetProductByBarcodeResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// the barcode that matched, its unit resolved to the base unit when empty
+	Barcode       *Barcode `protobuf:"bytes,2,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByBarcodeResponse) Reset() {
+	*x = GetProductByBarcodeResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByBarcodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByBarcodeResponse) ProtoMessage() {}
+
+func (x *GetProductByBarcodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByBarcodeResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByBarcodeResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetProductByBarcodeResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *GetProductByBarcodeResponse) GetBarcode() *Barcode {
+	if x != nil {
+		return x.Barcode
+	}
+	return nil
+}
+
+// UpdateProductRequest changes the fields listed in update_mask (name,
+// description, price, quantity, category_id, units, attributes, allergens, nutrition,
+// dietary_tags, barcodes) to the values given, an unset value in the mask clears the field. Without a
+// mask the fields that are set are changed.
+type UpdateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	// Deprecated: use price_money, read as USD when price_money is not set
+	Price *float64 `protobuf:"fixed64,4,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	// the new price, the mask path is price
+	PriceMoney *Money `protobuf:"bytes,9,opt,name=price_money,json=priceMoney,proto3" json:"price_money,omitempty"`
+	Quantity   *int32 `protobuf:"varint,5,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	// an empty category leaves the product uncategorized
+	CategoryId *string `protobuf:"bytes,8,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	// when set the update fails with FAILED_PRECONDITION unless the product still has
+	// this version
+	ExpectedVersion int64                  `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	UpdateMask      *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// replaces the base unit and all conversions, the base unit cannot change
+	// while the product holds stock
+	Units *UnitsOfMeasure `protobuf:"bytes,10,opt,name=units,proto3" json:"units,omitempty"`
+	// the new quantity in a unit of the product, or of units when they change
+	// too, replaces quantity
+	Measure *Measure `protobuf:"bytes,11,opt,name=measure,proto3" json:"measure,omitempty"`
+	// replaces all attributes, clearing them takes the attributes mask path
+	Attributes []*AttributeValue `protobuf:"bytes,12,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// replace all declarations and tags, clearing them takes their mask path
+	Allergens     []*AllergenDeclaration `protobuf:"bytes,13,rep,name=allergens,proto3" json:"allergens,omitempty"`
+	Nutrition     *Nutrition             `protobuf:"bytes,14,opt,name=nutrition,proto3" json:"nutrition,omitempty"`
+	DietaryTags   []string               `protobuf:"bytes,15,rep,name=dietary_tags,json=dietaryTags,proto3" json:"dietary_tags,omitempty"`
+	Barcodes      []*Barcode             `protobuf:"bytes,16,rep,name=barcodes,proto3" json:"barcodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetPrice() float64 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetPriceMoney() *Money {
+	if x != nil {
+		return x.PriceMoney
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetQuantity() int32 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetCategoryId() string {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return ""
+}
+
+func (x *UpdateProductRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetUnits() *UnitsOfMeasure {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetMeasure() *Measure {
+	if x != nil {
+		return x.Measure
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetAttributes() []*AttributeValue {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetAllergens() []*AllergenDeclaration {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetNutrition() *Nutrition {
+	if x != nil {
+		return x.Nutrition
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetDietaryTags() []string {
+	if x != nil {
+		return x.DietaryTags
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetBarcodes() []*Barcode {
+	if x != nil {
+		return x.Barcodes
+	}
+	return nil
+}
+
+// DeleteProductRequest moves a product to the trash, RestoreProduct brings it
+// back until it is purged after the retention period. It fails with
+// FAILED_PRECONDITION while the product has variants.
+type DeleteProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// when set the delete fails with FAILED_PRECONDITION unless the product still has
+	// this version
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteProductRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type ListProductsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Page       int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NameFilter string                 `protobuf:"bytes,3,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	// one of name, price, quantity, created_at (default), updated_at
+	SortBy string `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc (default) or desc, ties are broken by id in the same direction
+	SortOrder string `protobuf:"bytes,5,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// next_page_token of the previous page, takes precedence over page
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// AIP-160 style filter over name, description, price, quantity,
+	// created_at and updated_at, e.g. `quantity < 10 AND price >= 2.5`. A
+	// quantity may name a unit, `quantity >= 2case` matches the products
+	// defining a case that hold at least two. parent_id matches variants and
+	// attributes.<name> an attribute, e.g. `attributes.size_g >= 1000`.
+	// allergen, free_from and dietary test membership, `NOT allergen = peanut`
+	// leaves out the products that contain or may contain peanuts.
+	Filter string `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
+	// only products with stock in this warehouse
+	WarehouseId string `protobuf:"bytes,8,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// only products in this category or any of its descendants
+	CategoryId    string `protobuf:"bytes,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetNameFilter() string {
+	if x != nil {
+		return x.NameFilter
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+// ListDeletedProductsRequest pages through the trash like ListProducts pages
+// through the live products
+type ListDeletedProductsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Page       int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NameFilter string                 `protobuf:"bytes,3,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`
+	// one of name, price, quantity, created_at (default), updated_at, the
+	// updated_at of a deleted product is when it was deleted
+	SortBy string `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc (default) or desc
+	SortOrder string `protobuf:"bytes,5,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// next_page_token of the previous page, takes precedence over page
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// filter over the same fields as ListProducts
+	Filter        string `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedProductsRequest) Reset() {
+	*x = ListDeletedProductsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedProductsRequest) ProtoMessage() {}
+
+func (x *ListDeletedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListDeletedProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeletedProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedProductsRequest) GetNameFilter() string {
+	if x != nil {
+		return x.NameFilter
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+type RestoreProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// when set the restore fails with FAILED_PRECONDITION unless the product
+	// still has this version
+	ExpectedVersion int64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestoreProductRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type SearchProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// free text matched against name and description
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// maximum number of results, default 10, at most 100
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// Response messages
+type CreateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductResponse) ProtoMessage() {}
+
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductResponse) Reset() {
+	*x = GetProductResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductResponse) ProtoMessage() {}
+
+func (x *GetProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductResponse.ProtoReflect.Descriptor instead.
+func (*GetProductResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteProductResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// opaque token for the following page, empty on the last page
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListDeletedProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page     int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// opaque token for the following page, empty on the last page
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedProductsResponse) Reset() {
+	*x = ListDeletedProductsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedProductsResponse) ProtoMessage() {}
+
+func (x *ListDeletedProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedProductsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListDeletedProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListDeletedProductsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDeletedProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeletedProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDeletedProductsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RestoreProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductResponse) Reset() {
+	*x = RestoreProductResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductResponse) ProtoMessage() {}
+
+func (x *RestoreProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductResponse.ProtoReflect.Descriptor instead.
+func (*RestoreProductResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RestoreProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+// SearchResult is a product matching a search query
+type SearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// BM25 relevance, higher is better
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// name and description with matched words wrapped in <em></em>,
+	// empty when the field did not match
+	NameHighlight        string `protobuf:"bytes,3,opt,name=name_highlight,json=nameHighlight,proto3" json:"name_highlight,omitempty"`
+	DescriptionHighlight string `protobuf:"bytes,4,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SearchResult) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetNameHighlight() string {
+	if x != nil {
+		return x.NameHighlight
+	}
+	return ""
+}
+
+func (x *SearchResult) GetDescriptionHighlight() string {
+	if x != nil {
+		return x.DescriptionHighlight
+	}
+	return ""
+}
+
+type SearchProductsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by descending score
+	Results       []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SearchProductsResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// StockMovement is an immutable entry of a product's stock ledger, the
+// product quantity is the balance of its latest movement
+type StockMovement struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// receipt, adjustment, dispatch or write_off
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// signed change of the on-hand quantity
+	Delta int32 `protobuf:"varint,4,opt,name=delta,proto3" json:"delta,omitempty"`
+	// on-hand quantity after the movement
+	Balance int32  `protobuf:"varint,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	Reason  string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor   string `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	// product version the movement produced
+	Version   int64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// warehouse whose stock changed by delta
+	WarehouseId string `protobuf:"bytes,10,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// warehouse receiving a transfer
+	ToWarehouseId string `protobuf:"bytes,11,opt,name=to_warehouse_id,json=toWarehouseId,proto3" json:"to_warehouse_id,omitempty"`
+	// delta split by lot, stock not tracked by lot is left out
+	Lots          []*LotQuantity `protobuf:"bytes,12,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockMovement) Reset() {
+	*x = StockMovement{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockMovement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockMovement) ProtoMessage() {}
+
+func (x *StockMovement) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockMovement.ProtoReflect.Descriptor instead.
+func (*StockMovement) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StockMovement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StockMovement) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *StockMovement) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *StockMovement) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *StockMovement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockMovement) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *StockMovement) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *StockMovement) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StockMovement) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetToWarehouseId() string {
+	if x != nil {
+		return x.ToWarehouseId
+	}
+	return ""
+}
+
+func (x *StockMovement) GetLots() []*LotQuantity {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+type RecordStockMovementRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// receipt, adjustment, dispatch or write_off
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// amount received, dispatched or written off, or the signed delta of an
+	// adjustment
+	Quantity int32 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// required for adjustments and write-offs
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// who or what caused the movement
+	Actor string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	// when set the movement fails with FAILED_PRECONDITION unless the product
+	// still has this version
+	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// default main
+	WarehouseId string `protobuf:"bytes,7,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// the quantity in a unit of the product, replaces quantity
+	Measure *Measure `protobuf:"bytes,8,opt,name=measure,proto3" json:"measure,omitempty"`
+	// lot a receipt adds to, or the lot stock is taken out of instead of the
+	// first expiring ones
+	LotCode string `protobuf:"bytes,9,opt,name=lot_code,json=lotCode,proto3" json:"lot_code,omitempty"`
+	// best-before date in unix seconds of a lot received for the first time
+	ExpiresAt     int64 `protobuf:"varint,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordStockMovementRequest) Reset() {
+	*x = RecordStockMovementRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordStockMovementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordStockMovementRequest) ProtoMessage() {}
+
+func (x *RecordStockMovementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordStockMovementRequest.ProtoReflect.Descriptor instead.
+func (*RecordStockMovementRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecordStockMovementRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RecordStockMovementRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RecordStockMovementRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *RecordStockMovementRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RecordStockMovementRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *RecordStockMovementRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *RecordStockMovementRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *RecordStockMovementRequest) GetMeasure() *Measure {
+	if x != nil {
+		return x.Measure
+	}
+	return nil
+}
+
+func (x *RecordStockMovementRequest) GetLotCode() string {
+	if x != nil {
+		return x.LotCode
+	}
+	return ""
+}
+
+func (x *RecordStockMovementRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type RecordStockMovementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movement      *StockMovement         `protobuf:"bytes,1,opt,name=movement,proto3" json:"movement,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordStockMovementResponse) Reset() {
+	*x = RecordStockMovementResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordStockMovementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordStockMovementResponse) ProtoMessage() {}
+
+func (x *RecordStockMovementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordStockMovementResponse.ProtoReflect.Descriptor instead.
+func (*RecordStockMovementResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RecordStockMovementResponse) GetMovement() *StockMovement {
+	if x != nil {
+		return x.Movement
+	}
+	return nil
+}
+
+func (x *RecordStockMovementResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ListStockMovementsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// default 10, at most 100
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of the previous page
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsRequest) Reset() {
+	*x = ListStockMovementsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsRequest) ProtoMessage() {}
+
+func (x *ListStockMovementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListStockMovementsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListStockMovementsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStockMovementsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListStockMovementsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// oldest first
+	Movements []*StockMovement `protobuf:"bytes,1,rep,name=movements,proto3" json:"movements,omitempty"`
+	// opaque token for the following page, empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockMovementsResponse) Reset() {
+	*x = ListStockMovementsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockMovementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockMovementsResponse) ProtoMessage() {}
+
+func (x *ListStockMovementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockMovementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockMovementsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListStockMovementsResponse) GetMovements() []*StockMovement {
+	if x != nil {
+		return x.Movements
+	}
+	return nil
+}
+
+func (x *ListStockMovementsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Reservation holds stock of a product for an order being checked out
+type Reservation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// held, committed, released or expired
+	Status      string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Actor       string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	ExpiresAt   int64  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   int64  `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	WarehouseId string `protobuf:"bytes,9,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// quantity split by the lots it holds, first expiry first out
+	Lots          []*LotQuantity `protobuf:"bytes,10,rep,name=lots,proto3" json:"lots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reservation) Reset() {
+	*x = Reservation{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reservation) ProtoMessage() {}
+
+func (x *Reservation) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Reservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Reservation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Reservation) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Reservation) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *Reservation) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Reservation) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Reservation) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Reservation) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *Reservation) GetLots() []*LotQuantity {
+	if x != nil {
+		return x.Lots
+	}
+	return nil
+}
+
+type ReserveStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// seconds the stock is held, default 900, at most 86400
+	TtlSeconds int64 `protobuf:"varint,3,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	// who holds the stock, e.g. an order reference
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// default main
+	WarehouseId string `protobuf:"bytes,5,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	// the quantity in a unit of the product, replaces quantity
+	Measure       *Measure `protobuf:"bytes,6,opt,name=measure,proto3" json:"measure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReserveStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveStockRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetMeasure() *Measure {
+	if x != nil {
+		return x.Measure
+	}
+	return nil
+}
+
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReserveStockResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *ReserveStockResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type CommitReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationRequest) Reset() {
+	*x = CommitReservationRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationRequest) ProtoMessage() {}
+
+func (x *CommitReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationRequest.ProtoReflect.Descriptor instead.
+func (*CommitReservationRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CommitReservationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CommitReservationResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Reservation *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	// the dispatch recorded in the product's ledger
+	Movement      *StockMovement `protobuf:"bytes,2,opt,name=movement,proto3" json:"movement,omitempty"`
+	Product       *Product       `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitReservationResponse) Reset() {
+	*x = CommitReservationResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitReservationResponse) ProtoMessage() {}
+
+func (x *CommitReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitReservationResponse.ProtoReflect.Descriptor instead.
+func (*CommitReservationResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CommitReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *CommitReservationResponse) GetMovement() *StockMovement {
+	if x != nil {
+		return x.Movement
+	}
+	return nil
+}
+
+func (x *CommitReservationResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReleaseReservationRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *Reservation           `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ReleaseReservationResponse) GetReservation() *Reservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *ReleaseReservationResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type TransferStockRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	FromWarehouseId string                 `protobuf:"bytes,2,opt,name=from_warehouse_id,json=fromWarehouseId,proto3" json:"from_warehouse_id,omitempty"`
+	ToWarehouseId   string                 `protobuf:"bytes,3,opt,name=to_warehouse_id,json=toWarehouseId,proto3" json:"to_warehouse_id,omitempty"`
+	Quantity        int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reason          string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor           string                 `protobuf:"bytes,6,opt,name=actor,proto3" json:"actor,omitempty"`
+	// when set the transfer fails with FAILED_PRECONDITION unless the product
+	// still has this version
+	ExpectedVersion int64 `protobuf:"varint,7,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// the quantity in a unit of the product, replaces quantity
+	Measure *Measure `protobuf:"bytes,8,opt,name=measure,proto3" json:"measure,omitempty"`
+	// lot to move instead of the first expiring ones
+	LotCode       string `protobuf:"bytes,9,opt,name=lot_code,json=lotCode,proto3" json:"lot_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStockRequest) Reset() {
+	*x = TransferStockRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStockRequest) ProtoMessage() {}
+
+func (x *TransferStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStockRequest.ProtoReflect.Descriptor instead.
+func (*TransferStockRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TransferStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetFromWarehouseId() string {
+	if x != nil {
+		return x.FromWarehouseId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetToWarehouseId() string {
+	if x != nil {
+		return x.ToWarehouseId
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *TransferStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *TransferStockRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *TransferStockRequest) GetMeasure() *Measure {
+	if x != nil {
+		return x.Measure
+	}
+	return nil
+}
+
+func (x *TransferStockRequest) GetLotCode() string {
+	if x != nil {
+		return x.LotCode
+	}
+	return ""
+}
+
+type TransferStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Movement      *StockMovement         `protobuf:"bytes,1,opt,name=movement,proto3" json:"movement,omitempty"`
+	Product       *Product               `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStockResponse) Reset() {
+	*x = TransferStockResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStockResponse) ProtoMessage() {}
+
+func (x *TransferStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStockResponse.ProtoReflect.Descriptor instead.
+func (*TransferStockResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TransferStockResponse) GetMovement() *StockMovement {
+	if x != nil {
+		return x.Movement
+	}
+	return nil
+}
+
+func (x *TransferStockResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type CreateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseRequest) Reset() {
+	*x = CreateWarehouseRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseRequest) ProtoMessage() {}
+
+func (x *CreateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateWarehouseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateWarehouseRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type CreateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWarehouseResponse) Reset() {
+	*x = CreateWarehouseResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWarehouseResponse) ProtoMessage() {}
+
+func (x *CreateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*CreateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type GetWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWarehouseRequest) Reset() {
+	*x = GetWarehouseRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWarehouseRequest) ProtoMessage() {}
+
+func (x *GetWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*GetWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetWarehouseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWarehouseResponse) Reset() {
+	*x = GetWarehouseResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWarehouseResponse) ProtoMessage() {}
+
+func (x *GetWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*GetWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+type ListWarehousesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesRequest) Reset() {
+	*x = ListWarehousesRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesRequest) ProtoMessage() {}
+
+func (x *ListWarehousesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesRequest.ProtoReflect.Descriptor instead.
+func (*ListWarehousesRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{52}
+}
+
+type ListWarehousesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by name
+	Warehouses    []*Warehouse `protobuf:"bytes,1,rep,name=warehouses,proto3" json:"warehouses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWarehousesResponse) Reset() {
+	*x = ListWarehousesResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWarehousesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWarehousesResponse) ProtoMessage() {}
+
+func (x *ListWarehousesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWarehousesResponse.ProtoReflect.Descriptor instead.
+func (*ListWarehousesResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListWarehousesResponse) GetWarehouses() []*Warehouse {
+	if x != nil {
+		return x.Warehouses
+	}
+	return nil
+}
+
+type ListExpiringStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// report the lots expiring within this many days, 0 reports the expired
+	// lots only
+	WithinDays int32 `protobuf:"varint,1,opt,name=within_days,json=withinDays,proto3" json:"within_days,omitempty"`
+	// empty reports every warehouse
+	WarehouseId   string `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringStockRequest) Reset() {
+	*x = ListExpiringStockRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringStockRequest) ProtoMessage() {}
+
+func (x *ListExpiringStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringStockRequest.ProtoReflect.Descriptor instead.
+func (*ListExpiringStockRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListExpiringStockRequest) GetWithinDays() int32 {
+	if x != nil {
+		return x.WithinDays
+	}
+	return 0
+}
+
+func (x *ListExpiringStockRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+// ExpiringStock is a short-dated batch of a product
+type ExpiringStock struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	Batch       *Batch                 `protobuf:"bytes,3,opt,name=batch,proto3" json:"batch,omitempty"`
+	// whole days until the lot expires, negative once it has expired
+	DaysLeft      int32 `protobuf:"varint,4,opt,name=days_left,json=daysLeft,proto3" json:"days_left,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpiringStock) Reset() {
+	*x = ExpiringStock{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpiringStock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpiringStock) ProtoMessage() {}
+
+func (x *ExpiringStock) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpiringStock.ProtoReflect.Descriptor instead.
+func (*ExpiringStock) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExpiringStock) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ExpiringStock) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ExpiringStock) GetBatch() *Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *ExpiringStock) GetDaysLeft() int32 {
+	if x != nil {
+		return x.DaysLeft
+	}
+	return 0
+}
+
+type ListExpiringStockResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// soonest expiry first
+	Stock         []*ExpiringStock `protobuf:"bytes,1,rep,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExpiringStockResponse) Reset() {
+	*x = ListExpiringStockResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExpiringStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExpiringStockResponse) ProtoMessage() {}
+
+func (x *ListExpiringStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExpiringStockResponse.ProtoReflect.Descriptor instead.
+func (*ListExpiringStockResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListExpiringStockResponse) GetStock() []*ExpiringStock {
+	if x != nil {
+		return x.Stock
+	}
+	return nil
+}
+
+// UpdateWarehouseRequest replaces the name and address of a warehouse
+type UpdateWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       string                 `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseRequest) Reset() {
+	*x = UpdateWarehouseRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseRequest) ProtoMessage() {}
+
+func (x *UpdateWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *UpdateWarehouseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateWarehouseRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type UpdateWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Warehouse     *Warehouse             `protobuf:"bytes,1,opt,name=warehouse,proto3" json:"warehouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWarehouseResponse) Reset() {
+	*x = UpdateWarehouseResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWarehouseResponse) ProtoMessage() {}
+
+func (x *UpdateWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *UpdateWarehouseResponse) GetWarehouse() *Warehouse {
+	if x != nil {
+		return x.Warehouse
+	}
+	return nil
+}
+
+// DeleteWarehouseRequest fails with FAILED_PRECONDITION while the warehouse
+// holds stock
+type DeleteWarehouseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWarehouseRequest) Reset() {
+	*x = DeleteWarehouseRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWarehouseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWarehouseRequest) ProtoMessage() {}
+
+func (x *DeleteWarehouseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWarehouseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWarehouseRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteWarehouseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteWarehouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWarehouseResponse) Reset() {
+	*x = DeleteWarehouseResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWarehouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWarehouseResponse) ProtoMessage() {}
+
+func (x *DeleteWarehouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWarehouseResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWarehouseResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DeleteWarehouseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// CreateCategoryRequest creates a category under parent_id, or a root
+// category when it is empty. Siblings must have distinct names.
+type CreateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCategoryRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// MoveCategoryRequest moves a category with its subtree under parent_id, or
+// to the root when it is empty. A category cannot move into its own subtree.
+type MoveCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCategoryRequest) Reset() {
+	*x = MoveCategoryRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCategoryRequest) ProtoMessage() {}
+
+func (x *MoveCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCategoryRequest.ProtoReflect.Descriptor instead.
+func (*MoveCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *MoveCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveCategoryRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type MoveCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveCategoryResponse) Reset() {
+	*x = MoveCategoryResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveCategoryResponse) ProtoMessage() {}
+
+func (x *MoveCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveCategoryResponse.ProtoReflect.Descriptor instead.
+func (*MoveCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *MoveCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// SetCategoryAttributesRequest replaces the attribute definitions of a
+// category, products are checked against them when next written
+type SetCategoryAttributesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Attributes    []*AttributeDefinition `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCategoryAttributesRequest) Reset() {
+	*x = SetCategoryAttributesRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCategoryAttributesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCategoryAttributesRequest) ProtoMessage() {}
+
+func (x *SetCategoryAttributesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCategoryAttributesRequest.ProtoReflect.Descriptor instead.
+func (*SetCategoryAttributesRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SetCategoryAttributesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetCategoryAttributesRequest) GetAttributes() []*AttributeDefinition {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type SetCategoryAttributesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCategoryAttributesResponse) Reset() {
+	*x = SetCategoryAttributesResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCategoryAttributesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCategoryAttributesResponse) ProtoMessage() {}
+
+func (x *SetCategoryAttributesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCategoryAttributesResponse.ProtoReflect.Descriptor instead.
+func (*SetCategoryAttributesResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SetCategoryAttributesResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// SetCategorySKUPrefixRequest sets the prefix of the SKUs generated for the
+// products of a category and of its subcategories that set none. SKUs
+// already given out keep their prefix.
+type SetCategorySKUPrefixRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 1 to 8 letters or digits, empty inherits the prefix of the parent
+	SkuPrefix     string `protobuf:"bytes,2,opt,name=sku_prefix,json=skuPrefix,proto3" json:"sku_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCategorySKUPrefixRequest) Reset() {
+	*x = SetCategorySKUPrefixRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCategorySKUPrefixRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCategorySKUPrefixRequest) ProtoMessage() {}
+
+func (x *SetCategorySKUPrefixRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCategorySKUPrefixRequest.ProtoReflect.Descriptor instead.
+func (*SetCategorySKUPrefixRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SetCategorySKUPrefixRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetCategorySKUPrefixRequest) GetSkuPrefix() string {
+	if x != nil {
+		return x.SkuPrefix
+	}
+	return ""
+}
+
+type SetCategorySKUPrefixResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCategorySKUPrefixResponse) Reset() {
+	*x = SetCategorySKUPrefixResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCategorySKUPrefixResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCategorySKUPrefixResponse) ProtoMessage() {}
+
+func (x *SetCategorySKUPrefixResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCategorySKUPrefixResponse.ProtoReflect.Descriptor instead.
+func (*SetCategorySKUPrefixResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *SetCategorySKUPrefixResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{69}
+}
+
+type ListCategoriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every category ordered by name, the tree follows from the parent ids
+	Categories    []*Category `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesResponse) ProtoMessage() {}
+
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ListCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+// Supplier is a company products are purchased from
+type Supplier struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContactName string                 `protobuf:"bytes,3,opt,name=contact_name,json=contactName,proto3" json:"contact_name,omitempty"`
+	Email       string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Phone       string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	// usual number of days from ordering to delivery
+	LeadTimeDays  int32 `protobuf:"varint,6,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	CreatedAt     int64 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Supplier) Reset() {
+	*x = Supplier{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Supplier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Supplier) ProtoMessage() {}
+
+func (x *Supplier) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Supplier.ProtoReflect.Descriptor instead.
+func (*Supplier) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *Supplier) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Supplier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Supplier) GetContactName() string {
+	if x != nil {
+		return x.ContactName
+	}
+	return ""
+}
+
+func (x *Supplier) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Supplier) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Supplier) GetLeadTimeDays() int32 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+func (x *Supplier) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Supplier) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// ProductSupplier links a product to a supplier it is sourced from
+type ProductSupplier struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProductId  string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SupplierId string                 `protobuf:"bytes,2,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	// the supplier's own code for the product
+	SupplierSku string `protobuf:"bytes,3,opt,name=supplier_sku,json=supplierSku,proto3" json:"supplier_sku,omitempty"`
+	// Deprecated: use cost_price_money, this is its amount as a double
+	CostPrice float64 `protobuf:"fixed64,4,opt,name=cost_price,json=costPrice,proto3" json:"cost_price,omitempty"`
+	// the supplier to order from first, a product has at most one
+	Preferred bool  `protobuf:"varint,5,opt,name=preferred,proto3" json:"preferred,omitempty"`
+	CreatedAt int64 `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// the linked supplier, filled in by ListProductSuppliers
+	Supplier *Supplier `protobuf:"bytes,8,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	// what the supplier charges per unit
+	CostPriceMoney *Money `protobuf:"bytes,9,opt,name=cost_price_money,json=costPriceMoney,proto3" json:"cost_price_money,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProductSupplier) Reset() {
+	*x = ProductSupplier{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSupplier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSupplier) ProtoMessage() {}
+
+func (x *ProductSupplier) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSupplier.ProtoReflect.Descriptor instead.
+func (*ProductSupplier) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ProductSupplier) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductSupplier) GetSupplierId() string {
+	if x != nil {
+		return x.SupplierId
+	}
+	return ""
+}
+
+func (x *ProductSupplier) GetSupplierSku() string {
+	if x != nil {
+		return x.SupplierSku
+	}
+	return ""
+}
+
+func (x *ProductSupplier) GetCostPrice() float64 {
+	if x != nil {
+		return x.CostPrice
+	}
+	return 0
+}
+
+func (x *ProductSupplier) GetPreferred() bool {
+	if x != nil {
+		return x.Preferred
+	}
+	return false
+}
+
+func (x *ProductSupplier) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ProductSupplier) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *ProductSupplier) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+func (x *ProductSupplier) GetCostPriceMoney() *Money {
+	if x != nil {
+		return x.CostPriceMoney
+	}
+	return nil
+}
+
+type CreateSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContactName   string                 `protobuf:"bytes,2,opt,name=contact_name,json=contactName,proto3" json:"contact_name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,4,opt,name=phone,proto3" json:"phone,omitempty"`
+	LeadTimeDays  int32                  `protobuf:"varint,5,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSupplierRequest) Reset() {
+	*x = CreateSupplierRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSupplierRequest) ProtoMessage() {}
+
+func (x *CreateSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSupplierRequest.ProtoReflect.Descriptor instead.
+func (*CreateSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *CreateSupplierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSupplierRequest) GetContactName() string {
+	if x != nil {
+		return x.ContactName
+	}
+	return ""
+}
+
+func (x *CreateSupplierRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateSupplierRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateSupplierRequest) GetLeadTimeDays() int32 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+type CreateSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      *Supplier              `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSupplierResponse) Reset() {
+	*x = CreateSupplierResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSupplierResponse) ProtoMessage() {}
+
+func (x *CreateSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSupplierResponse.ProtoReflect.Descriptor instead.
+func (*CreateSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *CreateSupplierResponse) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+type GetSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupplierRequest) Reset() {
+	*x = GetSupplierRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupplierRequest) ProtoMessage() {}
+
+func (x *GetSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupplierRequest.ProtoReflect.Descriptor instead.
+func (*GetSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetSupplierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      *Supplier              `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupplierResponse) Reset() {
+	*x = GetSupplierResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupplierResponse) ProtoMessage() {}
+
+func (x *GetSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupplierResponse.ProtoReflect.Descriptor instead.
+func (*GetSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetSupplierResponse) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+type ListSuppliersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppliersRequest) Reset() {
+	*x = ListSuppliersRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppliersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppliersRequest) ProtoMessage() {}
+
+func (x *ListSuppliersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppliersRequest.ProtoReflect.Descriptor instead.
+func (*ListSuppliersRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{77}
+}
+
+type ListSuppliersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by name
+	Suppliers     []*Supplier `protobuf:"bytes,1,rep,name=suppliers,proto3" json:"suppliers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuppliersResponse) Reset() {
+	*x = ListSuppliersResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuppliersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuppliersResponse) ProtoMessage() {}
+
+func (x *ListSuppliersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuppliersResponse.ProtoReflect.Descriptor instead.
+func (*ListSuppliersResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListSuppliersResponse) GetSuppliers() []*Supplier {
+	if x != nil {
+		return x.Suppliers
+	}
+	return nil
+}
+
+// UpdateSupplierRequest replaces all details of a supplier
+type UpdateSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContactName   string                 `protobuf:"bytes,3,opt,name=contact_name,json=contactName,proto3" json:"contact_name,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Phone         string                 `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	LeadTimeDays  int32                  `protobuf:"varint,6,opt,name=lead_time_days,json=leadTimeDays,proto3" json:"lead_time_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSupplierRequest) Reset() {
+	*x = UpdateSupplierRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSupplierRequest) ProtoMessage() {}
+
+func (x *UpdateSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSupplierRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *UpdateSupplierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateSupplierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSupplierRequest) GetContactName() string {
+	if x != nil {
+		return x.ContactName
+	}
+	return ""
+}
+
+func (x *UpdateSupplierRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpdateSupplierRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateSupplierRequest) GetLeadTimeDays() int32 {
+	if x != nil {
+		return x.LeadTimeDays
+	}
+	return 0
+}
+
+type UpdateSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Supplier      *Supplier              `protobuf:"bytes,1,opt,name=supplier,proto3" json:"supplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSupplierResponse) Reset() {
+	*x = UpdateSupplierResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSupplierResponse) ProtoMessage() {}
+
+func (x *UpdateSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSupplierResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *UpdateSupplierResponse) GetSupplier() *Supplier {
+	if x != nil {
+		return x.Supplier
+	}
+	return nil
+}
+
+// DeleteSupplierRequest fails with FAILED_PRECONDITION while products are
+// linked to the supplier
+type DeleteSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSupplierRequest) Reset() {
+	*x = DeleteSupplierRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSupplierRequest) ProtoMessage() {}
+
+func (x *DeleteSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSupplierRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *DeleteSupplierRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSupplierResponse) Reset() {
+	*x = DeleteSupplierResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSupplierResponse) ProtoMessage() {}
+
+func (x *DeleteSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSupplierResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *DeleteSupplierResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// LinkProductSupplierRequest links a product to a supplier or replaces the
+// existing link, preferring a supplier unmarks the previously preferred one
+type LinkProductSupplierRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SupplierId  string                 `protobuf:"bytes,2,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	SupplierSku string                 `protobuf:"bytes,3,opt,name=supplier_sku,json=supplierSku,proto3" json:"supplier_sku,omitempty"`
+	// Deprecated: use cost_price_money, read as USD when it is not set
+	CostPrice      float64 `protobuf:"fixed64,4,opt,name=cost_price,json=costPrice,proto3" json:"cost_price,omitempty"`
+	Preferred      bool    `protobuf:"varint,5,opt,name=preferred,proto3" json:"preferred,omitempty"`
+	CostPriceMoney *Money  `protobuf:"bytes,6,opt,name=cost_price_money,json=costPriceMoney,proto3" json:"cost_price_money,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LinkProductSupplierRequest) Reset() {
+	*x = LinkProductSupplierRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkProductSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkProductSupplierRequest) ProtoMessage() {}
+
+func (x *LinkProductSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkProductSupplierRequest.ProtoReflect.Descriptor instead.
+func (*LinkProductSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *LinkProductSupplierRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *LinkProductSupplierRequest) GetSupplierId() string {
+	if x != nil {
+		return x.SupplierId
+	}
+	return ""
+}
+
+func (x *LinkProductSupplierRequest) GetSupplierSku() string {
+	if x != nil {
+		return x.SupplierSku
+	}
+	return ""
+}
+
+func (x *LinkProductSupplierRequest) GetCostPrice() float64 {
+	if x != nil {
+		return x.CostPrice
+	}
+	return 0
+}
+
+func (x *LinkProductSupplierRequest) GetPreferred() bool {
+	if x != nil {
+		return x.Preferred
+	}
+	return false
+}
+
+func (x *LinkProductSupplierRequest) GetCostPriceMoney() *Money {
+	if x != nil {
+		return x.CostPriceMoney
+	}
+	return nil
+}
+
+type LinkProductSupplierResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductSupplier *ProductSupplier       `protobuf:"bytes,1,opt,name=product_supplier,json=productSupplier,proto3" json:"product_supplier,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LinkProductSupplierResponse) Reset() {
+	*x = LinkProductSupplierResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkProductSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkProductSupplierResponse) ProtoMessage() {}
+
+func (x *LinkProductSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkProductSupplierResponse.ProtoReflect.Descriptor instead.
+func (*LinkProductSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *LinkProductSupplierResponse) GetProductSupplier() *ProductSupplier {
+	if x != nil {
+		return x.ProductSupplier
+	}
+	return nil
+}
+
+type ListProductSuppliersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductSuppliersRequest) Reset() {
+	*x = ListProductSuppliersRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductSuppliersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductSuppliersRequest) ProtoMessage() {}
+
+func (x *ListProductSuppliersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductSuppliersRequest.ProtoReflect.Descriptor instead.
+func (*ListProductSuppliersRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ListProductSuppliersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListProductSuppliersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the preferred supplier first, then by supplier id
+	ProductSuppliers []*ProductSupplier `protobuf:"bytes,1,rep,name=product_suppliers,json=productSuppliers,proto3" json:"product_suppliers,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListProductSuppliersResponse) Reset() {
+	*x = ListProductSuppliersResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductSuppliersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductSuppliersResponse) ProtoMessage() {}
+
+func (x *ListProductSuppliersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductSuppliersResponse.ProtoReflect.Descriptor instead.
+func (*ListProductSuppliersResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ListProductSuppliersResponse) GetProductSuppliers() []*ProductSupplier {
+	if x != nil {
+		return x.ProductSuppliers
+	}
+	return nil
+}
+
+type UnlinkProductSupplierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	SupplierId    string                 `protobuf:"bytes,2,opt,name=supplier_id,json=supplierId,proto3" json:"supplier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkProductSupplierRequest) Reset() {
+	*x = UnlinkProductSupplierRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkProductSupplierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkProductSupplierRequest) ProtoMessage() {}
+
+func (x *UnlinkProductSupplierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkProductSupplierRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkProductSupplierRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *UnlinkProductSupplierRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UnlinkProductSupplierRequest) GetSupplierId() string {
+	if x != nil {
+		return x.SupplierId
+	}
+	return ""
+}
+
+type UnlinkProductSupplierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkProductSupplierResponse) Reset() {
+	*x = UnlinkProductSupplierResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkProductSupplierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkProductSupplierResponse) ProtoMessage() {}
+
+func (x *UnlinkProductSupplierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkProductSupplierResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkProductSupplierResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *UnlinkProductSupplierResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// PriceList is a named set of customer prices, a customer is on at most one
+type PriceList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CustomerIds   []string               `protobuf:"bytes,3,rep,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceList) Reset() {
+	*x = PriceList{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceList) ProtoMessage() {}
+
+func (x *PriceList) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceList.ProtoReflect.Descriptor instead.
+func (*PriceList) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *PriceList) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceList) GetCustomerIds() []string {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+func (x *PriceList) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *PriceList) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// PriceTier is the unit price of a product on a price list from a quantity
+// on, a tier from 1 overrides the list price
+type PriceTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinQuantity   int32                  `protobuf:"varint,1,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	Price         *Money                 `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceTier) Reset() {
+	*x = PriceTier{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceTier) ProtoMessage() {}
+
+func (x *PriceTier) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceTier.ProtoReflect.Descriptor instead.
+func (*PriceTier) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *PriceTier) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PriceTier) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+// PriceQuote is the effective price of a quantity of a product
+type PriceQuote struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// empty when no price list applied
+	PriceListId string `protobuf:"bytes,2,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"`
+	Quantity    int32  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice   *Money `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	LinePrice   *Money `protobuf:"bytes,5,opt,name=line_price,json=linePrice,proto3" json:"line_price,omitempty"`
+	// list_price, override or tier
+	Rule string `protobuf:"bytes,6,opt,name=rule,proto3" json:"rule,omitempty"`
+	// the minimum quantity of the applied tier, 0 for the list price
+	MinQuantity   int32  `protobuf:"varint,7,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	Explanation   string `protobuf:"bytes,8,opt,name=explanation,proto3" json:"explanation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceQuote) Reset() {
+	*x = PriceQuote{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceQuote) ProtoMessage() {}
+
+func (x *PriceQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceQuote.ProtoReflect.Descriptor instead.
+func (*PriceQuote) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *PriceQuote) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceQuote) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *PriceQuote) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PriceQuote) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *PriceQuote) GetLinePrice() *Money {
+	if x != nil {
+		return x.LinePrice
+	}
+	return nil
+}
+
+func (x *PriceQuote) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *PriceQuote) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PriceQuote) GetExplanation() string {
+	if x != nil {
+		return x.Explanation
+	}
+	return ""
+}
+
+type CreatePriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CustomerIds   []string               `protobuf:"bytes,2,rep,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceListRequest) Reset() {
+	*x = CreatePriceListRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListRequest) ProtoMessage() {}
+
+func (x *CreatePriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceListRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *CreatePriceListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePriceListRequest) GetCustomerIds() []string {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+type CreatePriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceListResponse) Reset() {
+	*x = CreatePriceListResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListResponse) ProtoMessage() {}
+
+func (x *CreatePriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListResponse.ProtoReflect.Descriptor instead.
+func (*CreatePriceListResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *CreatePriceListResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+type GetPriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceListRequest) Reset() {
+	*x = GetPriceListRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceListRequest) ProtoMessage() {}
+
+func (x *GetPriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceListRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceListRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *GetPriceListRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceListResponse) Reset() {
+	*x = GetPriceListResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceListResponse) ProtoMessage() {}
+
+func (x *GetPriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceListResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceListResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *GetPriceListResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+type ListPriceListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceListsRequest) Reset() {
+	*x = ListPriceListsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceListsRequest) ProtoMessage() {}
+
+func (x *ListPriceListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceListsRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceListsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{96}
+}
+
+type ListPriceListsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by name
+	PriceLists    []*PriceList `protobuf:"bytes,1,rep,name=price_lists,json=priceLists,proto3" json:"price_lists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceListsResponse) Reset() {
+	*x = ListPriceListsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceListsResponse) ProtoMessage() {}
+
+func (x *ListPriceListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceListsResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceListsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *ListPriceListsResponse) GetPriceLists() []*PriceList {
+	if x != nil {
+		return x.PriceLists
+	}
+	return nil
+}
+
+type UpdatePriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CustomerIds   []string               `protobuf:"bytes,3,rep,name=customer_ids,json=customerIds,proto3" json:"customer_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePriceListRequest) Reset() {
+	*x = UpdatePriceListRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePriceListRequest) ProtoMessage() {}
+
+func (x *UpdatePriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePriceListRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePriceListRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *UpdatePriceListRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdatePriceListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePriceListRequest) GetCustomerIds() []string {
+	if x != nil {
+		return x.CustomerIds
+	}
+	return nil
+}
+
+type UpdatePriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=price_list,json=priceList,proto3" json:"price_list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePriceListResponse) Reset() {
+	*x = UpdatePriceListResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePriceListResponse) ProtoMessage() {}
+
+func (x *UpdatePriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePriceListResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePriceListResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *UpdatePriceListResponse) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+// SetPriceTiersRequest replaces the tiers of a product on a price list, no
+// tiers remove them
+type SetPriceTiersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceListId   string                 `protobuf:"bytes,1,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Tiers         []*PriceTier           `protobuf:"bytes,3,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceTiersRequest) Reset() {
+	*x = SetPriceTiersRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceTiersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceTiersRequest) ProtoMessage() {}
+
+func (x *SetPriceTiersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceTiersRequest.ProtoReflect.Descriptor instead.
+func (*SetPriceTiersRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *SetPriceTiersRequest) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *SetPriceTiersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetPriceTiersRequest) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type SetPriceTiersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tiers         []*PriceTier           `protobuf:"bytes,1,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPriceTiersResponse) Reset() {
+	*x = SetPriceTiersResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPriceTiersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPriceTiersResponse) ProtoMessage() {}
+
+func (x *SetPriceTiersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPriceTiersResponse.ProtoReflect.Descriptor instead.
+func (*SetPriceTiersResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SetPriceTiersResponse) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type GetPriceTiersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceListId   string                 `protobuf:"bytes,1,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceTiersRequest) Reset() {
+	*x = GetPriceTiersRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceTiersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceTiersRequest) ProtoMessage() {}
+
+func (x *GetPriceTiersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceTiersRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceTiersRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *GetPriceTiersRequest) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *GetPriceTiersRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type GetPriceTiersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by minimum quantity
+	Tiers         []*PriceTier `protobuf:"bytes,1,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceTiersResponse) Reset() {
+	*x = GetPriceTiersResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceTiersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceTiersResponse) ProtoMessage() {}
+
+func (x *GetPriceTiersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceTiersResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceTiersResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *GetPriceTiersResponse) GetTiers() []*PriceTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+// QuotePriceRequest prices a quantity of a product on price_list_id, or on
+// the price list of customer_id, or at the list price when neither is set
+type QuotePriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	PriceListId   string                 `protobuf:"bytes,2,opt,name=price_list_id,json=priceListId,proto3" json:"price_list_id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotePriceRequest) Reset() {
+	*x = QuotePriceRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotePriceRequest) ProtoMessage() {}
+
+func (x *QuotePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotePriceRequest.ProtoReflect.Descriptor instead.
+func (*QuotePriceRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *QuotePriceRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *QuotePriceRequest) GetPriceListId() string {
+	if x != nil {
+		return x.PriceListId
+	}
+	return ""
+}
+
+func (x *QuotePriceRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *QuotePriceRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type QuotePriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Quote         *PriceQuote            `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuotePriceResponse) Reset() {
+	*x = QuotePriceResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuotePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuotePriceResponse) ProtoMessage() {}
+
+func (x *QuotePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuotePriceResponse.ProtoReflect.Descriptor instead.
+func (*QuotePriceResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *QuotePriceResponse) GetQuote() *PriceQuote {
+	if x != nil {
+		return x.Quote
+	}
+	return nil
+}
+
+// Auction sells a lot, quantity units of a product, to the best sealed bid
+// submitted between opens_at and closes_at
+type Auction struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId   string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId string                 `protobuf:"bytes,3,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity    int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// the lowest price the whole lot is sold for
+	ReservePrice *Money `protobuf:"bytes,5,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
+	OpensAt      int64  `protobuf:"varint,6,opt,name=opens_at,json=opensAt,proto3" json:"opens_at,omitempty"`
+	ClosesAt     int64  `protobuf:"varint,7,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	// open, awarded, unsold or cancelled
+	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// the reservation holding the lot's stock
+	ReservationId string `protobuf:"bytes,9,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	// set once awarded
+	WinningBidId string `protobuf:"bytes,10,opt,name=winning_bid_id,json=winningBidId,proto3" json:"winning_bid_id,omitempty"`
+	WinnerId     string `protobuf:"bytes,11,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	WinningPrice *Money `protobuf:"bytes,12,opt,name=winning_price,json=winningPrice,proto3" json:"winning_price,omitempty"`
+	// why the auction closed as it did
+	Outcome       string `protobuf:"bytes,13,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	CreatedAt     int64  `protobuf:"varint,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64  `protobuf:"varint,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auction) Reset() {
+	*x = Auction{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auction) ProtoMessage() {}
+
+func (x *Auction) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auction.ProtoReflect.Descriptor instead.
+func (*Auction) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *Auction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Auction) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Auction) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *Auction) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Auction) GetReservePrice() *Money {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return nil
+}
+
+func (x *Auction) GetOpensAt() int64 {
+	if x != nil {
+		return x.OpensAt
+	}
+	return 0
+}
+
+func (x *Auction) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+func (x *Auction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Auction) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *Auction) GetWinningBidId() string {
+	if x != nil {
+		return x.WinningBidId
+	}
+	return ""
+}
+
+func (x *Auction) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *Auction) GetWinningPrice() *Money {
+	if x != nil {
+		return x.WinningPrice
+	}
+	return nil
+}
+
+func (x *Auction) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *Auction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Auction) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+// Bid is a bidder's sealed offer for a whole lot
+type Bid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuctionId     string                 `protobuf:"bytes,2,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,3,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	SubmittedAt   int64                  `protobuf:"varint,5,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bid) Reset() {
+	*x = Bid{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bid) ProtoMessage() {}
+
+func (x *Bid) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bid.ProtoReflect.Descriptor instead.
+func (*Bid) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *Bid) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Bid) GetAuctionId() string {
+	if x != nil {
+		return x.AuctionId
+	}
+	return ""
+}
+
+func (x *Bid) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *Bid) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Bid) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+// CreateAuctionRequest puts a lot up for auction and holds its stock until
+// the auction closes. An empty warehouse_id sells stock of the default
+// warehouse, opens_at 0 opens the auction right away.
+type CreateAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	WarehouseId   string                 `protobuf:"bytes,2,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ReservePrice  *Money                 `protobuf:"bytes,4,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`
+	OpensAt       int64                  `protobuf:"varint,5,opt,name=opens_at,json=opensAt,proto3" json:"opens_at,omitempty"`
+	ClosesAt      int64                  `protobuf:"varint,6,opt,name=closes_at,json=closesAt,proto3" json:"closes_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuctionRequest) Reset() {
+	*x = CreateAuctionRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuctionRequest) ProtoMessage() {}
+
+func (x *CreateAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuctionRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *CreateAuctionRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetReservePrice() *Money {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return nil
+}
+
+func (x *CreateAuctionRequest) GetOpensAt() int64 {
+	if x != nil {
+		return x.OpensAt
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetClosesAt() int64 {
+	if x != nil {
+		return x.ClosesAt
+	}
+	return 0
+}
+
+type CreateAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuctionResponse) Reset() {
+	*x = CreateAuctionResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuctionResponse) ProtoMessage() {}
+
+func (x *CreateAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuctionResponse.ProtoReflect.Descriptor instead.
+func (*CreateAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *CreateAuctionResponse) GetAuction() *Auction {
+	if x != nil {
+		return x.Auction
 	}
 	return nil
 }
 
-type UpdateProductResponse struct {
+type GetAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuctionRequest) Reset() {
+	*x = GetAuctionRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuctionRequest) ProtoMessage() {}
+
+func (x *GetAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuctionRequest.ProtoReflect.Descriptor instead.
+func (*GetAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *GetAuctionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAuctionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductResponse) Reset() {
-	*x = UpdateProductResponse{}
-	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[8]
+func (x *GetAuctionResponse) Reset() {
+	*x = GetAuctionResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductResponse) String() string {
+func (x *GetAuctionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductResponse) ProtoMessage() {}
+func (*GetAuctionResponse) ProtoMessage() {}
 
-func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[8]
+func (x *GetAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -528,40 +7194,134 @@ func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetAuctionResponse.ProtoReflect.Descriptor instead.
+func (*GetAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *UpdateProductResponse) GetProduct() *Product {
+func (x *GetAuctionResponse) GetAuction() *Auction {
 	if x != nil {
-		return x.Product
+		return x.Auction
 	}
 	return nil
 }
 
-type DeleteProductResponse struct {
+type ListAuctionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only auctions with this status, every auction when empty
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuctionsRequest) Reset() {
+	*x = ListAuctionsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuctionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuctionsRequest) ProtoMessage() {}
+
+func (x *ListAuctionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuctionsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuctionsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *ListAuctionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListAuctionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by closing time
+	Auctions      []*Auction `protobuf:"bytes,1,rep,name=auctions,proto3" json:"auctions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuctionsResponse) Reset() {
+	*x = ListAuctionsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuctionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuctionsResponse) ProtoMessage() {}
+
+func (x *ListAuctionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuctionsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuctionsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ListAuctionsResponse) GetAuctions() []*Auction {
+	if x != nil {
+		return x.Auctions
+	}
+	return nil
+}
+
+// SubmitBidRequest places a bid for the whole lot, replacing the bidder's
+// earlier bid. It fails with FAILED_PRECONDITION outside the bidding window.
+type SubmitBidRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductResponse) Reset() {
-	*x = DeleteProductResponse{}
-	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[9]
+func (x *SubmitBidRequest) Reset() {
+	*x = SubmitBidRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductResponse) String() string {
+func (x *SubmitBidRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductResponse) ProtoMessage() {}
+func (*SubmitBidRequest) ProtoMessage() {}
 
-func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[9]
+func (x *SubmitBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -572,43 +7332,54 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
-	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use SubmitBidRequest.ProtoReflect.Descriptor instead.
+func (*SubmitBidRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *DeleteProductResponse) GetSuccess() bool {
+func (x *SubmitBidRequest) GetAuctionId() string {
 	if x != nil {
-		return x.Success
+		return x.AuctionId
 	}
-	return false
+	return ""
 }
 
-type ListProductsResponse struct {
+func (x *SubmitBidRequest) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *SubmitBidRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type SubmitBidResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[10]
+func (x *SubmitBidResponse) Reset() {
+	*x = SubmitBidResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *SubmitBidResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*SubmitBidResponse) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[10]
+func (x *SubmitBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,44 +7390,115 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SubmitBidResponse.ProtoReflect.Descriptor instead.
+func (*SubmitBidResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *SubmitBidResponse) GetBid() *Bid {
 	if x != nil {
-		return x.Products
+		return x.Bid
 	}
 	return nil
 }
 
-func (x *ListProductsResponse) GetTotal() int32 {
+// ListBidsRequest fails with FAILED_PRECONDITION while the auction is open,
+// bids are sealed until it closes
+type ListBidsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBidsRequest) Reset() {
+	*x = ListBidsRequest{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBidsRequest) ProtoMessage() {}
+
+func (x *ListBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[116]
 	if x != nil {
-		return x.Total
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ListProductsResponse) GetPage() int32 {
+// Deprecated: Use ListBidsRequest.ProtoReflect.Descriptor instead.
+func (*ListBidsRequest) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ListBidsRequest) GetAuctionId() string {
 	if x != nil {
-		return x.Page
+		return x.AuctionId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListProductsResponse) GetPageSize() int32 {
+type ListBidsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// from best to worst: the highest amount, then the earliest submitted,
+	// then by bidder
+	Bids          []*Bid `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBidsResponse) Reset() {
+	*x = ListBidsResponse{}
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBidsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBidsResponse) ProtoMessage() {}
+
+func (x *ListBidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidrpc_bidrpcproto_product_proto_msgTypes[117]
 	if x != nil {
-		return x.PageSize
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBidsResponse.ProtoReflect.Descriptor instead.
+func (*ListBidsResponse) Descriptor() ([]byte, []int) {
+	return file_bidrpc_bidrpcproto_product_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *ListBidsResponse) GetBids() []*Bid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
 }
 
 var File_bidrpc_bidrpcproto_product_proto protoreflect.FileDescriptor
 
 const file_bidrpc_bidrpcproto_product_proto_rawDesc = "" +
 	"\n" +
-	" bidrpc/bidrpcproto/product.proto\x12\vbidrpcproto\"\xbf\x01\n" +
+	" bidrpc/bidrpcproto/product.proto\x12\vbidrpcproto\x1a google/protobuf/field_mask.proto\"\x85\a\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -666,27 +7508,200 @@ const file_bidrpc_bidrpcproto_product_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\x03R\tupdatedAt\"~\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x12\x1a\n" +
+	"\breserved\x18\t \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\n" +
+	" \x01(\x05R\tavailable\x121\n" +
+	"\x05stock\x18\v \x03(\v2\x1b.bidrpcproto.WarehouseStockR\x05stock\x12\x1f\n" +
+	"\vcategory_id\x18\f \x01(\tR\n" +
+	"categoryId\x123\n" +
+	"\vprice_money\x18\r \x01(\v2\x12.bidrpcproto.MoneyR\n" +
+	"priceMoney\x121\n" +
+	"\x05units\x18\x0e \x01(\v2\x1b.bidrpcproto.UnitsOfMeasureR\x05units\x12,\n" +
+	"\abatches\x18\x0f \x03(\v2\x12.bidrpcproto.BatchR\abatches\x12\x1b\n" +
+	"\tparent_id\x18\x10 \x01(\tR\bparentId\x12;\n" +
+	"\n" +
+	"attributes\x18\x11 \x03(\v2\x1b.bidrpcproto.AttributeValueR\n" +
+	"attributes\x120\n" +
+	"\bvariants\x18\x12 \x03(\v2\x14.bidrpcproto.ProductR\bvariants\x12>\n" +
+	"\tallergens\x18\x13 \x03(\v2 .bidrpcproto.AllergenDeclarationR\tallergens\x124\n" +
+	"\tnutrition\x18\x14 \x01(\v2\x16.bidrpcproto.NutritionR\tnutrition\x12!\n" +
+	"\fdietary_tags\x18\x15 \x03(\tR\vdietaryTags\x120\n" +
+	"\bbarcodes\x18\x16 \x03(\v2\x14.bidrpcproto.BarcodeR\bbarcodes\x12\x10\n" +
+	"\x03sku\x18\x17 \x01(\tR\x03sku\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x18 \x01(\x03R\tdeletedAt\"1\n" +
+	"\aBarcode\x12\x12\n" +
+	"\x04gtin\x18\x01 \x01(\tR\x04gtin\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\"I\n" +
+	"\x13AllergenDeclaration\x12\x1a\n" +
+	"\ballergen\x18\x01 \x01(\tR\ballergen\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\x80\x02\n" +
+	"\tNutrition\x12\x1b\n" +
+	"\tenergy_kj\x18\x01 \x01(\x01R\benergyKj\x12\x1f\n" +
+	"\venergy_kcal\x18\x02 \x01(\x01R\n" +
+	"energyKcal\x12\x10\n" +
+	"\x03fat\x18\x03 \x01(\x01R\x03fat\x12#\n" +
+	"\rsaturated_fat\x18\x04 \x01(\x01R\fsaturatedFat\x12\"\n" +
+	"\fcarbohydrate\x18\x05 \x01(\x01R\fcarbohydrate\x12\x16\n" +
+	"\x06sugars\x18\x06 \x01(\x01R\x06sugars\x12\x14\n" +
+	"\x05fibre\x18\a \x01(\x01R\x05fibre\x12\x18\n" +
+	"\aprotein\x18\b \x01(\x01R\aprotein\x12\x12\n" +
+	"\x04salt\x18\t \x01(\x01R\x04salt\":\n" +
+	"\x0eAttributeValue\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x80\x01\n" +
+	"\x13AttributeDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0eallowed_values\x18\x03 \x03(\tR\rallowedValues\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\"l\n" +
+	"\x0eUnitsOfMeasure\x12\x1b\n" +
+	"\tbase_unit\x18\x01 \x01(\tR\bbaseUnit\x12=\n" +
+	"\vconversions\x18\x02 \x03(\v2\x1b.bidrpcproto.UnitConversionR\vconversions\"\\\n" +
+	"\x0eUnitConversion\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06factor\x18\x02 \x01(\x01R\x06factor\x12\x1e\n" +
+	"\n" +
+	"fractional\x18\x03 \x01(\bR\n" +
+	"fractional\"3\n" +
+	"\aMeasure\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\"O\n" +
+	"\x05Money\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12!\n" +
+	"\famount_minor\x18\x02 \x01(\x03R\vamountMinor\"\x89\x01\n" +
+	"\x0eWarehouseStock\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\x04 \x01(\x05R\tavailable\"\xdb\x01\n" +
+	"\x05Batch\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12\x19\n" +
+	"\blot_code\x18\x02 \x01(\tR\alotCode\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x1f\n" +
+	"\vreceived_at\x18\x04 \x01(\x03R\n" +
+	"receivedAt\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\breserved\x18\x06 \x01(\x05R\breserved\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x05R\tavailable\"D\n" +
+	"\vLotQuantity\x12\x19\n" +
+	"\blot_code\x18\x01 \x01(\tR\alotCode\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x87\x01\n" +
+	"\tWarehouse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"\xea\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12@\n" +
+	"\n" +
+	"attributes\x18\x06 \x03(\v2 .bidrpcproto.AttributeDefinitionR\n" +
+	"attributes\x12\x1d\n" +
+	"\n" +
+	"sku_prefix\x18\a \x01(\tR\tskuPrefix\"\xee\x04\n" +
 	"\x14CreateProductRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
 	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\"#\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\tR\n" +
+	"categoryId\x123\n" +
+	"\vprice_money\x18\x06 \x01(\v2\x12.bidrpcproto.MoneyR\n" +
+	"priceMoney\x121\n" +
+	"\x05units\x18\a \x01(\v2\x1b.bidrpcproto.UnitsOfMeasureR\x05units\x12.\n" +
+	"\ameasure\x18\b \x01(\v2\x14.bidrpcproto.MeasureR\ameasure\x12\x1b\n" +
+	"\tparent_id\x18\t \x01(\tR\bparentId\x12;\n" +
+	"\n" +
+	"attributes\x18\n" +
+	" \x03(\v2\x1b.bidrpcproto.AttributeValueR\n" +
+	"attributes\x12>\n" +
+	"\tallergens\x18\v \x03(\v2 .bidrpcproto.AllergenDeclarationR\tallergens\x124\n" +
+	"\tnutrition\x18\f \x01(\v2\x16.bidrpcproto.NutritionR\tnutrition\x12!\n" +
+	"\fdietary_tags\x18\r \x03(\tR\vdietaryTags\x120\n" +
+	"\bbarcodes\x18\x0e \x03(\v2\x14.bidrpcproto.BarcodeR\bbarcodes\x12\x10\n" +
+	"\x03sku\x18\x0f \x01(\tR\x03sku\"5\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x8e\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\"0\n" +
+	"\x1aGetProductByBarcodeRequest\x12\x12\n" +
+	"\x04gtin\x18\x01 \x01(\tR\x04gtin\"}\n" +
+	"\x1bGetProductByBarcodeResponse\x12.\n" +
+	"\aproduct\x18\x01 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\x12.\n" +
+	"\abarcode\x18\x02 \x01(\v2\x14.bidrpcproto.BarcodeR\abarcode\"\x90\x06\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x05 \x01(\x05R\bquantity\"&\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05price\x18\x04 \x01(\x01H\x02R\x05price\x88\x01\x01\x123\n" +
+	"\vprice_money\x18\t \x01(\v2\x12.bidrpcproto.MoneyR\n" +
+	"priceMoney\x12\x1f\n" +
+	"\bquantity\x18\x05 \x01(\x05H\x03R\bquantity\x88\x01\x01\x12$\n" +
+	"\vcategory_id\x18\b \x01(\tH\x04R\n" +
+	"categoryId\x88\x01\x01\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersion\x12;\n" +
+	"\vupdate_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x121\n" +
+	"\x05units\x18\n" +
+	" \x01(\v2\x1b.bidrpcproto.UnitsOfMeasureR\x05units\x12.\n" +
+	"\ameasure\x18\v \x01(\v2\x14.bidrpcproto.MeasureR\ameasure\x12;\n" +
+	"\n" +
+	"attributes\x18\f \x03(\v2\x1b.bidrpcproto.AttributeValueR\n" +
+	"attributes\x12>\n" +
+	"\tallergens\x18\r \x03(\v2 .bidrpcproto.AllergenDeclarationR\tallergens\x124\n" +
+	"\tnutrition\x18\x0e \x01(\v2\x16.bidrpcproto.NutritionR\tnutrition\x12!\n" +
+	"\fdietary_tags\x18\x0f \x03(\tR\vdietaryTags\x120\n" +
+	"\bbarcodes\x18\x10 \x03(\v2\x14.bidrpcproto.BarcodeR\bbarcodesB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_priceB\v\n" +
+	"\t_quantityB\x0e\n" +
+	"\f_category_id\"Q\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"g\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"\x9a\x02\n" +
 	"\x13ListProductsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
 	"\vname_filter\x18\x03 \x01(\tR\n" +
-	"nameFilter\"G\n" +
+	"nameFilter\x12\x17\n" +
+	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06filter\x18\a \x01(\tR\x06filter\x12!\n" +
+	"\fwarehouse_id\x18\b \x01(\tR\vwarehouseId\x12\x1f\n" +
+	"\vcategory_id\x18\t \x01(\tR\n" +
+	"categoryId\"\xdd\x01\n" +
+	"\x1aListDeletedProductsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vname_filter\x18\x03 \x01(\tR\n" +
+	"nameFilter\x12\x17\n" +
+	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\tR\tsortOrder\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12\x16\n" +
+	"\x06filter\x18\a \x01(\tR\x06filter\"R\n" +
+	"\x15RestoreProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x03R\x0fexpectedVersion\"J\n" +
+	"\x15SearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"G\n" +
 	"\x15CreateProductResponse\x12.\n" +
 	"\aproduct\x18\x01 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"D\n" +
 	"\x12GetProductResponse\x12.\n" +
@@ -694,19 +7709,443 @@ const file_bidrpc_bidrpcproto_product_proto_rawDesc = "" +
 	"\x15UpdateProductResponse\x12.\n" +
 	"\aproduct\x18\x01 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"1\n" +
 	"\x15DeleteProductResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8f\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb7\x01\n" +
 	"\x14ListProductsResponse\x120\n" +
 	"\bproducts\x18\x01 \x03(\v2\x14.bidrpcproto.ProductR\bproducts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize2\xbc\x03\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"\xbe\x01\n" +
+	"\x1bListDeletedProductsResponse\x120\n" +
+	"\bproducts\x18\x01 \x03(\v2\x14.bidrpcproto.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12&\n" +
+	"\x0fnext_page_token\x18\x05 \x01(\tR\rnextPageToken\"H\n" +
+	"\x16RestoreProductResponse\x12.\n" +
+	"\aproduct\x18\x01 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"\xb0\x01\n" +
+	"\fSearchResult\x12.\n" +
+	"\aproduct\x18\x01 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12%\n" +
+	"\x0ename_highlight\x18\x03 \x01(\tR\rnameHighlight\x123\n" +
+	"\x15description_highlight\x18\x04 \x01(\tR\x14descriptionHighlight\"M\n" +
+	"\x16SearchProductsResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.bidrpcproto.SearchResultR\aresults\"\xe2\x02\n" +
+	"\rStockMovement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05delta\x18\x04 \x01(\x05R\x05delta\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\x05R\abalance\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12!\n" +
+	"\fwarehouse_id\x18\n" +
+	" \x01(\tR\vwarehouseId\x12&\n" +
+	"\x0fto_warehouse_id\x18\v \x01(\tR\rtoWarehouseId\x12,\n" +
+	"\x04lots\x18\f \x03(\v2\x18.bidrpcproto.LotQuantityR\x04lots\"\xd1\x02\n" +
+	"\x1aRecordStockMovementRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersion\x12!\n" +
+	"\fwarehouse_id\x18\a \x01(\tR\vwarehouseId\x12.\n" +
+	"\ameasure\x18\b \x01(\v2\x14.bidrpcproto.MeasureR\ameasure\x12\x19\n" +
+	"\blot_code\x18\t \x01(\tR\alotCode\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\x03R\texpiresAt\"\x85\x01\n" +
+	"\x1bRecordStockMovementResponse\x126\n" +
+	"\bmovement\x18\x01 \x01(\v2\x1a.bidrpcproto.StockMovementR\bmovement\x12.\n" +
+	"\aproduct\x18\x02 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"v\n" +
+	"\x19ListStockMovementsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"~\n" +
+	"\x1aListStockMovementsResponse\x128\n" +
+	"\tmovements\x18\x01 \x03(\v2\x1a.bidrpcproto.StockMovementR\tmovements\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb4\x02\n" +
+	"\vReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\x12!\n" +
+	"\fwarehouse_id\x18\t \x01(\tR\vwarehouseId\x12,\n" +
+	"\x04lots\x18\n" +
+	" \x03(\v2\x18.bidrpcproto.LotQuantityR\x04lots\"\xda\x01\n" +
+	"\x13ReserveStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vttl_seconds\x18\x03 \x01(\x03R\n" +
+	"ttlSeconds\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12!\n" +
+	"\fwarehouse_id\x18\x05 \x01(\tR\vwarehouseId\x12.\n" +
+	"\ameasure\x18\x06 \x01(\v2\x14.bidrpcproto.MeasureR\ameasure\"\x82\x01\n" +
+	"\x14ReserveStockResponse\x12:\n" +
+	"\vreservation\x18\x01 \x01(\v2\x18.bidrpcproto.ReservationR\vreservation\x12.\n" +
+	"\aproduct\x18\x02 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"*\n" +
+	"\x18CommitReservationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xbf\x01\n" +
+	"\x19CommitReservationResponse\x12:\n" +
+	"\vreservation\x18\x01 \x01(\v2\x18.bidrpcproto.ReservationR\vreservation\x126\n" +
+	"\bmovement\x18\x02 \x01(\v2\x1a.bidrpcproto.StockMovementR\bmovement\x12.\n" +
+	"\aproduct\x18\x03 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"+\n" +
+	"\x19ReleaseReservationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x88\x01\n" +
+	"\x1aReleaseReservationResponse\x12:\n" +
+	"\vreservation\x18\x01 \x01(\v2\x18.bidrpcproto.ReservationR\vreservation\x12.\n" +
+	"\aproduct\x18\x02 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"\xc9\x02\n" +
+	"\x14TransferStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12*\n" +
+	"\x11from_warehouse_id\x18\x02 \x01(\tR\x0ffromWarehouseId\x12&\n" +
+	"\x0fto_warehouse_id\x18\x03 \x01(\tR\rtoWarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x06 \x01(\tR\x05actor\x12)\n" +
+	"\x10expected_version\x18\a \x01(\x03R\x0fexpectedVersion\x12.\n" +
+	"\ameasure\x18\b \x01(\v2\x14.bidrpcproto.MeasureR\ameasure\x12\x19\n" +
+	"\blot_code\x18\t \x01(\tR\alotCode\"\x7f\n" +
+	"\x15TransferStockResponse\x126\n" +
+	"\bmovement\x18\x01 \x01(\v2\x1a.bidrpcproto.StockMovementR\bmovement\x12.\n" +
+	"\aproduct\x18\x02 \x01(\v2\x14.bidrpcproto.ProductR\aproduct\"F\n" +
+	"\x16CreateWarehouseRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\"O\n" +
+	"\x17CreateWarehouseResponse\x124\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x16.bidrpcproto.WarehouseR\twarehouse\"%\n" +
+	"\x13GetWarehouseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"L\n" +
+	"\x14GetWarehouseResponse\x124\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x16.bidrpcproto.WarehouseR\twarehouse\"\x17\n" +
+	"\x15ListWarehousesRequest\"P\n" +
+	"\x16ListWarehousesResponse\x126\n" +
+	"\n" +
+	"warehouses\x18\x01 \x03(\v2\x16.bidrpcproto.WarehouseR\n" +
+	"warehouses\"^\n" +
+	"\x18ListExpiringStockRequest\x12\x1f\n" +
+	"\vwithin_days\x18\x01 \x01(\x05R\n" +
+	"withinDays\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\"\x98\x01\n" +
+	"\rExpiringStock\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12(\n" +
+	"\x05batch\x18\x03 \x01(\v2\x12.bidrpcproto.BatchR\x05batch\x12\x1b\n" +
+	"\tdays_left\x18\x04 \x01(\x05R\bdaysLeft\"M\n" +
+	"\x19ListExpiringStockResponse\x120\n" +
+	"\x05stock\x18\x01 \x03(\v2\x1a.bidrpcproto.ExpiringStockR\x05stock\"V\n" +
+	"\x16UpdateWarehouseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"O\n" +
+	"\x17UpdateWarehouseResponse\x124\n" +
+	"\twarehouse\x18\x01 \x01(\v2\x16.bidrpcproto.WarehouseR\twarehouse\"(\n" +
+	"\x16DeleteWarehouseRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"3\n" +
+	"\x17DeleteWarehouseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"H\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"K\n" +
+	"\x16CreateCategoryResponse\x121\n" +
+	"\bcategory\x18\x01 \x01(\v2\x15.bidrpcproto.CategoryR\bcategory\"B\n" +
+	"\x13MoveCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tparent_id\x18\x02 \x01(\tR\bparentId\"I\n" +
+	"\x14MoveCategoryResponse\x121\n" +
+	"\bcategory\x18\x01 \x01(\v2\x15.bidrpcproto.CategoryR\bcategory\"p\n" +
+	"\x1cSetCategoryAttributesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12@\n" +
+	"\n" +
+	"attributes\x18\x02 \x03(\v2 .bidrpcproto.AttributeDefinitionR\n" +
+	"attributes\"R\n" +
+	"\x1dSetCategoryAttributesResponse\x121\n" +
+	"\bcategory\x18\x01 \x01(\v2\x15.bidrpcproto.CategoryR\bcategory\"L\n" +
+	"\x1bSetCategorySKUPrefixRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"sku_prefix\x18\x02 \x01(\tR\tskuPrefix\"Q\n" +
+	"\x1cSetCategorySKUPrefixResponse\x121\n" +
+	"\bcategory\x18\x01 \x01(\v2\x15.bidrpcproto.CategoryR\bcategory\"\x17\n" +
+	"\x15ListCategoriesRequest\"O\n" +
+	"\x16ListCategoriesResponse\x125\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x15.bidrpcproto.CategoryR\n" +
+	"categories\"\xe1\x01\n" +
+	"\bSupplier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcontact_name\x18\x03 \x01(\tR\vcontactName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x12$\n" +
+	"\x0elead_time_days\x18\x06 \x01(\x05R\fleadTimeDays\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\x03R\tupdatedAt\"\xe0\x02\n" +
+	"\x0fProductSupplier\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vsupplier_id\x18\x02 \x01(\tR\n" +
+	"supplierId\x12!\n" +
+	"\fsupplier_sku\x18\x03 \x01(\tR\vsupplierSku\x12\x1d\n" +
+	"\n" +
+	"cost_price\x18\x04 \x01(\x01R\tcostPrice\x12\x1c\n" +
+	"\tpreferred\x18\x05 \x01(\bR\tpreferred\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x121\n" +
+	"\bsupplier\x18\b \x01(\v2\x15.bidrpcproto.SupplierR\bsupplier\x12<\n" +
+	"\x10cost_price_money\x18\t \x01(\v2\x12.bidrpcproto.MoneyR\x0ecostPriceMoney\"\xa0\x01\n" +
+	"\x15CreateSupplierRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontact_name\x18\x02 \x01(\tR\vcontactName\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x04 \x01(\tR\x05phone\x12$\n" +
+	"\x0elead_time_days\x18\x05 \x01(\x05R\fleadTimeDays\"K\n" +
+	"\x16CreateSupplierResponse\x121\n" +
+	"\bsupplier\x18\x01 \x01(\v2\x15.bidrpcproto.SupplierR\bsupplier\"$\n" +
+	"\x12GetSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"H\n" +
+	"\x13GetSupplierResponse\x121\n" +
+	"\bsupplier\x18\x01 \x01(\v2\x15.bidrpcproto.SupplierR\bsupplier\"\x16\n" +
+	"\x14ListSuppliersRequest\"L\n" +
+	"\x15ListSuppliersResponse\x123\n" +
+	"\tsuppliers\x18\x01 \x03(\v2\x15.bidrpcproto.SupplierR\tsuppliers\"\xb0\x01\n" +
+	"\x15UpdateSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcontact_name\x18\x03 \x01(\tR\vcontactName\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x14\n" +
+	"\x05phone\x18\x05 \x01(\tR\x05phone\x12$\n" +
+	"\x0elead_time_days\x18\x06 \x01(\x05R\fleadTimeDays\"K\n" +
+	"\x16UpdateSupplierResponse\x121\n" +
+	"\bsupplier\x18\x01 \x01(\v2\x15.bidrpcproto.SupplierR\bsupplier\"'\n" +
+	"\x15DeleteSupplierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"2\n" +
+	"\x16DeleteSupplierResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xfa\x01\n" +
+	"\x1aLinkProductSupplierRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vsupplier_id\x18\x02 \x01(\tR\n" +
+	"supplierId\x12!\n" +
+	"\fsupplier_sku\x18\x03 \x01(\tR\vsupplierSku\x12\x1d\n" +
+	"\n" +
+	"cost_price\x18\x04 \x01(\x01R\tcostPrice\x12\x1c\n" +
+	"\tpreferred\x18\x05 \x01(\bR\tpreferred\x12<\n" +
+	"\x10cost_price_money\x18\x06 \x01(\v2\x12.bidrpcproto.MoneyR\x0ecostPriceMoney\"f\n" +
+	"\x1bLinkProductSupplierResponse\x12G\n" +
+	"\x10product_supplier\x18\x01 \x01(\v2\x1c.bidrpcproto.ProductSupplierR\x0fproductSupplier\"<\n" +
+	"\x1bListProductSuppliersRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"i\n" +
+	"\x1cListProductSuppliersResponse\x12I\n" +
+	"\x11product_suppliers\x18\x01 \x03(\v2\x1c.bidrpcproto.ProductSupplierR\x10productSuppliers\"^\n" +
+	"\x1cUnlinkProductSupplierRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vsupplier_id\x18\x02 \x01(\tR\n" +
+	"supplierId\"9\n" +
+	"\x1dUnlinkProductSupplierResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x90\x01\n" +
+	"\tPriceList\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcustomer_ids\x18\x03 \x03(\tR\vcustomerIds\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\"X\n" +
+	"\tPriceTier\x12!\n" +
+	"\fmin_quantity\x18\x01 \x01(\x05R\vminQuantity\x12(\n" +
+	"\x05price\x18\x02 \x01(\v2\x12.bidrpcproto.MoneyR\x05price\"\xaa\x02\n" +
+	"\n" +
+	"PriceQuote\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\"\n" +
+	"\rprice_list_id\x18\x02 \x01(\tR\vpriceListId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x121\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\x12.bidrpcproto.MoneyR\tunitPrice\x121\n" +
+	"\n" +
+	"line_price\x18\x05 \x01(\v2\x12.bidrpcproto.MoneyR\tlinePrice\x12\x12\n" +
+	"\x04rule\x18\x06 \x01(\tR\x04rule\x12!\n" +
+	"\fmin_quantity\x18\a \x01(\x05R\vminQuantity\x12 \n" +
+	"\vexplanation\x18\b \x01(\tR\vexplanation\"O\n" +
+	"\x16CreatePriceListRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcustomer_ids\x18\x02 \x03(\tR\vcustomerIds\"P\n" +
+	"\x17CreatePriceListResponse\x125\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x16.bidrpcproto.PriceListR\tpriceList\"%\n" +
+	"\x13GetPriceListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
+	"\x14GetPriceListResponse\x125\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x16.bidrpcproto.PriceListR\tpriceList\"\x17\n" +
+	"\x15ListPriceListsRequest\"Q\n" +
+	"\x16ListPriceListsResponse\x127\n" +
+	"\vprice_lists\x18\x01 \x03(\v2\x16.bidrpcproto.PriceListR\n" +
+	"priceLists\"_\n" +
+	"\x16UpdatePriceListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fcustomer_ids\x18\x03 \x03(\tR\vcustomerIds\"P\n" +
+	"\x17UpdatePriceListResponse\x125\n" +
+	"\n" +
+	"price_list\x18\x01 \x01(\v2\x16.bidrpcproto.PriceListR\tpriceList\"\x87\x01\n" +
+	"\x14SetPriceTiersRequest\x12\"\n" +
+	"\rprice_list_id\x18\x01 \x01(\tR\vpriceListId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12,\n" +
+	"\x05tiers\x18\x03 \x03(\v2\x16.bidrpcproto.PriceTierR\x05tiers\"E\n" +
+	"\x15SetPriceTiersResponse\x12,\n" +
+	"\x05tiers\x18\x01 \x03(\v2\x16.bidrpcproto.PriceTierR\x05tiers\"Y\n" +
+	"\x14GetPriceTiersRequest\x12\"\n" +
+	"\rprice_list_id\x18\x01 \x01(\tR\vpriceListId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\"E\n" +
+	"\x15GetPriceTiersResponse\x12,\n" +
+	"\x05tiers\x18\x01 \x03(\v2\x16.bidrpcproto.PriceTierR\x05tiers\"\x93\x01\n" +
+	"\x11QuotePriceRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\"\n" +
+	"\rprice_list_id\x18\x02 \x01(\tR\vpriceListId\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"C\n" +
+	"\x12QuotePriceResponse\x12-\n" +
+	"\x05quote\x18\x01 \x01(\v2\x17.bidrpcproto.PriceQuoteR\x05quote\"\xfb\x03\n" +
+	"\aAuction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12!\n" +
+	"\fwarehouse_id\x18\x03 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x127\n" +
+	"\rreserve_price\x18\x05 \x01(\v2\x12.bidrpcproto.MoneyR\freservePrice\x12\x19\n" +
+	"\bopens_at\x18\x06 \x01(\x03R\aopensAt\x12\x1b\n" +
+	"\tcloses_at\x18\a \x01(\x03R\bclosesAt\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12%\n" +
+	"\x0ereservation_id\x18\t \x01(\tR\rreservationId\x12$\n" +
+	"\x0ewinning_bid_id\x18\n" +
+	" \x01(\tR\fwinningBidId\x12\x1b\n" +
+	"\twinner_id\x18\v \x01(\tR\bwinnerId\x127\n" +
+	"\rwinning_price\x18\f \x01(\v2\x12.bidrpcproto.MoneyR\fwinningPrice\x12\x18\n" +
+	"\aoutcome\x18\r \x01(\tR\aoutcome\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\x03R\tupdatedAt\"\xa0\x01\n" +
+	"\x03Bid\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x02 \x01(\tR\tauctionId\x12\x1b\n" +
+	"\tbidder_id\x18\x03 \x01(\tR\bbidderId\x12*\n" +
+	"\x06amount\x18\x04 \x01(\v2\x12.bidrpcproto.MoneyR\x06amount\x12!\n" +
+	"\fsubmitted_at\x18\x05 \x01(\x03R\vsubmittedAt\"\xe5\x01\n" +
+	"\x14CreateAuctionRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12!\n" +
+	"\fwarehouse_id\x18\x02 \x01(\tR\vwarehouseId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x127\n" +
+	"\rreserve_price\x18\x04 \x01(\v2\x12.bidrpcproto.MoneyR\freservePrice\x12\x19\n" +
+	"\bopens_at\x18\x05 \x01(\x03R\aopensAt\x12\x1b\n" +
+	"\tcloses_at\x18\x06 \x01(\x03R\bclosesAt\"G\n" +
+	"\x15CreateAuctionResponse\x12.\n" +
+	"\aauction\x18\x01 \x01(\v2\x14.bidrpcproto.AuctionR\aauction\"#\n" +
+	"\x11GetAuctionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x12GetAuctionResponse\x12.\n" +
+	"\aauction\x18\x01 \x01(\v2\x14.bidrpcproto.AuctionR\aauction\"-\n" +
+	"\x13ListAuctionsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"H\n" +
+	"\x14ListAuctionsResponse\x120\n" +
+	"\bauctions\x18\x01 \x03(\v2\x14.bidrpcproto.AuctionR\bauctions\"z\n" +
+	"\x10SubmitBidRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\x12*\n" +
+	"\x06amount\x18\x03 \x01(\v2\x12.bidrpcproto.MoneyR\x06amount\"7\n" +
+	"\x11SubmitBidResponse\x12\"\n" +
+	"\x03bid\x18\x01 \x01(\v2\x10.bidrpcproto.BidR\x03bid\"0\n" +
+	"\x0fListBidsRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\"8\n" +
+	"\x10ListBidsResponse\x12$\n" +
+	"\x04bids\x18\x01 \x03(\v2\x10.bidrpcproto.BidR\x04bids2\xa5\x13\n" +
 	"\x0eProductService\x12V\n" +
 	"\rCreateProduct\x12!.bidrpcproto.CreateProductRequest\x1a\".bidrpcproto.CreateProductResponse\x12M\n" +
 	"\n" +
-	"GetProduct\x12\x1e.bidrpcproto.GetProductRequest\x1a\x1f.bidrpcproto.GetProductResponse\x12V\n" +
+	"GetProduct\x12\x1e.bidrpcproto.GetProductRequest\x1a\x1f.bidrpcproto.GetProductResponse\x12h\n" +
+	"\x13GetProductByBarcode\x12'.bidrpcproto.GetProductByBarcodeRequest\x1a(.bidrpcproto.GetProductByBarcodeResponse\x12V\n" +
 	"\rUpdateProduct\x12!.bidrpcproto.UpdateProductRequest\x1a\".bidrpcproto.UpdateProductResponse\x12V\n" +
 	"\rDeleteProduct\x12!.bidrpcproto.DeleteProductRequest\x1a\".bidrpcproto.DeleteProductResponse\x12S\n" +
-	"\fListProducts\x12 .bidrpcproto.ListProductsRequest\x1a!.bidrpcproto.ListProductsResponseB'Z%bidone/bidrpc/bidrpcproto;bidrpcprotob\x06proto3"
+	"\fListProducts\x12 .bidrpcproto.ListProductsRequest\x1a!.bidrpcproto.ListProductsResponse\x12h\n" +
+	"\x13ListDeletedProducts\x12'.bidrpcproto.ListDeletedProductsRequest\x1a(.bidrpcproto.ListDeletedProductsResponse\x12Y\n" +
+	"\x0eRestoreProduct\x12\".bidrpcproto.RestoreProductRequest\x1a#.bidrpcproto.RestoreProductResponse\x12Y\n" +
+	"\x0eSearchProducts\x12\".bidrpcproto.SearchProductsRequest\x1a#.bidrpcproto.SearchProductsResponse\x12h\n" +
+	"\x13RecordStockMovement\x12'.bidrpcproto.RecordStockMovementRequest\x1a(.bidrpcproto.RecordStockMovementResponse\x12e\n" +
+	"\x12ListStockMovements\x12&.bidrpcproto.ListStockMovementsRequest\x1a'.bidrpcproto.ListStockMovementsResponse\x12S\n" +
+	"\fReserveStock\x12 .bidrpcproto.ReserveStockRequest\x1a!.bidrpcproto.ReserveStockResponse\x12b\n" +
+	"\x11CommitReservation\x12%.bidrpcproto.CommitReservationRequest\x1a&.bidrpcproto.CommitReservationResponse\x12e\n" +
+	"\x12ReleaseReservation\x12&.bidrpcproto.ReleaseReservationRequest\x1a'.bidrpcproto.ReleaseReservationResponse\x12V\n" +
+	"\rTransferStock\x12!.bidrpcproto.TransferStockRequest\x1a\".bidrpcproto.TransferStockResponse\x12b\n" +
+	"\x11ListExpiringStock\x12%.bidrpcproto.ListExpiringStockRequest\x1a&.bidrpcproto.ListExpiringStockResponse\x12\\\n" +
+	"\x0fCreateWarehouse\x12#.bidrpcproto.CreateWarehouseRequest\x1a$.bidrpcproto.CreateWarehouseResponse\x12S\n" +
+	"\fGetWarehouse\x12 .bidrpcproto.GetWarehouseRequest\x1a!.bidrpcproto.GetWarehouseResponse\x12Y\n" +
+	"\x0eListWarehouses\x12\".bidrpcproto.ListWarehousesRequest\x1a#.bidrpcproto.ListWarehousesResponse\x12\\\n" +
+	"\x0fUpdateWarehouse\x12#.bidrpcproto.UpdateWarehouseRequest\x1a$.bidrpcproto.UpdateWarehouseResponse\x12\\\n" +
+	"\x0fDeleteWarehouse\x12#.bidrpcproto.DeleteWarehouseRequest\x1a$.bidrpcproto.DeleteWarehouseResponse\x12Y\n" +
+	"\x0eCreateCategory\x12\".bidrpcproto.CreateCategoryRequest\x1a#.bidrpcproto.CreateCategoryResponse\x12S\n" +
+	"\fMoveCategory\x12 .bidrpcproto.MoveCategoryRequest\x1a!.bidrpcproto.MoveCategoryResponse\x12n\n" +
+	"\x15SetCategoryAttributes\x12).bidrpcproto.SetCategoryAttributesRequest\x1a*.bidrpcproto.SetCategoryAttributesResponse\x12k\n" +
+	"\x14SetCategorySKUPrefix\x12(.bidrpcproto.SetCategorySKUPrefixRequest\x1a).bidrpcproto.SetCategorySKUPrefixResponse\x12Y\n" +
+	"\x0eListCategories\x12\".bidrpcproto.ListCategoriesRequest\x1a#.bidrpcproto.ListCategoriesResponse2\x93\x06\n" +
+	"\x0fSupplierService\x12Y\n" +
+	"\x0eCreateSupplier\x12\".bidrpcproto.CreateSupplierRequest\x1a#.bidrpcproto.CreateSupplierResponse\x12P\n" +
+	"\vGetSupplier\x12\x1f.bidrpcproto.GetSupplierRequest\x1a .bidrpcproto.GetSupplierResponse\x12V\n" +
+	"\rListSuppliers\x12!.bidrpcproto.ListSuppliersRequest\x1a\".bidrpcproto.ListSuppliersResponse\x12Y\n" +
+	"\x0eUpdateSupplier\x12\".bidrpcproto.UpdateSupplierRequest\x1a#.bidrpcproto.UpdateSupplierResponse\x12Y\n" +
+	"\x0eDeleteSupplier\x12\".bidrpcproto.DeleteSupplierRequest\x1a#.bidrpcproto.DeleteSupplierResponse\x12h\n" +
+	"\x13LinkProductSupplier\x12'.bidrpcproto.LinkProductSupplierRequest\x1a(.bidrpcproto.LinkProductSupplierResponse\x12k\n" +
+	"\x14ListProductSuppliers\x12(.bidrpcproto.ListProductSuppliersRequest\x1a).bidrpcproto.ListProductSuppliersResponse\x12n\n" +
+	"\x15UnlinkProductSupplier\x12).bidrpcproto.UnlinkProductSupplierRequest\x1a*.bidrpcproto.UnlinkProductSupplierResponse2\xfb\x04\n" +
+	"\x0ePricingService\x12\\\n" +
+	"\x0fCreatePriceList\x12#.bidrpcproto.CreatePriceListRequest\x1a$.bidrpcproto.CreatePriceListResponse\x12S\n" +
+	"\fGetPriceList\x12 .bidrpcproto.GetPriceListRequest\x1a!.bidrpcproto.GetPriceListResponse\x12Y\n" +
+	"\x0eListPriceLists\x12\".bidrpcproto.ListPriceListsRequest\x1a#.bidrpcproto.ListPriceListsResponse\x12\\\n" +
+	"\x0fUpdatePriceList\x12#.bidrpcproto.UpdatePriceListRequest\x1a$.bidrpcproto.UpdatePriceListResponse\x12V\n" +
+	"\rSetPriceTiers\x12!.bidrpcproto.SetPriceTiersRequest\x1a\".bidrpcproto.SetPriceTiersResponse\x12V\n" +
+	"\rGetPriceTiers\x12!.bidrpcproto.GetPriceTiersRequest\x1a\".bidrpcproto.GetPriceTiersResponse\x12M\n" +
+	"\n" +
+	"QuotePrice\x12\x1e.bidrpcproto.QuotePriceRequest\x1a\x1f.bidrpcproto.QuotePriceResponse2\xa1\x03\n" +
+	"\x0eAuctionService\x12V\n" +
+	"\rCreateAuction\x12!.bidrpcproto.CreateAuctionRequest\x1a\".bidrpcproto.CreateAuctionResponse\x12M\n" +
+	"\n" +
+	"GetAuction\x12\x1e.bidrpcproto.GetAuctionRequest\x1a\x1f.bidrpcproto.GetAuctionResponse\x12S\n" +
+	"\fListAuctions\x12 .bidrpcproto.ListAuctionsRequest\x1a!.bidrpcproto.ListAuctionsResponse\x12J\n" +
+	"\tSubmitBid\x12\x1d.bidrpcproto.SubmitBidRequest\x1a\x1e.bidrpcproto.SubmitBidResponse\x12G\n" +
+	"\bListBids\x12\x1c.bidrpcproto.ListBidsRequest\x1a\x1d.bidrpcproto.ListBidsResponseB9Z7github.com/athxx/bidfood/bidrpc/bidrpcproto;bidrpcprotob\x06proto3"
 
 var (
 	file_bidrpc_bidrpcproto_product_proto_rawDescOnce sync.Once
@@ -720,40 +8159,321 @@ func file_bidrpc_bidrpcproto_product_proto_rawDescGZIP() []byte {
 	return file_bidrpc_bidrpcproto_product_proto_rawDescData
 }
 
-var file_bidrpc_bidrpcproto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_bidrpc_bidrpcproto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 118)
 var file_bidrpc_bidrpcproto_product_proto_goTypes = []any{
-	(*Product)(nil),               // 0: bidrpcproto.Product
-	(*CreateProductRequest)(nil),  // 1: bidrpcproto.CreateProductRequest
-	(*GetProductRequest)(nil),     // 2: bidrpcproto.GetProductRequest
-	(*UpdateProductRequest)(nil),  // 3: bidrpcproto.UpdateProductRequest
-	(*DeleteProductRequest)(nil),  // 4: bidrpcproto.DeleteProductRequest
-	(*ListProductsRequest)(nil),   // 5: bidrpcproto.ListProductsRequest
-	(*CreateProductResponse)(nil), // 6: bidrpcproto.CreateProductResponse
-	(*GetProductResponse)(nil),    // 7: bidrpcproto.GetProductResponse
-	(*UpdateProductResponse)(nil), // 8: bidrpcproto.UpdateProductResponse
-	(*DeleteProductResponse)(nil), // 9: bidrpcproto.DeleteProductResponse
-	(*ListProductsResponse)(nil),  // 10: bidrpcproto.ListProductsResponse
+	(*Product)(nil),                       // 0: bidrpcproto.Product
+	(*Barcode)(nil),                       // 1: bidrpcproto.Barcode
+	(*AllergenDeclaration)(nil),           // 2: bidrpcproto.AllergenDeclaration
+	(*Nutrition)(nil),                     // 3: bidrpcproto.Nutrition
+	(*AttributeValue)(nil),                // 4: bidrpcproto.AttributeValue
+	(*AttributeDefinition)(nil),           // 5: bidrpcproto.AttributeDefinition
+	(*UnitsOfMeasure)(nil),                // 6: bidrpcproto.UnitsOfMeasure
+	(*UnitConversion)(nil),                // 7: bidrpcproto.UnitConversion
+	(*Measure)(nil),                       // 8: bidrpcproto.Measure
+	(*Money)(nil),                         // 9: bidrpcproto.Money
+	(*WarehouseStock)(nil),                // 10: bidrpcproto.WarehouseStock
+	(*Batch)(nil),                         // 11: bidrpcproto.Batch
+	(*LotQuantity)(nil),                   // 12: bidrpcproto.LotQuantity
+	(*Warehouse)(nil),                     // 13: bidrpcproto.Warehouse
+	(*Category)(nil),                      // 14: bidrpcproto.Category
+	(*CreateProductRequest)(nil),          // 15: bidrpcproto.CreateProductRequest
+	(*GetProductRequest)(nil),             // 16: bidrpcproto.GetProductRequest
+	(*GetProductByBarcodeRequest)(nil),    // 17: bidrpcproto.GetProductByBarcodeRequest
+	(*GetProductByBarcodeResponse)(nil),   // 18: bidrpcproto.GetProductByBarcodeResponse
+	(*UpdateProductRequest)(nil),          // 19: bidrpcproto.UpdateProductRequest
+	(*DeleteProductRequest)(nil),          // 20: bidrpcproto.DeleteProductRequest
+	(*ListProductsRequest)(nil),           // 21: bidrpcproto.ListProductsRequest
+	(*ListDeletedProductsRequest)(nil),    // 22: bidrpcproto.ListDeletedProductsRequest
+	(*RestoreProductRequest)(nil),         // 23: bidrpcproto.RestoreProductRequest
+	(*SearchProductsRequest)(nil),         // 24: bidrpcproto.SearchProductsRequest
+	(*CreateProductResponse)(nil),         // 25: bidrpcproto.CreateProductResponse
+	(*GetProductResponse)(nil),            // 26: bidrpcproto.GetProductResponse
+	(*UpdateProductResponse)(nil),         // 27: bidrpcproto.UpdateProductResponse
+	(*DeleteProductResponse)(nil),         // 28: bidrpcproto.DeleteProductResponse
+	(*ListProductsResponse)(nil),          // 29: bidrpcproto.ListProductsResponse
+	(*ListDeletedProductsResponse)(nil),   // 30: bidrpcproto.ListDeletedProductsResponse
+	(*RestoreProductResponse)(nil),        // 31: bidrpcproto.RestoreProductResponse
+	(*SearchResult)(nil),                  // 32: bidrpcproto.SearchResult
+	(*SearchProductsResponse)(nil),        // 33: bidrpcproto.SearchProductsResponse
+	(*StockMovement)(nil),                 // 34: bidrpcproto.StockMovement
+	(*RecordStockMovementRequest)(nil),    // 35: bidrpcproto.RecordStockMovementRequest
+	(*RecordStockMovementResponse)(nil),   // 36: bidrpcproto.RecordStockMovementResponse
+	(*ListStockMovementsRequest)(nil),     // 37: bidrpcproto.ListStockMovementsRequest
+	(*ListStockMovementsResponse)(nil),    // 38: bidrpcproto.ListStockMovementsResponse
+	(*Reservation)(nil),                   // 39: bidrpcproto.Reservation
+	(*ReserveStockRequest)(nil),           // 40: bidrpcproto.ReserveStockRequest
+	(*ReserveStockResponse)(nil),          // 41: bidrpcproto.ReserveStockResponse
+	(*CommitReservationRequest)(nil),      // 42: bidrpcproto.CommitReservationRequest
+	(*CommitReservationResponse)(nil),     // 43: bidrpcproto.CommitReservationResponse
+	(*ReleaseReservationRequest)(nil),     // 44: bidrpcproto.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),    // 45: bidrpcproto.ReleaseReservationResponse
+	(*TransferStockRequest)(nil),          // 46: bidrpcproto.TransferStockRequest
+	(*TransferStockResponse)(nil),         // 47: bidrpcproto.TransferStockResponse
+	(*CreateWarehouseRequest)(nil),        // 48: bidrpcproto.CreateWarehouseRequest
+	(*CreateWarehouseResponse)(nil),       // 49: bidrpcproto.CreateWarehouseResponse
+	(*GetWarehouseRequest)(nil),           // 50: bidrpcproto.GetWarehouseRequest
+	(*GetWarehouseResponse)(nil),          // 51: bidrpcproto.GetWarehouseResponse
+	(*ListWarehousesRequest)(nil),         // 52: bidrpcproto.ListWarehousesRequest
+	(*ListWarehousesResponse)(nil),        // 53: bidrpcproto.ListWarehousesResponse
+	(*ListExpiringStockRequest)(nil),      // 54: bidrpcproto.ListExpiringStockRequest
+	(*ExpiringStock)(nil),                 // 55: bidrpcproto.ExpiringStock
+	(*ListExpiringStockResponse)(nil),     // 56: bidrpcproto.ListExpiringStockResponse
+	(*UpdateWarehouseRequest)(nil),        // 57: bidrpcproto.UpdateWarehouseRequest
+	(*UpdateWarehouseResponse)(nil),       // 58: bidrpcproto.UpdateWarehouseResponse
+	(*DeleteWarehouseRequest)(nil),        // 59: bidrpcproto.DeleteWarehouseRequest
+	(*DeleteWarehouseResponse)(nil),       // 60: bidrpcproto.DeleteWarehouseResponse
+	(*CreateCategoryRequest)(nil),         // 61: bidrpcproto.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),        // 62: bidrpcproto.CreateCategoryResponse
+	(*MoveCategoryRequest)(nil),           // 63: bidrpcproto.MoveCategoryRequest
+	(*MoveCategoryResponse)(nil),          // 64: bidrpcproto.MoveCategoryResponse
+	(*SetCategoryAttributesRequest)(nil),  // 65: bidrpcproto.SetCategoryAttributesRequest
+	(*SetCategoryAttributesResponse)(nil), // 66: bidrpcproto.SetCategoryAttributesResponse
+	(*SetCategorySKUPrefixRequest)(nil),   // 67: bidrpcproto.SetCategorySKUPrefixRequest
+	(*SetCategorySKUPrefixResponse)(nil),  // 68: bidrpcproto.SetCategorySKUPrefixResponse
+	(*ListCategoriesRequest)(nil),         // 69: bidrpcproto.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),        // 70: bidrpcproto.ListCategoriesResponse
+	(*Supplier)(nil),                      // 71: bidrpcproto.Supplier
+	(*ProductSupplier)(nil),               // 72: bidrpcproto.ProductSupplier
+	(*CreateSupplierRequest)(nil),         // 73: bidrpcproto.CreateSupplierRequest
+	(*CreateSupplierResponse)(nil),        // 74: bidrpcproto.CreateSupplierResponse
+	(*GetSupplierRequest)(nil),            // 75: bidrpcproto.GetSupplierRequest
+	(*GetSupplierResponse)(nil),           // 76: bidrpcproto.GetSupplierResponse
+	(*ListSuppliersRequest)(nil),          // 77: bidrpcproto.ListSuppliersRequest
+	(*ListSuppliersResponse)(nil),         // 78: bidrpcproto.ListSuppliersResponse
+	(*UpdateSupplierRequest)(nil),         // 79: bidrpcproto.UpdateSupplierRequest
+	(*UpdateSupplierResponse)(nil),        // 80: bidrpcproto.UpdateSupplierResponse
+	(*DeleteSupplierRequest)(nil),         // 81: bidrpcproto.DeleteSupplierRequest
+	(*DeleteSupplierResponse)(nil),        // 82: bidrpcproto.DeleteSupplierResponse
+	(*LinkProductSupplierRequest)(nil),    // 83: bidrpcproto.LinkProductSupplierRequest
+	(*LinkProductSupplierResponse)(nil),   // 84: bidrpcproto.LinkProductSupplierResponse
+	(*ListProductSuppliersRequest)(nil),   // 85: bidrpcproto.ListProductSuppliersRequest
+	(*ListProductSuppliersResponse)(nil),  // 86: bidrpcproto.ListProductSuppliersResponse
+	(*UnlinkProductSupplierRequest)(nil),  // 87: bidrpcproto.UnlinkProductSupplierRequest
+	(*UnlinkProductSupplierResponse)(nil), // 88: bidrpcproto.UnlinkProductSupplierResponse
+	(*PriceList)(nil),                     // 89: bidrpcproto.PriceList
+	(*PriceTier)(nil),                     // 90: bidrpcproto.PriceTier
+	(*PriceQuote)(nil),                    // 91: bidrpcproto.PriceQuote
+	(*CreatePriceListRequest)(nil),        // 92: bidrpcproto.CreatePriceListRequest
+	(*CreatePriceListResponse)(nil),       // 93: bidrpcproto.CreatePriceListResponse
+	(*GetPriceListRequest)(nil),           // 94: bidrpcproto.GetPriceListRequest
+	(*GetPriceListResponse)(nil),          // 95: bidrpcproto.GetPriceListResponse
+	(*ListPriceListsRequest)(nil),         // 96: bidrpcproto.ListPriceListsRequest
+	(*ListPriceListsResponse)(nil),        // 97: bidrpcproto.ListPriceListsResponse
+	(*UpdatePriceListRequest)(nil),        // 98: bidrpcproto.UpdatePriceListRequest
+	(*UpdatePriceListResponse)(nil),       // 99: bidrpcproto.UpdatePriceListResponse
+	(*SetPriceTiersRequest)(nil),          // 100: bidrpcproto.SetPriceTiersRequest
+	(*SetPriceTiersResponse)(nil),         // 101: bidrpcproto.SetPriceTiersResponse
+	(*GetPriceTiersRequest)(nil),          // 102: bidrpcproto.GetPriceTiersRequest
+	(*GetPriceTiersResponse)(nil),         // 103: bidrpcproto.GetPriceTiersResponse
+	(*QuotePriceRequest)(nil),             // 104: bidrpcproto.QuotePriceRequest
+	(*QuotePriceResponse)(nil),            // 105: bidrpcproto.QuotePriceResponse
+	(*Auction)(nil),                       // 106: bidrpcproto.Auction
+	(*Bid)(nil),                           // 107: bidrpcproto.Bid
+	(*CreateAuctionRequest)(nil),          // 108: bidrpcproto.CreateAuctionRequest
+	(*CreateAuctionResponse)(nil),         // 109: bidrpcproto.CreateAuctionResponse
+	(*GetAuctionRequest)(nil),             // 110: bidrpcproto.GetAuctionRequest
+	(*GetAuctionResponse)(nil),            // 111: bidrpcproto.GetAuctionResponse
+	(*ListAuctionsRequest)(nil),           // 112: bidrpcproto.ListAuctionsRequest
+	(*ListAuctionsResponse)(nil),          // 113: bidrpcproto.ListAuctionsResponse
+	(*SubmitBidRequest)(nil),              // 114: bidrpcproto.SubmitBidRequest
+	(*SubmitBidResponse)(nil),             // 115: bidrpcproto.SubmitBidResponse
+	(*ListBidsRequest)(nil),               // 116: bidrpcproto.ListBidsRequest
+	(*ListBidsResponse)(nil),              // 117: bidrpcproto.ListBidsResponse
+	(*fieldmaskpb.FieldMask)(nil),         // 118: google.protobuf.FieldMask
 }
 var file_bidrpc_bidrpcproto_product_proto_depIdxs = []int32{
-	0,  // 0: bidrpcproto.CreateProductResponse.product:type_name -> bidrpcproto.Product
-	0,  // 1: bidrpcproto.GetProductResponse.product:type_name -> bidrpcproto.Product
-	0,  // 2: bidrpcproto.UpdateProductResponse.product:type_name -> bidrpcproto.Product
-	0,  // 3: bidrpcproto.ListProductsResponse.products:type_name -> bidrpcproto.Product
-	1,  // 4: bidrpcproto.ProductService.CreateProduct:input_type -> bidrpcproto.CreateProductRequest
-	2,  // 5: bidrpcproto.ProductService.GetProduct:input_type -> bidrpcproto.GetProductRequest
-	3,  // 6: bidrpcproto.ProductService.UpdateProduct:input_type -> bidrpcproto.UpdateProductRequest
-	4,  // 7: bidrpcproto.ProductService.DeleteProduct:input_type -> bidrpcproto.DeleteProductRequest
-	5,  // 8: bidrpcproto.ProductService.ListProducts:input_type -> bidrpcproto.ListProductsRequest
-	6,  // 9: bidrpcproto.ProductService.CreateProduct:output_type -> bidrpcproto.CreateProductResponse
-	7,  // 10: bidrpcproto.ProductService.GetProduct:output_type -> bidrpcproto.GetProductResponse
-	8,  // 11: bidrpcproto.ProductService.UpdateProduct:output_type -> bidrpcproto.UpdateProductResponse
-	9,  // 12: bidrpcproto.ProductService.DeleteProduct:output_type -> bidrpcproto.DeleteProductResponse
-	10, // 13: bidrpcproto.ProductService.ListProducts:output_type -> bidrpcproto.ListProductsResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	10,  // 0: bidrpcproto.Product.stock:type_name -> bidrpcproto.WarehouseStock
+	9,   // 1: bidrpcproto.Product.price_money:type_name -> bidrpcproto.Money
+	6,   // 2: bidrpcproto.Product.units:type_name -> bidrpcproto.UnitsOfMeasure
+	11,  // 3: bidrpcproto.Product.batches:type_name -> bidrpcproto.Batch
+	4,   // 4: bidrpcproto.Product.attributes:type_name -> bidrpcproto.AttributeValue
+	0,   // 5: bidrpcproto.Product.variants:type_name -> bidrpcproto.Product
+	2,   // 6: bidrpcproto.Product.allergens:type_name -> bidrpcproto.AllergenDeclaration
+	3,   // 7: bidrpcproto.Product.nutrition:type_name -> bidrpcproto.Nutrition
+	1,   // 8: bidrpcproto.Product.barcodes:type_name -> bidrpcproto.Barcode
+	7,   // 9: bidrpcproto.UnitsOfMeasure.conversions:type_name -> bidrpcproto.UnitConversion
+	5,   // 10: bidrpcproto.Category.attributes:type_name -> bidrpcproto.AttributeDefinition
+	9,   // 11: bidrpcproto.CreateProductRequest.price_money:type_name -> bidrpcproto.Money
+	6,   // 12: bidrpcproto.CreateProductRequest.units:type_name -> bidrpcproto.UnitsOfMeasure
+	8,   // 13: bidrpcproto.CreateProductRequest.measure:type_name -> bidrpcproto.Measure
+	4,   // 14: bidrpcproto.CreateProductRequest.attributes:type_name -> bidrpcproto.AttributeValue
+	2,   // 15: bidrpcproto.CreateProductRequest.allergens:type_name -> bidrpcproto.AllergenDeclaration
+	3,   // 16: bidrpcproto.CreateProductRequest.nutrition:type_name -> bidrpcproto.Nutrition
+	1,   // 17: bidrpcproto.CreateProductRequest.barcodes:type_name -> bidrpcproto.Barcode
+	0,   // 18: bidrpcproto.GetProductByBarcodeResponse.product:type_name -> bidrpcproto.Product
+	1,   // 19: bidrpcproto.GetProductByBarcodeResponse.barcode:type_name -> bidrpcproto.Barcode
+	9,   // 20: bidrpcproto.UpdateProductRequest.price_money:type_name -> bidrpcproto.Money
+	118, // 21: bidrpcproto.UpdateProductRequest.update_mask:type_name -> google.protobuf.FieldMask
+	6,   // 22: bidrpcproto.UpdateProductRequest.units:type_name -> bidrpcproto.UnitsOfMeasure
+	8,   // 23: bidrpcproto.UpdateProductRequest.measure:type_name -> bidrpcproto.Measure
+	4,   // 24: bidrpcproto.UpdateProductRequest.attributes:type_name -> bidrpcproto.AttributeValue
+	2,   // 25: bidrpcproto.UpdateProductRequest.allergens:type_name -> bidrpcproto.AllergenDeclaration
+	3,   // 26: bidrpcproto.UpdateProductRequest.nutrition:type_name -> bidrpcproto.Nutrition
+	1,   // 27: bidrpcproto.UpdateProductRequest.barcodes:type_name -> bidrpcproto.Barcode
+	0,   // 28: bidrpcproto.CreateProductResponse.product:type_name -> bidrpcproto.Product
+	0,   // 29: bidrpcproto.GetProductResponse.product:type_name -> bidrpcproto.Product
+	0,   // 30: bidrpcproto.UpdateProductResponse.product:type_name -> bidrpcproto.Product
+	0,   // 31: bidrpcproto.ListProductsResponse.products:type_name -> bidrpcproto.Product
+	0,   // 32: bidrpcproto.ListDeletedProductsResponse.products:type_name -> bidrpcproto.Product
+	0,   // 33: bidrpcproto.RestoreProductResponse.product:type_name -> bidrpcproto.Product
+	0,   // 34: bidrpcproto.SearchResult.product:type_name -> bidrpcproto.Product
+	32,  // 35: bidrpcproto.SearchProductsResponse.results:type_name -> bidrpcproto.SearchResult
+	12,  // 36: bidrpcproto.StockMovement.lots:type_name -> bidrpcproto.LotQuantity
+	8,   // 37: bidrpcproto.RecordStockMovementRequest.measure:type_name -> bidrpcproto.Measure
+	34,  // 38: bidrpcproto.RecordStockMovementResponse.movement:type_name -> bidrpcproto.StockMovement
+	0,   // 39: bidrpcproto.RecordStockMovementResponse.product:type_name -> bidrpcproto.Product
+	34,  // 40: bidrpcproto.ListStockMovementsResponse.movements:type_name -> bidrpcproto.StockMovement
+	12,  // 41: bidrpcproto.Reservation.lots:type_name -> bidrpcproto.LotQuantity
+	8,   // 42: bidrpcproto.ReserveStockRequest.measure:type_name -> bidrpcproto.Measure
+	39,  // 43: bidrpcproto.ReserveStockResponse.reservation:type_name -> bidrpcproto.Reservation
+	0,   // 44: bidrpcproto.ReserveStockResponse.product:type_name -> bidrpcproto.Product
+	39,  // 45: bidrpcproto.CommitReservationResponse.reservation:type_name -> bidrpcproto.Reservation
+	34,  // 46: bidrpcproto.CommitReservationResponse.movement:type_name -> bidrpcproto.StockMovement
+	0,   // 47: bidrpcproto.CommitReservationResponse.product:type_name -> bidrpcproto.Product
+	39,  // 48: bidrpcproto.ReleaseReservationResponse.reservation:type_name -> bidrpcproto.Reservation
+	0,   // 49: bidrpcproto.ReleaseReservationResponse.product:type_name -> bidrpcproto.Product
+	8,   // 50: bidrpcproto.TransferStockRequest.measure:type_name -> bidrpcproto.Measure
+	34,  // 51: bidrpcproto.TransferStockResponse.movement:type_name -> bidrpcproto.StockMovement
+	0,   // 52: bidrpcproto.TransferStockResponse.product:type_name -> bidrpcproto.Product
+	13,  // 53: bidrpcproto.CreateWarehouseResponse.warehouse:type_name -> bidrpcproto.Warehouse
+	13,  // 54: bidrpcproto.GetWarehouseResponse.warehouse:type_name -> bidrpcproto.Warehouse
+	13,  // 55: bidrpcproto.ListWarehousesResponse.warehouses:type_name -> bidrpcproto.Warehouse
+	11,  // 56: bidrpcproto.ExpiringStock.batch:type_name -> bidrpcproto.Batch
+	55,  // 57: bidrpcproto.ListExpiringStockResponse.stock:type_name -> bidrpcproto.ExpiringStock
+	13,  // 58: bidrpcproto.UpdateWarehouseResponse.warehouse:type_name -> bidrpcproto.Warehouse
+	14,  // 59: bidrpcproto.CreateCategoryResponse.category:type_name -> bidrpcproto.Category
+	14,  // 60: bidrpcproto.MoveCategoryResponse.category:type_name -> bidrpcproto.Category
+	5,   // 61: bidrpcproto.SetCategoryAttributesRequest.attributes:type_name -> bidrpcproto.AttributeDefinition
+	14,  // 62: bidrpcproto.SetCategoryAttributesResponse.category:type_name -> bidrpcproto.Category
+	14,  // 63: bidrpcproto.SetCategorySKUPrefixResponse.category:type_name -> bidrpcproto.Category
+	14,  // 64: bidrpcproto.ListCategoriesResponse.categories:type_name -> bidrpcproto.Category
+	71,  // 65: bidrpcproto.ProductSupplier.supplier:type_name -> bidrpcproto.Supplier
+	9,   // 66: bidrpcproto.ProductSupplier.cost_price_money:type_name -> bidrpcproto.Money
+	71,  // 67: bidrpcproto.CreateSupplierResponse.supplier:type_name -> bidrpcproto.Supplier
+	71,  // 68: bidrpcproto.GetSupplierResponse.supplier:type_name -> bidrpcproto.Supplier
+	71,  // 69: bidrpcproto.ListSuppliersResponse.suppliers:type_name -> bidrpcproto.Supplier
+	71,  // 70: bidrpcproto.UpdateSupplierResponse.supplier:type_name -> bidrpcproto.Supplier
+	9,   // 71: bidrpcproto.LinkProductSupplierRequest.cost_price_money:type_name -> bidrpcproto.Money
+	72,  // 72: bidrpcproto.LinkProductSupplierResponse.product_supplier:type_name -> bidrpcproto.ProductSupplier
+	72,  // 73: bidrpcproto.ListProductSuppliersResponse.product_suppliers:type_name -> bidrpcproto.ProductSupplier
+	9,   // 74: bidrpcproto.PriceTier.price:type_name -> bidrpcproto.Money
+	9,   // 75: bidrpcproto.PriceQuote.unit_price:type_name -> bidrpcproto.Money
+	9,   // 76: bidrpcproto.PriceQuote.line_price:type_name -> bidrpcproto.Money
+	89,  // 77: bidrpcproto.CreatePriceListResponse.price_list:type_name -> bidrpcproto.PriceList
+	89,  // 78: bidrpcproto.GetPriceListResponse.price_list:type_name -> bidrpcproto.PriceList
+	89,  // 79: bidrpcproto.ListPriceListsResponse.price_lists:type_name -> bidrpcproto.PriceList
+	89,  // 80: bidrpcproto.UpdatePriceListResponse.price_list:type_name -> bidrpcproto.PriceList
+	90,  // 81: bidrpcproto.SetPriceTiersRequest.tiers:type_name -> bidrpcproto.PriceTier
+	90,  // 82: bidrpcproto.SetPriceTiersResponse.tiers:type_name -> bidrpcproto.PriceTier
+	90,  // 83: bidrpcproto.GetPriceTiersResponse.tiers:type_name -> bidrpcproto.PriceTier
+	91,  // 84: bidrpcproto.QuotePriceResponse.quote:type_name -> bidrpcproto.PriceQuote
+	9,   // 85: bidrpcproto.Auction.reserve_price:type_name -> bidrpcproto.Money
+	9,   // 86: bidrpcproto.Auction.winning_price:type_name -> bidrpcproto.Money
+	9,   // 87: bidrpcproto.Bid.amount:type_name -> bidrpcproto.Money
+	9,   // 88: bidrpcproto.CreateAuctionRequest.reserve_price:type_name -> bidrpcproto.Money
+	106, // 89: bidrpcproto.CreateAuctionResponse.auction:type_name -> bidrpcproto.Auction
+	106, // 90: bidrpcproto.GetAuctionResponse.auction:type_name -> bidrpcproto.Auction
+	106, // 91: bidrpcproto.ListAuctionsResponse.auctions:type_name -> bidrpcproto.Auction
+	9,   // 92: bidrpcproto.SubmitBidRequest.amount:type_name -> bidrpcproto.Money
+	107, // 93: bidrpcproto.SubmitBidResponse.bid:type_name -> bidrpcproto.Bid
+	107, // 94: bidrpcproto.ListBidsResponse.bids:type_name -> bidrpcproto.Bid
+	15,  // 95: bidrpcproto.ProductService.CreateProduct:input_type -> bidrpcproto.CreateProductRequest
+	16,  // 96: bidrpcproto.ProductService.GetProduct:input_type -> bidrpcproto.GetProductRequest
+	17,  // 97: bidrpcproto.ProductService.GetProductByBarcode:input_type -> bidrpcproto.GetProductByBarcodeRequest
+	19,  // 98: bidrpcproto.ProductService.UpdateProduct:input_type -> bidrpcproto.UpdateProductRequest
+	20,  // 99: bidrpcproto.ProductService.DeleteProduct:input_type -> bidrpcproto.DeleteProductRequest
+	21,  // 100: bidrpcproto.ProductService.ListProducts:input_type -> bidrpcproto.ListProductsRequest
+	22,  // 101: bidrpcproto.ProductService.ListDeletedProducts:input_type -> bidrpcproto.ListDeletedProductsRequest
+	23,  // 102: bidrpcproto.ProductService.RestoreProduct:input_type -> bidrpcproto.RestoreProductRequest
+	24,  // 103: bidrpcproto.ProductService.SearchProducts:input_type -> bidrpcproto.SearchProductsRequest
+	35,  // 104: bidrpcproto.ProductService.RecordStockMovement:input_type -> bidrpcproto.RecordStockMovementRequest
+	37,  // 105: bidrpcproto.ProductService.ListStockMovements:input_type -> bidrpcproto.ListStockMovementsRequest
+	40,  // 106: bidrpcproto.ProductService.ReserveStock:input_type -> bidrpcproto.ReserveStockRequest
+	42,  // 107: bidrpcproto.ProductService.CommitReservation:input_type -> bidrpcproto.CommitReservationRequest
+	44,  // 108: bidrpcproto.ProductService.ReleaseReservation:input_type -> bidrpcproto.ReleaseReservationRequest
+	46,  // 109: bidrpcproto.ProductService.TransferStock:input_type -> bidrpcproto.TransferStockRequest
+	54,  // 110: bidrpcproto.ProductService.ListExpiringStock:input_type -> bidrpcproto.ListExpiringStockRequest
+	48,  // 111: bidrpcproto.ProductService.CreateWarehouse:input_type -> bidrpcproto.CreateWarehouseRequest
+	50,  // 112: bidrpcproto.ProductService.GetWarehouse:input_type -> bidrpcproto.GetWarehouseRequest
+	52,  // 113: bidrpcproto.ProductService.ListWarehouses:input_type -> bidrpcproto.ListWarehousesRequest
+	57,  // 114: bidrpcproto.ProductService.UpdateWarehouse:input_type -> bidrpcproto.UpdateWarehouseRequest
+	59,  // 115: bidrpcproto.ProductService.DeleteWarehouse:input_type -> bidrpcproto.DeleteWarehouseRequest
+	61,  // 116: bidrpcproto.ProductService.CreateCategory:input_type -> bidrpcproto.CreateCategoryRequest
+	63,  // 117: bidrpcproto.ProductService.MoveCategory:input_type -> bidrpcproto.MoveCategoryRequest
+	65,  // 118: bidrpcproto.ProductService.SetCategoryAttributes:input_type -> bidrpcproto.SetCategoryAttributesRequest
+	67,  // 119: bidrpcproto.ProductService.SetCategorySKUPrefix:input_type -> bidrpcproto.SetCategorySKUPrefixRequest
+	69,  // 120: bidrpcproto.ProductService.ListCategories:input_type -> bidrpcproto.ListCategoriesRequest
+	73,  // 121: bidrpcproto.SupplierService.CreateSupplier:input_type -> bidrpcproto.CreateSupplierRequest
+	75,  // 122: bidrpcproto.SupplierService.GetSupplier:input_type -> bidrpcproto.GetSupplierRequest
+	77,  // 123: bidrpcproto.SupplierService.ListSuppliers:input_type -> bidrpcproto.ListSuppliersRequest
+	79,  // 124: bidrpcproto.SupplierService.UpdateSupplier:input_type -> bidrpcproto.UpdateSupplierRequest
+	81,  // 125: bidrpcproto.SupplierService.DeleteSupplier:input_type -> bidrpcproto.DeleteSupplierRequest
+	83,  // 126: bidrpcproto.SupplierService.LinkProductSupplier:input_type -> bidrpcproto.LinkProductSupplierRequest
+	85,  // 127: bidrpcproto.SupplierService.ListProductSuppliers:input_type -> bidrpcproto.ListProductSuppliersRequest
+	87,  // 128: bidrpcproto.SupplierService.UnlinkProductSupplier:input_type -> bidrpcproto.UnlinkProductSupplierRequest
+	92,  // 129: bidrpcproto.PricingService.CreatePriceList:input_type -> bidrpcproto.CreatePriceListRequest
+	94,  // 130: bidrpcproto.PricingService.GetPriceList:input_type -> bidrpcproto.GetPriceListRequest
+	96,  // 131: bidrpcproto.PricingService.ListPriceLists:input_type -> bidrpcproto.ListPriceListsRequest
+	98,  // 132: bidrpcproto.PricingService.UpdatePriceList:input_type -> bidrpcproto.UpdatePriceListRequest
+	100, // 133: bidrpcproto.PricingService.SetPriceTiers:input_type -> bidrpcproto.SetPriceTiersRequest
+	102, // 134: bidrpcproto.PricingService.GetPriceTiers:input_type -> bidrpcproto.GetPriceTiersRequest
+	104, // 135: bidrpcproto.PricingService.QuotePrice:input_type -> bidrpcproto.QuotePriceRequest
+	108, // 136: bidrpcproto.AuctionService.CreateAuction:input_type -> bidrpcproto.CreateAuctionRequest
+	110, // 137: bidrpcproto.AuctionService.GetAuction:input_type -> bidrpcproto.GetAuctionRequest
+	112, // 138: bidrpcproto.AuctionService.ListAuctions:input_type -> bidrpcproto.ListAuctionsRequest
+	114, // 139: bidrpcproto.AuctionService.SubmitBid:input_type -> bidrpcproto.SubmitBidRequest
+	116, // 140: bidrpcproto.AuctionService.ListBids:input_type -> bidrpcproto.ListBidsRequest
+	25,  // 141: bidrpcproto.ProductService.CreateProduct:output_type -> bidrpcproto.CreateProductResponse
+	26,  // 142: bidrpcproto.ProductService.GetProduct:output_type -> bidrpcproto.GetProductResponse
+	18,  // 143: bidrpcproto.ProductService.GetProductByBarcode:output_type -> bidrpcproto.GetProductByBarcodeResponse
+	27,  // 144: bidrpcproto.ProductService.UpdateProduct:output_type -> bidrpcproto.UpdateProductResponse
+	28,  // 145: bidrpcproto.ProductService.DeleteProduct:output_type -> bidrpcproto.DeleteProductResponse
+	29,  // 146: bidrpcproto.ProductService.ListProducts:output_type -> bidrpcproto.ListProductsResponse
+	30,  // 147: bidrpcproto.ProductService.ListDeletedProducts:output_type -> bidrpcproto.ListDeletedProductsResponse
+	31,  // 148: bidrpcproto.ProductService.RestoreProduct:output_type -> bidrpcproto.RestoreProductResponse
+	33,  // 149: bidrpcproto.ProductService.SearchProducts:output_type -> bidrpcproto.SearchProductsResponse
+	36,  // 150: bidrpcproto.ProductService.RecordStockMovement:output_type -> bidrpcproto.RecordStockMovementResponse
+	38,  // 151: bidrpcproto.ProductService.ListStockMovements:output_type -> bidrpcproto.ListStockMovementsResponse
+	41,  // 152: bidrpcproto.ProductService.ReserveStock:output_type -> bidrpcproto.ReserveStockResponse
+	43,  // 153: bidrpcproto.ProductService.CommitReservation:output_type -> bidrpcproto.CommitReservationResponse
+	45,  // 154: bidrpcproto.ProductService.ReleaseReservation:output_type -> bidrpcproto.ReleaseReservationResponse
+	47,  // 155: bidrpcproto.ProductService.TransferStock:output_type -> bidrpcproto.TransferStockResponse
+	56,  // 156: bidrpcproto.ProductService.ListExpiringStock:output_type -> bidrpcproto.ListExpiringStockResponse
+	49,  // 157: bidrpcproto.ProductService.CreateWarehouse:output_type -> bidrpcproto.CreateWarehouseResponse
+	51,  // 158: bidrpcproto.ProductService.GetWarehouse:output_type -> bidrpcproto.GetWarehouseResponse
+	53,  // 159: bidrpcproto.ProductService.ListWarehouses:output_type -> bidrpcproto.ListWarehousesResponse
+	58,  // 160: bidrpcproto.ProductService.UpdateWarehouse:output_type -> bidrpcproto.UpdateWarehouseResponse
+	60,  // 161: bidrpcproto.ProductService.DeleteWarehouse:output_type -> bidrpcproto.DeleteWarehouseResponse
+	62,  // 162: bidrpcproto.ProductService.CreateCategory:output_type -> bidrpcproto.CreateCategoryResponse
+	64,  // 163: bidrpcproto.ProductService.MoveCategory:output_type -> bidrpcproto.MoveCategoryResponse
+	66,  // 164: bidrpcproto.ProductService.SetCategoryAttributes:output_type -> bidrpcproto.SetCategoryAttributesResponse
+	68,  // 165: bidrpcproto.ProductService.SetCategorySKUPrefix:output_type -> bidrpcproto.SetCategorySKUPrefixResponse
+	70,  // 166: bidrpcproto.ProductService.ListCategories:output_type -> bidrpcproto.ListCategoriesResponse
+	74,  // 167: bidrpcproto.SupplierService.CreateSupplier:output_type -> bidrpcproto.CreateSupplierResponse
+	76,  // 168: bidrpcproto.SupplierService.GetSupplier:output_type -> bidrpcproto.GetSupplierResponse
+	78,  // 169: bidrpcproto.SupplierService.ListSuppliers:output_type -> bidrpcproto.ListSuppliersResponse
+	80,  // 170: bidrpcproto.SupplierService.UpdateSupplier:output_type -> bidrpcproto.UpdateSupplierResponse
+	82,  // 171: bidrpcproto.SupplierService.DeleteSupplier:output_type -> bidrpcproto.DeleteSupplierResponse
+	84,  // 172: bidrpcproto.SupplierService.LinkProductSupplier:output_type -> bidrpcproto.LinkProductSupplierResponse
+	86,  // 173: bidrpcproto.SupplierService.ListProductSuppliers:output_type -> bidrpcproto.ListProductSuppliersResponse
+	88,  // 174: bidrpcproto.SupplierService.UnlinkProductSupplier:output_type -> bidrpcproto.UnlinkProductSupplierResponse
+	93,  // 175: bidrpcproto.PricingService.CreatePriceList:output_type -> bidrpcproto.CreatePriceListResponse
+	95,  // 176: bidrpcproto.PricingService.GetPriceList:output_type -> bidrpcproto.GetPriceListResponse
+	97,  // 177: bidrpcproto.PricingService.ListPriceLists:output_type -> bidrpcproto.ListPriceListsResponse
+	99,  // 178: bidrpcproto.PricingService.UpdatePriceList:output_type -> bidrpcproto.UpdatePriceListResponse
+	101, // 179: bidrpcproto.PricingService.SetPriceTiers:output_type -> bidrpcproto.SetPriceTiersResponse
+	103, // 180: bidrpcproto.PricingService.GetPriceTiers:output_type -> bidrpcproto.GetPriceTiersResponse
+	105, // 181: bidrpcproto.PricingService.QuotePrice:output_type -> bidrpcproto.QuotePriceResponse
+	109, // 182: bidrpcproto.AuctionService.CreateAuction:output_type -> bidrpcproto.CreateAuctionResponse
+	111, // 183: bidrpcproto.AuctionService.GetAuction:output_type -> bidrpcproto.GetAuctionResponse
+	113, // 184: bidrpcproto.AuctionService.ListAuctions:output_type -> bidrpcproto.ListAuctionsResponse
+	115, // 185: bidrpcproto.AuctionService.SubmitBid:output_type -> bidrpcproto.SubmitBidResponse
+	117, // 186: bidrpcproto.AuctionService.ListBids:output_type -> bidrpcproto.ListBidsResponse
+	141, // [141:187] is the sub-list for method output_type
+	95,  // [95:141] is the sub-list for method input_type
+	95,  // [95:95] is the sub-list for extension type_name
+	95,  // [95:95] is the sub-list for extension extendee
+	0,   // [0:95] is the sub-list for field type_name
 }
 
 func init() { file_bidrpc_bidrpcproto_product_proto_init() }
@@ -761,15 +8481,16 @@ func file_bidrpc_bidrpcproto_product_proto_init() {
 	if File_bidrpc_bidrpcproto_product_proto != nil {
 		return
 	}
+	file_bidrpc_bidrpcproto_product_proto_msgTypes[19].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bidrpc_bidrpcproto_product_proto_rawDesc), len(file_bidrpc_bidrpcproto_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   118,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   4,
 		},
 		GoTypes:           file_bidrpc_bidrpcproto_product_proto_goTypes,
 		DependencyIndexes: file_bidrpc_bidrpcproto_product_proto_depIdxs,
@@ -1,40 +1,126 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"flag"
+	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
 	"github.com/athxx/bidfood/bidrpc/internal/biz"
 	"github.com/athxx/bidfood/bidrpc/internal/data"
 	"github.com/athxx/bidfood/bidrpc/internal/service"
 
+	_ "github.com/mattn/go-sqlite3"
 	"google.golang.org/grpc"
 )
 
 var (
-	port = flag.String("port", "9000", "gRPC server port")
+	port      = flag.String("port", "9000", "gRPC server port")
+	storage   = flag.String("storage", "file", "product storage backend: file, sql or kv")
+	dataDir   = flag.String("data-dir", ".", "directory holding the product files (-storage=file and -storage=kv)")
+	dsn       = flag.String("dsn", "file:bidfood.db?_busy_timeout=5000&_journal_mode=WAL", "SQLite data source name (-storage=sql)")
+	sweep     = flag.Duration("reservation-sweep", 30*time.Second, "interval for releasing expired stock reservations, 0 disables it")
+	closing   = flag.Duration("auction-sweep", 10*time.Second, "interval for closing auctions past their deadline, 0 disables it")
+	skuPrefix = flag.String("sku-prefix", biz.DefaultSKUFormat.Prefix, "prefix of generated SKUs, categories may set their own")
+	skuDigits = flag.Int("sku-digits", biz.DefaultSKUFormat.Digits, "width the counter of generated SKUs is zero padded to")
+	skuCheck  = flag.Bool("sku-check", biz.DefaultSKUFormat.Check, "append a check character to generated SKUs")
+	purge     = flag.Duration("trash-sweep", time.Hour, "interval for purging deleted products past their retention, 0 disables it")
+	retention = flag.Duration("trash-retention", 30*24*time.Hour, "how long deleted products can be restored before they are purged")
 )
 
+// newProductRepo opens the storage backend selected by -storage.
+// The returned cleanup function releases it.
+func newProductRepo() (biz.ProductRepo, func(), error) {
+	switch *storage {
+	case "file":
+		d, err := data.NewProductData(*dataDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		return d, func() { d.Close() }, nil
+	case "sql":
+		db, err := sql.Open("sqlite3", *dsn)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, err := data.NewProductSQL(context.Background(), db)
+		if err != nil {
+			db.Close()
+			return nil, nil, err
+		}
+		return r, func() { db.Close() }, nil
+	case "kv":
+		if err := os.MkdirAll(*dataDir, 0o755); err != nil {
+			return nil, nil, err
+		}
+		r, err := data.NewProductKV(filepath.Join(*dataDir, "products.bolt"))
+		if err != nil {
+			return nil, nil, err
+		}
+		return r, func() { r.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown storage %q", *storage)
+	}
+}
+
 func main() {
 	flag.Parse()
 
 	// Initialize repository
-	repo := data.NewProductData()
+	repo, cleanup, err := newProductRepo()
+	if err != nil {
+		log.Fatalf("failed to open product data: %v", err)
+	}
+	defer cleanup()
 
 	// Initialize use case
-	uc := biz.NewProductUseCase(repo)
+	uc := biz.NewProductUseCase(repo, data.NewSearchIndex())
+	if err := uc.SetSKUFormat(biz.SKUFormat{Prefix: *skuPrefix, Digits: *skuDigits, Check: *skuCheck}); err != nil {
+		log.Fatalf("invalid SKU format: %v", err)
+	}
+	if err := uc.Reindex(context.Background()); err != nil {
+		log.Fatalf("failed to build search index: %v", err)
+	}
+	if err := uc.EnsureDefaultWarehouse(context.Background()); err != nil {
+		log.Fatalf("failed to create the default warehouse: %v", err)
+	}
+
+	// release expired reservations until shutdown
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	defer stopSweep()
+	if *sweep > 0 {
+		go uc.SweepReservations(sweepCtx, *sweep)
+	}
+	// purge the trash past its retention until shutdown
+	if *purge > 0 {
+		go uc.SweepDeletedProducts(sweepCtx, *purge, *retention)
+	}
+	// award auctions at their deadline until shutdown
+	auctions := biz.NewAuctionUseCase(repo, uc)
+	if *closing > 0 {
+		go auctions.SweepAuctions(sweepCtx, *closing)
+	}
 
 	// Initialize service
 	productService := service.NewProductService(uc)
+	supplierService := service.NewSupplierService(biz.NewSupplierUseCase(repo))
+	pricingService := service.NewPricingService(biz.NewPricingUseCase(repo))
+	auctionService := service.NewAuctionService(auctions)
 
 	// Create gRPC server
 	s := grpc.NewServer()
 	pb.RegisterProductServiceServer(s, productService)
+	pb.RegisterSupplierServiceServer(s, supplierService)
+	pb.RegisterPricingServiceServer(s, pricingService)
+	pb.RegisterAuctionServiceServer(s, auctionService)
 
 	// Start server
 	lis, err := net.Listen("tcp", ":"+*port)
@@ -51,6 +137,7 @@ func main() {
 		<-c
 
 		log.Println("Shutting down gRPC server...")
+		stopSweep()
 		s.GracefulStop()
 	}()
 
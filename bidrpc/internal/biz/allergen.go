@@ -0,0 +1,237 @@
+package biz
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Allergen is one of the 14 major allergens food labels must declare
+type Allergen string
+
+const (
+	AllergenCelery     Allergen = "celery"
+	AllergenGluten     Allergen = "gluten"
+	AllergenCrustacean Allergen = "crustacean"
+	AllergenEgg        Allergen = "egg"
+	AllergenFish       Allergen = "fish"
+	AllergenLupin      Allergen = "lupin"
+	AllergenMilk       Allergen = "milk"
+	AllergenMollusc    Allergen = "mollusc"
+	AllergenMustard    Allergen = "mustard"
+	AllergenTreeNut    Allergen = "tree_nut"
+	AllergenPeanut     Allergen = "peanut"
+	AllergenSesame     Allergen = "sesame"
+	AllergenSoy        Allergen = "soy"
+	AllergenSulphite   Allergen = "sulphite"
+)
+
+// allergens lists the major allergens in label order
+var allergens = []Allergen{
+	AllergenCelery, AllergenGluten, AllergenCrustacean, AllergenEgg, AllergenFish, AllergenLupin, AllergenMilk,
+	AllergenMollusc, AllergenMustard, AllergenTreeNut, AllergenPeanut, AllergenSesame, AllergenSoy, AllergenSulphite,
+}
+
+// AllergenStatus is what a product declares about an allergen
+type AllergenStatus string
+
+const (
+	// AllergenContains is an ingredient of the product
+	AllergenContains AllergenStatus = "contains"
+	// AllergenMayContain is a cross-contamination risk of the production
+	AllergenMayContain AllergenStatus = "may_contain"
+	// AllergenFreeFrom is guaranteed absent
+	AllergenFreeFrom AllergenStatus = "free_from"
+)
+
+// Allergens are the allergen declarations of a product, an allergen that is
+// not declared is unknown
+type Allergens map[Allergen]AllergenStatus
+
+// Ordered returns the declared allergens in label order
+func (a Allergens) Ordered() []Allergen {
+	var out []Allergen
+	for _, al := range allergens {
+		if _, ok := a[al]; ok {
+			out = append(out, al)
+		}
+	}
+	return out
+}
+
+// present returns the allergens the product contains or may contain
+func (a Allergens) present() []Allergen {
+	var out []Allergen
+	for _, al := range allergens {
+		if s := a[al]; s == AllergenContains || s == AllergenMayContain {
+			out = append(out, al)
+		}
+	}
+	return out
+}
+
+// freeFrom returns the allergens the product is declared free from
+func (a Allergens) freeFrom() []Allergen {
+	var out []Allergen
+	for _, al := range allergens {
+		if a[al] == AllergenFreeFrom {
+			out = append(out, al)
+		}
+	}
+	return out
+}
+
+// normalize returns nil for no declarations
+func (a Allergens) normalize() Allergens {
+	if len(a) == 0 {
+		return nil
+	}
+	return a
+}
+
+func (a Allergens) validate(field string) error {
+	var errs []error
+	for _, al := range slices.Sorted(maps.Keys(a)) {
+		f := field + "." + string(al)
+		if !slices.Contains(allergens, al) {
+			errs = append(errs, InvalidArgument(f, "is not one of the major allergens %s", joinCodes(allergens)))
+			continue
+		}
+		switch a[al] {
+		case AllergenContains, AllergenMayContain, AllergenFreeFrom:
+		default:
+			errs = append(errs, InvalidArgument(f, "must be contains, may_contain or free_from"))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// Nutrition are the nutrition facts of a product per 100 g, or per 100 ml
+// of a drink, in grams except for the energy. All zero facts are not
+// declared.
+type Nutrition struct {
+	EnergyKJ     float64
+	EnergyKcal   float64
+	Fat          float64
+	SaturatedFat float64
+	Carbohydrate float64
+	Sugars       float64
+	Fibre        float64
+	Protein      float64
+	Salt         float64
+}
+
+// Declared reports whether the product declares its nutrition facts
+func (n Nutrition) Declared() bool {
+	return n != Nutrition{}
+}
+
+// maxKcalPer100g is the energy of 100 g of pure fat
+const maxKcalPer100g = 900
+
+func (n Nutrition) validate(field string) error {
+	var errs []error
+	facts := []struct {
+		name  string
+		value float64
+	}{
+		{"energy_kj", n.EnergyKJ}, {"energy_kcal", n.EnergyKcal}, {"fat", n.Fat}, {"saturated_fat", n.SaturatedFat},
+		{"carbohydrate", n.Carbohydrate}, {"sugars", n.Sugars}, {"fibre", n.Fibre}, {"protein", n.Protein}, {"salt", n.Salt},
+	}
+	for _, f := range facts {
+		if math.IsNaN(f.value) || f.value < 0 {
+			errs = append(errs, InvalidArgument(field+"."+f.name, "must not be negative"))
+		}
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return err
+	}
+
+	if n.EnergyKcal > maxKcalPer100g || n.EnergyKJ > kcalToKJ(maxKcalPer100g) {
+		errs = append(errs, InvalidArgument(field+".energy_kcal", "must not exceed %d kcal (%.0f kJ) per 100 g", maxKcalPer100g, kcalToKJ(maxKcalPer100g)))
+	}
+	if n.SaturatedFat > n.Fat {
+		errs = append(errs, InvalidArgument(field+".saturated_fat", "must not exceed the fat of %g g", n.Fat))
+	}
+	if n.Sugars > n.Carbohydrate {
+		errs = append(errs, InvalidArgument(field+".sugars", "must not exceed the carbohydrate of %g g", n.Carbohydrate))
+	}
+	if total := n.Fat + n.Carbohydrate + n.Fibre + n.Protein + n.Salt; total > 100 {
+		errs = append(errs, InvalidArgument(field, "fat, carbohydrate, fibre, protein and salt add up to %g g, more than 100 g", total))
+	}
+	return InvalidArguments(errs...)
+}
+
+func kcalToKJ(kcal float64) float64 {
+	return kcal * 4.184
+}
+
+// DietaryTag is a dietary or religious compliance claim of a product
+type DietaryTag string
+
+const (
+	DietaryVegan      DietaryTag = "vegan"
+	DietaryVegetarian DietaryTag = "vegetarian"
+	DietaryHalal      DietaryTag = "halal"
+	DietaryKosher     DietaryTag = "kosher"
+	DietaryGlutenFree DietaryTag = "gluten_free"
+)
+
+var dietaryTags = []DietaryTag{DietaryVegan, DietaryVegetarian, DietaryHalal, DietaryKosher, DietaryGlutenFree}
+
+// dietaryConflicts lists the allergens a tag rules out, with the statuses
+// that contradict it
+var dietaryConflicts = map[DietaryTag]struct {
+	allergens []Allergen
+	statuses  []AllergenStatus
+}{
+	DietaryVegan:      {[]Allergen{AllergenMilk, AllergenEgg, AllergenFish, AllergenCrustacean, AllergenMollusc}, []AllergenStatus{AllergenContains}},
+	DietaryVegetarian: {[]Allergen{AllergenFish, AllergenCrustacean, AllergenMollusc}, []AllergenStatus{AllergenContains}},
+	// gluten free is a legal claim, a contamination risk voids it
+	DietaryGlutenFree: {[]Allergen{AllergenGluten}, []AllergenStatus{AllergenContains, AllergenMayContain}},
+}
+
+func validateDietaryTags(field string, tags []DietaryTag) error {
+	var errs []error
+	for i, t := range tags {
+		switch {
+		case !slices.Contains(dietaryTags, t):
+			errs = append(errs, InvalidArgument(fmt.Sprintf("%s[%d]", field, i), "must be one of %s", joinCodes(dietaryTags)))
+		case slices.Index(tags, t) != i:
+			errs = append(errs, InvalidArgument(fmt.Sprintf("%s[%d]", field, i), "repeats %s", t))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// checkDietary fails for the dietary tags of p that its allergen
+// declarations contradict
+func (p *Product) checkDietary() error {
+	var errs []error
+	for _, t := range p.DietaryTags {
+		c := dietaryConflicts[t]
+		for _, al := range c.allergens {
+			if s, ok := p.Allergens[al]; ok && slices.Contains(c.statuses, s) {
+				errs = append(errs, InvalidArgument("dietary_tags", "%s contradicts the allergen declaration %s %s", t, s, al))
+			}
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// normalizeDietaryTags orders tags, the caller validated that they are
+// distinct
+func normalizeDietaryTags(tags []DietaryTag) []DietaryTag {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := slices.Clone(tags)
+	slices.Sort(out)
+	return out
+}
+
+func joinCodes[T ~string](in []T) string {
+	return strings.Join(codes(in), ", ")
+}
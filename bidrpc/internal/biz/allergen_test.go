@@ -0,0 +1,67 @@
+package biz
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestProductUseCase_Labelling(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	_, err := uc.CreateProduct(ctx, ProductInput{Name: "flapjack", Price: usd(150),
+		Allergens:   Allergens{"nuts": AllergenContains, AllergenMilk: "traces"},
+		Nutrition:   Nutrition{EnergyKcal: 950, Fat: 20, SaturatedFat: 25, Carbohydrate: 60, Sugars: 70, Protein: 30},
+		DietaryTags: []DietaryTag{"paleo", DietaryVegan, DietaryVegan},
+	})
+	for _, field := range []string{"allergens.nuts", "allergens.milk", "nutrition.energy_kcal", "nutrition.saturated_fat", "nutrition.sugars",
+		"nutrition", "dietary_tags[0]", "dietary_tags[2]"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(1), Nutrition: Nutrition{Salt: -1}}); !hasViolation(err, "nutrition.salt") {
+		t.Errorf("negative facts should fail, got %v", err)
+	}
+
+	// oats may carry gluten, which voids a gluten free claim
+	_, err = uc.CreateProduct(ctx, ProductInput{Name: "flapjack", Price: usd(150),
+		Allergens:   Allergens{AllergenGluten: AllergenMayContain, AllergenMilk: AllergenContains},
+		DietaryTags: []DietaryTag{DietaryGlutenFree, DietaryVegetarian, DietaryVegan},
+	})
+	if !hasViolation(err, "dietary_tags") || len(FieldViolations(err)) != 2 {
+		t.Errorf("gluten free and vegan should contradict the declarations, got %v", err)
+	}
+	p, err := uc.CreateProduct(ctx, ProductInput{Name: "flapjack", Price: usd(150),
+		Allergens:   Allergens{AllergenGluten: AllergenMayContain, AllergenMilk: AllergenContains, AllergenPeanut: AllergenFreeFrom},
+		Nutrition:   Nutrition{EnergyKJ: 1925, EnergyKcal: 460, Fat: 22, SaturatedFat: 11, Carbohydrate: 58, Sugars: 29, Fibre: 4, Protein: 6, Salt: 0.3},
+		DietaryTags: []DietaryTag{DietaryVegetarian, DietaryHalal},
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+	if !slices.Equal(p.DietaryTags, []DietaryTag{DietaryHalal, DietaryVegetarian}) || !p.Nutrition.Declared() {
+		t.Errorf("expected sorted tags and declared nutrition, got %+v", p)
+	}
+
+	// the declarations are checked together with the tags they would break
+	vegan := []DietaryTag{DietaryVegan}
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{DietaryTags: &vegan}, 0); !hasViolation(err, "dietary_tags") {
+		t.Errorf("a vegan claim on milk should fail, got %v", err)
+	}
+	fish := Allergens{AllergenFish: AllergenContains}
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Allergens: &fish}, 0); !hasViolation(err, "dietary_tags") {
+		t.Errorf("declaring fish on a vegetarian product should fail, got %v", err)
+	}
+	none, clear := Allergens{}, Nutrition{}
+	got, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Allergens: &none, Nutrition: &clear, DietaryTags: &vegan}, 0)
+	if err != nil || got.Allergens != nil || got.Nutrition.Declared() || len(got.DietaryTags) != 1 {
+		t.Errorf("the labelling should be replaced, got %+v, %v", got, err)
+	}
+
+	page, err := uc.ListProducts(ctx, ListOptions{Filter: `NOT allergen = milk AND dietary = vegan`})
+	if err != nil || len(page.Products) != 1 {
+		t.Errorf("expected the vegan flapjack, got %+v, %v", page, err)
+	}
+}
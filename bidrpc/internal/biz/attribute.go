@@ -0,0 +1,260 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// maxAttributes bounds the attribute definitions of a category
+	maxAttributes = 32
+	// maxAttributeValueLength bounds a text attribute value
+	maxAttributeValueLength = 256
+	// maxVariants bounds the variants of a product
+	maxVariants = 100
+)
+
+// AttributeType is the value type of an attribute
+type AttributeType string
+
+const (
+	AttributeText    AttributeType = "text"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+	// AttributeEnum takes one of the values the definition allows
+	AttributeEnum AttributeType = "enum"
+)
+
+// AttributeDef defines an attribute the products of a category may have,
+// e.g. size, flavour or an allergen flag
+type AttributeDef struct {
+	Name string
+	Type AttributeType
+	// Values are the allowed values of an enum
+	Values []string
+	// Required attributes must be set on every product of the category
+	Required bool
+}
+
+// Attributes are the attribute values of a product by name, in the
+// canonical form of their type: numbers as formatted by strconv and
+// booleans as true or false
+type Attributes map[string]string
+
+// validateAttributeDefs checks the attribute definitions of a category,
+// reported as field
+func validateAttributeDefs(field string, defs []AttributeDef) error {
+	var errs []error
+	if len(defs) > maxAttributes {
+		errs = append(errs, InvalidArgument(field, "must not be more than %d", maxAttributes))
+	}
+	seen := make(map[string]bool)
+	for i, d := range defs {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		switch {
+		case !validAttributeName(d.Name):
+			errs = append(errs, InvalidArgument(f+".name", "must be 1 to 32 lowercase letters, digits or underscores"))
+		case seen[d.Name]:
+			errs = append(errs, InvalidArgument(f+".name", "repeats %s", d.Name))
+		}
+		seen[d.Name] = true
+		switch d.Type {
+		case AttributeEnum:
+			if len(d.Values) == 0 {
+				errs = append(errs, InvalidArgument(f+".values", "are required for an enum"))
+			}
+			for j, v := range d.Values {
+				if strings.TrimSpace(v) != v || v == "" || len(v) > maxAttributeValueLength || slices.Index(d.Values, v) != j {
+					errs = append(errs, InvalidArgument(fmt.Sprintf("%s.values[%d]", f, j), "must be distinct, not blank and at most %d characters", maxAttributeValueLength))
+				}
+			}
+		case AttributeText, AttributeNumber, AttributeBoolean:
+			if len(d.Values) > 0 {
+				errs = append(errs, InvalidArgument(f+".values", "are only allowed for an enum"))
+			}
+		default:
+			errs = append(errs, InvalidArgument(f+".type", "must be text, number, boolean or enum"))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// validAttributeName reports whether s has the form of an attribute name
+// such as size or contains_nuts
+func validAttributeName(s string) bool {
+	return s != "" && len(s) <= 32 && !strings.ContainsFunc(s, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_'
+	})
+}
+
+// canonical checks value against d and returns it in canonical form
+func (d AttributeDef) canonical(field, value string) (string, error) {
+	switch d.Type {
+	case AttributeNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", InvalidArgument(field, "%s is a number, got %q", d.Name, value)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case AttributeBoolean:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", InvalidArgument(field, "%s is true or false, got %q", d.Name, value)
+		}
+		return strconv.FormatBool(b), nil
+	case AttributeEnum:
+		if !slices.Contains(d.Values, value) {
+			return "", InvalidArgument(field, "%s is one of %s, got %q", d.Name, strings.Join(d.Values, ", "), value)
+		}
+		return value, nil
+	default:
+		value = strings.TrimSpace(value)
+		if value == "" || len(value) > maxAttributeValueLength {
+			return "", InvalidArgument(field, "must not be blank or more than %d characters", maxAttributeValueLength)
+		}
+		return value, nil
+	}
+}
+
+// checkAttributes validates attrs against the definitions of a category and
+// returns them in canonical form. Products without a category have no
+// attributes.
+func (uc *ProductUseCase) checkAttributes(ctx context.Context, categoryID string, attrs Attributes) (Attributes, error) {
+	if categoryID == "" {
+		if len(attrs) > 0 {
+			return nil, InvalidArgument("attributes", "are defined by categories, the product has none")
+		}
+		return nil, nil
+	}
+	c, err := uc.repo.FindCategory(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	out := make(Attributes, len(attrs))
+	for _, name := range slices.Sorted(maps.Keys(attrs)) {
+		field := "attributes." + name
+		i := slices.IndexFunc(c.Attributes, func(d AttributeDef) bool { return d.Name == name })
+		if i < 0 {
+			errs = append(errs, InvalidArgument(field, "is not an attribute of category %s", c.Name))
+			continue
+		}
+		v, err := c.Attributes[i].canonical(field, attrs[name])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		out[name] = v
+	}
+	for _, d := range c.Attributes {
+		if _, ok := attrs[d.Name]; d.Required && !ok {
+			errs = append(errs, InvalidArgument("attributes."+d.Name, "is required in category %s", c.Name))
+		}
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// SetCategoryAttributes replaces the attribute definitions of a category.
+// Products are checked against them when their attributes or category are
+// next written.
+func (uc *ProductUseCase) SetCategoryAttributes(ctx context.Context, id string, defs []AttributeDef) (*Category, error) {
+	slog.Info("Setting category attributes", "id", id, "count", len(defs))
+	if id == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	if err := validateAttributeDefs("attributes", defs); err != nil {
+		return nil, err
+	}
+
+	uc.categoryMu.Lock()
+	defer uc.categoryMu.Unlock()
+
+	c, err := uc.repo.FindCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.Attributes = slices.Clone(defs)
+	c.UpdatedAt = time.Now()
+	if err := uc.repo.SaveCategory(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// variantFilter matches the variants of a product
+func variantFilter(parentID string) *Filter {
+	return &Filter{
+		Expr: &FilterCompare{Field: "parent_id", Kind: FilterText, Op: "=", Text: strings.ToLower(parentID)},
+		src:  "parent_id = " + strconv.Quote(parentID),
+	}
+}
+
+// ListVariants returns the variants of a product ordered by name
+func (uc *ProductUseCase) ListVariants(ctx context.Context, parentID string) ([]*Product, error) {
+	slog.Info("Listing variants", "parentID", parentID)
+	if parentID == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	return uc.variants(ctx, parentID)
+}
+
+func (uc *ProductUseCase) variants(ctx context.Context, parentID string) ([]*Product, error) {
+	variants, _, err := uc.repo.FindAll(ctx, ListQuery{Limit: maxVariants, Filter: variantFilter(parentID), SortBy: SortByName})
+	return variants, err
+}
+
+// checkParent fails with an invalid parent_id unless parentID is a product
+// that can take another variant, and returns it
+func (uc *ProductUseCase) checkParent(ctx context.Context, parentID string) (*Product, error) {
+	parent, err := live(uc.repo.FindByID(ctx, parentID))
+	if errors.Is(err, ErrProductNotFound) {
+		return nil, InvalidArgument("parent_id", "unknown product %q", parentID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if parent.ParentID != "" {
+		return nil, InvalidArgument("parent_id", "is itself a variant of %s, variants do not nest", parent.ParentID)
+	}
+	_, total, err := uc.repo.FindAll(ctx, ListQuery{Limit: 1, Filter: variantFilter(parentID)})
+	if err != nil {
+		return nil, err
+	}
+	if total >= maxVariants {
+		return nil, InvalidArgument("parent_id", "already has %d variants", total)
+	}
+	return parent, nil
+}
+
+// checkCategoryChange fails unless p may move to categoryID: variants
+// follow their parent and a parent keeps its category while it has variants
+func (uc *ProductUseCase) checkCategoryChange(ctx context.Context, p *Product, categoryID *string) error {
+	if categoryID == nil || *categoryID == p.CategoryID {
+		return nil
+	}
+	if p.ParentID != "" {
+		return InvalidArgument("category_id", "is the category of the parent product %s", p.ParentID)
+	}
+	_, variants, err := uc.repo.FindAll(ctx, ListQuery{Limit: 1, Filter: variantFilter(p.ID)})
+	if err != nil {
+		return err
+	}
+	if variants > 0 {
+		return InvalidArgument("category_id", "cannot change while the product has %d variants", variants)
+	}
+	return nil
+}
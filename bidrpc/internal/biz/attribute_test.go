@@ -0,0 +1,109 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProductUseCase_Variants(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	cheese, _ := uc.CreateCategory(ctx, "Cheese", "")
+	_, err := uc.SetCategoryAttributes(ctx, cheese.ID, []AttributeDef{
+		{Name: "Size", Type: AttributeNumber},
+		{Name: "flavour", Type: AttributeEnum},
+		{Name: "flavour", Type: "colour"},
+		{Name: "vegan", Type: AttributeBoolean, Values: []string{"yes"}},
+	})
+	for _, field := range []string{"attributes[0].name", "attributes[1].values", "attributes[2].name", "attributes[2].type", "attributes[3].values"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+	if _, err := uc.SetCategoryAttributes(ctx, cheese.ID, []AttributeDef{
+		{Name: "size_g", Type: AttributeNumber, Required: true},
+		{Name: "flavour", Type: AttributeEnum, Values: []string{"mild", "mature"}},
+		{Name: "vegan", Type: AttributeBoolean},
+	}); err != nil {
+		t.Fatalf("SetCategoryAttributes failed: %v", err)
+	}
+
+	// required attributes apply to parents too
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "Cheddar", Price: usd(500), CategoryID: cheese.ID}); !hasViolation(err, "attributes.size_g") {
+		t.Errorf("a missing required attribute should fail, got %v", err)
+	}
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "Cheddar", Price: usd(500), Attributes: Attributes{"size_g": "1"}}); !hasViolation(err, "attributes") {
+		t.Errorf("attributes without a category should fail, got %v", err)
+	}
+	parent, err := uc.CreateProduct(ctx, ProductInput{Name: "Cheddar", Price: usd(500), CategoryID: cheese.ID, Attributes: Attributes{"size_g": "0"}})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+
+	_, err = uc.CreateProduct(ctx, ProductInput{Name: "Cheddar 200g", Price: usd(250), ParentID: parent.ID,
+		Attributes: Attributes{"size_g": "small", "flavour": "sharp", "vegan": "maybe", "colour": "red"}})
+	for _, field := range []string{"attributes.size_g", "attributes.flavour", "attributes.vegan", "attributes.colour"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+	small, err := uc.CreateProduct(ctx, ProductInput{Name: "Cheddar 200g", Price: usd(250), Quantity: 5, ParentID: parent.ID,
+		Attributes: Attributes{"size_g": " 200.0 ", "flavour": "mild", "vegan": "F"}})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+	if small.CategoryID != cheese.ID || small.Attributes["size_g"] != "200" || small.Attributes["vegan"] != "false" {
+		t.Errorf("expected the parent's category and canonical values, got %+v", small)
+	}
+	block, _ := uc.CreateProduct(ctx, ProductInput{Name: "Cheddar 5kg block", Price: usd(4000), ParentID: parent.ID, Attributes: Attributes{"size_g": "5000"}})
+
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(1), ParentID: small.ID, Attributes: Attributes{"size_g": "1"}}); !hasViolation(err, "parent_id") {
+		t.Errorf("variants of variants should fail, got %v", err)
+	}
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(1), ParentID: "missing"}); !hasViolation(err, "parent_id") {
+		t.Errorf("an unknown parent should fail, got %v", err)
+	}
+	other, _ := uc.CreateCategory(ctx, "Butter", "")
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(1), ParentID: parent.ID, CategoryID: other.ID}); !hasViolation(err, "category_id") {
+		t.Errorf("a variant in another category should fail, got %v", err)
+	}
+
+	variants, err := uc.ListVariants(ctx, parent.ID)
+	if err != nil || len(variants) != 2 || variants[0].ID != small.ID || variants[1].ID != block.ID {
+		t.Errorf("expected the 200g and 5kg variants, got %+v, %v", variants, err)
+	}
+	page, err := uc.ListProducts(ctx, ListOptions{Filter: `attributes.size_g >= 1000`})
+	if err != nil || len(page.Products) != 1 || page.Products[0].ID != block.ID {
+		t.Errorf("expected the 5kg block, got %+v, %v", page, err)
+	}
+
+	// variants follow their parent's category
+	if _, err := uc.UpdateProduct(ctx, small.ID, ProductPatch{CategoryID: &other.ID}, 0); !hasViolation(err, "category_id") {
+		t.Errorf("moving a variant should fail, got %v", err)
+	}
+	if _, err := uc.UpdateProduct(ctx, parent.ID, ProductPatch{CategoryID: &other.ID}, 0); !hasViolation(err, "category_id") {
+		t.Errorf("moving a parent with variants should fail, got %v", err)
+	}
+	attrs := Attributes{"size_g": "250"}
+	if got, err := uc.UpdateProduct(ctx, small.ID, ProductPatch{Attributes: &attrs}, 0); err != nil || len(got.Attributes) != 1 || got.Attributes["size_g"] != "250" {
+		t.Errorf("the attributes should be replaced, got %+v, %v", got, err)
+	}
+	attrs = Attributes{}
+	if _, err := uc.UpdateProduct(ctx, small.ID, ProductPatch{Attributes: &attrs}, 0); !hasViolation(err, "attributes.size_g") {
+		t.Errorf("dropping a required attribute should fail, got %v", err)
+	}
+
+	if err := uc.DeleteProduct(ctx, parent.ID, 0); !errors.Is(err, ErrProductHasVariants) || KindOf(err) != KindInvalidState {
+		t.Errorf("deleting a parent with variants should conflict, got %v", err)
+	}
+	for _, v := range []*Product{small, block} {
+		if err := uc.DeleteProduct(ctx, v.ID, 0); err != nil {
+			t.Fatalf("DeleteProduct failed: %v", err)
+		}
+	}
+	if err := uc.DeleteProduct(ctx, parent.ID, 0); err != nil {
+		t.Errorf("a parent without variants should delete, got %v", err)
+	}
+}
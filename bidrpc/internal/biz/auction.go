@@ -0,0 +1,391 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAuctionDuration bounds how long a lot is open for bids
+	MaxAuctionDuration = 30 * 24 * time.Hour
+
+	// auctionSettleWindow is how long after closing the stock of a lot stays
+	// held, so an auction closed late by the sweeper can still be awarded
+	auctionSettleWindow = MaxReservationTTL
+)
+
+// AuctionStatus is the state of an auction, only open auctions take bids
+type AuctionStatus string
+
+const (
+	AuctionOpen AuctionStatus = "open"
+	// AuctionAwarded is a closed auction whose best bid met the reserve
+	// price, the lot was dispatched to its bidder
+	AuctionAwarded AuctionStatus = "awarded"
+	// AuctionUnsold is a closed auction without a bid meeting the reserve
+	// price, the lot went back to stock
+	AuctionUnsold AuctionStatus = "unsold"
+	// AuctionCancelled is a closed auction whose lot was no longer held, e.g.
+	// because its product was deleted
+	AuctionCancelled AuctionStatus = "cancelled"
+)
+
+// ParseAuctionStatus validates an auction status name, empty for any
+func ParseAuctionStatus(s string) (AuctionStatus, error) {
+	switch st := AuctionStatus(strings.ToLower(s)); st {
+	case "", AuctionOpen, AuctionAwarded, AuctionUnsold, AuctionCancelled:
+		return st, nil
+	default:
+		return "", InvalidArgument("status", "unknown status %q", s)
+	}
+}
+
+// Auction sells a lot, Quantity units of a product, to the best sealed bid
+// submitted between OpensAt and ClosesAt. The lot's stock is held by a
+// reservation from creation until the auction closes.
+type Auction struct {
+	ID          string
+	ProductID   string
+	WarehouseID string
+	Quantity    int32
+	// ReservePrice is the lowest price the whole lot is sold for
+	ReservePrice  Money
+	OpensAt       time.Time
+	ClosesAt      time.Time
+	Status        AuctionStatus
+	ReservationID string
+	// WinningBidID, WinnerID and WinningPrice are set once awarded
+	WinningBidID string
+	WinnerID     string
+	WinningPrice Money
+	// Outcome tells in words why the auction closed as it did
+	Outcome   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Bid is the sealed offer of a bidder for a whole lot, a bidder has one bid
+// per auction
+type Bid struct {
+	ID        string
+	AuctionID string
+	BidderID  string
+	Amount    Money
+	// SubmittedAt is when Amount was bid, an earlier bid wins a tie
+	SubmittedAt time.Time
+}
+
+// AuctionRepo stores auctions and their bids
+type AuctionRepo interface {
+	// SaveAuction inserts or replaces a
+	SaveAuction(ctx context.Context, a *Auction) error
+	// FindAuction fails with ErrAuctionNotFound for an unknown id
+	FindAuction(ctx context.Context, id string) (*Auction, error)
+	// FindAuctions returns every auction ordered by closing time
+	FindAuctions(ctx context.Context) ([]*Auction, error)
+	// FindDueAuctions returns at most limit open auctions that closed at or
+	// before now
+	FindDueAuctions(ctx context.Context, now time.Time, limit int32) ([]*Auction, error)
+	// SaveBid inserts or replaces the bid of b.BidderID on b.AuctionID, it
+	// fails with ErrAuctionNotFound when the auction does not exist
+	SaveBid(ctx context.Context, b *Bid) error
+	// FindBids returns the bids on an auction ordered by bidder
+	FindBids(ctx context.Context, auctionID string) ([]*Bid, error)
+}
+
+// AuctionInput is a caller supplied lot. An empty WarehouseID sells stock of
+// the default warehouse and a zero OpensAt opens the auction right away.
+type AuctionInput struct {
+	ProductID    string
+	WarehouseID  string
+	Quantity     int32
+	ReservePrice Money
+	OpensAt      time.Time
+	ClosesAt     time.Time
+}
+
+// AuctionUseCase runs sealed-bid auctions of product lots
+type AuctionUseCase struct {
+	repo     ProductRepo
+	products *ProductUseCase
+	// mu serializes bids with closing, so no bid lands on an auction being
+	// awarded
+	mu sync.Mutex
+}
+
+// NewAuctionUseCase creates a new auction use case, holding and dispatching
+// stock through products
+func NewAuctionUseCase(repo ProductRepo, products *ProductUseCase) *AuctionUseCase {
+	return &AuctionUseCase{repo: repo, products: products}
+}
+
+// CompareBids orders bids from best to worst: the highest amount, then the
+// earliest submitted, then by bidder so ties resolve the same way every time
+func CompareBids(a, b *Bid) int {
+	if c := cmp.Compare(b.Amount.Amount, a.Amount.Amount); c != 0 {
+		return c
+	}
+	if c := a.SubmittedAt.Compare(b.SubmittedAt); c != 0 {
+		return c
+	}
+	return strings.Compare(a.BidderID, b.BidderID)
+}
+
+// CreateAuction puts a lot up for auction, holding its stock until the
+// auction closes
+func (uc *AuctionUseCase) CreateAuction(ctx context.Context, in AuctionInput) (*Auction, error) {
+	slog.Info("Creating auction", "productID", in.ProductID, "warehouseID", in.WarehouseID, "quantity", in.Quantity,
+		"reservePrice", in.ReservePrice, "opensAt", in.OpensAt, "closesAt", in.ClosesAt)
+	now := time.Now()
+	if in.OpensAt.IsZero() {
+		in.OpensAt = now
+	}
+	var errs []error
+	if in.ProductID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if in.Quantity <= 0 {
+		errs = append(errs, InvalidArgument("quantity", "must be positive"))
+	}
+	errs = append(errs, in.ReservePrice.validate("reserve_price"))
+	switch {
+	case in.ClosesAt.IsZero():
+		errs = append(errs, InvalidArgument("closes_at", "is required"))
+	case !in.ClosesAt.After(now) || !in.ClosesAt.After(in.OpensAt):
+		errs = append(errs, InvalidArgument("closes_at", "must be in the future and after opens_at"))
+	case in.ClosesAt.Sub(now) > MaxAuctionDuration:
+		errs = append(errs, InvalidArgument("closes_at", "must be within %d days", int(MaxAuctionDuration/(24*time.Hour))))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+
+	a := &Auction{
+		ID:           uuid.New().String(),
+		ProductID:    in.ProductID,
+		Quantity:     in.Quantity,
+		ReservePrice: in.ReservePrice,
+		OpensAt:      in.OpensAt,
+		ClosesAt:     in.ClosesAt,
+		Status:       AuctionOpen,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	r, _, err := uc.products.reserve(ctx, in.ProductID, in.WarehouseID, in.Quantity, in.ClosesAt.Sub(now)+auctionSettleWindow, "auction "+a.ID)
+	if err != nil {
+		return nil, err
+	}
+	a.WarehouseID, a.ReservationID = r.WarehouseID, r.ID
+	if err := uc.repo.SaveAuction(ctx, a); err != nil {
+		if _, _, rerr := uc.products.ReleaseReservation(ctx, r.ID); rerr != nil {
+			slog.Error("Releasing the stock of an unsaved auction failed", "reservationID", r.ID, "error", rerr)
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+// GetAuction retrieves an auction by ID
+func (uc *AuctionUseCase) GetAuction(ctx context.Context, id string) (*Auction, error) {
+	if id == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	return uc.repo.FindAuction(ctx, id)
+}
+
+// ListAuctions lists the auctions with status, every one when it is empty,
+// ordered by closing time
+func (uc *AuctionUseCase) ListAuctions(ctx context.Context, status AuctionStatus) ([]*Auction, error) {
+	auctions, err := uc.repo.FindAuctions(ctx)
+	if err != nil || status == "" {
+		return auctions, err
+	}
+	return slices.DeleteFunc(auctions, func(a *Auction) bool { return a.Status != status }), nil
+}
+
+// SubmitBid places the sealed bid of a bidder on an open auction, replacing
+// the bidder's earlier bid
+func (uc *AuctionUseCase) SubmitBid(ctx context.Context, auctionID, bidderID string, amount Money) (*Bid, error) {
+	slog.Info("Submitting bid", "auctionID", auctionID, "bidderID", bidderID)
+	bidderID = strings.TrimSpace(bidderID)
+	var errs []error
+	if auctionID == "" {
+		errs = append(errs, InvalidArgument("auction_id", "is required"))
+	}
+	if bidderID == "" {
+		errs = append(errs, InvalidArgument("bidder_id", "is required"))
+	}
+	if amount.Amount == 0 {
+		errs = append(errs, InvalidArgument("amount", "must be positive"))
+	}
+	errs = append(errs, amount.validate("amount"))
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	a, err := uc.repo.FindAuction(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	switch {
+	case a.Status != AuctionOpen:
+		return nil, fmt.Errorf("%w: it is %s", ErrAuctionClosed, a.Status)
+	case now.Before(a.OpensAt):
+		return nil, fmt.Errorf("%w: it opens at %s", ErrAuctionClosed, a.OpensAt.Format(time.RFC3339))
+	case !now.Before(a.ClosesAt):
+		return nil, fmt.Errorf("%w: it closed at %s", ErrAuctionClosed, a.ClosesAt.Format(time.RFC3339))
+	}
+	if amount.Currency != a.ReservePrice.Currency {
+		return nil, InvalidArgument("amount.currency_code", "must be %s like the reserve price", a.ReservePrice.Currency)
+	}
+
+	bids, err := uc.repo.FindBids(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	b := &Bid{ID: uuid.New().String(), AuctionID: auctionID, BidderID: bidderID}
+	if i := slices.IndexFunc(bids, func(b *Bid) bool { return b.BidderID == bidderID }); i >= 0 {
+		b.ID = bids[i].ID
+	}
+	b.Amount, b.SubmittedAt = amount, now
+	if err := uc.repo.SaveBid(ctx, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListBids lists the bids on a closed auction from best to worst, bids are
+// sealed while the auction is open
+func (uc *AuctionUseCase) ListBids(ctx context.Context, auctionID string) ([]*Bid, error) {
+	a, err := uc.GetAuction(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if a.Status == AuctionOpen {
+		return nil, fmt.Errorf("%w until %s", ErrBidsSealed, a.ClosesAt.Format(time.RFC3339))
+	}
+	bids, err := uc.repo.FindBids(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(bids, CompareBids)
+	return bids, nil
+}
+
+// CloseDue closes every open auction past its closing time at now and
+// returns how many it closed. An auction failing to close is logged and
+// left open for the next sweep, it does not hold up the others.
+func (uc *AuctionUseCase) CloseDue(ctx context.Context, now time.Time) (int, error) {
+	var n int
+	failed := make(map[string]bool)
+	for {
+		// the failed auctions are still due, the batch grows by them so it
+		// always reaches further
+		limit := sweepBatch + len(failed)
+		due, err := uc.repo.FindDueAuctions(ctx, now, int32(limit))
+		if err != nil {
+			return n, err
+		}
+		for _, a := range due {
+			if failed[a.ID] {
+				continue
+			}
+			if err := uc.closeAuction(ctx, a.ID); err != nil {
+				if ctx.Err() != nil {
+					return n, err
+				}
+				slog.Error("Closing auction failed", "id", a.ID, "error", err)
+				failed[a.ID] = true
+				continue
+			}
+			n++
+		}
+		if len(due) < limit {
+			return n, nil
+		}
+	}
+}
+
+// SweepAuctions closes and awards due auctions every interval until ctx is
+// done
+func (uc *AuctionUseCase) SweepAuctions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := uc.CloseDue(ctx, now)
+			if err != nil && ctx.Err() == nil {
+				slog.Error("Closing due auctions failed", "error", err)
+			}
+			if n > 0 {
+				slog.Info("Closed due auctions", "count", n)
+			}
+		}
+	}
+}
+
+// closeAuction awards an open auction to its best bid meeting the reserve
+// price, dispatching the lot, or returns the lot to stock without one
+func (uc *AuctionUseCase) closeAuction(ctx context.Context, id string) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	a, err := uc.repo.FindAuction(ctx, id)
+	if err != nil || a.Status != AuctionOpen {
+		return err
+	}
+	bids, err := uc.repo.FindBids(ctx, id)
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(bids, CompareBids)
+
+	if len(bids) == 0 || bids[0].Amount.Amount < a.ReservePrice.Amount {
+		a.Status = AuctionUnsold
+		a.Outcome = fmt.Sprintf("no bid met the reserve price of %s", a.ReservePrice)
+		if len(bids) == 0 {
+			a.Outcome = "no bids"
+		}
+		// a lot no longer held has nothing to return
+		if _, _, err := uc.products.ReleaseReservation(ctx, a.ReservationID); err != nil && !lotGone(err) {
+			return err
+		}
+	} else {
+		win := bids[0]
+		a.Status, a.WinningBidID, a.WinnerID, a.WinningPrice = AuctionAwarded, win.ID, win.BidderID, win.Amount
+		a.Outcome = fmt.Sprintf("won by %s with %s", win.BidderID, win.Amount)
+		if _, _, _, err := uc.products.CommitReservation(ctx, a.ReservationID); lotGone(err) {
+			// committed before a crash left the auction open, or lost
+			r, ferr := uc.repo.FindReservation(ctx, a.ReservationID)
+			if ferr != nil || r.Status != ReservationCommitted {
+				a.Status, a.WinningBidID, a.WinnerID, a.WinningPrice = AuctionCancelled, "", "", Money{}
+				a.Outcome = "the stock of the lot was no longer held"
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+	a.UpdatedAt = time.Now()
+	slog.Info("Closed auction", "id", a.ID, "status", a.Status, "outcome", a.Outcome)
+	return uc.repo.SaveAuction(ctx, a)
+}
+
+// lotGone reports whether err says the reservation holding a lot was closed
+// or deleted with its product
+func lotGone(err error) bool {
+	return errors.Is(err, ErrReservationClosed) || errors.Is(err, ErrReservationNotFound) || errors.Is(err, ErrProductNotFound)
+}
@@ -0,0 +1,194 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// closeNow moves the deadline of an auction into the past and closes it
+func closeNow(t *testing.T, repo *mockProductRepo, uc *AuctionUseCase, id string) *Auction {
+	t.Helper()
+	repo.auctions[id].ClosesAt = time.Now().Add(-time.Second)
+	if _, err := uc.CloseDue(context.Background(), time.Now()); err != nil {
+		t.Fatalf("CloseDue failed: %v", err)
+	}
+	a, _ := uc.GetAuction(context.Background(), id)
+	return a
+}
+
+func TestAuctionUseCase_CreateAuction(t *testing.T) {
+	repo := newMockProductRepo()
+	products := NewProductUseCase(repo, nil)
+	uc := NewAuctionUseCase(repo, products)
+	ctx := context.Background()
+	p, _ := products.CreateProduct(ctx, ProductInput{Name: "salmon", Price: usd(2000), Quantity: 10})
+
+	_, err := uc.CreateAuction(ctx, AuctionInput{ReservePrice: Money{Amount: -1, Currency: "usd"}, ClosesAt: time.Now().Add(-time.Hour)})
+	for _, field := range []string{"product_id", "quantity", "reserve_price", "reserve_price.currency_code", "closes_at"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+	if _, err := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 11, ReservePrice: usd(100), ClosesAt: time.Now().Add(time.Hour)}); !hasViolation(err, "quantity") {
+		t.Errorf("a lot larger than the available stock should fail, got %v", err)
+	}
+
+	a, err := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 4, ReservePrice: usd(6000), ClosesAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("CreateAuction failed: %v", err)
+	}
+	if a.Status != AuctionOpen || a.WarehouseID != DefaultWarehouseID || a.ReservationID == "" {
+		t.Errorf("expected an open auction holding default stock, got %+v", a)
+	}
+	if got, _ := products.GetProduct(ctx, p.ID); got.Reserved != 4 {
+		t.Errorf("the lot should be held, got %d reserved", got.Reserved)
+	}
+	if open, err := uc.ListAuctions(ctx, AuctionOpen); err != nil || len(open) != 1 {
+		t.Errorf("expected 1 open auction, got %+v, %v", open, err)
+	}
+	if awarded, _ := uc.ListAuctions(ctx, AuctionAwarded); len(awarded) != 0 {
+		t.Errorf("expected no awarded auction, got %+v", awarded)
+	}
+}
+
+func TestAuctionUseCase_Award(t *testing.T) {
+	repo := newMockProductRepo()
+	products := NewProductUseCase(repo, nil)
+	uc := NewAuctionUseCase(repo, products)
+	ctx := context.Background()
+	p, _ := products.CreateProduct(ctx, ProductInput{Name: "salmon", Price: usd(2000), Quantity: 10})
+	a, _ := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 4, ReservePrice: usd(6000), ClosesAt: time.Now().Add(time.Hour)})
+
+	if _, err := uc.SubmitBid(ctx, a.ID, "bistro", Money{Amount: 7000, Currency: "EUR"}); !hasViolation(err, "amount.currency_code") {
+		t.Errorf("a bid in another currency should fail, got %v", err)
+	}
+	if _, err := uc.SubmitBid(ctx, a.ID, "", usd(0)); !hasViolation(err, "bidder_id") || !hasViolation(err, "amount") {
+		t.Errorf("expected bidder_id and amount violations, got %v", err)
+	}
+	first, err := uc.SubmitBid(ctx, a.ID, "bistro", usd(6500))
+	if err != nil {
+		t.Fatalf("SubmitBid failed: %v", err)
+	}
+	// raising a bid replaces it
+	raised, _ := uc.SubmitBid(ctx, a.ID, "bistro", usd(7200))
+	if raised.ID != first.ID || raised.Amount != usd(7200) {
+		t.Errorf("the bidder's bid should be replaced, got %+v", raised)
+	}
+	uc.SubmitBid(ctx, a.ID, "harbour", usd(7200))
+	uc.SubmitBid(ctx, a.ID, "canteen", usd(5000))
+	if _, err := uc.ListBids(ctx, a.ID); !errors.Is(err, ErrBidsSealed) || KindOf(err) != KindInvalidState {
+		t.Errorf("bids should be sealed while the auction is open, got %v", err)
+	}
+
+	// harbour and bistro tie on amount, the earlier bid wins and the bidder
+	// breaks a tie on time
+	repo.bids[[2]string{a.ID, "harbour"}].SubmittedAt = repo.bids[[2]string{a.ID, "bistro"}].SubmittedAt
+	got := closeNow(t, repo, uc, a.ID)
+	if got.Status != AuctionAwarded || got.WinnerID != "bistro" || got.WinningBidID != first.ID || got.WinningPrice != usd(7200) {
+		t.Errorf("expected bistro to win with 72.00 USD, got %+v", got)
+	}
+	product, _ := products.GetProduct(ctx, p.ID)
+	if product.Quantity != 6 || product.Reserved != 0 {
+		t.Errorf("the lot should be dispatched, got quantity %d reserved %d", product.Quantity, product.Reserved)
+	}
+	page, err := products.ListStockMovements(ctx, p.ID, 10, "")
+	if err != nil || len(page.Movements) == 0 {
+		t.Fatalf("ListStockMovements failed: %v", err)
+	}
+	if m := page.Movements[len(page.Movements)-1]; m.Kind != MovementDispatch || m.Delta != -4 || m.Actor != "auction "+a.ID {
+		t.Errorf("expected the award in the ledger, got %+v", m)
+	}
+
+	bids, err := uc.ListBids(ctx, a.ID)
+	if err != nil || len(bids) != 3 || bids[0].BidderID != "bistro" || bids[1].BidderID != "harbour" || bids[2].BidderID != "canteen" {
+		t.Errorf("expected the bids ranked bistro, harbour, canteen, got %+v, %v", bids, err)
+	}
+	if _, err := uc.SubmitBid(ctx, a.ID, "late", usd(9000)); !errors.Is(err, ErrAuctionClosed) {
+		t.Errorf("bidding on a closed auction should fail, got %v", err)
+	}
+	if n, err := uc.CloseDue(ctx, time.Now()); err != nil || n != 0 {
+		t.Errorf("a closed auction should not close again, got %d, %v", n, err)
+	}
+}
+
+// failingBids fails to load the bids of the auctions in failing
+type failingBids struct {
+	*mockProductRepo
+	failing map[string]bool
+}
+
+func (r failingBids) FindBids(ctx context.Context, auctionID string) ([]*Bid, error) {
+	if r.failing[auctionID] {
+		return nil, errors.New("disk read error")
+	}
+	return r.mockProductRepo.FindBids(ctx, auctionID)
+}
+
+func TestAuctionUseCase_CloseDueSkipsFailures(t *testing.T) {
+	repo := newMockProductRepo()
+	failing := make(map[string]bool)
+	products := NewProductUseCase(repo, nil)
+	uc := NewAuctionUseCase(failingBids{repo, failing}, products)
+	ctx := context.Background()
+	p, _ := products.CreateProduct(ctx, ProductInput{Name: "salmon", Price: usd(2000), Quantity: 2 * sweepBatch})
+
+	// a full batch of auctions that fail to close does not hold up the rest
+	for range sweepBatch {
+		a, err := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 1, ReservePrice: usd(100), ClosesAt: time.Now().Add(time.Hour)})
+		if err != nil {
+			t.Fatalf("CreateAuction failed: %v", err)
+		}
+		failing[a.ID] = true
+		repo.auctions[a.ID].ClosesAt = time.Now().Add(-time.Hour)
+	}
+	ok, _ := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 1, ReservePrice: usd(100), ClosesAt: time.Now().Add(time.Hour)})
+	repo.auctions[ok.ID].ClosesAt = time.Now().Add(-time.Minute)
+
+	n, err := uc.CloseDue(ctx, time.Now())
+	if err != nil || n != 1 {
+		t.Fatalf("expected one auction closed, got %d, %v", n, err)
+	}
+	if got, _ := uc.GetAuction(ctx, ok.ID); got.Status != AuctionUnsold {
+		t.Errorf("the auction after the failing ones should close, got %s", got.Status)
+	}
+	for id := range failing {
+		if got, _ := uc.GetAuction(ctx, id); got.Status != AuctionOpen {
+			t.Fatalf("a failing auction should stay open, got %s", got.Status)
+		}
+	}
+}
+
+func TestAuctionUseCase_Unsold(t *testing.T) {
+	repo := newMockProductRepo()
+	products := NewProductUseCase(repo, nil)
+	uc := NewAuctionUseCase(repo, products)
+	ctx := context.Background()
+	p, _ := products.CreateProduct(ctx, ProductInput{Name: "salmon", Price: usd(2000), Quantity: 10})
+
+	below, _ := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 4, ReservePrice: usd(6000), ClosesAt: time.Now().Add(time.Hour)})
+	uc.SubmitBid(ctx, below.ID, "bistro", usd(5999))
+	if got := closeNow(t, repo, uc, below.ID); got.Status != AuctionUnsold || got.WinnerID != "" {
+		t.Errorf("a bid below the reserve price should not win, got %+v", got)
+	}
+	if product, _ := products.GetProduct(ctx, p.ID); product.Quantity != 10 || product.Reserved != 0 {
+		t.Errorf("the lot should return to stock, got quantity %d reserved %d", product.Quantity, product.Reserved)
+	}
+
+	later, _ := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 2, ReservePrice: usd(100),
+		OpensAt: time.Now().Add(time.Hour), ClosesAt: time.Now().Add(2 * time.Hour)})
+	if _, err := uc.SubmitBid(ctx, later.ID, "bistro", usd(200)); !errors.Is(err, ErrAuctionClosed) {
+		t.Errorf("bidding before the auction opens should fail, got %v", err)
+	}
+
+	// the lot goes with its product
+	gone, _ := uc.CreateAuction(ctx, AuctionInput{ProductID: p.ID, Quantity: 2, ReservePrice: usd(100), ClosesAt: time.Now().Add(time.Hour)})
+	uc.SubmitBid(ctx, gone.ID, "bistro", usd(200))
+	if err := products.DeleteProduct(ctx, p.ID, 0); err != nil {
+		t.Fatalf("DeleteProduct failed: %v", err)
+	}
+	if got := closeNow(t, repo, uc, gone.ID); got.Status != AuctionCancelled || got.WinnerID != "" {
+		t.Errorf("an auction without its lot should be cancelled, got %+v", got)
+	}
+}
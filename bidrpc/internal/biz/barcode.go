@@ -0,0 +1,167 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+const (
+	// gtinLength is the length of a GTIN-14, the form barcodes are stored
+	// and looked up in
+	gtinLength = 14
+	// maxBarcodes bounds the barcodes of a product
+	maxBarcodes = 16
+)
+
+// Barcode is a GTIN printed on a product or on one of its packs
+type Barcode struct {
+	// GTIN is the GTIN-8, 12, 13 or 14 zero padded to a GTIN-14, so the
+	// UPC-A 036000291452 is stored as 00036000291452
+	GTIN string
+	// Unit is the unit of the product that carries the barcode, e.g. the
+	// case of a pack-level GTIN-14. Empty is the base unit.
+	Unit string
+}
+
+// parseGTIN checks the length and check digit of a GTIN-8, 12, 13 or 14 and
+// returns it as a GTIN-14, reporting an invalid s as field
+func parseGTIN(field, s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.ContainsFunc(s, func(r rune) bool { return r < '0' || r > '9' }):
+		return "", InvalidArgument(field, "must be digits only, got %q", s)
+	case len(s) != 8 && len(s) != 12 && len(s) != 13 && len(s) != 14:
+		return "", InvalidArgument(field, "must be a GTIN-8, 12, 13 or 14, got %d digits", len(s))
+	}
+	if want := gtinCheckDigit(s[:len(s)-1]); s[len(s)-1] != want {
+		return "", InvalidArgument(field, "has check digit %c, expected %c", s[len(s)-1], want)
+	}
+	return strings.Repeat("0", gtinLength-len(s)) + s, nil
+}
+
+// gtinCheckDigit computes the GS1 check digit of the digits preceding it:
+// weighted 3 and 1 alternately from the right, it rounds their sum up to a
+// multiple of ten
+func gtinCheckDigit(digits string) byte {
+	sum := 0
+	for i := range len(digits) {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// packLevel reports whether a GTIN-14 identifies a pack of trade items,
+// its indicator digit is 1 to 8. 0 marks a trade item and 9 a variable
+// measure one.
+func packLevel(gtin string) bool {
+	return gtin[0] >= '1' && gtin[0] <= '8'
+}
+
+// validateBarcodes checks the GTINs of barcodes and that they are distinct
+func validateBarcodes(field string, barcodes []Barcode) error {
+	var errs []error
+	if len(barcodes) > maxBarcodes {
+		errs = append(errs, InvalidArgument(field, "must not be more than %d", maxBarcodes))
+	}
+	seen := make(map[string]bool)
+	for i, b := range barcodes {
+		f := fmt.Sprintf("%s[%d].gtin", field, i)
+		gtin, err := parseGTIN(f, b.GTIN)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if seen[gtin] {
+			errs = append(errs, InvalidArgument(f, "repeats %s", gtin))
+		}
+		seen[gtin] = true
+	}
+	return InvalidArguments(errs...)
+}
+
+// normalizeBarcodes pads the GTINs to GTIN-14 and orders the barcodes by
+// them, the caller validated the GTINs
+func normalizeBarcodes(barcodes []Barcode) []Barcode {
+	if len(barcodes) == 0 {
+		return nil
+	}
+	out := make([]Barcode, len(barcodes))
+	for i, b := range barcodes {
+		gtin, _ := parseGTIN("", b.GTIN)
+		out[i] = Barcode{GTIN: gtin, Unit: strings.TrimSpace(b.Unit)}
+	}
+	slices.SortFunc(out, func(a, b Barcode) int { return cmp.Compare(a.GTIN, b.GTIN) })
+	return out
+}
+
+// checkBarcodes fails for the barcodes of p on a unit it does not define and
+// for a pack-level GTIN on its base unit
+func (p *Product) checkBarcodes() error {
+	var errs []error
+	for _, b := range p.Barcodes {
+		if _, _, ok := p.Units.factor(b.Unit); !ok {
+			errs = append(errs, InvalidArgument("barcodes", "%s is on %q, which is not a unit of the product", b.GTIN, b.Unit))
+			continue
+		}
+		if packLevel(b.GTIN) && cmp.Or(b.Unit, p.Units.BaseUnit()) == p.Units.BaseUnit() {
+			errs = append(errs, InvalidArgument("barcodes", "%s is a pack-level GTIN-14, its unit must be a pack such as a case", b.GTIN))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// checkBarcodesFree fails for the barcodes that are already on a product
+// other than id. The repositories refuse them too, with ErrBarcodeInUse.
+func (uc *ProductUseCase) checkBarcodesFree(ctx context.Context, id string, barcodes []Barcode) error {
+	var errs []error
+	for _, b := range barcodes {
+		p, err := uc.repo.FindByBarcode(ctx, b.GTIN)
+		if errors.Is(err, ErrProductNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if p.ID != id {
+			errs = append(errs, InvalidArgument("barcodes", "%s is already the barcode of product %s", b.GTIN, p.ID))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// Barcode returns the barcode of p with a GTIN-14
+func (p *Product) Barcode(gtin string) (Barcode, bool) {
+	i := slices.IndexFunc(p.Barcodes, func(b Barcode) bool { return b.GTIN == gtin })
+	if i < 0 {
+		return Barcode{}, false
+	}
+	return p.Barcodes[i], true
+}
+
+// GetProductByBarcode finds the product carrying a GTIN-8, 12, 13 or 14 and
+// returns it with the barcode, whose unit is the one that was scanned
+func (uc *ProductUseCase) GetProductByBarcode(ctx context.Context, gtin string) (*Product, Barcode, error) {
+	slog.Info("Getting product by barcode", "gtin", gtin)
+	key, err := parseGTIN("gtin", gtin)
+	if err != nil {
+		return nil, Barcode{}, err
+	}
+	p, err := live(uc.repo.FindByBarcode(ctx, key))
+	if err != nil {
+		return nil, Barcode{}, err
+	}
+	b, ok := p.Barcode(key)
+	if !ok {
+		return nil, Barcode{}, ErrProductNotFound
+	}
+	return p, b, nil
+}
@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestParseGTIN(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"96385074", "00000096385074", true},
+		{"036000291452", "00036000291452", true},
+		{" 4006381333931 ", "04006381333931", true},
+		{"10012345678902", "10012345678902", true},
+		{"4006381333932", "", false},
+		{"400638133393", "", false},
+		{"40063813339A1", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseGTIN("gtin", tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseGTIN(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestProductUseCase_Barcodes(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	units := UnitsOfMeasure{Conversions: []UnitConversion{{Code: "case", Factor: 12}}}
+
+	_, err := uc.CreateProduct(ctx, ProductInput{Name: "cola", Price: usd(100), Units: units,
+		Barcodes: []Barcode{{GTIN: "5449000000997"}, {GTIN: "5449000000996"}, {GTIN: "05449000000996"}}})
+	for _, field := range []string{"barcodes[0].gtin", "barcodes[2].gtin"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+	_, err = uc.CreateProduct(ctx, ProductInput{Name: "cola", Price: usd(100), Units: units,
+		Barcodes: []Barcode{{GTIN: "15449000000993"}, {GTIN: "5449000000996", Unit: "pallet"}}})
+	if !hasViolation(err, "barcodes") || len(FieldViolations(err)) != 2 {
+		t.Errorf("a pack GTIN on the base unit and an unknown unit should fail, got %v", err)
+	}
+
+	cola, err := uc.CreateProduct(ctx, ProductInput{Name: "cola", Price: usd(100), Units: units,
+		Barcodes: []Barcode{{GTIN: "15449000000993", Unit: "case"}, {GTIN: "5449000000996"}}})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+	if len(cola.Barcodes) != 2 || cola.Barcodes[0] != (Barcode{GTIN: "05449000000996"}) {
+		t.Errorf("expected GTIN-14s in order, got %+v", cola.Barcodes)
+	}
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "copy", Price: usd(100), Barcodes: []Barcode{{GTIN: "5449000000996"}}}); !hasViolation(err, "barcodes") {
+		t.Errorf("a barcode of another product should fail, got %v", err)
+	}
+
+	// a scanner may read the EAN-13 of the unit or the GTIN-14 of the case
+	p, b, err := uc.GetProductByBarcode(ctx, "5449000000996")
+	if err != nil || p.ID != cola.ID || b.Unit != "" {
+		t.Errorf("expected the cola unit, got %+v, %+v, %v", p, b, err)
+	}
+	if _, b, err := uc.GetProductByBarcode(ctx, "15449000000993"); err != nil || b.Unit != "case" {
+		t.Errorf("expected the cola case, got %+v, %v", b, err)
+	}
+	if _, _, err := uc.GetProductByBarcode(ctx, "96385074"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("an unknown barcode should not be found, got %v", err)
+	}
+	if _, _, err := uc.GetProductByBarcode(ctx, "1234"); !hasViolation(err, "gtin") {
+		t.Errorf("a malformed barcode should fail, got %v", err)
+	}
+
+	// the case unit cannot go while a barcode is on it
+	none := UnitsOfMeasure{}
+	if _, err := uc.UpdateProduct(ctx, cola.ID, ProductPatch{Units: &none}, 0); !hasViolation(err, "barcodes") {
+		t.Errorf("dropping the unit of a barcode should fail, got %v", err)
+	}
+	barcodes := []Barcode{{GTIN: "5449000000996"}}
+	if got, err := uc.UpdateProduct(ctx, cola.ID, ProductPatch{Barcodes: &barcodes}, 0); err != nil || len(got.Barcodes) != 1 {
+		t.Errorf("the barcodes should be replaced, got %+v, %v", got, err)
+	}
+	if _, _, err := uc.GetProductByBarcode(ctx, "15449000000993"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a removed barcode should not be found, got %v", err)
+	}
+}
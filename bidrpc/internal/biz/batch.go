@@ -0,0 +1,254 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxLotCodeLength bounds the lot codes printed on perishable goods
+const maxLotCodeLength = 64
+
+// Batch is the stock of one lot of a product in a warehouse. The batches of
+// a warehouse cover part or all of its stock level, stock received without
+// a lot is untracked and is taken after the batches.
+type Batch struct {
+	WarehouseID string
+	LotCode     string
+	// ExpiresAt is the best-before date, zero for a lot that does not expire
+	ExpiresAt time.Time
+	// ReceivedAt is when the lot first arrived in the warehouse
+	ReceivedAt time.Time
+	Quantity   int32
+	// Reserved is the part of Quantity held by open reservations
+	Reserved int32
+}
+
+// Available returns the stock of the batch that is not reserved
+func (b Batch) Available() int32 {
+	return b.Quantity - b.Reserved
+}
+
+// Expired reports whether the batch is past its best-before date at now
+func (b Batch) Expired(now time.Time) bool {
+	return !b.ExpiresAt.IsZero() && !now.Before(b.ExpiresAt)
+}
+
+// LotQuantity is the part of a movement or reservation that came out of or
+// went into one lot
+type LotQuantity struct {
+	LotCode  string
+	Quantity int32
+}
+
+// Lot names the batch a movement books: the lot stock is received into, or
+// the lot it is taken out of instead of the first expiring ones
+type Lot struct {
+	Code string
+	// ExpiresAt is the best-before date of a lot that is received for the
+	// first time, zero when it does not expire
+	ExpiresAt time.Time
+}
+
+// validate checks l as the lot of a movement of kind
+func (l Lot) validate(kind MovementKind) error {
+	var errs []error
+	if len(l.Code) > maxLotCodeLength || strings.TrimSpace(l.Code) != l.Code {
+		errs = append(errs, InvalidArgument("lot_code", "must be at most %d characters without surrounding spaces", maxLotCodeLength))
+	}
+	if !l.ExpiresAt.IsZero() {
+		switch {
+		case l.Code == "":
+			errs = append(errs, InvalidArgument("expires_at", "requires a lot_code"))
+		case kind != MovementReceipt:
+			errs = append(errs, InvalidArgument("expires_at", "is only recorded by receipts"))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// fefo orders batches by warehouse and then in the order stock is taken from
+// them: first expiry first out, lots without expiry last, and lots expiring
+// together first received first out
+func fefo(a, b Batch) int {
+	return cmp.Or(
+		strings.Compare(a.WarehouseID, b.WarehouseID),
+		cmp.Compare(boolInt(a.ExpiresAt.IsZero()), boolInt(b.ExpiresAt.IsZero())),
+		a.ExpiresAt.Compare(b.ExpiresAt),
+		a.ReceivedAt.Compare(b.ReceivedAt),
+		strings.Compare(a.LotCode, b.LotCode),
+	)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// batchIndex returns the index of a lot in a warehouse, -1 when it holds no
+// stock of the lot
+func (p *Product) batchIndex(warehouseID, code string) int {
+	return slices.IndexFunc(p.Batches, func(b Batch) bool { return b.WarehouseID == warehouseID && b.LotCode == code })
+}
+
+// changeBatches applies change to a copy of the batches, since p may share
+// them with the stored product, and keeps them in FEFO order without the
+// empty ones
+func (p *Product) changeBatches(change func(batches []Batch) []Batch) {
+	batches := change(slices.Clone(p.Batches))
+	batches = slices.DeleteFunc(batches, func(b Batch) bool { return b.Quantity == 0 && b.Reserved == 0 })
+	slices.SortFunc(batches, fefo)
+	if len(batches) == 0 {
+		batches = nil
+	}
+	p.Batches = batches
+}
+
+// receiveLot adds quantity to a lot in a warehouse, creating its batch the
+// first time the lot arrives there
+func (p *Product) receiveLot(warehouseID string, lot Lot, quantity int32, now time.Time) error {
+	i := p.batchIndex(warehouseID, lot.Code)
+	if i >= 0 && !lot.ExpiresAt.IsZero() && !lot.ExpiresAt.Equal(p.Batches[i].ExpiresAt) {
+		return InvalidArgument("expires_at", "differs from the expiry of lot %s already in warehouse %s", lot.Code, warehouseID)
+	}
+	p.changeBatches(func(batches []Batch) []Batch {
+		if i < 0 {
+			return append(batches, Batch{WarehouseID: warehouseID, LotCode: lot.Code, ExpiresAt: lot.ExpiresAt, ReceivedAt: now, Quantity: quantity})
+		}
+		batches[i].Quantity += quantity
+		return batches
+	})
+	return nil
+}
+
+// allocate picks quantity of the unreserved stock in a warehouse out of lot
+// when it is set, or else first expiry first out. Sellable stock skips the
+// lots past their expiry at now. What the batches do not cover comes out of
+// the untracked stock, the caller checked that the warehouse has enough.
+func (p *Product) allocate(warehouseID, lot string, quantity int32, sellable bool, now time.Time) ([]LotQuantity, error) {
+	if lot != "" {
+		i := p.batchIndex(warehouseID, lot)
+		if i < 0 {
+			return nil, InvalidArgument("lot_code", "warehouse %s holds no stock of lot %q", warehouseID, lot)
+		}
+		if b := p.Batches[i]; quantity > b.Available() {
+			return nil, InvalidArgument("quantity", "exceeds the available stock of %d of lot %s", b.Available(), lot)
+		}
+		return []LotQuantity{{LotCode: lot, Quantity: quantity}}, nil
+	}
+
+	var lots []LotQuantity
+	var tracked, expired int32
+	for _, b := range p.Batches {
+		if b.WarehouseID != warehouseID {
+			continue
+		}
+		tracked += b.Available()
+		if sellable && b.Expired(now) {
+			expired += b.Available()
+			continue
+		}
+		if take := min(quantity, b.Available()); take > 0 {
+			lots = append(lots, LotQuantity{LotCode: b.LotCode, Quantity: take})
+			quantity -= take
+		}
+	}
+	if untracked := p.Levels()[warehouseID].Available() - tracked; quantity > untracked {
+		return nil, InvalidArgument("quantity", "exceeds the available stock in warehouse %s, %d of it is past its expiry", warehouseID, expired)
+	}
+	return lots, nil
+}
+
+// takeLots removes the stock of lots from their batches in a warehouse
+func (p *Product) takeLots(warehouseID string, lots []LotQuantity) {
+	p.changeLots(warehouseID, lots, func(b *Batch, quantity int32) { b.Quantity -= quantity })
+}
+
+// reserveLots changes the reserved stock of lots in a warehouse by sign
+// times their quantities
+func (p *Product) reserveLots(warehouseID string, lots []LotQuantity, sign int32) {
+	p.changeLots(warehouseID, lots, func(b *Batch, quantity int32) { b.Reserved += sign * quantity })
+}
+
+// changeLots applies change to the batch of each of lots in a warehouse
+func (p *Product) changeLots(warehouseID string, lots []LotQuantity, change func(b *Batch, quantity int32)) {
+	if len(lots) == 0 {
+		return
+	}
+	p.changeBatches(func(batches []Batch) []Batch {
+		for _, l := range lots {
+			if i := slices.IndexFunc(batches, func(b Batch) bool { return b.WarehouseID == warehouseID && b.LotCode == l.LotCode }); i >= 0 {
+				change(&batches[i], l.Quantity)
+			}
+		}
+		return batches
+	})
+}
+
+// moveLots moves the stock of lots from one warehouse to another, keeping
+// their expiry and when they were received
+func (p *Product) moveLots(from, to string, lots []LotQuantity) {
+	p.changeBatches(func(batches []Batch) []Batch {
+		for _, l := range lots {
+			i := slices.IndexFunc(batches, func(b Batch) bool { return b.WarehouseID == from && b.LotCode == l.LotCode })
+			if i < 0 {
+				continue
+			}
+			batches[i].Quantity -= l.Quantity
+			src := batches[i]
+			if j := slices.IndexFunc(batches, func(b Batch) bool { return b.WarehouseID == to && b.LotCode == l.LotCode }); j >= 0 {
+				batches[j].Quantity += l.Quantity
+				continue
+			}
+			batches = append(batches, Batch{WarehouseID: to, LotCode: l.LotCode, ExpiresAt: src.ExpiresAt, ReceivedAt: src.ReceivedAt, Quantity: l.Quantity})
+		}
+		return batches
+	})
+}
+
+// ExpiringBatch is a batch reported as short-dated
+type ExpiringBatch struct {
+	ProductID   string
+	ProductName string
+	Batch
+}
+
+// BatchRepo queries the batches stored with the products
+type BatchRepo interface {
+	// FindExpiringBatches returns the batches that expire before before, in
+	// warehouseID unless it is empty, in any order. Deleted products are
+	// left out.
+	FindExpiringBatches(ctx context.Context, before time.Time, warehouseID string) ([]*ExpiringBatch, error)
+}
+
+// maxExpiryWindowDays bounds how far ahead the expiry report looks
+const maxExpiryWindowDays = 3650
+
+// ListExpiringStock returns the batches holding stock that expire within
+// withinDays from now, including those already past their expiry, soonest
+// first. An empty warehouseID reports every warehouse.
+func (uc *ProductUseCase) ListExpiringStock(ctx context.Context, withinDays int32, warehouseID string, now time.Time) ([]*ExpiringBatch, error) {
+	slog.Info("Listing expiring stock", "withinDays", withinDays, "warehouseID", warehouseID)
+	if withinDays < 0 || withinDays > maxExpiryWindowDays {
+		return nil, InvalidArgument("within_days", "must be between 0 and %d", maxExpiryWindowDays)
+	}
+	if warehouseID != "" {
+		if err := uc.checkWarehouse(ctx, "warehouse_id", warehouseID); err != nil {
+			return nil, err
+		}
+	}
+	batches, err := uc.repo.FindExpiringBatches(ctx, now.AddDate(0, 0, int(withinDays)), warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(batches, func(a, b *ExpiringBatch) int {
+		return cmp.Or(a.ExpiresAt.Compare(b.ExpiresAt), strings.Compare(a.ProductName, b.ProductName),
+			strings.Compare(a.ProductID, b.ProductID), fefo(a.Batch, b.Batch))
+	})
+	return batches, nil
+}
@@ -0,0 +1,99 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProductUseCase_Batches(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	now := time.Now()
+	day := func(n int) time.Time { return now.AddDate(0, 0, n).Truncate(time.Second) }
+
+	// 2 untracked, then lots expiring in 10 days, 3 days and already expired
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "yoghurt", Price: usd(100), Quantity: 2})
+	receive := func(lot string, expires time.Time, quantity int32) {
+		t.Helper()
+		in := MovementInput{Kind: "receipt", Quantity: quantity, Actor: "a", Lot: Lot{Code: lot, ExpiresAt: expires}}
+		if _, _, err := uc.RecordStockMovement(ctx, p.ID, in, 0); err != nil {
+			t.Fatalf("receiving lot %s failed: %v", lot, err)
+		}
+	}
+	receive("L10", day(10), 5)
+	receive("L3", day(3), 4)
+	receive("OLD", day(-1), 3)
+	receive("L3", time.Time{}, 1)
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "receipt", Quantity: 1, Actor: "a", Lot: Lot{Code: "L3", ExpiresAt: day(4)}}, 0); !hasViolation(err, "expires_at") {
+		t.Errorf("receiving a lot with another expiry should fail, got %v", err)
+	}
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "dispatch", Quantity: 1, Actor: "a", Lot: Lot{Code: "L3", ExpiresAt: day(3)}}, 0); !hasViolation(err, "expires_at") {
+		t.Errorf("only receipts should take an expiry, got %v", err)
+	}
+
+	got, _ := uc.GetProduct(ctx, p.ID)
+	if len(got.Batches) != 3 || got.Batches[0].LotCode != "OLD" || got.Batches[1].LotCode != "L3" || got.Batches[1].Quantity != 5 || got.Quantity != 15 {
+		t.Fatalf("expected OLD, L3 of 5 and L10 in FEFO order, got %+v", got.Batches)
+	}
+
+	// reservations and dispatches skip the expired lot, first expiry first
+	r, _, err := uc.ReserveStock(ctx, p.ID, "", 7, 0, "order-1")
+	if err != nil {
+		t.Fatalf("ReserveStock failed: %v", err)
+	}
+	if len(r.Lots) != 2 || r.Lots[0] != (LotQuantity{"L3", 5}) || r.Lots[1] != (LotQuantity{"L10", 2}) {
+		t.Errorf("expected 5 of L3 and 2 of L10, got %+v", r.Lots)
+	}
+	m, got, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "dispatch", Quantity: 4, Actor: "a"}, 0)
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if len(m.Lots) != 1 || m.Lots[0] != (LotQuantity{"L10", 3}) || got.Quantity != 11 {
+		t.Errorf("the dispatch should take the rest of L10 then 1 untracked, got %+v", m.Lots)
+	}
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "dispatch", Quantity: 2, Actor: "a"}, 0); !hasViolation(err, "quantity") {
+		t.Errorf("dispatching expired stock should fail, got %v", err)
+	}
+
+	// committing dispatches the reserved lots, the expired lot is written off
+	_, m, _, err = uc.CommitReservation(ctx, r.ID)
+	if err != nil || len(m.Lots) != 2 || m.Delta != -7 {
+		t.Fatalf("the commit should dispatch the reserved lots, got %+v, %v", m, err)
+	}
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "write_off", Quantity: 4, Actor: "a", Reason: "expired", Lot: Lot{Code: "OLD"}}, 0); !hasViolation(err, "quantity") {
+		t.Errorf("writing off more than the lot holds should fail, got %v", err)
+	}
+	if _, got, err = uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "write_off", Quantity: 3, Actor: "a", Reason: "expired", Lot: Lot{Code: "OLD"}}, 0); err != nil {
+		t.Fatalf("write-off failed: %v", err)
+	}
+	if len(got.Batches) != 0 || got.Quantity != 1 {
+		t.Errorf("every lot should be used up, got %+v of %d", got.Batches, got.Quantity)
+	}
+
+	// a released reservation gives its lots back, transfers keep the lots
+	north, _ := uc.CreateWarehouse(ctx, "North", "")
+	receive("L20", day(20), 4)
+	r, _, _ = uc.ReserveStock(ctx, p.ID, "", 3, 0, "order-2")
+	if _, got, _ = uc.ReleaseReservation(ctx, r.ID); got.Batches[0].Reserved != 0 {
+		t.Errorf("releasing should unreserve the lot, got %+v", got.Batches)
+	}
+	m, got, err = uc.TransferStock(ctx, p.ID, TransferInput{From: DefaultWarehouseID, To: north.ID, Quantity: 3, Actor: "a", Lot: "L20"}, 0)
+	if err != nil {
+		t.Fatalf("TransferStock failed: %v", err)
+	}
+	if i := got.batchIndex(north.ID, "L20"); len(got.Batches) != 2 || i < 0 || got.Batches[i].Quantity != 3 || !got.Batches[i].ExpiresAt.Equal(day(20)) {
+		t.Errorf("3 of L20 should have moved north with its expiry, got %+v", got.Batches)
+	}
+
+	expiring, err := uc.ListExpiringStock(ctx, 30, north.ID, now)
+	if err != nil || len(expiring) != 1 || expiring[0].ProductName != "yoghurt" || expiring[0].Quantity != 3 {
+		t.Errorf("expected L20 in the north, got %+v, %v", expiring, err)
+	}
+	if expiring, _ := uc.ListExpiringStock(ctx, 7, "", now); len(expiring) != 0 {
+		t.Errorf("nothing expires within a week, got %+v", expiring)
+	}
+	if _, err := uc.ListExpiringStock(ctx, -1, "", now); !hasViolation(err, "within_days") {
+		t.Errorf("a negative window should fail, got %v", err)
+	}
+}
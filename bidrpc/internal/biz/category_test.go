@@ -0,0 +1,125 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProductUseCase_Categories(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	chilled, err := uc.CreateCategory(ctx, "Chilled", "")
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+	dairy, _ := uc.CreateCategory(ctx, "Dairy", chilled.ID)
+	cheese, _ := uc.CreateCategory(ctx, "Cheese", dairy.ID)
+	dry, _ := uc.CreateCategory(ctx, "Dry goods", "")
+	if cheese.ParentID != dairy.ID {
+		t.Errorf("expected cheese under dairy, got %+v", cheese)
+	}
+
+	if _, err := uc.CreateCategory(ctx, " ", ""); KindOf(err) != KindInvalidArgument {
+		t.Errorf("a category without a name should be rejected, got %v", err)
+	}
+	if _, err := uc.CreateCategory(ctx, "Milk", "missing"); !hasViolation(err, "parent_id") {
+		t.Errorf("an unknown parent should be rejected, got %v", err)
+	}
+	if _, err := uc.CreateCategory(ctx, "dairy", chilled.ID); !hasViolation(err, "name") {
+		t.Errorf("siblings with the same name should be rejected, got %v", err)
+	}
+	if _, err := uc.CreateCategory(ctx, "Dairy", ""); err != nil {
+		t.Errorf("the same name under another parent should be accepted, got %v", err)
+	}
+
+	all, err := uc.ListCategories(ctx)
+	if err != nil || len(all) != 5 || all[0].Name != "Cheese" {
+		t.Errorf("expected 5 categories by name, got %+v, %v", all, err)
+	}
+
+	// a category cannot move into its own subtree
+	for _, parent := range []string{chilled.ID, cheese.ID} {
+		if _, err := uc.MoveCategory(ctx, chilled.ID, parent); !hasViolation(err, "parent_id") {
+			t.Errorf("moving chilled under %s should be rejected, got %v", parent, err)
+		}
+	}
+	if _, err := uc.MoveCategory(ctx, dairy.ID, ""); !hasViolation(err, "name") {
+		t.Errorf("moving dairy next to another dairy should be rejected, got %v", err)
+	}
+	moved, err := uc.MoveCategory(ctx, cheese.ID, dry.ID)
+	if err != nil || moved.ParentID != dry.ID {
+		t.Fatalf("MoveCategory failed: %+v, %v", moved, err)
+	}
+	if _, err := uc.MoveCategory(ctx, "missing", ""); !errors.Is(err, ErrCategoryNotFound) || KindOf(err) != KindNotFound {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
+func TestProductUseCase_ListProductsByCategory(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	chilled, _ := uc.CreateCategory(ctx, "Chilled", "")
+	dairy, _ := uc.CreateCategory(ctx, "Dairy", chilled.ID)
+	cheese, _ := uc.CreateCategory(ctx, "Cheese", dairy.ID)
+	dry, _ := uc.CreateCategory(ctx, "Dry goods", "")
+
+	brie, err := uc.CreateProduct(ctx, ProductInput{Name: "brie", Price: usd(100), CategoryID: cheese.ID})
+	if err != nil || brie.CategoryID != cheese.ID {
+		t.Fatalf("CreateProduct failed: %+v, %v", brie, err)
+	}
+	milk, _ := uc.CreateProduct(ctx, ProductInput{Name: "milk", Price: usd(100), CategoryID: dairy.ID})
+	uc.CreateProduct(ctx, ProductInput{Name: "flour", Price: usd(100), CategoryID: dry.ID})
+	uc.CreateProduct(ctx, ProductInput{Name: "salt", Price: usd(100)})
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "sugar", Price: usd(100), CategoryID: "missing"}); !hasViolation(err, "category_id") {
+		t.Errorf("an unknown category should be rejected, got %v", err)
+	}
+
+	count := func(category string) int32 {
+		t.Helper()
+		page, err := uc.ListProducts(ctx, ListOptions{Category: category})
+		if err != nil {
+			t.Fatalf("ListProducts(%s) failed: %v", category, err)
+		}
+		return page.Total
+	}
+	if n := count(chilled.ID); n != 2 {
+		t.Errorf("chilled should include its descendants, got %d products", n)
+	}
+	if n := count(cheese.ID); n != 1 {
+		t.Errorf("expected 1 cheese, got %d", n)
+	}
+	if _, err := uc.ListProducts(ctx, ListOptions{Category: "missing"}); !hasViolation(err, "category_id") {
+		t.Errorf("an unknown category filter should be rejected, got %v", err)
+	}
+
+	// moving a category takes its products along
+	if _, err := uc.MoveCategory(ctx, cheese.ID, dry.ID); err != nil {
+		t.Fatalf("MoveCategory failed: %v", err)
+	}
+	if n := count(chilled.ID); n != 1 {
+		t.Errorf("only milk should be left in chilled, got %d", n)
+	}
+	if n := count(dry.ID); n != 2 {
+		t.Errorf("dry goods should now include cheese, got %d", n)
+	}
+
+	p, err := uc.UpdateProduct(ctx, milk.ID, ProductPatch{CategoryID: ptr("")}, 0)
+	if err != nil || p.CategoryID != "" {
+		t.Errorf("an empty category should uncategorize the product, got %+v, %v", p, err)
+	}
+	if _, err := uc.UpdateProduct(ctx, milk.ID, ProductPatch{CategoryID: ptr("missing")}, 0); !hasViolation(err, "category_id") {
+		t.Errorf("an unknown category should be rejected, got %v", err)
+	}
+}
+
+// hasViolation reports whether err is an invalid argument naming field
+func hasViolation(err error, field string) bool {
+	for _, v := range FieldViolations(err) {
+		if v.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package biz
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor marks the position a keyset page starts after: the sort key and ID
+// of the last product on the previous page.
+//
+// Unlike an offset it stays valid when products are inserted or deleted
+// before it, and repositories can seek to it instead of skipping rows.
+type Cursor struct {
+	SortBy SortField `json:"s"`
+	Desc   bool      `json:"d,omitempty"`
+	ID     string    `json:"i"`
+
+	// sort key, only the field matching SortBy is set
+	Name string `json:"n,omitempty"`
+	// Price is the amount in minor units of Currency, prices sort by their
+	// value in major units
+	Price    int64  `json:"p,omitempty"`
+	Currency string `json:"c,omitempty"`
+	Quantity int32  `json:"q,omitempty"`
+	Time     int64  `json:"t,omitempty"`
+
+	// Scope fingerprints the filters the cursor was issued for
+	Scope string `json:"f,omitempty"`
+}
+
+// NewCursor returns the cursor positioned on p in the order of q
+func NewCursor(p *Product, q ListQuery) *Cursor {
+	c := &Cursor{SortBy: q.SortBy, Desc: q.Desc, ID: p.ID}
+	switch q.SortBy {
+	case SortByName:
+		c.Name = strings.ToLower(p.Name)
+	case SortByPrice:
+		c.Price, c.Currency = p.Price.Amount, p.Price.Currency
+	case SortByQuantity:
+		c.Quantity = p.Quantity
+	case SortByUpdatedAt:
+		c.Time = p.UpdatedAt.UnixNano()
+	default:
+		c.Time = p.CreatedAt.UnixNano()
+	}
+	return c
+}
+
+// Pivot returns a product carrying the cursor's sort key and ID, so it can be
+// compared with ListQuery.Compare or indexed like a stored product
+func (c *Cursor) Pivot() *Product {
+	t := time.Unix(0, c.Time)
+	return &Product{
+		ID:        c.ID,
+		Name:      c.Name,
+		Price:     Money{Amount: c.Price, Currency: c.Currency},
+		Quantity:  c.Quantity,
+		CreatedAt: t,
+		UpdatedAt: t,
+	}
+}
+
+// Encode returns the opaque page token for c
+func (c *Cursor) Encode() string {
+	buf, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// DecodeCursor parses a page token produced by Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, InvalidArgument("page_token", "is malformed")
+	}
+	var c Cursor
+	if err := json.Unmarshal(buf, &c); err != nil {
+		return nil, InvalidArgument("page_token", "is malformed")
+	}
+	if _, err := ParseSortField(string(c.SortBy)); err != nil || c.ID == "" {
+		return nil, InvalidArgument("page_token", "is malformed")
+	}
+	return &c, nil
+}
+
+// scopeOf fingerprints the listing filters, a page token is only valid for
+// the filters it was issued with
+func scopeOf(parts ...string) string {
+	h := fnv.New64a()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return strconv.FormatUint(h.Sum64(), 36)
+}
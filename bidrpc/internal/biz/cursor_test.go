@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	p := &Product{ID: "p1", Name: "Cheddar", Price: usd(450), UpdatedAt: time.Unix(0, 42)}
+	c := NewCursor(p, ListQuery{SortBy: SortByName, Desc: true})
+
+	got, err := DecodeCursor(c.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor failed: %v", err)
+	}
+	if *got != *c {
+		t.Errorf("round trip mismatch: got %+v, want %+v", got, c)
+	}
+	if got.Name != "cheddar" {
+		t.Errorf("name key should be lower-cased, got %q", got.Name)
+	}
+
+	for _, token := range []string{"not base64!", "e30", "eyJzIjoiY29sb3VyIiwiaSI6IngifQ"} {
+		if _, err := DecodeCursor(token); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("DecodeCursor(%q) should fail with ErrInvalidInput, got %v", token, err)
+		}
+	}
+}
+
+func TestProductUseCase_ListProducts_PageToken(t *testing.T) {
+	repo := newMockProductRepo()
+	uc := NewProductUseCase(repo, nil)
+	ctx := context.Background()
+
+	for i := 0; i < 7; i++ {
+		if _, err := uc.CreateProduct(ctx, ProductInput{Name: fmt.Sprintf("product %d", i), Price: usd(100), Quantity: 1}); err != nil {
+			t.Fatalf("CreateProduct failed: %v", err)
+		}
+	}
+
+	seen := map[string]bool{}
+	opts := ListOptions{PageSize: 3, SortBy: "name"}
+	for pages := 1; ; pages++ {
+		page, err := uc.ListProducts(ctx, opts)
+		if err != nil {
+			t.Fatalf("ListProducts failed: %v", err)
+		}
+		if page.Total != int32(7+pages-1) {
+			t.Errorf("page %d: total %d, want %d", pages, page.Total, 7+pages-1)
+		}
+		for _, p := range page.Products {
+			if seen[p.ID] {
+				t.Errorf("product %s returned twice", p.Name)
+			}
+			seen[p.ID] = true
+		}
+		if page.NextPageToken == "" {
+			if pages != 3 {
+				t.Errorf("expected 3 pages, got %d", pages)
+			}
+			break
+		}
+
+		// an insert before the cursor must not shift the next page
+		if _, err := uc.CreateProduct(ctx, ProductInput{Name: fmt.Sprintf("a new product %d", pages), Price: usd(100), Quantity: 1}); err != nil {
+			t.Fatalf("CreateProduct failed: %v", err)
+		}
+		opts.PageToken = page.NextPageToken
+	}
+	if len(seen) != 7 {
+		t.Errorf("expected the 7 original products, saw %d", len(seen))
+	}
+
+	// a token is bound to the filter and order it was issued for
+	first, _ := uc.ListProducts(ctx, ListOptions{PageSize: 1})
+	if _, err := uc.ListProducts(ctx, ListOptions{PageToken: first.NextPageToken, NameFilter: "x"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("changed filter should be rejected, got %v", err)
+	}
+	if _, err := uc.ListProducts(ctx, ListOptions{PageToken: first.NextPageToken, SortBy: "price"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("changed sort should be rejected, got %v", err)
+	}
+	if _, err := uc.ListProducts(ctx, ListOptions{PageToken: first.NextPageToken}); err != nil {
+		t.Errorf("token without explicit sort should be accepted, got %v", err)
+	}
+}
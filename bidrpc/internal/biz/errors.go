@@ -0,0 +1,183 @@
+package biz
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrProductNotFound = errors.New("product not found")
+	// ErrProductHasVariants is returned when deleting a product that still
+	// has variants
+	ErrProductHasVariants = errors.New("product has variants")
+	// ErrProductNotDeleted is returned when restoring a product that is not
+	// in the trash
+	ErrProductNotDeleted = errors.New("product is not deleted")
+	// ErrParentDeleted is returned when restoring a variant whose parent is
+	// deleted
+	ErrParentDeleted = errors.New("parent product is deleted")
+	// ErrBarcodeInUse is returned when saving a product with a barcode that
+	// is on another product
+	ErrBarcodeInUse = errors.New("barcode is in use by another product")
+	// ErrSKUInUse is returned when saving a product with the SKU of another
+	// product
+	ErrSKUInUse     = errors.New("SKU is in use by another product")
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrVersionConflict is returned when a product changed since the
+	// version the caller read
+	ErrVersionConflict = errors.New("product version conflict")
+
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrReservationClosed is returned when a reservation is no longer held,
+	// because it was committed, released or expired
+	ErrReservationClosed = errors.New("reservation is no longer held")
+
+	ErrWarehouseNotFound = errors.New("warehouse not found")
+	// ErrWarehouseInUse is returned when deleting a warehouse that still
+	// holds stock
+	ErrWarehouseInUse = errors.New("warehouse holds stock")
+
+	ErrCategoryNotFound = errors.New("category not found")
+
+	ErrSupplierNotFound        = errors.New("supplier not found")
+	ErrProductSupplierNotFound = errors.New("product is not linked to the supplier")
+	// ErrSupplierInUse is returned when deleting a supplier products are
+	// still linked to
+	ErrSupplierInUse = errors.New("supplier is linked to products")
+
+	ErrPriceListNotFound = errors.New("price list not found")
+
+	ErrAuctionNotFound = errors.New("auction not found")
+	// ErrAuctionClosed is returned when bidding outside the bidding window of
+	// an auction or on one that closed
+	ErrAuctionClosed = errors.New("auction is not taking bids")
+	// ErrBidsSealed is returned when listing the bids of an open auction
+	ErrBidsSealed = errors.New("bids are sealed")
+)
+
+// ErrorKind classifies an error so the transport layers can pick a status
+type ErrorKind int
+
+const (
+	KindUnknown ErrorKind = iota
+	KindNotFound
+	KindInvalidArgument
+	// KindConflict is a write that lost a race with another writer, the
+	// caller may simply retry
+	KindConflict
+	// KindAlreadyExists is a write that would give a unique key, such as a
+	// barcode or SKU, to a second record
+	KindAlreadyExists
+	// KindInvalidState is a request the current state of a record does not
+	// allow, such as deleting a warehouse that holds stock. Retrying only
+	// helps once that state changed.
+	KindInvalidState
+	// KindPreconditionFailed is a write whose expected version no longer
+	// matches, the caller has to re-read before retrying
+	KindPreconditionFailed
+	KindUnavailable
+)
+
+// FieldViolation names an invalid request field and why it is invalid
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is a domain error with a kind and, for invalid arguments, the
+// offending fields. It matches the sentinel it wraps with errors.Is.
+type Error struct {
+	Kind       ErrorKind
+	Violations []FieldViolation
+	msg        string
+	err        error
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// InvalidArgument reports a single invalid field, it matches ErrInvalidInput
+func InvalidArgument(field, format string, args ...any) *Error {
+	v := FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)}
+	return &Error{
+		Kind:       KindInvalidArgument,
+		Violations: []FieldViolation{v},
+		msg:        fmt.Sprintf("%s: %s: %s", ErrInvalidInput, v.Field, v.Description),
+		err:        ErrInvalidInput,
+	}
+}
+
+// InvalidArguments merges the violations of errs into one error, nil when
+// errs holds no error
+func InvalidArguments(errs ...error) error {
+	var violations []FieldViolation
+	var msgs []string
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		violations = append(violations, FieldViolations(err)...)
+		msgs = append(msgs, strings.TrimPrefix(err.Error(), ErrInvalidInput.Error()+": "))
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return &Error{
+		Kind:       KindInvalidArgument,
+		Violations: violations,
+		msg:        fmt.Sprintf("%s: %s", ErrInvalidInput, strings.Join(msgs, "; ")),
+		err:        ErrInvalidInput,
+	}
+}
+
+// PreconditionFailed reports that product id is no longer at version, it
+// matches ErrVersionConflict
+func PreconditionFailed(id string, version int64) *Error {
+	return &Error{
+		Kind: KindPreconditionFailed,
+		msg:  fmt.Sprintf("%s: product %s is not at version %d", ErrVersionConflict, id, version),
+		err:  ErrVersionConflict,
+	}
+}
+
+// KindOf classifies err, plain sentinels from the repositories included
+func KindOf(err error) ErrorKind {
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+		return e.Kind
+	case errors.Is(err, ErrProductNotFound), errors.Is(err, ErrReservationNotFound), errors.Is(err, ErrWarehouseNotFound),
+		errors.Is(err, ErrCategoryNotFound), errors.Is(err, ErrSupplierNotFound), errors.Is(err, ErrProductSupplierNotFound),
+		errors.Is(err, ErrPriceListNotFound), errors.Is(err, ErrAuctionNotFound):
+		return KindNotFound
+	case errors.Is(err, ErrInvalidInput):
+		return KindInvalidArgument
+	case errors.Is(err, ErrVersionConflict):
+		return KindConflict
+	case errors.Is(err, ErrBarcodeInUse), errors.Is(err, ErrSKUInUse):
+		return KindAlreadyExists
+	case errors.Is(err, ErrReservationClosed), errors.Is(err, ErrWarehouseInUse), errors.Is(err, ErrSupplierInUse),
+		errors.Is(err, ErrAuctionClosed), errors.Is(err, ErrBidsSealed), errors.Is(err, ErrProductHasVariants),
+		errors.Is(err, ErrProductNotDeleted), errors.Is(err, ErrParentDeleted):
+		return KindInvalidState
+	case errors.Is(err, ErrSearchUnavailable):
+		return KindUnavailable
+	default:
+		return KindUnknown
+	}
+}
+
+// FieldViolations returns the invalid fields carried by err, if any
+func FieldViolations(err error) []FieldViolation {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Violations
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		err  error
+		want ErrorKind
+	}{
+		{ErrProductNotFound, KindNotFound},
+		{fmt.Errorf("load: %w", ErrProductNotFound), KindNotFound},
+		{ErrInvalidInput, KindInvalidArgument},
+		{InvalidArgument("name", "is required"), KindInvalidArgument},
+		{ErrVersionConflict, KindConflict},
+		{fmt.Errorf("%w: 00012345678905", ErrBarcodeInUse), KindAlreadyExists},
+		{ErrSKUInUse, KindAlreadyExists},
+		{ErrWarehouseInUse, KindInvalidState},
+		{ErrReservationClosed, KindInvalidState},
+		{PreconditionFailed("p1", 2), KindPreconditionFailed},
+		{ErrSearchUnavailable, KindUnavailable},
+		{errors.New("disk full"), KindUnknown},
+	}
+	for _, tt := range tests {
+		if got := KindOf(tt.err); got != tt.want {
+			t.Errorf("KindOf(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestErrorMatchesSentinel(t *testing.T) {
+	if err := InvalidArgument("price", "must not be negative"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("InvalidArgument should match ErrInvalidInput")
+	}
+	if err := PreconditionFailed("p1", 2); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("PreconditionFailed should match ErrVersionConflict")
+	}
+	if err := InvalidArguments(nil, nil); err != nil {
+		t.Errorf("InvalidArguments without errors should be nil, got %v", err)
+	}
+}
+
+func TestProductUseCase_FieldViolations(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	_, err := uc.CreateProduct(ctx, ProductInput{Name: "", Description: "desc", Price: usd(-100), Quantity: -1})
+	var fields []string
+	for _, v := range FieldViolations(err) {
+		fields = append(fields, v.Field)
+	}
+	if fmt.Sprint(fields) != "[name price quantity]" {
+		t.Errorf("expected a violation per invalid field, got %v (%v)", fields, err)
+	}
+
+	p, err := uc.CreateProduct(ctx, ProductInput{Name: "name", Description: "desc", Price: usd(100), Quantity: 1})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("x")}, 7); KindOf(err) != KindPreconditionFailed {
+		t.Errorf("a stale expected version should fail its precondition, got %v", err)
+	}
+	if _, err := uc.GetProduct(ctx, "missing"); KindOf(err) != KindNotFound {
+		t.Errorf("expected KindNotFound, got %v", err)
+	}
+}
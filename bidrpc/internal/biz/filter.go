@@ -0,0 +1,489 @@
+package biz
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Filter is a parsed product filter expression in the style of AIP-160:
+//
+//	price >= 10 AND price < 20.5
+//	quantity < 10 OR name:"chicken breast"
+//	NOT (description:frozen) updated_at > 2024-01-31T00:00:00Z
+//
+// Comparisons are `field op value` with op one of = != < <= > >= and `:`,
+// which means "contains" for text and "equals" otherwise. Text matches
+// ignore case, prices compare in major units of the product's own currency.
+// A quantity in base units may name a unit of measure instead, as in
+// `quantity >= 2case` or `quantity < "1.5 kg"`, which only products defining
+// that unit match. `attributes.<name>` compares an attribute of the
+// product, as numbers when both sides are numbers and as text otherwise;
+// products without the attribute never match. The labelling fields
+// allergen (contained or possibly contained), free_from and dietary hold
+// sets, `=` and `:` test membership and `!=` its absence, so
+// `NOT allergen = peanut AND dietary = halal` excludes every product that may
+// contain peanuts.
+// Terms combine with AND, OR and NOT, and parentheses; adjacent
+// terms are ANDed and OR binds tighter than AND, as in AIP-160.
+type Filter struct {
+	Expr FilterExpr
+	src  string
+}
+
+// String returns the source text of the filter
+func (f *Filter) String() string {
+	return f.src
+}
+
+// Match reports whether p satisfies the filter
+func (f *Filter) Match(p *Product) bool {
+	return f == nil || f.Expr.Match(p)
+}
+
+// FilterExpr is a node of a parsed filter
+type FilterExpr interface {
+	Match(p *Product) bool
+}
+
+// FilterAnd matches when all of its terms match
+type FilterAnd struct {
+	Terms []FilterExpr
+}
+
+// FilterOr matches when any of its terms matches
+type FilterOr struct {
+	Terms []FilterExpr
+}
+
+// FilterNot inverts its term
+type FilterNot struct {
+	Term FilterExpr
+}
+
+// FilterKind is the value type of a filterable field
+type FilterKind int
+
+const (
+	FilterText FilterKind = iota
+	FilterNumber
+	FilterTime
+	// FilterAttribute compares a product attribute, Field is
+	// attributes.<name> and Text the value
+	FilterAttribute
+	// FilterSet tests whether a set of codes holds Text
+	FilterSet
+)
+
+// attributeFieldPrefix starts the filter fields of product attributes
+const attributeFieldPrefix = "attributes."
+
+// FilterCompare compares a product field with a literal. Exactly one of
+// Text, Number and Time is meaningful, chosen by Kind. Unit, when set, is
+// the unit of measure Number is in.
+type FilterCompare struct {
+	Field  string
+	Kind   FilterKind
+	Op     string
+	Text   string
+	Number float64
+	Unit   string
+	Time   time.Time
+}
+
+// filterField describes a field that can appear in a filter
+type filterField struct {
+	kind  FilterKind
+	value func(p *Product) any // string, float64, time.Time or []string
+	// measured numbers are base units of the product and may name a unit
+	measured bool
+}
+
+var filterFields = map[string]filterField{
+	"id":          {FilterText, func(p *Product) any { return p.ID }, false},
+	"name":        {FilterText, func(p *Product) any { return p.Name }, false},
+	"description": {FilterText, func(p *Product) any { return p.Description }, false},
+	"parent_id":   {FilterText, func(p *Product) any { return p.ParentID }, false},
+	"price":       {FilterNumber, func(p *Product) any { return p.Price.Float() }, false},
+	"quantity":    {FilterNumber, func(p *Product) any { return float64(p.Quantity) }, true},
+	"created_at":  {FilterTime, func(p *Product) any { return p.CreatedAt }, false},
+	"updated_at":  {FilterTime, func(p *Product) any { return p.UpdatedAt }, false},
+	"allergen":    {FilterSet, func(p *Product) any { return codes(p.Allergens.present()) }, false},
+	"free_from":   {FilterSet, func(p *Product) any { return codes(p.Allergens.freeFrom()) }, false},
+	"dietary":     {FilterSet, func(p *Product) any { return codes(p.DietaryTags) }, false},
+}
+
+func codes[T ~string](in []T) []string {
+	out := make([]string, len(in))
+	for i, c := range in {
+		out[i] = string(c)
+	}
+	return out
+}
+
+func (e *FilterAnd) Match(p *Product) bool {
+	for _, t := range e.Terms {
+		if !t.Match(p) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *FilterOr) Match(p *Product) bool {
+	for _, t := range e.Terms {
+		if t.Match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *FilterNot) Match(p *Product) bool {
+	return !e.Term.Match(p)
+}
+
+func (e *FilterCompare) Match(p *Product) bool {
+	if e.Kind == FilterAttribute {
+		return e.matchAttribute(p)
+	}
+	v := filterFields[e.Field].value(p)
+
+	var c int
+	switch e.Kind {
+	case FilterText:
+		s := strings.ToLower(v.(string))
+		if e.Op == ":" {
+			return strings.Contains(s, e.Text)
+		}
+		c = strings.Compare(s, e.Text)
+	case FilterNumber:
+		n := e.Number
+		if e.Unit != "" {
+			factor, _, ok := p.Units.factor(e.Unit)
+			if !ok {
+				return false
+			}
+			n *= factor
+		}
+		c = cmp.Compare(v.(float64), n)
+	case FilterTime:
+		c = v.(time.Time).Compare(e.Time)
+	case FilterSet:
+		return slices.Contains(v.([]string), e.Text) == (e.Op != "!=")
+	}
+	return e.holds(c)
+}
+
+func (e *FilterCompare) matchAttribute(p *Product) bool {
+	v, ok := p.Attributes[strings.TrimPrefix(e.Field, attributeFieldPrefix)]
+	if !ok {
+		return false
+	}
+	n, errV := strconv.ParseFloat(v, 64)
+	m, errT := strconv.ParseFloat(e.Text, 64)
+	if errV == nil && errT == nil {
+		return e.holds(cmp.Compare(n, m))
+	}
+	s := strings.ToLower(v)
+	if e.Op == ":" {
+		return strings.Contains(s, e.Text)
+	}
+	return e.holds(strings.Compare(s, e.Text))
+}
+
+// holds reports whether the operator holds for c, the comparison of the
+// product value with the literal
+func (e *FilterCompare) holds(c int) bool {
+	switch e.Op {
+	case "=", ":":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	}
+	return false
+}
+
+// ParseFilter parses a filter expression, an empty string yields a nil filter
+func ParseFilter(src string) (*Filter, error) {
+	src = strings.TrimSpace(src)
+	if src == "" {
+		return nil, nil
+	}
+
+	tokens, err := lexFilter(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	expr, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, filterError("unexpected %q", p.tokens[p.pos].text)
+	}
+	return &Filter{Expr: expr, src: src}, nil
+}
+
+func filterError(format string, args ...any) error {
+	return InvalidArgument("filter", format, args...)
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type filterToken struct {
+	kind tokenKind
+	text string
+}
+
+// lexFilter splits src into words, quoted strings, operators and parentheses
+func lexFilter(src string) ([]filterToken, error) {
+	var tokens []filterToken
+	rs := []rune(src)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, filterToken{tokLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, filterToken{tokRParen, ")"})
+			i++
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(rs) && rs[j] != r; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) {
+					j++
+				}
+				sb.WriteRune(rs[j])
+			}
+			if j >= len(rs) {
+				return nil, filterError("unterminated string")
+			}
+			tokens = append(tokens, filterToken{tokString, sb.String()})
+			i = j + 1
+		case strings.ContainsRune("<>=!:", r):
+			op := string(r)
+			if i+1 < len(rs) && rs[i+1] == '=' && r != '=' && r != ':' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, filterError("unexpected '!'")
+			}
+			tokens = append(tokens, filterToken{tokOp, op})
+			i += len(op)
+		default:
+			// a value may contain colons, as timestamps do
+			stop := "()<>=!:\"'"
+			if len(tokens) > 0 && tokens[len(tokens)-1].kind == tokOp {
+				stop = "()<>=!\"'"
+			}
+			j := i
+			for j < len(rs) && !unicode.IsSpace(rs[j]) && !strings.ContainsRune(stop, rs[j]) {
+				j++
+			}
+			tokens = append(tokens, filterToken{tokWord, string(rs[i:j])})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() *filterToken {
+	if p.pos < len(p.tokens) {
+		return &p.tokens[p.pos]
+	}
+	return nil
+}
+
+func (p *filterParser) keyword(kw string) bool {
+	if t := p.peek(); t != nil && t.kind == tokWord && t.text == kw {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseAnd parses `or { [AND] or }`
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	var terms []FilterExpr
+	for {
+		term, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		explicit := p.keyword("AND")
+		t := p.peek()
+		if t == nil || t.kind == tokRParen {
+			if explicit {
+				return nil, filterError("expected a term after AND")
+			}
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return &FilterAnd{Terms: terms}, nil
+}
+
+// parseOr parses `unary { OR unary }`
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	term, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	terms := []FilterExpr{term}
+	for p.keyword("OR") {
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return &FilterOr{Terms: terms}, nil
+}
+
+// parseUnary parses `[NOT] primary`
+func (p *filterParser) parseUnary() (FilterExpr, error) {
+	if p.keyword("NOT") {
+		term, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &FilterNot{Term: term}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary parses `( and )` or a comparison
+func (p *filterParser) parsePrimary() (FilterExpr, error) {
+	t := p.peek()
+	if t == nil {
+		return nil, filterError("unexpected end of filter")
+	}
+	if t.kind == tokLParen {
+		p.pos++
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.peek(); t == nil || t.kind != tokRParen {
+			return nil, filterError("missing ')'")
+		}
+		p.pos++
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses `field op value`
+func (p *filterParser) parseComparison() (FilterExpr, error) {
+	t := p.peek()
+	if t.kind != tokWord {
+		return nil, filterError("expected a field name, got %q", t.text)
+	}
+	name := strings.ToLower(t.text)
+	field, ok := filterFields[name]
+	if attr, found := strings.CutPrefix(name, attributeFieldPrefix); found && validAttributeName(attr) {
+		field, ok = filterField{kind: FilterAttribute}, true
+	}
+	if !ok {
+		return nil, filterError("unknown field %q", t.text)
+	}
+	p.pos++
+
+	op := p.peek()
+	if op == nil || op.kind != tokOp {
+		return nil, filterError("expected an operator after %q", t.text)
+	}
+	p.pos++
+
+	v := p.peek()
+	if v == nil || (v.kind != tokWord && v.kind != tokString) {
+		return nil, filterError("expected a value after %s %s", t.text, op.text)
+	}
+	p.pos++
+
+	cmp := &FilterCompare{Field: name, Kind: field.kind, Op: op.text}
+	switch field.kind {
+	case FilterText, FilterAttribute:
+		cmp.Text = strings.ToLower(v.text)
+	case FilterSet:
+		if op.text != "=" && op.text != ":" && op.text != "!=" {
+			return nil, filterError("%s only takes =, : or !=, got %s", name, op.text)
+		}
+		cmp.Text = strings.ToLower(v.text)
+	case FilterNumber:
+		n, err := strconv.ParseFloat(v.text, 64)
+		if err != nil && field.measured {
+			n, cmp.Unit, err = parseFilterMeasure(v.text)
+		}
+		if err != nil {
+			if field.measured {
+				return nil, filterError("%s expects a number with an optional unit, got %q", name, v.text)
+			}
+			return nil, filterError("%s expects a number, got %q", name, v.text)
+		}
+		cmp.Number = n
+	case FilterTime:
+		ts, err := parseFilterTime(v.text)
+		if err != nil {
+			return nil, filterError("%s expects an RFC 3339 timestamp or a date, got %q", name, v.text)
+		}
+		cmp.Time = ts
+	}
+	return cmp, nil
+}
+
+// parseFilterMeasure splits a number followed by a unit code, as in 2case
+// or "1.5 kg"
+func parseFilterMeasure(s string) (float64, string, error) {
+	i := strings.IndexFunc(s, func(r rune) bool { return r >= 'a' && r <= 'z' })
+	if i <= 0 || !validUnitCode(s[i:]) {
+		return 0, "", strconv.ErrSyntax
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s[:i]), 64)
+	return n, s[i:], err
+}
+
+// parseFilterTime accepts RFC 3339 timestamps and YYYY-MM-DD dates (UTC midnight)
+func parseFilterTime(s string) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return ts, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
@@ -0,0 +1,115 @@
+package biz
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseFilter_Match(t *testing.T) {
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	p := &Product{
+		ID:          "p1",
+		Name:        "Chicken Breast Fillet",
+		Description: "Frozen, 2kg bag",
+		Price:       usd(1250),
+		Quantity:    8,
+		ParentID:    "P0",
+		Attributes:  Attributes{"size_g": "200", "flavour": "Smoked", "vegan": "false"},
+		Allergens:   Allergens{AllergenEgg: AllergenMayContain, AllergenPeanut: AllergenFreeFrom},
+		DietaryTags: []DietaryTag{DietaryHalal},
+		CreatedAt:   day,
+		UpdatedAt:   day.Add(24 * time.Hour),
+	}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{`quantity < 10`, true},
+		{`quantity<10`, true},
+		{`quantity >= 10`, false},
+		{`price >= 10 AND price < 20.5`, true},
+		{`price > 10 price < 12`, false},
+		{`name:chicken`, true},
+		{`name:"breast fillet"`, true},
+		{`name = "chicken breast fillet"`, true},
+		{`name = chicken`, false},
+		{`name != chicken`, true},
+		{`description:FROZEN`, true},
+		{`NOT description:frozen`, false},
+		{`quantity > 100 OR name:chicken`, true},
+		{`(quantity > 100 OR name:beef) AND price < 20`, false},
+		{`quantity > 100 OR name:beef price < 20`, false},
+		{`created_at >= 2024-03-01 AND created_at < 2024-03-02`, true},
+		{`updated_at > 2024-03-02T00:00:00Z`, true},
+		{`updated_at < "2024-03-02T00:00:00Z"`, false},
+		{`price:12.5`, true},
+		{`parent_id = p0`, true},
+		{`attributes.size_g >= 100 AND attributes.size_g < 1000`, true},
+		{`attributes.size_g > 50.5`, true},
+		{`attributes.size_g = 200.0`, true},
+		{`attributes.flavour = smoked`, true},
+		{`attributes.flavour:smo`, true},
+		{`attributes.vegan = true`, false},
+		{`attributes.colour = red`, false},
+		{`NOT attributes.colour = red`, true},
+		{`allergen = egg`, true},
+		{`allergen:EGG`, true},
+		{`NOT allergen = peanut`, true},
+		{`allergen != egg`, false},
+		{`free_from = peanut AND dietary = halal`, true},
+		{`dietary = vegan`, false},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) failed: %v", tt.filter, err)
+			continue
+		}
+		if got := f.Match(p); got != tt.want {
+			t.Errorf("ParseFilter(%q).Match = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestParseFilter_Precedence(t *testing.T) {
+	// OR binds tighter than AND
+	f, err := ParseFilter(`name:x OR name:y AND price > 1`)
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+	and, ok := f.Expr.(*FilterAnd)
+	if !ok || len(and.Terms) != 2 {
+		t.Fatalf("expected AND of two terms, got %#v", f.Expr)
+	}
+	if _, ok := and.Terms[0].(*FilterOr); !ok {
+		t.Errorf("expected OR as first AND term, got %#v", and.Terms[0])
+	}
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	for _, filter := range []string{
+		`colour = red`,
+		`attributes. = red`,
+		`attributes.Size-G = 1`,
+		`allergen > egg`,
+		`price > cheap`,
+		`created_at > yesterday`,
+		`name`,
+		`name =`,
+		`(name:x`,
+		`name:x)`,
+		`name:x AND`,
+		`name:"unterminated`,
+		`name ! x`,
+	} {
+		if _, err := ParseFilter(filter); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ParseFilter(%q) should fail with ErrInvalidInput, got %v", filter, err)
+		}
+	}
+
+	if f, err := ParseFilter("  "); f != nil || err != nil {
+		t.Errorf("blank filter should parse to nil, got %v, %v", f, err)
+	}
+}
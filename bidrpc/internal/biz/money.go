@@ -0,0 +1,120 @@
+package biz
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+)
+
+// LegacyCurrency is the currency of amounts sent or stored as a bare float
+// before Money existed, which carried no currency
+const LegacyCurrency = "USD"
+
+// Money is an exact amount: Amount minor units (e.g. cents) of the ISO-4217
+// currency Currency, so 999.99 USD is Money{Amount: 99999, Currency: "USD"}
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// minorDigits holds the ISO-4217 currencies whose minor unit is not a
+// hundredth, with the number of its decimal digits
+var minorDigits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// CurrencyDigits returns the number of decimal digits of the minor unit of
+// currency, 2 for most
+func CurrencyDigits(currency string) int {
+	if d, ok := minorDigits[currency]; ok {
+		return d
+	}
+	return 2
+}
+
+// MoneyFromFloat converts a legacy float amount of currency, failing with an
+// invalid field when it is more precise than the currency's minor unit
+func MoneyFromFloat(field string, amount float64, currency string) (Money, error) {
+	scaled := amount * math.Pow10(CurrencyDigits(currency))
+	units := math.Round(scaled)
+	if math.IsNaN(scaled) || math.Abs(units) > 1<<53 {
+		return Money{}, InvalidArgument(field, "is out of range")
+	}
+	// the product of a decimal and a power of ten is off by a few ulps,
+	// anything more is a fraction of the minor unit
+	if math.Abs(scaled-units) > math.Max(1, math.Abs(scaled))*1e-12 {
+		return Money{}, InvalidArgument(field, "is more precise than the minor unit of %s", currency)
+	}
+	return Money{Amount: int64(units), Currency: currency}, nil
+}
+
+// Float returns the amount in major units, for comparisons with filter
+// literals only
+func (m Money) Float() float64 {
+	return float64(m.Amount) / math.Pow10(CurrencyDigits(m.Currency))
+}
+
+// String formats m exactly, e.g. "999.99 USD"
+func (m Money) String() string {
+	digits := CurrencyDigits(m.Currency)
+	if digits == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	unit := int64(math.Pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/unit, digits, amount%unit, m.Currency)
+}
+
+func (m Money) LogValue() slog.Value {
+	return slog.StringValue(m.String())
+}
+
+// validate checks m as a price or cost reported as field
+func (m Money) validate(field string) error {
+	var errs []error
+	if m.Amount < 0 {
+		errs = append(errs, InvalidArgument(field, "must not be negative"))
+	}
+	if !validCurrency(m.Currency) {
+		errs = append(errs, InvalidArgument(field+".currency_code", "must be a three letter ISO-4217 code"))
+	}
+	return InvalidArguments(errs...)
+}
+
+// validCurrency reports whether c has the form of an ISO-4217 code
+func validCurrency(c string) bool {
+	if len(c) != 3 {
+		return false
+	}
+	for i := range len(c) {
+		if c[i] < 'A' || c[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// UnmarshalJSON also reads the bare number amounts were stored as before
+// they had a currency, rounded to the minor unit of LegacyCurrency
+func (m *Money) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '{' {
+		type money Money
+		return json.Unmarshal(b, (*money)(m))
+	}
+	var legacy *float64
+	if err := json.Unmarshal(b, &legacy); err != nil || legacy == nil {
+		return err
+	}
+	*m = Money{
+		Amount:   int64(math.Round(*legacy * math.Pow10(CurrencyDigits(LegacyCurrency)))),
+		Currency: LegacyCurrency,
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoneyFromFloat(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     Money
+		ok       bool
+	}{
+		{999.99, "USD", Money{99999, "USD"}, true},
+		{0.07, "USD", Money{7, "USD"}, true},
+		{12.5, "EUR", Money{1250, "EUR"}, true},
+		{1500, "JPY", Money{1500, "JPY"}, true},
+		{1.234, "BHD", Money{1234, "BHD"}, true},
+		{1.005, "USD", Money{}, false},
+		{0.001, "USD", Money{}, false},
+		{1.5, "JPY", Money{}, false},
+		{1e300, "USD", Money{}, false},
+	}
+	for _, tt := range tests {
+		got, err := MoneyFromFloat("price", tt.amount, tt.currency)
+		if tt.ok && (err != nil || got != tt.want) {
+			t.Errorf("MoneyFromFloat(%v, %s) = %v, %v, want %v", tt.amount, tt.currency, got, err, tt.want)
+		}
+		if !tt.ok && !hasViolation(err, "price") {
+			t.Errorf("MoneyFromFloat(%v, %s) should fail on price, got %v", tt.amount, tt.currency, err)
+		}
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{99999, "USD"}, "999.99 USD"},
+		{Money{5, "EUR"}, "0.05 EUR"},
+		{Money{-1250, "GBP"}, "-12.50 GBP"},
+		{Money{1500, "JPY"}, "1500 JPY"},
+		{Money{1234, "BHD"}, "1.234 BHD"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.m, got, tt.want)
+		}
+		if tt.m.Amount > 0 && tt.m.Float() <= 0 {
+			t.Errorf("%#v.Float() = %v", tt.m, tt.m.Float())
+		}
+	}
+}
+
+func TestMoney_UnmarshalJSON(t *testing.T) {
+	var p struct{ Price Money }
+	if err := json.Unmarshal([]byte(`{"Price": 999.99}`), &p); err != nil || p.Price != (Money{99999, LegacyCurrency}) {
+		t.Errorf("a legacy float should decode as %s minor units, got %v, %v", LegacyCurrency, p.Price, err)
+	}
+
+	buf, err := json.Marshal(struct{ Price Money }{Money{1500, "JPY"}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	p.Price = Money{}
+	if err := json.Unmarshal(buf, &p); err != nil || p.Price != (Money{1500, "JPY"}) {
+		t.Errorf("round trip of %s = %v, %v", buf, p.Price, err)
+	}
+
+	p.Price = Money{}
+	if err := json.Unmarshal([]byte(`{"Price": null}`), &p); err != nil || p.Price != (Money{}) {
+		t.Errorf("null should leave the zero amount, got %v, %v", p.Price, err)
+	}
+}
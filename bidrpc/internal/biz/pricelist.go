@@ -0,0 +1,379 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceList is a named set of contract prices, e.g. "Hotels 2025", that
+// replace the list price of its products for the customers buying on it
+type PriceList struct {
+	ID   string
+	Name string
+	// CustomerIDs are the customers buying on the list, sorted. A customer
+	// is on at most one price list.
+	CustomerIDs []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PriceTier is the unit price of a product on a price list from an order
+// quantity on. A tier from 1 unit overrides the list price outright, higher
+// ones are volume breaks.
+type PriceTier struct {
+	PriceListID string
+	ProductID   string
+	MinQuantity int32
+	Price       Money
+}
+
+// PriceListRepo stores price lists and their tiers
+type PriceListRepo interface {
+	// SavePriceList inserts or replaces l
+	SavePriceList(ctx context.Context, l *PriceList) error
+	// FindPriceList fails with ErrPriceListNotFound for an unknown id
+	FindPriceList(ctx context.Context, id string) (*PriceList, error)
+	// FindPriceLists returns every price list ordered by name
+	FindPriceLists(ctx context.Context) ([]*PriceList, error)
+	// SavePriceTiers replaces the tiers of a product on a price list, no
+	// tiers take the product off the list. It fails with
+	// ErrPriceListNotFound or ErrProductNotFound when either does not exist.
+	SavePriceTiers(ctx context.Context, priceListID, productID string, tiers []*PriceTier) error
+	// FindPriceTiers returns the tiers of a product on a price list ordered
+	// by minimum quantity
+	FindPriceTiers(ctx context.Context, priceListID, productID string) ([]*PriceTier, error)
+}
+
+// PriceRule names the rule a quote was priced by
+type PriceRule string
+
+const (
+	PriceRuleListPrice PriceRule = "list_price"
+	// PriceRuleOverride is a price list tier from 1 unit
+	PriceRuleOverride PriceRule = "override"
+	// PriceRuleTier is a price list tier from a higher quantity
+	PriceRuleTier PriceRule = "tier"
+)
+
+// PriceQuote is the effective price of an order line
+type PriceQuote struct {
+	ProductID string
+	// PriceListID is the list the quote was looked up on, empty when no list
+	// applies
+	PriceListID string
+	Quantity    int32
+	UnitPrice   Money
+	// LinePrice is UnitPrice times Quantity
+	LinePrice Money
+	Rule      PriceRule
+	// MinQuantity is the quantity break of the tier applied, 0 for the list
+	// price
+	MinQuantity int32
+	// Explanation tells in words which rule applied and why
+	Explanation string
+}
+
+// QuoteRequest asks for the price of Quantity units of a product, on a price
+// list given directly or as the one of a customer
+type QuoteRequest struct {
+	ProductID   string
+	PriceListID string
+	CustomerID  string
+	Quantity    int32
+}
+
+// PricingUseCase handles price lists and price quotes
+type PricingUseCase struct {
+	repo ProductRepo
+	// mu serializes price list writes, which check that a customer is on one
+	// list only
+	mu sync.Mutex
+}
+
+// NewPricingUseCase creates a new pricing use case
+func NewPricingUseCase(repo ProductRepo) *PricingUseCase {
+	return &PricingUseCase{repo: repo}
+}
+
+// normalizeCustomers trims, sorts and deduplicates customer IDs, dropping
+// empty ones
+func normalizeCustomers(ids []string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			out = append(out, id)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// checkCustomers fails when one of customerIDs is on a price list other than id
+func (uc *PricingUseCase) checkCustomers(ctx context.Context, id string, customerIDs []string) error {
+	lists, err := uc.repo.FindPriceLists(ctx)
+	if err != nil {
+		return err
+	}
+	for _, l := range lists {
+		if l.ID == id {
+			continue
+		}
+		for _, c := range customerIDs {
+			if _, ok := slices.BinarySearch(l.CustomerIDs, c); ok {
+				return InvalidArgument("customer_ids", "customer %q is already on price list %q", c, l.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// CreatePriceList creates a price list for the given customers
+func (uc *PricingUseCase) CreatePriceList(ctx context.Context, name string, customerIDs []string) (*PriceList, error) {
+	slog.Info("Creating price list", "name", name, "customerIDs", customerIDs)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, InvalidArgument("name", "is required")
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	customerIDs = normalizeCustomers(customerIDs)
+	if err := uc.checkCustomers(ctx, "", customerIDs); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	l := &PriceList{
+		ID:          uuid.New().String(),
+		Name:        name,
+		CustomerIDs: customerIDs,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.repo.SavePriceList(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetPriceList retrieves a price list by ID
+func (uc *PricingUseCase) GetPriceList(ctx context.Context, id string) (*PriceList, error) {
+	slog.Info("Getting price list", "id", id)
+	if id == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	return uc.repo.FindPriceList(ctx, id)
+}
+
+// ListPriceLists retrieves every price list ordered by name
+func (uc *PricingUseCase) ListPriceLists(ctx context.Context) ([]*PriceList, error) {
+	slog.Info("Listing price lists")
+	return uc.repo.FindPriceLists(ctx)
+}
+
+// UpdatePriceList renames a price list and replaces its customers
+func (uc *PricingUseCase) UpdatePriceList(ctx context.Context, id, name string, customerIDs []string) (*PriceList, error) {
+	slog.Info("Updating price list", "id", id, "name", name, "customerIDs", customerIDs)
+	var errs []error
+	if id == "" {
+		errs = append(errs, InvalidArgument("id", "is required"))
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		errs = append(errs, InvalidArgument("name", "is required"))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	l, err := uc.repo.FindPriceList(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	customerIDs = normalizeCustomers(customerIDs)
+	if err := uc.checkCustomers(ctx, id, customerIDs); err != nil {
+		return nil, err
+	}
+	l.Name, l.CustomerIDs, l.UpdatedAt = name, customerIDs, time.Now()
+	if err := uc.repo.SavePriceList(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetPriceTiers replaces the tiers of a product on a price list, only their
+// MinQuantity and Price are read. No tiers take the product off the list.
+// Tier prices are in the currency of the product price, which quotes are in.
+func (uc *PricingUseCase) SetPriceTiers(ctx context.Context, priceListID, productID string, tiers []*PriceTier) ([]*PriceTier, error) {
+	slog.Info("Setting price tiers", "priceListID", priceListID, "productID", productID, "tiers", len(tiers))
+	var errs []error
+	if priceListID == "" {
+		errs = append(errs, InvalidArgument("price_list_id", "is required"))
+	}
+	if productID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	seen := make(map[int32]bool, len(tiers))
+	out := make([]*PriceTier, len(tiers))
+	for i, t := range tiers {
+		field := fmt.Sprintf("tiers[%d]", i)
+		if t.MinQuantity < 1 {
+			errs = append(errs, InvalidArgument(field+".min_quantity", "must be at least 1"))
+		} else if seen[t.MinQuantity] {
+			errs = append(errs, InvalidArgument(field+".min_quantity", "repeats %d", t.MinQuantity))
+		}
+		seen[t.MinQuantity] = true
+		if err := t.Price.validate(field + ".price"); err != nil {
+			errs = append(errs, err)
+		}
+		out[i] = &PriceTier{PriceListID: priceListID, ProductID: productID, MinQuantity: t.MinQuantity, Price: t.Price}
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+
+	p, err := live(uc.repo.FindByID(ctx, productID))
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range out {
+		if t.Price.Currency != p.Price.Currency {
+			errs = append(errs, InvalidArgument(fmt.Sprintf("tiers[%d].price", i), "must be in %s, the currency of the product price", p.Price.Currency))
+		}
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(out, func(a, b *PriceTier) int { return cmp.Compare(a.MinQuantity, b.MinQuantity) })
+	if err := uc.repo.SavePriceTiers(ctx, priceListID, productID, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetPriceTiers retrieves the tiers of a product on a price list
+func (uc *PricingUseCase) GetPriceTiers(ctx context.Context, priceListID, productID string) ([]*PriceTier, error) {
+	slog.Info("Getting price tiers", "priceListID", priceListID, "productID", productID)
+	var errs []error
+	if priceListID == "" {
+		errs = append(errs, InvalidArgument("price_list_id", "is required"))
+	}
+	if productID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+	if _, err := uc.repo.FindPriceList(ctx, priceListID); err != nil {
+		return nil, err
+	}
+	if _, err := live(uc.repo.FindByID(ctx, productID)); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindPriceTiers(ctx, priceListID, productID)
+}
+
+// priceListOf returns the price list of a customer, nil when it has none
+func (uc *PricingUseCase) priceListOf(ctx context.Context, customerID string) (*PriceList, error) {
+	lists, err := uc.repo.FindPriceLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		if _, ok := slices.BinarySearch(l.CustomerIDs, customerID); ok {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+// QuotePrice resolves the unit and line price of an order line. The tier
+// with the highest minimum quantity not above the ordered quantity applies,
+// without one the product's list price does.
+func (uc *PricingUseCase) QuotePrice(ctx context.Context, req QuoteRequest) (*PriceQuote, error) {
+	slog.Info("Quoting price", "productID", req.ProductID, "priceListID", req.PriceListID, "customerID", req.CustomerID, "quantity", req.Quantity)
+	var errs []error
+	if req.ProductID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if req.PriceListID != "" && req.CustomerID != "" {
+		errs = append(errs, InvalidArgument("customer_id", "cannot be combined with price_list_id"))
+	}
+	if req.Quantity < 1 {
+		errs = append(errs, InvalidArgument("quantity", "must be at least 1"))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+
+	product, err := live(uc.repo.FindByID(ctx, req.ProductID))
+	if err != nil {
+		return nil, err
+	}
+	var list *PriceList
+	switch {
+	case req.PriceListID != "":
+		if list, err = uc.repo.FindPriceList(ctx, req.PriceListID); err != nil {
+			return nil, err
+		}
+	case req.CustomerID != "":
+		if list, err = uc.priceListOf(ctx, req.CustomerID); err != nil {
+			return nil, err
+		}
+	}
+
+	q := &PriceQuote{
+		ProductID: product.ID,
+		Quantity:  req.Quantity,
+		UnitPrice: product.Price,
+		Rule:      PriceRuleListPrice,
+	}
+	var tiers []*PriceTier
+	if list != nil {
+		q.PriceListID = list.ID
+		if tiers, err = uc.repo.FindPriceTiers(ctx, list.ID, product.ID); err != nil {
+			return nil, err
+		}
+	}
+	var tier *PriceTier
+	for _, t := range tiers {
+		if t.MinQuantity <= req.Quantity {
+			tier = t
+		}
+	}
+
+	switch {
+	case tier != nil && tier.MinQuantity == 1:
+		q.UnitPrice, q.Rule, q.MinQuantity = tier.Price, PriceRuleOverride, 1
+		q.Explanation = fmt.Sprintf("contract price of price list %q", list.Name)
+	case tier != nil:
+		q.UnitPrice, q.Rule, q.MinQuantity = tier.Price, PriceRuleTier, tier.MinQuantity
+		q.Explanation = fmt.Sprintf("volume price of price list %q from %d units", list.Name, tier.MinQuantity)
+	case len(tiers) > 0:
+		q.Explanation = fmt.Sprintf("list price, price list %q has no tier below %d units", list.Name, tiers[0].MinQuantity)
+	case list != nil:
+		q.Explanation = fmt.Sprintf("list price, price list %q does not price the product", list.Name)
+	case req.CustomerID != "":
+		q.Explanation = fmt.Sprintf("list price, customer %q has no price list", req.CustomerID)
+	default:
+		q.Explanation = "list price"
+	}
+
+	if q.UnitPrice.Amount > math.MaxInt64/int64(req.Quantity) {
+		return nil, InvalidArgument("quantity", "makes the line price overflow")
+	}
+	q.LinePrice = Money{Amount: q.UnitPrice.Amount * int64(req.Quantity), Currency: q.UnitPrice.Currency}
+	return q, nil
+}
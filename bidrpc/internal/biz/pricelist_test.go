@@ -0,0 +1,134 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPricingUseCase_PriceLists(t *testing.T) {
+	uc := NewPricingUseCase(newMockProductRepo())
+	ctx := context.Background()
+
+	hotels, err := uc.CreatePriceList(ctx, " Hotels ", []string{"ritz", " savoy", "ritz", ""})
+	if err != nil {
+		t.Fatalf("CreatePriceList failed: %v", err)
+	}
+	if hotels.Name != "Hotels" || len(hotels.CustomerIDs) != 2 || hotels.CustomerIDs[0] != "ritz" || hotels.CustomerIDs[1] != "savoy" {
+		t.Errorf("expected a trimmed name and sorted unique customers, got %+v", hotels)
+	}
+	if _, err := uc.CreatePriceList(ctx, "Schools", []string{"savoy"}); !hasViolation(err, "customer_ids") {
+		t.Errorf("a customer should be on one price list only, got %v", err)
+	}
+	if _, err := uc.CreatePriceList(ctx, "", nil); !hasViolation(err, "name") {
+		t.Errorf("expected a name violation, got %v", err)
+	}
+
+	updated, err := uc.UpdatePriceList(ctx, hotels.ID, "Hotels 2025", []string{"savoy", "claridges"})
+	if err != nil || updated.Name != "Hotels 2025" || len(updated.CustomerIDs) != 2 || updated.CreatedAt != hotels.CreatedAt {
+		t.Errorf("UpdatePriceList should rename and replace the customers, got %+v, %v", updated, err)
+	}
+	if _, err := uc.GetPriceList(ctx, "missing"); !errors.Is(err, ErrPriceListNotFound) || KindOf(err) != KindNotFound {
+		t.Errorf("expected ErrPriceListNotFound, got %v", err)
+	}
+	if all, err := uc.ListPriceLists(ctx); err != nil || len(all) != 1 {
+		t.Errorf("expected 1 price list, got %+v, %v", all, err)
+	}
+}
+
+func TestPricingUseCase_QuotePrice(t *testing.T) {
+	repo := newMockProductRepo()
+	products := NewProductUseCase(repo, nil)
+	uc := NewPricingUseCase(repo)
+	ctx := context.Background()
+
+	p, _ := products.CreateProduct(ctx, ProductInput{Name: "brie", Price: usd(500)})
+	hotels, _ := uc.CreatePriceList(ctx, "Hotels", []string{"ritz"})
+	schools, _ := uc.CreatePriceList(ctx, "Schools", nil)
+
+	tiers, err := uc.SetPriceTiers(ctx, hotels.ID, p.ID, []*PriceTier{
+		{MinQuantity: 100, Price: usd(380)},
+		{MinQuantity: 1, Price: usd(450)},
+		{MinQuantity: 20, Price: usd(420)},
+	})
+	if err != nil {
+		t.Fatalf("SetPriceTiers failed: %v", err)
+	}
+	if len(tiers) != 3 || tiers[0].MinQuantity != 1 || tiers[2].MinQuantity != 100 || tiers[0].PriceListID != hotels.ID {
+		t.Errorf("tiers should be sorted by minimum quantity, got %+v", tiers)
+	}
+	uc.SetPriceTiers(ctx, schools.ID, p.ID, []*PriceTier{{MinQuantity: 50, Price: usd(400)}})
+
+	tests := []struct {
+		req  QuoteRequest
+		unit int64
+		rule PriceRule
+		min  int32
+	}{
+		{QuoteRequest{ProductID: p.ID, Quantity: 3}, 500, PriceRuleListPrice, 0},
+		{QuoteRequest{ProductID: p.ID, PriceListID: hotels.ID, Quantity: 3}, 450, PriceRuleOverride, 1},
+		{QuoteRequest{ProductID: p.ID, PriceListID: hotels.ID, Quantity: 20}, 420, PriceRuleTier, 20},
+		{QuoteRequest{ProductID: p.ID, CustomerID: "ritz", Quantity: 250}, 380, PriceRuleTier, 100},
+		{QuoteRequest{ProductID: p.ID, CustomerID: "unknown", Quantity: 250}, 500, PriceRuleListPrice, 0},
+		{QuoteRequest{ProductID: p.ID, PriceListID: schools.ID, Quantity: 10}, 500, PriceRuleListPrice, 0},
+		{QuoteRequest{ProductID: p.ID, PriceListID: schools.ID, Quantity: 50}, 400, PriceRuleTier, 50},
+	}
+	for _, tt := range tests {
+		q, err := uc.QuotePrice(ctx, tt.req)
+		if err != nil {
+			t.Fatalf("QuotePrice(%+v) failed: %v", tt.req, err)
+		}
+		if q.UnitPrice != usd(tt.unit) || q.LinePrice != usd(tt.unit*int64(tt.req.Quantity)) || q.Rule != tt.rule || q.MinQuantity != tt.min || q.Explanation == "" {
+			t.Errorf("QuotePrice(%+v) = %+v, want unit %d by %s from %d", tt.req, q, tt.unit, tt.rule, tt.min)
+		}
+	}
+
+	if _, err := uc.QuotePrice(ctx, QuoteRequest{ProductID: p.ID, PriceListID: "missing", Quantity: 1}); !errors.Is(err, ErrPriceListNotFound) {
+		t.Errorf("expected ErrPriceListNotFound, got %v", err)
+	}
+	if _, err := uc.QuotePrice(ctx, QuoteRequest{ProductID: p.ID, PriceListID: hotels.ID, CustomerID: "ritz"}); !hasViolation(err, "customer_id") || !hasViolation(err, "quantity") {
+		t.Errorf("expected customer_id and quantity violations, got %v", err)
+	}
+
+	// no tiers take the product off the list
+	if _, err := uc.SetPriceTiers(ctx, hotels.ID, p.ID, nil); err != nil {
+		t.Fatalf("SetPriceTiers failed: %v", err)
+	}
+	if q, _ := uc.QuotePrice(ctx, QuoteRequest{ProductID: p.ID, PriceListID: hotels.ID, Quantity: 3}); q.Rule != PriceRuleListPrice {
+		t.Errorf("expected the list price once the tiers are removed, got %+v", q)
+	}
+}
+
+func TestPricingUseCase_SetPriceTiers_Validation(t *testing.T) {
+	repo := newMockProductRepo()
+	uc := NewPricingUseCase(repo)
+	ctx := context.Background()
+	p, _ := NewProductUseCase(repo, nil).CreateProduct(ctx, ProductInput{Name: "brie", Price: usd(500)})
+	l, _ := uc.CreatePriceList(ctx, "Hotels", nil)
+
+	_, err := uc.SetPriceTiers(ctx, l.ID, p.ID, []*PriceTier{
+		{MinQuantity: 0, Price: usd(100)},
+		{MinQuantity: 5, Price: usd(-1)},
+		{MinQuantity: 5, Price: usd(90)},
+	})
+	for _, field := range []string{"tiers[0].min_quantity", "tiers[1].price", "tiers[2].min_quantity"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+
+	// the product is priced in USD, a quote must not come out in euros
+	_, err = uc.SetPriceTiers(ctx, l.ID, p.ID, []*PriceTier{
+		{MinQuantity: 1, Price: usd(450)},
+		{MinQuantity: 10, Price: Money{Amount: 400, Currency: "EUR"}},
+	})
+	if v := FieldViolations(err); KindOf(err) != KindInvalidArgument || len(v) != 1 || v[0].Field != "tiers[1].price" {
+		t.Errorf("expected a tiers[1].price violation, got %v", err)
+	}
+	if tiers, _ := uc.GetPriceTiers(ctx, l.ID, p.ID); len(tiers) != 0 {
+		t.Errorf("a rejected update should store no tiers, got %+v", tiers)
+	}
+	if _, err := uc.SetPriceTiers(ctx, "missing", p.ID, nil); !errors.Is(err, ErrPriceListNotFound) {
+		t.Errorf("expected ErrPriceListNotFound, got %v", err)
+	}
+}
@@ -1,154 +1,665 @@
 package biz
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-var (
-	ErrProductNotFound = errors.New("product not found")
-	ErrInvalidInput    = errors.New("invalid input")
-)
+// updateRetries bounds how often an update without an expected version is
+// retried after losing a race with a concurrent writer
+const updateRetries = 3
 
 // Product represents a product in the business domain
 type Product struct {
-	ID          string
+	ID string
+	// SKU is the short code of the product on pick lists and in the ERP,
+	// unique and fixed at creation. Products created before SKUs existed
+	// have none.
+	SKU         string
 	Name        string
 	Description string
-	Price       float64
-	Quantity    int32
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Price       Money
+	// Quantity is the stock on hand across all warehouses, the balance of
+	// the product's ledger
+	Quantity int32
+	// Reserved is the part of Quantity held by open reservations
+	Reserved int32
+	// Stock holds the levels by warehouse ID, Quantity and Reserved are
+	// their totals. Warehouses without stock are left out.
+	Stock map[string]StockLevel
+	// Batches holds the stock tracked by lot, by warehouse in FEFO order
+	Batches []Batch
+	// CategoryID is the category the product is filed under, empty when it
+	// is uncategorized
+	CategoryID string
+	// ParentID is the product this one is a variant of, empty for a
+	// standalone product or a parent. Variants share the category of their
+	// parent and have their own price and stock.
+	ParentID string
+	// Attributes are the values of the attributes the category defines
+	Attributes Attributes
+	// Allergens, Nutrition and DietaryTags are the food labelling of the
+	// product, DietaryTags are sorted
+	Allergens   Allergens
+	Nutrition   Nutrition
+	DietaryTags []DietaryTag
+	// Units is how the stock is counted, Quantity and Reserved are in its
+	// base unit
+	Units UnitsOfMeasure
+	// Barcodes are the GTINs of the product and its packs ordered by GTIN,
+	// a GTIN is on one product of the catalog only
+	Barcodes []Barcode
+	// Version starts at 1 and increases by one with every update
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// DeletedAt is when the product was moved to the trash, zero while it
+	// is live. A deleted product keeps its SKU, barcodes, ledger, supplier
+	// links and price tiers until it is purged.
+	DeletedAt time.Time
+}
+
+// Deleted reports whether the product is in the trash
+func (p *Product) Deleted() bool {
+	return !p.DeletedAt.IsZero()
+}
+
+// Available returns the stock on hand that is not reserved
+func (p *Product) Available() int32 {
+	return p.Quantity - p.Reserved
+}
+
+// ProductPatch lists the fields an update changes, nil fields are left as
+// they are. An empty Description clears it, an empty CategoryID leaves the
+// product uncategorized. Units replaces the base unit and all conversions,
+// Attributes, Allergens, DietaryTags and Barcodes replace all attributes,
+// declarations, tags and barcodes, and a zero Nutrition clears the nutrition
+// facts.
+type ProductPatch struct {
+	Name        *string
+	Description *string
+	Price       *Money
+	Quantity    *int32
+	CategoryID  *string
+	Units       *UnitsOfMeasure
+	Attributes  *Attributes
+	Allergens   *Allergens
+	Nutrition   *Nutrition
+	DietaryTags *[]DietaryTag
+	Barcodes    *[]Barcode
+}
+
+// Validate checks the fields that are set, reporting every invalid one
+func (p ProductPatch) Validate() error {
+	var errs []error
+	if p.Name != nil && *p.Name == "" {
+		errs = append(errs, InvalidArgument("name", "is required"))
+	}
+	if p.Price != nil {
+		errs = append(errs, p.Price.validate("price"))
+	}
+	if p.Quantity != nil && *p.Quantity < 0 {
+		errs = append(errs, InvalidArgument("quantity", "must not be negative"))
+	}
+	if p.Units != nil {
+		errs = append(errs, p.Units.validate("units"))
+	}
+	if p.Allergens != nil {
+		errs = append(errs, p.Allergens.validate("allergens"))
+	}
+	if p.Nutrition != nil {
+		errs = append(errs, p.Nutrition.validate("nutrition"))
+	}
+	if p.DietaryTags != nil {
+		errs = append(errs, validateDietaryTags("dietary_tags", *p.DietaryTags))
+	}
+	if p.Barcodes != nil {
+		errs = append(errs, validateBarcodes("barcodes", *p.Barcodes))
+	}
+	return InvalidArguments(errs...)
+}
+
+// LogValue logs the set fields only
+func (p ProductPatch) LogValue() slog.Value {
+	var attrs []slog.Attr
+	if p.Name != nil {
+		attrs = append(attrs, slog.String("name", *p.Name))
+	}
+	if p.Description != nil {
+		attrs = append(attrs, slog.String("description", *p.Description))
+	}
+	if p.Price != nil {
+		attrs = append(attrs, slog.String("price", p.Price.String()))
+	}
+	if p.Quantity != nil {
+		attrs = append(attrs, slog.Int("quantity", int(*p.Quantity)))
+	}
+	if p.CategoryID != nil {
+		attrs = append(attrs, slog.String("category_id", *p.CategoryID))
+	}
+	if p.Units != nil {
+		attrs = append(attrs, slog.String("units", strings.Join(p.Units.codes(), ",")))
+	}
+	if p.Attributes != nil {
+		attrs = append(attrs, slog.Any("attributes", map[string]string(*p.Attributes)))
+	}
+	if p.Allergens != nil {
+		attrs = append(attrs, slog.Any("allergens", *p.Allergens))
+	}
+	if p.Nutrition != nil {
+		attrs = append(attrs, slog.Any("nutrition", *p.Nutrition))
+	}
+	if p.DietaryTags != nil {
+		attrs = append(attrs, slog.String("dietary_tags", joinCodes(*p.DietaryTags)))
+	}
+	if p.Barcodes != nil {
+		attrs = append(attrs, slog.Any("barcodes", *p.Barcodes))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// apply copies the set fields onto product
+func (p ProductPatch) apply(product *Product) {
+	if p.Name != nil {
+		product.Name = *p.Name
+	}
+	if p.Description != nil {
+		product.Description = *p.Description
+	}
+	if p.Price != nil {
+		product.Price = *p.Price
+	}
+	if p.Quantity != nil {
+		product.Quantity = *p.Quantity
+	}
+	if p.CategoryID != nil {
+		product.CategoryID = *p.CategoryID
+	}
+	if p.Units != nil {
+		product.Units = p.Units.normalize()
+	}
+	if p.Attributes != nil {
+		product.Attributes = *p.Attributes
+	}
+	if p.Allergens != nil {
+		product.Allergens = p.Allergens.normalize()
+	}
+	if p.Nutrition != nil {
+		product.Nutrition = *p.Nutrition
+	}
+	if p.DietaryTags != nil {
+		product.DietaryTags = normalizeDietaryTags(*p.DietaryTags)
+	}
+	if p.Barcodes != nil {
+		product.Barcodes = normalizeBarcodes(*p.Barcodes)
+	}
 }
 
 // ProductRepo defines the interface for product data access
 type ProductRepo interface {
 	Save(ctx context.Context, product *Product) error
 	FindByID(ctx context.Context, id string) (*Product, error)
-	FindAll(ctx context.Context, page, pageSize int32, nameFilter string) ([]*Product, int32, error)
+	FindAll(ctx context.Context, q ListQuery) ([]*Product, int32, error)
+	// Update stores product if the stored version is product.Version-1,
+	// otherwise it fails with ErrVersionConflict
 	Update(ctx context.Context, product *Product) error
-	Delete(ctx context.Context, id string) error
+	// Delete permanently removes the product if its version is version, 0
+	// deletes whatever version is stored. Deleting a product to the trash is
+	// an Update setting DeletedAt.
+	Delete(ctx context.Context, id string, version int64) error
+	StockLedger
+	ReservationRepo
+	WarehouseRepo
+	CategoryRepo
+	// FindBySKU finds a product by SKU. Saving a product with the SKU of
+	// another one fails with ErrSKUInUse.
+	FindBySKU(ctx context.Context, sku string) (*Product, error)
+	// NextSKUNumber increments the SKU counter of prefix and returns it, the
+	// first number of a prefix is 1
+	NextSKUNumber(ctx context.Context, prefix string) (int64, error)
+	// FindByBarcode finds the product carrying a GTIN-14. Saving a product
+	// with the barcode of another one fails with ErrBarcodeInUse.
+	FindByBarcode(ctx context.Context, gtin string) (*Product, error)
+	// Delete also removes the product's supplier links and price tiers
+	SupplierRepo
+	PriceListRepo
+	// Delete keeps the product's auctions, the reservations holding their
+	// lots go with the product
+	AuctionRepo
+	BatchRepo
 }
 
 // ProductUseCase handles product business logic
 type ProductUseCase struct {
-	repo ProductRepo
-	mu   sync.RWMutex
+	repo  ProductRepo
+	index ProductIndex
+	mu    sync.RWMutex
+	// categoryMu serializes category writes, which check the whole tree
+	categoryMu sync.Mutex
+	skuFormat  SKUFormat
 }
 
-// NewProductUseCase creates a new product use case
-func NewProductUseCase(repo ProductRepo) *ProductUseCase {
+// NewProductUseCase creates a new product use case.
+// index may be nil, which disables SearchProducts.
+func NewProductUseCase(repo ProductRepo, index ProductIndex) *ProductUseCase {
 	return &ProductUseCase{
-		repo: repo,
+		repo:      repo,
+		index:     index,
+		skuFormat: DefaultSKUFormat,
 	}
 }
 
+// ProductInput describes a new product. An empty CategoryID leaves it
+// uncategorized. Quantity is in the base unit of Units, zero units count the
+// product in DefaultBaseUnit. A ParentID makes the product a variant of
+// that product, filed under its category. An empty SKU is generated in the
+// format of the use case.
+type ProductInput struct {
+	SKU         string
+	Name        string
+	Description string
+	Price       Money
+	Quantity    int32
+	CategoryID  string
+	Units       UnitsOfMeasure
+	ParentID    string
+	Attributes  Attributes
+	Allergens   Allergens
+	Nutrition   Nutrition
+	DietaryTags []DietaryTag
+	Barcodes    []Barcode
+}
+
 // CreateProduct creates a new product
-func (uc *ProductUseCase) CreateProduct(ctx context.Context, name, description string, price float64, quantity int32) (*Product, error) {
-	slog.Info("Creating product", "name", name, "description", description, "price", price, "quantity", quantity)
-	if name == "" {
-		return nil, ErrInvalidInput
+func (uc *ProductUseCase) CreateProduct(ctx context.Context, in ProductInput) (*Product, error) {
+	slog.Info("Creating product", "name", in.Name, "description", in.Description, "price", in.Price, "quantity", in.Quantity,
+		"sku", in.SKU, "categoryID", in.CategoryID, "units", strings.Join(in.Units.codes(), ","), "parentID", in.ParentID, "attributes", map[string]string(in.Attributes))
+	patch := ProductPatch{Name: &in.Name, Price: &in.Price, Quantity: &in.Quantity, Units: &in.Units,
+		Allergens: &in.Allergens, Nutrition: &in.Nutrition, DietaryTags: &in.DietaryTags, Barcodes: &in.Barcodes}
+	if err := patch.Validate(); err != nil {
+		return nil, err
 	}
-	if price < 0 {
-		return nil, ErrInvalidInput
+	var sku string
+	if in.SKU != "" {
+		var err error
+		if sku, err = uc.checkSKU(ctx, in.SKU); err != nil {
+			return nil, err
+		}
 	}
-	if quantity < 0 {
-		return nil, ErrInvalidInput
+	categoryID := in.CategoryID
+	if in.ParentID != "" {
+		parent, err := uc.checkParent(ctx, in.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if categoryID != "" && categoryID != parent.CategoryID {
+			return nil, InvalidArgument("category_id", "must be the category of the parent product or empty")
+		}
+		categoryID = parent.CategoryID
+	} else if categoryID != "" {
+		if err := uc.checkCategory(ctx, "category_id", categoryID); err != nil {
+			return nil, err
+		}
+	}
+	attrs, err := uc.checkAttributes(ctx, categoryID, in.Attributes)
+	if err != nil {
+		return nil, err
 	}
 
 	product := &Product{
 		ID:          uuid.New().String(),
-		Name:        name,
-		Description: description,
-		Price:       price,
-		Quantity:    quantity,
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       in.Price,
+		CategoryID:  categoryID,
+		ParentID:    in.ParentID,
+		Attributes:  attrs,
+		Units:       in.Units.normalize(),
+		Allergens:   in.Allergens.normalize(),
+		Nutrition:   in.Nutrition,
+		DietaryTags: normalizeDietaryTags(in.DietaryTags),
+		Barcodes:    normalizeBarcodes(in.Barcodes),
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
+	if err := InvalidArguments(product.checkDietary(), product.checkBarcodes()); err != nil {
+		return nil, err
+	}
+	if err := uc.checkBarcodesFree(ctx, product.ID, product.Barcodes); err != nil {
+		return nil, err
+	}
+	// generated last, a product that fails validation uses up no number
+	if sku == "" {
+		if sku, err = uc.newSKU(ctx, categoryID); err != nil {
+			return nil, err
+		}
+	}
+	product.SKU = sku
 
-	if err := uc.repo.Save(ctx, product); err != nil {
+	// stock on hand at creation is the opening entry of the ledger, received
+	// in the default warehouse
+	if in.Quantity > 0 {
+		m, err := newMovement(product, DefaultWarehouseID, MovementReceipt, in.Quantity, Lot{}, "opening stock", systemActor)
+		if err != nil {
+			return nil, err
+		}
+		m.Version, m.CreatedAt = product.Version, product.CreatedAt
+		if err := uc.repo.RecordMovement(ctx, product, m); err != nil {
+			return nil, err
+		}
+	} else if err := uc.repo.Save(ctx, product); err != nil {
 		return nil, err
 	}
+	if uc.index != nil {
+		uc.index.Index(product)
+	}
 
 	return product, nil
 }
 
-// GetProduct retrieves a product by ID
+// GetProduct retrieves a product by ID or, failing that, by SKU
 func (uc *ProductUseCase) GetProduct(ctx context.Context, id string) (*Product, error) {
 	slog.Info("Getting product", "id", id)
 	if id == "" {
-		return nil, ErrInvalidInput
+		return nil, InvalidArgument("id", "is required")
 	}
 
-	return uc.repo.FindByID(ctx, id)
+	p, err := live(uc.repo.FindByID(ctx, id))
+	if sku := normalizeSKU(id); errors.Is(err, ErrProductNotFound) && validSKU(sku) {
+		return live(uc.repo.FindBySKU(ctx, sku))
+	}
+	return p, err
 }
 
-// ListProducts retrieves all products with pagination and filtering
-func (uc *ProductUseCase) ListProducts(ctx context.Context, page, pageSize int32, nameFilter string) ([]*Product, int32, error) {
-	slog.Info("Listing products", "page", page, "pageSize", pageSize, "nameFilter", nameFilter)
-	if page <= 0 {
-		page = 1
+// ListProducts retrieves all products with pagination, filtering and sorting.
+// Pages are addressed either by number or by the token returned with the
+// previous page.
+func (uc *ProductUseCase) ListProducts(ctx context.Context, opts ListOptions) (*ProductPage, error) {
+	slog.Info("Listing products", "page", opts.Page, "pageSize", opts.PageSize, "pageToken", opts.PageToken,
+		"nameFilter", opts.NameFilter, "filter", opts.Filter, "warehouse", opts.Warehouse, "category", opts.Category,
+		"sortBy", opts.SortBy, "sortOrder", opts.SortOrder)
+	if opts.Page <= 0 {
+		opts.Page = 1
 	}
-	if pageSize <= 0 {
-		pageSize = 10
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10
 	}
-	if pageSize > 100 {
-		pageSize = 100
+	if opts.PageSize > 100 {
+		opts.PageSize = 100
 	}
 
-	return uc.repo.FindAll(ctx, page, pageSize, nameFilter)
-}
-
-// UpdateProduct updates an existing product
-func (uc *ProductUseCase) UpdateProduct(ctx context.Context, id, name, description string, price float64, quantity int32) (*Product, error) {
-	slog.Info("Updating product", "id", id, "name", name, "description", description, "price", price, "quantity", quantity)
-	if id == "" {
-		return nil, ErrInvalidInput
+	sortBy, err := ParseSortField(opts.SortBy)
+	if err != nil {
+		return nil, err
 	}
-
-	existing, err := uc.repo.FindByID(ctx, id)
+	desc, err := ParseSortOrder(opts.SortOrder)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := ParseFilter(opts.Filter)
 	if err != nil {
 		return nil, err
 	}
+	var categories []string
+	if opts.Category != "" {
+		tree, err := uc.categoryTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tree.byID[opts.Category]; !ok {
+			return nil, InvalidArgument("category_id", "unknown category %q", opts.Category)
+		}
+		categories = tree.subtree(opts.Category)
+	}
 
-	if name != "" {
-		existing.Name = name
+	q := ListQuery{
+		Offset:     (opts.Page - 1) * opts.PageSize,
+		NameFilter: opts.NameFilter,
+		Warehouse:  opts.Warehouse,
+		Categories: categories,
+		Filter:     filter,
+		SortBy:     sortBy,
+		Desc:       desc,
+		Deleted:    opts.Deleted,
+	}
+	scope := scopeOf(opts.NameFilter, strings.TrimSpace(opts.Filter), opts.Warehouse, opts.Category)
+	if opts.Deleted {
+		scope = scopeOf(scope, "deleted")
 	}
-	if description != "" {
-		existing.Description = description
+
+	if opts.PageToken != "" {
+		after, err := DecodeCursor(opts.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		// a token keeps the order it was issued with, an explicit
+		// conflicting order or a changed filter is a caller error
+		if (opts.SortBy != "" && after.SortBy != sortBy) || (opts.SortOrder != "" && after.Desc != desc) || after.Scope != scope {
+			return nil, InvalidArgument("page_token", "was issued for a different filter or order")
+		}
+		q.SortBy, q.Desc, q.After = after.SortBy, after.Desc, after
 	}
-	if price >= 0 {
-		existing.Price = price
+
+	// fetch one extra product to learn whether another page follows
+	q.Limit = opts.PageSize + 1
+	products, total, err := uc.repo.FindAll(ctx, q)
+	if err != nil {
+		return nil, err
 	}
-	if quantity >= 0 {
-		existing.Quantity = quantity
+
+	page := &ProductPage{Products: products, Total: total}
+	if int32(len(products)) > opts.PageSize {
+		page.Products = products[:opts.PageSize]
+		next := NewCursor(page.Products[opts.PageSize-1], q)
+		next.Scope = scope
+		page.NextPageToken = next.Encode()
 	}
-	existing.UpdatedAt = time.Now()
+	return page, nil
+}
 
-	if err := uc.repo.Update(ctx, existing); err != nil {
+// UpdateProduct applies patch to an existing product, a changed quantity is
+// booked as a stock adjustment of the only warehouse holding stock. The
+// quantity of a product stocked in several warehouses cannot be set.
+// A non-zero expectedVersion makes the update fail with ErrVersionConflict
+// unless the stored product still has that version.
+func (uc *ProductUseCase) UpdateProduct(ctx context.Context, id string, patch ProductPatch, expectedVersion int64) (*Product, error) {
+	slog.Info("Updating product", "id", id, "patch", patch, "expectedVersion", expectedVersion)
+	if err := validateTarget(id, expectedVersion); err != nil {
+		return nil, err
+	}
+	if err := patch.Validate(); err != nil {
 		return nil, err
 	}
+	if patch.CategoryID != nil && *patch.CategoryID != "" {
+		if err := uc.checkCategory(ctx, "category_id", *patch.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+	if patch.Barcodes != nil {
+		if err := uc.checkBarcodesFree(ctx, id, normalizeBarcodes(*patch.Barcodes)); err != nil {
+			return nil, err
+		}
+	}
 
-	return existing, nil
+	return uc.write(ctx, id, expectedVersion, func(p *Product) (productWrite, error) {
+		// stock on hand is counted in the base unit, changing it would
+		// change the amount of stock
+		if patch.Units != nil && patch.Units.BaseUnit() != p.Units.BaseUnit() && (p.Quantity != 0 || p.Reserved != 0) {
+			return productWrite{}, InvalidArgument("units.base_unit", "cannot change while the product holds %d %s", p.Quantity, p.Units.BaseUnit())
+		}
+		if err := uc.checkCategoryChange(ctx, p, patch.CategoryID); err != nil {
+			return productWrite{}, err
+		}
+		old, category := p.Quantity, p.CategoryID
+		patch.apply(p)
+		if patch.Attributes != nil || p.CategoryID != category {
+			attrs, err := uc.checkAttributes(ctx, p.CategoryID, p.Attributes)
+			if err != nil {
+				return productWrite{}, err
+			}
+			p.Attributes = attrs
+		}
+		if patch.Allergens != nil || patch.DietaryTags != nil {
+			if err := p.checkDietary(); err != nil {
+				return productWrite{}, err
+			}
+		}
+		if patch.Barcodes != nil || patch.Units != nil {
+			if err := p.checkBarcodes(); err != nil {
+				return productWrite{}, err
+			}
+		}
+		if p.Quantity == old {
+			return productWrite{}, nil
+		}
+		// the quantity is owned by the ledger, setting it books the difference
+		quantity := p.Quantity
+		p.Quantity = old
+		levels := p.Levels()
+		if len(levels) > 1 {
+			return productWrite{}, InvalidArgument("quantity", "is spread over %d warehouses, record a movement per warehouse instead", len(levels))
+		}
+		warehouseID := DefaultWarehouseID
+		for id := range levels {
+			warehouseID = id
+		}
+		m, err := newMovement(p, warehouseID, MovementAdjustment, quantity-old, Lot{}, "quantity set by product update", systemActor)
+		return productWrite{movement: m}, err
+	})
 }
 
-// DeleteProduct deletes a product by ID
-func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id string) error {
-	slog.Info("Deleting product", "id", id)
-	if id == "" {
-		return ErrInvalidInput
+// productWrite is what a change stores together with the product
+type productWrite struct {
+	movement    *StockMovement
+	reservation *Reservation
+	// released are reservations closed along with the change
+	released []*Reservation
+}
+
+// write applies change to the stored product and stores the result together
+// with the movement and reservation change returns, if any. Without an
+// expected version the caller does not mind a concurrent write and change is
+// reapplied on top of it.
+func (uc *ProductUseCase) write(ctx context.Context, id string, expectedVersion int64, change func(p *Product) (productWrite, error)) (*Product, error) {
+	for attempt := 1; ; attempt++ {
+		existing, err := live(uc.repo.FindByID(ctx, id))
+		if err != nil {
+			return nil, err
+		}
+		if expectedVersion != 0 && existing.Version != expectedVersion {
+			return nil, PreconditionFailed(id, expectedVersion)
+		}
+
+		w, err := change(existing)
+		if err != nil {
+			return nil, err
+		}
+		existing.Version++
+		existing.UpdatedAt = time.Now()
+
+		if w.movement != nil {
+			w.movement.Version, w.movement.CreatedAt = existing.Version, existing.UpdatedAt
+		}
+		switch {
+		case len(w.released) > 0:
+			for _, r := range w.released {
+				r.UpdatedAt = existing.UpdatedAt
+			}
+			err = uc.repo.SaveReservations(ctx, existing, w.released)
+		case w.reservation != nil:
+			w.reservation.UpdatedAt = existing.UpdatedAt
+			err = uc.repo.SaveReservation(ctx, existing, w.reservation, w.movement)
+		case w.movement != nil:
+			err = uc.repo.RecordMovement(ctx, existing, w.movement)
+		default:
+			err = uc.repo.Update(ctx, existing)
+		}
+		if errors.Is(err, ErrVersionConflict) && expectedVersion == 0 && attempt < updateRetries {
+			continue
+		}
+		if errors.Is(err, ErrVersionConflict) && expectedVersion != 0 {
+			return nil, PreconditionFailed(id, expectedVersion)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uc.index != nil && existing.Deleted() {
+			uc.index.Remove(id)
+		} else if uc.index != nil {
+			uc.index.Index(existing)
+		}
+		return existing, nil
 	}
+}
 
-	// Check if product exists
-	_, err := uc.repo.FindByID(ctx, id)
+// DeleteProduct moves a product to the trash, where RestoreProduct brings it
+// back from until PurgeDeletedProducts removes it for good. The reservations
+// of the product are released in the same write, open auctions of the
+// product are cancelled when they close.
+// A non-zero expectedVersion makes the delete fail with ErrVersionConflict
+// unless the stored product still has that version.
+func (uc *ProductUseCase) DeleteProduct(ctx context.Context, id string, expectedVersion int64) error {
+	slog.Info("Deleting product", "id", id, "expectedVersion", expectedVersion)
+	if err := validateTarget(id, expectedVersion); err != nil {
+		return err
+	}
+
+	p, err := live(uc.repo.FindByID(ctx, id))
+	if err != nil {
+		return err
+	}
+	if expectedVersion != 0 && p.Version != expectedVersion {
+		return PreconditionFailed(id, expectedVersion)
+	}
+	// variants would be left without their parent
+	_, variants, err := uc.repo.FindAll(ctx, ListQuery{Limit: 1, Filter: variantFilter(id)})
 	if err != nil {
 		return err
 	}
+	if variants > 0 {
+		return fmt.Errorf("%w: delete its %d variants first", ErrProductHasVariants, variants)
+	}
+
+	_, err = uc.write(ctx, id, expectedVersion, func(p *Product) (productWrite, error) {
+		// read again on every attempt, the product version guards the
+		// reservations against a concurrent reserve or close
+		held, err := uc.repo.FindHeldReservations(ctx, id)
+		if err != nil {
+			return productWrite{}, err
+		}
+		now := time.Now()
+		for _, r := range held {
+			// reservations made before warehouses existed hold default stock
+			r.WarehouseID = cmp.Or(r.WarehouseID, DefaultWarehouseID)
+			p.unreserve(r)
+			r.Status = ReservationReleased
+			if !now.Before(r.ExpiresAt) {
+				r.Status = ReservationExpired
+			}
+		}
+		p.DeletedAt = now
+		return productWrite{released: held}, nil
+	})
+	return err
+}
 
-	return uc.repo.Delete(ctx, id)
+// validateTarget checks the product a write addresses
+func validateTarget(id string, expectedVersion int64) error {
+	var errs []error
+	if id == "" {
+		errs = append(errs, InvalidArgument("id", "is required"))
+	}
+	if expectedVersion < 0 {
+		errs = append(errs, InvalidArgument("expected_version", "must not be negative"))
+	}
+	return InvalidArguments(errs...)
 }
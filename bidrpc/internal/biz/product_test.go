@@ -2,15 +2,46 @@ package biz
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 type mockProductRepo struct {
-	products map[string]*Product
+	products     map[string]*Product
+	movements    map[string][]*StockMovement
+	reservations map[string]*Reservation
+	warehouses   map[string]*Warehouse
+	categories   map[string]*Category
+	suppliers    map[string]*Supplier
+	links        map[string][]*ProductSupplier
+	priceLists   map[string]*PriceList
+	// tiers are keyed by price list ID and product ID
+	tiers    map[[2]string][]*PriceTier
+	auctions map[string]*Auction
+	// bids are keyed by auction ID and bidder ID
+	bids map[[2]string]*Bid
+	// skuCounters are keyed by prefix
+	skuCounters map[string]int64
 }
 
 func newMockProductRepo() *mockProductRepo {
-	return &mockProductRepo{products: make(map[string]*Product)}
+	return &mockProductRepo{
+		products:     make(map[string]*Product),
+		movements:    make(map[string][]*StockMovement),
+		reservations: make(map[string]*Reservation),
+		warehouses:   make(map[string]*Warehouse),
+		categories:   make(map[string]*Category),
+		suppliers:    make(map[string]*Supplier),
+		links:        make(map[string][]*ProductSupplier),
+		priceLists:   make(map[string]*PriceList),
+		tiers:        make(map[[2]string][]*PriceTier),
+		auctions:     make(map[string]*Auction),
+		bids:         make(map[[2]string]*Bid),
+		skuCounters:  make(map[string]int64),
+	}
 }
 
 func (m *mockProductRepo) Save(ctx context.Context, product *Product) error {
@@ -22,38 +53,403 @@ func (m *mockProductRepo) FindByID(ctx context.Context, id string) (*Product, er
 	if !ok {
 		return nil, ErrProductNotFound
 	}
-	return p, nil
+	clone := *p
+	return &clone, nil
+}
+func (m *mockProductRepo) FindBySKU(ctx context.Context, sku string) (*Product, error) {
+	for _, p := range m.products {
+		if p.SKU == sku {
+			clone := *p
+			return &clone, nil
+		}
+	}
+	return nil, ErrProductNotFound
+}
+func (m *mockProductRepo) NextSKUNumber(ctx context.Context, prefix string) (int64, error) {
+	m.skuCounters[prefix]++
+	return m.skuCounters[prefix], nil
 }
-func (m *mockProductRepo) FindAll(ctx context.Context, page, pageSize int32, nameFilter string) ([]*Product, int32, error) {
+func (m *mockProductRepo) FindByBarcode(ctx context.Context, gtin string) (*Product, error) {
+	for _, p := range m.products {
+		if _, ok := p.Barcode(gtin); ok {
+			clone := *p
+			return &clone, nil
+		}
+	}
+	return nil, ErrProductNotFound
+}
+func (m *mockProductRepo) FindAll(ctx context.Context, q ListQuery) ([]*Product, int32, error) {
 	var out []*Product
 	for _, p := range m.products {
-		out = append(out, p)
+		if q.Match(p) {
+			out = append(out, p)
+		}
 	}
+	slices.SortFunc(out, q.Compare)
 	total := int32(len(out))
-	return out, total, nil
+	return q.Window(out), total, nil
 }
 func (m *mockProductRepo) Update(ctx context.Context, product *Product) error {
-	if _, ok := m.products[product.ID]; !ok {
+	old, ok := m.products[product.ID]
+	if !ok {
 		return ErrProductNotFound
 	}
+	if old.Version != product.Version-1 {
+		return ErrVersionConflict
+	}
 	m.products[product.ID] = product
 	return nil
 }
-func (m *mockProductRepo) Delete(ctx context.Context, id string) error {
-	if _, ok := m.products[id]; !ok {
+func (m *mockProductRepo) Delete(ctx context.Context, id string, version int64) error {
+	old, ok := m.products[id]
+	if !ok {
 		return ErrProductNotFound
 	}
+	if version != 0 && old.Version != version {
+		return ErrVersionConflict
+	}
 	delete(m.products, id)
+	delete(m.movements, id)
+	for rid, r := range m.reservations {
+		if r.ProductID == id {
+			delete(m.reservations, rid)
+		}
+	}
+	return nil
+}
+func (m *mockProductRepo) RecordMovement(ctx context.Context, product *Product, mv *StockMovement) error {
+	old, ok := m.products[product.ID]
+	if product.Version == 1 && ok {
+		return ErrVersionConflict
+	}
+	if product.Version > 1 && !ok {
+		return ErrProductNotFound
+	}
+	if product.Version > 1 && old.Version != product.Version-1 {
+		return ErrVersionConflict
+	}
+	m.products[product.ID] = product
+	m.movements[product.ID] = append(m.movements[product.ID], mv)
+	return nil
+}
+func (m *mockProductRepo) FindMovements(ctx context.Context, productID string, afterVersion int64, limit int32) ([]*StockMovement, error) {
+	var out []*StockMovement
+	for _, mv := range m.movements[productID] {
+		if mv.Version > afterVersion && int32(len(out)) < limit {
+			out = append(out, mv)
+		}
+	}
+	return out, nil
+}
+func (m *mockProductRepo) SaveReservation(ctx context.Context, product *Product, r *Reservation, mv *StockMovement) error {
+	old, ok := m.products[product.ID]
+	if !ok {
+		return ErrProductNotFound
+	}
+	if old.Version != product.Version-1 {
+		return ErrVersionConflict
+	}
+	m.products[product.ID] = product
+	if mv != nil {
+		m.movements[product.ID] = append(m.movements[product.ID], mv)
+	}
+	clone := *r
+	m.reservations[r.ID] = &clone
+	return nil
+}
+func (m *mockProductRepo) SaveReservations(ctx context.Context, product *Product, rs []*Reservation) error {
+	old, ok := m.products[product.ID]
+	if !ok {
+		return ErrProductNotFound
+	}
+	if old.Version != product.Version-1 {
+		return ErrVersionConflict
+	}
+	m.products[product.ID] = product
+	for _, r := range rs {
+		clone := *r
+		m.reservations[r.ID] = &clone
+	}
+	return nil
+}
+func (m *mockProductRepo) FindReservation(ctx context.Context, id string) (*Reservation, error) {
+	r, ok := m.reservations[id]
+	if !ok {
+		return nil, ErrReservationNotFound
+	}
+	clone := *r
+	return &clone, nil
+}
+func (m *mockProductRepo) FindExpiredReservations(ctx context.Context, now time.Time, limit int32) ([]*Reservation, error) {
+	var out []*Reservation
+	for _, r := range m.reservations {
+		if r.Status == ReservationHeld && !r.ExpiresAt.After(now) {
+			clone := *r
+			out = append(out, &clone)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Reservation) int { return a.ExpiresAt.Compare(b.ExpiresAt) })
+	if int32(len(out)) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+func (m *mockProductRepo) FindHeldReservations(ctx context.Context, productID string) ([]*Reservation, error) {
+	var out []*Reservation
+	for _, r := range m.reservations {
+		if r.Status == ReservationHeld && r.ProductID == productID {
+			clone := *r
+			out = append(out, &clone)
+		}
+	}
+	return out, nil
+}
+func (m *mockProductRepo) FindExpiringBatches(ctx context.Context, before time.Time, warehouseID string) ([]*ExpiringBatch, error) {
+	var out []*ExpiringBatch
+	for _, p := range m.products {
+		if p.Deleted() {
+			continue
+		}
+		for _, b := range p.Batches {
+			if !b.ExpiresAt.IsZero() && b.ExpiresAt.Before(before) && (warehouseID == "" || b.WarehouseID == warehouseID) {
+				out = append(out, &ExpiringBatch{ProductID: p.ID, ProductName: p.Name, Batch: b})
+			}
+		}
+	}
+	return out, nil
+}
+func (m *mockProductRepo) SaveWarehouse(ctx context.Context, w *Warehouse) error {
+	clone := *w
+	m.warehouses[w.ID] = &clone
 	return nil
 }
+func (m *mockProductRepo) FindWarehouse(ctx context.Context, id string) (*Warehouse, error) {
+	w, ok := m.warehouses[id]
+	if !ok {
+		return nil, ErrWarehouseNotFound
+	}
+	clone := *w
+	return &clone, nil
+}
+func (m *mockProductRepo) FindWarehouses(ctx context.Context) ([]*Warehouse, error) {
+	var out []*Warehouse
+	for _, w := range m.warehouses {
+		clone := *w
+		out = append(out, &clone)
+	}
+	slices.SortFunc(out, func(a, b *Warehouse) int { return strings.Compare(a.Name, b.Name) })
+	return out, nil
+}
+func (m *mockProductRepo) DeleteWarehouse(ctx context.Context, id string) error {
+	if _, ok := m.warehouses[id]; !ok {
+		return ErrWarehouseNotFound
+	}
+	delete(m.warehouses, id)
+	return nil
+}
+func (m *mockProductRepo) SaveCategory(ctx context.Context, c *Category) error {
+	clone := *c
+	m.categories[c.ID] = &clone
+	return nil
+}
+func (m *mockProductRepo) FindCategory(ctx context.Context, id string) (*Category, error) {
+	c, ok := m.categories[id]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+	clone := *c
+	return &clone, nil
+}
+func (m *mockProductRepo) FindCategories(ctx context.Context) ([]*Category, error) {
+	var out []*Category
+	for _, c := range m.categories {
+		clone := *c
+		out = append(out, &clone)
+	}
+	slices.SortFunc(out, func(a, b *Category) int { return strings.Compare(a.Name, b.Name) })
+	return out, nil
+}
+
+func (m *mockProductRepo) SaveSupplier(ctx context.Context, s *Supplier) error {
+	clone := *s
+	m.suppliers[s.ID] = &clone
+	return nil
+}
+func (m *mockProductRepo) FindSupplier(ctx context.Context, id string) (*Supplier, error) {
+	s, ok := m.suppliers[id]
+	if !ok {
+		return nil, ErrSupplierNotFound
+	}
+	clone := *s
+	return &clone, nil
+}
+func (m *mockProductRepo) FindSuppliers(ctx context.Context) ([]*Supplier, error) {
+	var out []*Supplier
+	for _, s := range m.suppliers {
+		clone := *s
+		out = append(out, &clone)
+	}
+	slices.SortFunc(out, func(a, b *Supplier) int { return strings.Compare(a.Name, b.Name) })
+	return out, nil
+}
+func (m *mockProductRepo) DeleteSupplier(ctx context.Context, id string) error {
+	if _, ok := m.suppliers[id]; !ok {
+		return ErrSupplierNotFound
+	}
+	for _, links := range m.links {
+		for _, l := range links {
+			if l.SupplierID == id {
+				return ErrSupplierInUse
+			}
+		}
+	}
+	delete(m.suppliers, id)
+	return nil
+}
+func (m *mockProductRepo) SaveProductSupplier(ctx context.Context, l *ProductSupplier) error {
+	if p, ok := m.products[l.ProductID]; !ok || p.Deleted() {
+		return ErrProductNotFound
+	}
+	if _, ok := m.suppliers[l.SupplierID]; !ok {
+		return ErrSupplierNotFound
+	}
+	links := slices.DeleteFunc(m.links[l.ProductID], func(old *ProductSupplier) bool { return old.SupplierID == l.SupplierID })
+	for _, old := range links {
+		old.Preferred = old.Preferred && !l.Preferred
+	}
+	clone := *l
+	m.links[l.ProductID] = append(links, &clone)
+	return nil
+}
+func (m *mockProductRepo) FindProductSuppliers(ctx context.Context, productID string) ([]*ProductSupplier, error) {
+	if p, ok := m.products[productID]; !ok || p.Deleted() {
+		return nil, ErrProductNotFound
+	}
+	out := []*ProductSupplier{}
+	for _, l := range m.links[productID] {
+		clone := *l
+		out = append(out, &clone)
+	}
+	slices.SortFunc(out, func(a, b *ProductSupplier) int {
+		if a.Preferred != b.Preferred {
+			if a.Preferred {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.SupplierID, b.SupplierID)
+	})
+	return out, nil
+}
+func (m *mockProductRepo) DeleteProductSupplier(ctx context.Context, productID, supplierID string) error {
+	links := m.links[productID]
+	i := slices.IndexFunc(links, func(l *ProductSupplier) bool { return l.SupplierID == supplierID })
+	if i < 0 {
+		return ErrProductSupplierNotFound
+	}
+	m.links[productID] = slices.Delete(links, i, i+1)
+	return nil
+}
+
+func (m *mockProductRepo) SavePriceList(ctx context.Context, l *PriceList) error {
+	clone := *l
+	m.priceLists[l.ID] = &clone
+	return nil
+}
+func (m *mockProductRepo) FindPriceList(ctx context.Context, id string) (*PriceList, error) {
+	l, ok := m.priceLists[id]
+	if !ok {
+		return nil, ErrPriceListNotFound
+	}
+	clone := *l
+	return &clone, nil
+}
+func (m *mockProductRepo) FindPriceLists(ctx context.Context) ([]*PriceList, error) {
+	var out []*PriceList
+	for _, l := range m.priceLists {
+		clone := *l
+		out = append(out, &clone)
+	}
+	slices.SortFunc(out, func(a, b *PriceList) int { return strings.Compare(a.Name, b.Name) })
+	return out, nil
+}
+func (m *mockProductRepo) SavePriceTiers(ctx context.Context, priceListID, productID string, tiers []*PriceTier) error {
+	if _, ok := m.priceLists[priceListID]; !ok {
+		return ErrPriceListNotFound
+	}
+	if _, ok := m.products[productID]; !ok {
+		return ErrProductNotFound
+	}
+	m.tiers[[2]string{priceListID, productID}] = tiers
+	return nil
+}
+func (m *mockProductRepo) FindPriceTiers(ctx context.Context, priceListID, productID string) ([]*PriceTier, error) {
+	return m.tiers[[2]string{priceListID, productID}], nil
+}
+
+func (m *mockProductRepo) SaveAuction(ctx context.Context, a *Auction) error {
+	clone := *a
+	m.auctions[a.ID] = &clone
+	return nil
+}
+func (m *mockProductRepo) FindAuction(ctx context.Context, id string) (*Auction, error) {
+	a, ok := m.auctions[id]
+	if !ok {
+		return nil, ErrAuctionNotFound
+	}
+	clone := *a
+	return &clone, nil
+}
+func (m *mockProductRepo) FindAuctions(ctx context.Context) ([]*Auction, error) {
+	var out []*Auction
+	for _, a := range m.auctions {
+		clone := *a
+		out = append(out, &clone)
+	}
+	slices.SortFunc(out, func(a, b *Auction) int { return a.ClosesAt.Compare(b.ClosesAt) })
+	return out, nil
+}
+func (m *mockProductRepo) FindDueAuctions(ctx context.Context, now time.Time, limit int32) ([]*Auction, error) {
+	var out []*Auction
+	for _, a := range m.auctions {
+		if a.Status == AuctionOpen && !a.ClosesAt.After(now) && int32(len(out)) < limit {
+			clone := *a
+			out = append(out, &clone)
+		}
+	}
+	return out, nil
+}
+func (m *mockProductRepo) SaveBid(ctx context.Context, b *Bid) error {
+	if _, ok := m.auctions[b.AuctionID]; !ok {
+		return ErrAuctionNotFound
+	}
+	clone := *b
+	m.bids[[2]string{b.AuctionID, b.BidderID}] = &clone
+	return nil
+}
+func (m *mockProductRepo) FindBids(ctx context.Context, auctionID string) ([]*Bid, error) {
+	var out []*Bid
+	for k, b := range m.bids {
+		if k[0] == auctionID {
+			clone := *b
+			out = append(out, &clone)
+		}
+	}
+	slices.SortFunc(out, func(a, b *Bid) int { return strings.Compare(a.BidderID, b.BidderID) })
+	return out, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func usd(cents int64) Money { return Money{Amount: cents, Currency: "USD"} }
 
 func TestProductUseCase_CRUD(t *testing.T) {
 	repo := newMockProductRepo()
-	uc := NewProductUseCase(repo)
+	uc := NewProductUseCase(repo, nil)
 	ctx := context.Background()
 
 	// Create
-	p, err := uc.CreateProduct(ctx, "name", "desc", 1.2, 3)
+	p, err := uc.CreateProduct(ctx, ProductInput{Name: "name", Description: "desc", Price: usd(120), Quantity: 3})
 	if err != nil {
 		t.Fatalf("CreateProduct failed: %v", err)
 	}
@@ -71,22 +467,22 @@ func TestProductUseCase_CRUD(t *testing.T) {
 	}
 
 	// List
-	list, total, err := uc.ListProducts(ctx, 1, 10, "")
-	if err != nil || total != 1 || len(list) != 1 {
-		t.Errorf("ListProducts failed: err=%v, total=%d, len=%d", err, total, len(list))
+	list, err := uc.ListProducts(ctx, ListOptions{Page: 1, PageSize: 10})
+	if err != nil || list.Total != 1 || len(list.Products) != 1 {
+		t.Errorf("ListProducts failed: err=%v, list=%+v", err, list)
 	}
 
 	// Update
-	updated, err := uc.UpdateProduct(ctx, p.ID, "newname", "newdesc", 2.3, 5)
+	updated, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("newname"), Description: ptr("newdesc"), Price: ptr(usd(230)), Quantity: ptr[int32](5)}, 0)
 	if err != nil {
 		t.Fatalf("UpdateProduct failed: %v", err)
 	}
-	if updated.Name != "newname" || updated.Price != 2.3 {
+	if updated.Name != "newname" || updated.Price != usd(230) {
 		t.Errorf("UpdateProduct did not update fields")
 	}
 
 	// Delete
-	if err := uc.DeleteProduct(ctx, p.ID); err != nil {
+	if err := uc.DeleteProduct(ctx, p.ID, 0); err != nil {
 		t.Fatalf("DeleteProduct failed: %v", err)
 	}
 	_, err = uc.GetProduct(ctx, p.ID)
@@ -94,3 +490,106 @@ func TestProductUseCase_CRUD(t *testing.T) {
 		t.Error("GetProduct should fail after delete")
 	}
 }
+
+// racingRepo lets another writer update the product right before the first
+// Update goes through
+type racingRepo struct {
+	*mockProductRepo
+	raced bool
+}
+
+func (r *racingRepo) Update(ctx context.Context, product *Product) error {
+	if !r.raced {
+		r.raced = true
+		other := *r.products[product.ID]
+		other.Version++
+		other.Description = "concurrent"
+		r.products[product.ID] = &other
+	}
+	return r.mockProductRepo.Update(ctx, product)
+}
+
+func TestProductUseCase_Versions(t *testing.T) {
+	repo := newMockProductRepo()
+	uc := NewProductUseCase(repo, nil)
+	ctx := context.Background()
+
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "name", Description: "desc", Price: usd(100), Quantity: 1})
+	if p.Version != 1 {
+		t.Fatalf("new product should have version 1, got %d", p.Version)
+	}
+
+	updated, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("renamed")}, 1)
+	if err != nil {
+		t.Fatalf("UpdateProduct with current version failed: %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("update should bump the version to 2, got %d", updated.Version)
+	}
+
+	// a stale version must not overwrite the newer write
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("stale")}, 1); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict, got %v", err)
+	}
+	if err := uc.DeleteProduct(ctx, p.ID, 1); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict on delete, got %v", err)
+	}
+	if got, _ := uc.GetProduct(ctx, p.ID); got.Name != "renamed" {
+		t.Errorf("stale write was applied, name is %q", got.Name)
+	}
+
+	// a write racing between read and update is detected by the repository
+	racing := &racingRepo{mockProductRepo: repo}
+	uc = NewProductUseCase(racing, nil)
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("raced")}, 2); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("expected ErrVersionConflict after a racing write, got %v", err)
+	}
+	// without an expected version the update is retried on top of it
+	racing.raced = false
+	got, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("retried")}, 0)
+	if err != nil {
+		t.Fatalf("unconditional UpdateProduct failed: %v", err)
+	}
+	if got.Name != "retried" || got.Description != "concurrent" || got.Version != 5 {
+		t.Errorf("expected retried update on top of the concurrent one, got %+v", got)
+	}
+
+	if err := uc.DeleteProduct(ctx, p.ID, 5); err != nil {
+		t.Errorf("DeleteProduct with current version failed: %v", err)
+	}
+}
+
+func TestProductUseCase_UpdateProduct_Patch(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "name", Description: "desc", Price: usd(150), Quantity: 3})
+
+	// unset fields are left alone
+	got, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Quantity: ptr[int32](100)}, 0)
+	if err != nil {
+		t.Fatalf("UpdateProduct failed: %v", err)
+	}
+	if got.Quantity != 100 || got.Price != usd(150) || got.Name != "name" || got.Description != "desc" {
+		t.Errorf("quantity-only patch changed other fields: %+v", got)
+	}
+
+	// zero values are real values
+	got, err = uc.UpdateProduct(ctx, p.ID, ProductPatch{Description: ptr(""), Quantity: ptr[int32](0)}, 0)
+	if err != nil {
+		t.Fatalf("UpdateProduct failed: %v", err)
+	}
+	if got.Description != "" || got.Quantity != 0 {
+		t.Errorf("description and quantity should be cleared: %+v", got)
+	}
+
+	for _, patch := range []ProductPatch{
+		{Name: ptr("")},
+		{Price: ptr(usd(-100))},
+		{Price: ptr(Money{Amount: 100, Currency: "usd"})},
+		{Quantity: ptr[int32](-1)},
+	} {
+		if _, err := uc.UpdateProduct(ctx, p.ID, patch, 0); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("patch %v should fail with ErrInvalidInput, got %v", patch.LogValue(), err)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package biz
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SortField is a product attribute ListProducts can order by
+type SortField string
+
+const (
+	SortByName      SortField = "name"
+	SortByPrice     SortField = "price"
+	SortByQuantity  SortField = "quantity"
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+
+	// DefaultSortField orders listings by creation time when none is requested
+	DefaultSortField = SortByCreatedAt
+)
+
+// ParseSortField validates a sort field name, "" selects DefaultSortField
+func ParseSortField(s string) (SortField, error) {
+	switch f := SortField(strings.ToLower(s)); f {
+	case "":
+		return DefaultSortField, nil
+	case SortByName, SortByPrice, SortByQuantity, SortByCreatedAt, SortByUpdatedAt:
+		return f, nil
+	default:
+		return "", InvalidArgument("sort_by", "unknown field %q", s)
+	}
+}
+
+// ParseSortOrder reports whether s requests descending order, "" means ascending
+func ParseSortOrder(s string) (desc bool, err error) {
+	switch strings.ToLower(s) {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	default:
+		return false, InvalidArgument("sort_order", "must be \"asc\" or \"desc\"")
+	}
+}
+
+// ListOptions are the caller supplied parameters of ListProducts.
+// A PageToken takes precedence over Page.
+type ListOptions struct {
+	Page       int32
+	PageSize   int32
+	PageToken  string
+	NameFilter string
+	// Filter is an expression parsed by ParseFilter
+	Filter string
+	// Warehouse limits the listing to products with stock in it
+	Warehouse string
+	// Category limits the listing to products in that category or any of
+	// its descendants
+	Category  string
+	SortBy    string
+	SortOrder string
+	// Deleted lists the products in the trash instead of the live ones
+	Deleted bool
+}
+
+// ProductPage is one page of a product listing
+type ProductPage struct {
+	Products []*Product
+	Total    int32
+	// NextPageToken resumes the listing after this page, empty on the last page
+	NextPageToken string
+}
+
+// ListQuery is a validated listing request handed to a ProductRepo.
+//
+// Results are ordered by SortBy, ties broken by ID, both in the same
+// direction, so every repository returns the same deterministic sequence.
+// Names are compared lower-cased.
+//
+// When After is set the page starts right after that cursor and Offset is
+// ignored. Either way the total counts every product matching the filters,
+// which takes a pass over all products on every page when a repository has
+// no index for them: the key-value store decodes each product to count the
+// matches of Filter, Warehouse and Categories, and scans its name index for
+// NameFilter.
+type ListQuery struct {
+	Offset     int32
+	Limit      int32
+	After      *Cursor
+	NameFilter string
+	Filter     *Filter
+	// Warehouse, when set, matches products with stock in that warehouse
+	Warehouse string
+	// Categories, when set, matches products in one of these categories,
+	// sorted by ID
+	Categories []string
+	SortBy     SortField
+	Desc       bool
+	// Deleted matches the products in the trash instead of the live ones
+	Deleted bool
+}
+
+// Match reports whether p passes the query filters
+func (q ListQuery) Match(p *Product) bool {
+	if p.Deleted() != q.Deleted {
+		return false
+	}
+	if q.NameFilter != "" && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(q.NameFilter)) {
+		return false
+	}
+	if _, ok := p.Levels()[q.Warehouse]; q.Warehouse != "" && !ok {
+		return false
+	}
+	if _, ok := slices.BinarySearch(q.Categories, p.CategoryID); q.Categories != nil && !ok {
+		return false
+	}
+	return q.Filter.Match(p)
+}
+
+// Compare orders a and b the way the query requests
+func (q ListQuery) Compare(a, b *Product) int {
+	var c int
+	switch q.SortBy {
+	case SortByName:
+		c = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case SortByPrice:
+		// in major units like the price filter, 1.500 BHD is less than 10 USD
+		c = cmp.Compare(a.Price.Float(), b.Price.Float())
+	case SortByQuantity:
+		c = cmp.Compare(a.Quantity, b.Quantity)
+	case SortByUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	default:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if q.Desc {
+		return -c
+	}
+	return c
+}
+
+// Window cuts the requested page out of products, which must hold every
+// match already sorted with Compare. It serves repositories that filter
+// and sort in memory.
+func (q ListQuery) Window(products []*Product) []*Product {
+	start := int(q.Offset)
+	if q.After != nil {
+		pivot := q.After.Pivot()
+		start, _ = slices.BinarySearchFunc(products, pivot, q.Compare)
+		if start < len(products) && products[start].ID == pivot.ID {
+			start++
+		}
+	}
+	if start > len(products) {
+		return []*Product{}
+	}
+
+	end := start + int(q.Limit)
+	if end > len(products) {
+		end = len(products)
+	}
+	return products[start:end]
+}
@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseSort(t *testing.T) {
+	if f, err := ParseSortField(""); err != nil || f != DefaultSortField {
+		t.Errorf("empty sort field: got %q, %v", f, err)
+	}
+	if f, err := ParseSortField("Price"); err != nil || f != SortByPrice {
+		t.Errorf("sort field should be case-insensitive: got %q, %v", f, err)
+	}
+	if _, err := ParseSortField("colour"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("unknown sort field should be rejected, got %v", err)
+	}
+
+	if desc, err := ParseSortOrder("DESC"); err != nil || !desc {
+		t.Errorf("DESC: got %v, %v", desc, err)
+	}
+	if desc, err := ParseSortOrder(""); err != nil || desc {
+		t.Errorf("empty order should be ascending: got %v, %v", desc, err)
+	}
+	if _, err := ParseSortOrder("up"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("unknown sort order should be rejected, got %v", err)
+	}
+}
+
+func TestListQuery_Compare(t *testing.T) {
+	now := time.Now()
+	products := []*Product{
+		{ID: "c", Name: "apple", Price: usd(200), CreatedAt: now},
+		{ID: "a", Name: "Banana", Price: usd(100), CreatedAt: now},
+		{ID: "b", Name: "apple", Price: usd(200), CreatedAt: now.Add(-time.Hour)},
+	}
+	ids := func(ps []*Product) []string {
+		var out []string
+		for _, p := range ps {
+			out = append(out, p.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		q    ListQuery
+		want []string
+	}{
+		{ListQuery{SortBy: SortByName}, []string{"b", "c", "a"}},
+		{ListQuery{SortBy: SortByName, Desc: true}, []string{"a", "c", "b"}},
+		{ListQuery{SortBy: SortByPrice}, []string{"a", "b", "c"}},
+		{ListQuery{SortBy: SortByCreatedAt}, []string{"b", "a", "c"}},
+	}
+	for _, tt := range tests {
+		got := slices.Clone(products)
+		slices.SortFunc(got, tt.q.Compare)
+		if !slices.Equal(ids(got), tt.want) {
+			t.Errorf("%+v: got %v, want %v", tt.q, ids(got), tt.want)
+		}
+	}
+}
+
+func TestProductUseCase_ListProducts_InvalidSort(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	if _, err := uc.ListProducts(ctx, ListOptions{SortBy: "colour"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for sort_by, got %v", err)
+	}
+	if _, err := uc.ListProducts(ctx, ListOptions{SortOrder: "sideways"}); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("expected ErrInvalidInput for sort_order, got %v", err)
+	}
+}
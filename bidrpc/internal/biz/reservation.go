@@ -0,0 +1,273 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultReservationTTL is how long a reservation holds stock when the
+	// caller does not say
+	DefaultReservationTTL = 15 * time.Minute
+	// MaxReservationTTL bounds how long stock can be held
+	MaxReservationTTL = 24 * time.Hour
+
+	// sweepBatch is the number of expired reservations released, or deleted
+	// products purged, per query
+	sweepBatch = 100
+)
+
+// ReservationStatus is the state of a reservation, only held reservations
+// count towards Product.Reserved
+type ReservationStatus string
+
+const (
+	ReservationHeld      ReservationStatus = "held"
+	ReservationCommitted ReservationStatus = "committed"
+	ReservationReleased  ReservationStatus = "released"
+	ReservationExpired   ReservationStatus = "expired"
+)
+
+// Reservation holds stock of a product for an order being checked out
+// without taking it off the shelf. Committing it dispatches the stock,
+// releasing it or letting it expire makes the stock available again.
+type Reservation struct {
+	ID          string
+	ProductID   string
+	WarehouseID string
+	Quantity    int32
+	// Lots splits Quantity by the lots it holds, first expiry first out
+	Lots   []LotQuantity
+	Status ReservationStatus
+	// Actor is who holds the stock, e.g. an order reference
+	Actor     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ReservationRepo stores reservations
+type ReservationRepo interface {
+	// SaveReservation stores product together with r, inserted or replaced,
+	// and the stock movement m when it is not nil. The stored product must be
+	// at product.Version-1 or it fails with ErrVersionConflict. Deleting a
+	// product drops its reservations.
+	SaveReservation(ctx context.Context, product *Product, r *Reservation, m *StockMovement) error
+	// SaveReservations stores product together with the reservations rs,
+	// replaced, in one write. The stored product must be at
+	// product.Version-1 or it fails with ErrVersionConflict.
+	SaveReservations(ctx context.Context, product *Product, rs []*Reservation) error
+	// FindReservation fails with ErrReservationNotFound for an unknown id
+	FindReservation(ctx context.Context, id string) (*Reservation, error)
+	// FindExpiredReservations returns at most limit held reservations that
+	// expired at or before now
+	FindExpiredReservations(ctx context.Context, now time.Time, limit int32) ([]*Reservation, error)
+	// FindHeldReservations returns the held reservations of a product
+	FindHeldReservations(ctx context.Context, productID string) ([]*Reservation, error)
+}
+
+// ReserveStock holds quantity of a product's available stock in a warehouse
+// for ttl. An empty warehouseID reserves in the default warehouse and a zero
+// ttl holds the stock for the default TTL.
+func (uc *ProductUseCase) ReserveStock(ctx context.Context, productID, warehouseID string, quantity int32, ttl time.Duration, actor string) (*Reservation, *Product, error) {
+	slog.Info("Reserving stock", "productID", productID, "warehouseID", warehouseID, "quantity", quantity, "ttl", ttl, "actor", actor)
+	var errs []error
+	if productID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if quantity <= 0 {
+		errs = append(errs, InvalidArgument("quantity", "must be positive"))
+	}
+	if ttl < 0 || ttl > MaxReservationTTL {
+		errs = append(errs, InvalidArgument("ttl_seconds", "must be between 0 and %d", int64(MaxReservationTTL/time.Second)))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, nil, err
+	}
+	if ttl == 0 {
+		ttl = DefaultReservationTTL
+	}
+	return uc.reserve(ctx, productID, warehouseID, quantity, ttl, actor)
+}
+
+// reserve holds quantity of a product's available stock in a warehouse for
+// ttl, for callers that validated their arguments and may exceed
+// MaxReservationTTL
+func (uc *ProductUseCase) reserve(ctx context.Context, productID, warehouseID string, quantity int32, ttl time.Duration, actor string) (*Reservation, *Product, error) {
+	warehouseID = cmp.Or(warehouseID, DefaultWarehouseID)
+	if err := uc.checkWarehouse(ctx, "warehouse_id", warehouseID); err != nil {
+		return nil, nil, err
+	}
+
+	var r *Reservation
+	product, err := uc.write(ctx, productID, 0, func(p *Product) (productWrite, error) {
+		level := p.Levels()[warehouseID]
+		if quantity > level.Available() {
+			return productWrite{}, InvalidArgument("quantity", "exceeds the available stock of %d in warehouse %s", level.Available(), warehouseID)
+		}
+		now := time.Now()
+		lots, err := p.allocate(warehouseID, "", quantity, true, now)
+		if err != nil {
+			return productWrite{}, err
+		}
+		p.reserveLots(warehouseID, lots, 1)
+		p.moveStock(warehouseID, 0, quantity)
+		r = &Reservation{
+			ID:          uuid.New().String(),
+			ProductID:   p.ID,
+			WarehouseID: warehouseID,
+			Quantity:    quantity,
+			Lots:        lots,
+			Status:      ReservationHeld,
+			Actor:       strings.TrimSpace(actor),
+			ExpiresAt:   now.Add(ttl),
+			CreatedAt:   now,
+		}
+		return productWrite{reservation: r}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, product, nil
+}
+
+// CommitReservation dispatches the stock a reservation holds, recording it
+// in the product's ledger
+func (uc *ProductUseCase) CommitReservation(ctx context.Context, id string) (*Reservation, *StockMovement, *Product, error) {
+	slog.Info("Committing reservation", "id", id)
+	var m *StockMovement
+	r, product, err := uc.closeReservation(ctx, id, ReservationCommitted, time.Now(), func(p *Product, r *Reservation) (*StockMovement, error) {
+		actor := r.Actor
+		if actor == "" {
+			actor = systemActor
+		}
+		// the stock leaves out of the lots the reservation held, which
+		// closing it gave back
+		p.takeLots(r.WarehouseID, r.Lots)
+		m = bookMovement(p, r.WarehouseID, MovementDispatch, -r.Quantity, r.Lots, "reservation "+r.ID+" committed", actor)
+		return m, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return r, m, product, nil
+}
+
+// ReleaseReservation gives the stock a reservation holds back
+func (uc *ProductUseCase) ReleaseReservation(ctx context.Context, id string) (*Reservation, *Product, error) {
+	slog.Info("Releasing reservation", "id", id)
+	r, product, err := uc.closeReservation(ctx, id, ReservationReleased, time.Now(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, product, nil
+}
+
+// ReleaseExpired releases every reservation held past its expiry at now and
+// returns how many it released
+func (uc *ProductUseCase) ReleaseExpired(ctx context.Context, now time.Time) (int, error) {
+	var n int
+	for {
+		expired, err := uc.repo.FindExpiredReservations(ctx, now, sweepBatch)
+		if err != nil {
+			return n, err
+		}
+		for _, r := range expired {
+			_, _, err := uc.closeReservation(ctx, r.ID, ReservationExpired, now, nil)
+			// committed or released in the meantime, or gone with its product
+			if errors.Is(err, ErrReservationClosed) || errors.Is(err, ErrReservationNotFound) || errors.Is(err, ErrProductNotFound) {
+				continue
+			}
+			if err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(expired) < sweepBatch {
+			return n, nil
+		}
+	}
+}
+
+// SweepReservations releases expired reservations every interval until ctx
+// is done
+func (uc *ProductUseCase) SweepReservations(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := uc.ReleaseExpired(ctx, now)
+			if err != nil && ctx.Err() == nil {
+				slog.Error("Releasing expired reservations failed", "error", err)
+			}
+			if n > 0 {
+				slog.Info("Released expired reservations", "count", n)
+			}
+		}
+	}
+}
+
+// closeReservation moves a held reservation to status, returning its stock
+// to the product and applying dispatch, if given, to the product first.
+// Only the sweeper may close a reservation past its expiry.
+// unreserve returns the stock r holds to the available stock of p
+func (p *Product) unreserve(r *Reservation) {
+	p.moveStock(r.WarehouseID, 0, -r.Quantity)
+	p.reserveLots(r.WarehouseID, r.Lots, -1)
+}
+
+func (uc *ProductUseCase) closeReservation(ctx context.Context, id string, status ReservationStatus, now time.Time, dispatch func(p *Product, r *Reservation) (*StockMovement, error)) (*Reservation, *Product, error) {
+	if id == "" {
+		return nil, nil, InvalidArgument("id", "is required")
+	}
+	r, err := uc.repo.FindReservation(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product, err := uc.write(ctx, r.ProductID, 0, func(p *Product) (productWrite, error) {
+		// read again on every attempt, the product version guards the
+		// reservation against a concurrent close
+		var err error
+		r, err = uc.repo.FindReservation(ctx, id)
+		if err != nil {
+			return productWrite{}, err
+		}
+		// reservations made before warehouses existed hold default stock
+		r.WarehouseID = cmp.Or(r.WarehouseID, DefaultWarehouseID)
+		if r.Status != ReservationHeld {
+			return productWrite{}, fmt.Errorf("%w: it is %s", ErrReservationClosed, r.Status)
+		}
+		expired := !now.Before(r.ExpiresAt)
+		if expired != (status == ReservationExpired) {
+			if expired {
+				return productWrite{}, fmt.Errorf("%w: it expired at %s", ErrReservationClosed, r.ExpiresAt.Format(time.RFC3339))
+			}
+			return productWrite{}, fmt.Errorf("%w: it expires at %s", ErrReservationClosed, r.ExpiresAt.Format(time.RFC3339))
+		}
+
+		p.unreserve(r)
+		r.Status = status
+		w := productWrite{reservation: r}
+		if dispatch != nil {
+			if w.movement, err = dispatch(p, r); err != nil {
+				return productWrite{}, err
+			}
+		}
+		return w, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, product, nil
+}
@@ -0,0 +1,118 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProductUseCase_Reservations(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "flour", Price: usd(100), Quantity: 10})
+
+	r, got, err := uc.ReserveStock(ctx, p.ID, "", 6, 0, "order-1")
+	if err != nil {
+		t.Fatalf("ReserveStock failed: %v", err)
+	}
+	if r.Status != ReservationHeld || r.ExpiresAt.Sub(r.CreatedAt) != DefaultReservationTTL {
+		t.Errorf("expected a held reservation with the default TTL, got %+v", r)
+	}
+	if got.Quantity != 10 || got.Reserved != 6 || got.Available() != 4 {
+		t.Errorf("reserving should only hold stock, got quantity %d reserved %d", got.Quantity, got.Reserved)
+	}
+
+	// reserved stock can neither be reserved again nor dispatched
+	if _, _, err := uc.ReserveStock(ctx, p.ID, "", 5, 0, "order-2"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("reserving more than is available should fail, got %v", err)
+	}
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "dispatch", Quantity: 5, Actor: "a"}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("dispatching reserved stock should fail, got %v", err)
+	}
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Quantity: ptr[int32](5)}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("setting the quantity below the reserved stock should fail, got %v", err)
+	}
+
+	r, m, got, err := uc.CommitReservation(ctx, r.ID)
+	if err != nil {
+		t.Fatalf("CommitReservation failed: %v", err)
+	}
+	if r.Status != ReservationCommitted || m.Kind != MovementDispatch || m.Delta != -6 || m.Actor != "order-1" {
+		t.Errorf("committing should dispatch the reserved stock, got %+v and %+v", r, m)
+	}
+	if got.Quantity != 4 || got.Reserved != 0 {
+		t.Errorf("expected 4 on hand and none reserved, got %d and %d", got.Quantity, got.Reserved)
+	}
+	if page, _ := uc.ListStockMovements(ctx, p.ID, 10, ""); len(page.Movements) != 2 || page.Movements[1].ID != m.ID {
+		t.Errorf("the dispatch should be in the ledger, got %+v", page.Movements)
+	}
+	if _, _, _, err := uc.CommitReservation(ctx, r.ID); !errors.Is(err, ErrReservationClosed) || KindOf(err) != KindInvalidState {
+		t.Errorf("committing twice should fail with ErrReservationClosed, got %v", err)
+	}
+
+	r, _, _ = uc.ReserveStock(ctx, p.ID, "", 3, time.Minute, "order-2")
+	r, got, err = uc.ReleaseReservation(ctx, r.ID)
+	if err != nil {
+		t.Fatalf("ReleaseReservation failed: %v", err)
+	}
+	if r.Status != ReservationReleased || got.Quantity != 4 || got.Available() != 4 {
+		t.Errorf("releasing should give the stock back, got %+v and %+v", r, got)
+	}
+	if _, _, err := uc.ReleaseReservation(ctx, "missing"); KindOf(err) != KindNotFound {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestProductUseCase_ReleaseExpired(t *testing.T) {
+	repo := newMockProductRepo()
+	uc := NewProductUseCase(repo, nil)
+	ctx := context.Background()
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "salt", Price: usd(100), Quantity: 10})
+
+	short, _, _ := uc.ReserveStock(ctx, p.ID, "", 2, time.Minute, "a")
+	long, _, _ := uc.ReserveStock(ctx, p.ID, "", 3, time.Hour, "b")
+	committed, _, _ := uc.ReserveStock(ctx, p.ID, "", 1, time.Minute, "c")
+	if _, _, _, err := uc.CommitReservation(ctx, committed.ID); err != nil {
+		t.Fatalf("CommitReservation failed: %v", err)
+	}
+
+	n, err := uc.ReleaseExpired(ctx, time.Now().Add(2*time.Minute))
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 expired reservation, got %d, %v", n, err)
+	}
+	if r, _ := repo.FindReservation(ctx, short.ID); r.Status != ReservationExpired {
+		t.Errorf("the short reservation should have expired, got %s", r.Status)
+	}
+	if r, _ := repo.FindReservation(ctx, long.ID); r.Status != ReservationHeld {
+		t.Errorf("the long reservation should still be held, got %s", r.Status)
+	}
+	got, _ := uc.GetProduct(ctx, p.ID)
+	if got.Quantity != 9 || got.Reserved != 3 {
+		t.Errorf("expected 9 on hand and 3 reserved, got %d and %d", got.Quantity, got.Reserved)
+	}
+
+	// only the sweeper closes a reservation past its expiry
+	r, _, _ := uc.ReserveStock(ctx, p.ID, "", 1, time.Nanosecond, "d")
+	time.Sleep(time.Millisecond)
+	if _, _, _, err := uc.CommitReservation(ctx, r.ID); !errors.Is(err, ErrReservationClosed) {
+		t.Errorf("committing an expired reservation should fail, got %v", err)
+	}
+}
+
+func TestProductUseCase_ReserveStockValidation(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	_, _, err := uc.ReserveStock(ctx, "", "", 0, 48*time.Hour, "")
+	var fields []string
+	for _, v := range FieldViolations(err) {
+		fields = append(fields, v.Field)
+	}
+	if len(fields) != 3 || fields[0] != "product_id" || fields[1] != "quantity" || fields[2] != "ttl_seconds" {
+		t.Errorf("expected product_id, quantity and ttl_seconds to be invalid, got %v", fields)
+	}
+	if _, _, err := uc.ReserveStock(ctx, "missing", "", 1, 0, ""); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+)
+
+// SearchHit is a product matching a full-text query
+type SearchHit struct {
+	ID      string
+	Product *Product
+	// Score is the relevance of the product, higher is better
+	Score float64
+	// NameHighlight and DescriptionHighlight are snippets of the fields with
+	// the matched words wrapped in <em></em>, empty when the field did not match
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+// ProductIndex is a full-text index over product names and descriptions
+type ProductIndex interface {
+	// Index adds p or replaces its previous version
+	Index(p *Product)
+	Remove(id string)
+	// Search returns at most limit hits ordered by descending score
+	Search(query string, limit int) []SearchHit
+}
+
+// ErrSearchUnavailable is returned when no index is configured
+var ErrSearchUnavailable = errors.New("search index unavailable")
+
+// SearchProducts runs a relevance ranked full-text search over names and descriptions
+func (uc *ProductUseCase) SearchProducts(ctx context.Context, query string, limit int32) ([]SearchHit, error) {
+	slog.Info("Searching products", "query", query, "limit", limit)
+	if uc.index == nil {
+		return nil, ErrSearchUnavailable
+	}
+	if strings.TrimSpace(query) == "" {
+		return nil, InvalidArgument("query", "is required")
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	hits := uc.index.Search(query, int(limit))
+	out := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		// the repository is the source of truth, the index may lag a delete
+		p, err := live(uc.repo.FindByID(ctx, hit.ID))
+		if errors.Is(err, ErrProductNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hit.Product = p
+		out = append(out, hit)
+	}
+	return out, nil
+}
+
+// Reindex loads every stored product into the search index, it is called
+// once on startup before serving requests
+func (uc *ProductUseCase) Reindex(ctx context.Context) error {
+	if uc.index == nil {
+		return nil
+	}
+
+	q := ListQuery{SortBy: DefaultSortField, Limit: 500}
+	var n int
+	for {
+		products, _, err := uc.repo.FindAll(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, p := range products {
+			uc.index.Index(p)
+		}
+		n += len(products)
+		if int32(len(products)) < q.Limit {
+			break
+		}
+		q.After = NewCursor(products[len(products)-1], q)
+	}
+
+	slog.Info("Search index built", "products", n)
+	return nil
+}
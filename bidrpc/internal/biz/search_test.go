@@ -0,0 +1,75 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// mockProductIndex matches products whose name contains the query
+type mockProductIndex struct {
+	names map[string]string
+}
+
+func (m *mockProductIndex) Index(p *Product) { m.names[p.ID] = p.Name }
+func (m *mockProductIndex) Remove(id string) { delete(m.names, id) }
+func (m *mockProductIndex) Search(query string, limit int) []SearchHit {
+	var hits []SearchHit
+	for id, name := range m.names {
+		if strings.Contains(name, query) && len(hits) < limit {
+			hits = append(hits, SearchHit{ID: id, Score: 1})
+		}
+	}
+	return hits
+}
+
+func TestProductUseCase_SearchProducts(t *testing.T) {
+	repo := newMockProductRepo()
+	index := &mockProductIndex{names: make(map[string]string)}
+	ctx := context.Background()
+
+	// products stored before the use case exists are picked up by Reindex
+	repo.Save(ctx, &Product{ID: "old", Name: "old cheese"})
+	uc := NewProductUseCase(repo, index)
+	if err := uc.Reindex(ctx); err != nil {
+		t.Fatalf("Reindex failed: %v", err)
+	}
+
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "blue cheese", Price: usd(100), Quantity: 1})
+	hits, err := uc.SearchProducts(ctx, "cheese", 0)
+	if err != nil {
+		t.Fatalf("SearchProducts failed: %v", err)
+	}
+	if len(hits) != 2 {
+		t.Fatalf("expected 2 hits, got %d", len(hits))
+	}
+	for _, hit := range hits {
+		if hit.Product == nil || hit.Product.ID != hit.ID {
+			t.Errorf("hit %s not resolved to its product", hit.ID)
+		}
+	}
+
+	// the index follows updates and deletes
+	uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: ptr("brie")}, 0)
+	if hits, _ := uc.SearchProducts(ctx, "brie", 0); len(hits) != 1 {
+		t.Errorf("updated product not found, got %d hits", len(hits))
+	}
+	uc.DeleteProduct(ctx, p.ID, 0)
+	if hits, _ := uc.SearchProducts(ctx, "brie", 0); len(hits) != 0 {
+		t.Errorf("deleted product still found, got %d hits", len(hits))
+	}
+
+	// a hit the repository no longer knows is skipped
+	delete(repo.products, "old")
+	if hits, err := uc.SearchProducts(ctx, "cheese", 0); err != nil || len(hits) != 0 {
+		t.Errorf("stale hit should be skipped, got %d hits, %v", len(hits), err)
+	}
+
+	if _, err := uc.SearchProducts(ctx, "  ", 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("blank query should fail with ErrInvalidInput, got %v", err)
+	}
+	if _, err := NewProductUseCase(repo, nil).SearchProducts(ctx, "cheese", 0); !errors.Is(err, ErrSearchUnavailable) {
+		t.Errorf("expected ErrSearchUnavailable without an index, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	// maxSKULength keeps SKUs shorter than the 36 characters of a UUID, so
+	// a product ID is never taken for a SKU
+	maxSKULength = 32
+	// maxSKUPrefixLength bounds the prefix of generated SKUs
+	maxSKUPrefixLength = 8
+	// maxSKUDigits bounds the width of the counter of generated SKUs
+	maxSKUDigits = 12
+	// skuAttempts bounds the counter values tried past SKUs callers chose
+	skuAttempts = 10
+	// skuAlphabet are the characters of a SKU body in check character order
+	skuAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// SKUFormat configures the SKUs CreateProduct generates: a prefix, a counter
+// per prefix zero padded to Digits and an optional check character, as in
+// CHE-00042-4
+type SKUFormat struct {
+	// Prefix starts the SKUs of products whose category sets no prefix,
+	// neither directly nor through its ancestors
+	Prefix string
+	Digits int
+	// Check appends a Luhn mod 36 check character, which catches every
+	// single mistyped character and most swapped neighbours
+	Check bool
+}
+
+// DefaultSKUFormat generates SKUs such as SKU-00001-7
+var DefaultSKUFormat = SKUFormat{Prefix: "SKU", Digits: 5, Check: true}
+
+func (f SKUFormat) validate() error {
+	var errs []error
+	if !validSKUPrefix(f.Prefix) {
+		errs = append(errs, InvalidArgument("prefix", "must be 1 to %d uppercase letters or digits", maxSKUPrefixLength))
+	}
+	if f.Digits < 1 || f.Digits > maxSKUDigits {
+		errs = append(errs, InvalidArgument("digits", "must be 1 to %d", maxSKUDigits))
+	}
+	return InvalidArguments(errs...)
+}
+
+// format returns the SKU numbered n among those starting with prefix
+func (f SKUFormat) format(prefix string, n int64) string {
+	digits := fmt.Sprintf("%0*d", f.Digits, n)
+	sku := prefix + "-" + digits
+	if f.Check {
+		sku += "-" + string(skuCheckCharacter(prefix+digits))
+	}
+	return sku
+}
+
+// skuCheckCharacter computes the Luhn mod 36 check character of s, which
+// holds characters of skuAlphabet only
+func skuCheckCharacter(s string) byte {
+	const n = len(skuAlphabet)
+	sum, factor := 0, 2
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(skuAlphabet, s[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return skuAlphabet[(n-sum%n)%n]
+}
+
+// validSKUPrefix reports whether s can start generated SKUs
+func validSKUPrefix(s string) bool {
+	return s != "" && len(s) <= maxSKUPrefixLength && !strings.ContainsFunc(s, func(r rune) bool {
+		return !strings.ContainsRune(skuAlphabet, r)
+	})
+}
+
+// validSKU reports whether s has the form of a SKU: uppercase letters,
+// digits and dashes, starting with a letter or digit
+func validSKU(s string) bool {
+	return s != "" && len(s) <= maxSKULength && s[0] != '-' && !strings.ContainsFunc(s, func(r rune) bool {
+		return r != '-' && !strings.ContainsRune(skuAlphabet, r)
+	})
+}
+
+// normalizeSKU returns s as SKUs are stored, they are matched regardless of
+// case
+func normalizeSKU(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// SetSKUFormat changes the format of the SKUs generated from now on, SKUs
+// already given out stay as they are. It is meant to be called before the
+// use case serves requests.
+func (uc *ProductUseCase) SetSKUFormat(f SKUFormat) error {
+	f.Prefix = normalizeSKU(f.Prefix)
+	if err := f.validate(); err != nil {
+		return err
+	}
+	uc.skuFormat = f
+	return nil
+}
+
+// skuPrefix returns the SKU prefix of the nearest category from categoryID
+// up that sets one, the prefix of the format when none does
+func (uc *ProductUseCase) skuPrefix(ctx context.Context, categoryID string) (string, error) {
+	if categoryID == "" {
+		return uc.skuFormat.Prefix, nil
+	}
+	tree, err := uc.categoryTree(ctx)
+	if err != nil {
+		return "", err
+	}
+	// a stored tree has no cycles, the depth bound only guards a corrupt one
+	for depth := 0; categoryID != "" && depth <= len(tree.byID); depth++ {
+		c, ok := tree.byID[categoryID]
+		if !ok {
+			break
+		}
+		if c.SKUPrefix != "" {
+			return c.SKUPrefix, nil
+		}
+		categoryID = c.ParentID
+	}
+	return uc.skuFormat.Prefix, nil
+}
+
+// newSKU generates the next free SKU of a product filed under categoryID.
+// Counters never go back, the SKUs of deleted products are not given out
+// again.
+func (uc *ProductUseCase) newSKU(ctx context.Context, categoryID string) (string, error) {
+	prefix, err := uc.skuPrefix(ctx, categoryID)
+	if err != nil {
+		return "", err
+	}
+	for range skuAttempts {
+		n, err := uc.repo.NextSKUNumber(ctx, prefix)
+		if err != nil {
+			return "", err
+		}
+		// a caller may have chosen the SKU the counter arrived at
+		sku := uc.skuFormat.format(prefix, n)
+		_, err = uc.repo.FindBySKU(ctx, sku)
+		if errors.Is(err, ErrProductNotFound) {
+			return sku, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free SKU starting with %s after %d attempts", prefix, skuAttempts)
+}
+
+// checkSKU returns a SKU chosen by the caller as it is stored, failing
+// unless it is well formed and free
+func (uc *ProductUseCase) checkSKU(ctx context.Context, sku string) (string, error) {
+	sku = normalizeSKU(sku)
+	if !validSKU(sku) {
+		return "", InvalidArgument("sku", "must be 1 to %d letters, digits or dashes, starting with a letter or digit", maxSKULength)
+	}
+	p, err := uc.repo.FindBySKU(ctx, sku)
+	if err == nil {
+		return "", InvalidArgument("sku", "is already the SKU of product %s", p.ID)
+	}
+	if !errors.Is(err, ErrProductNotFound) {
+		return "", err
+	}
+	return sku, nil
+}
+
+// GetProductBySKU retrieves a product by SKU
+func (uc *ProductUseCase) GetProductBySKU(ctx context.Context, sku string) (*Product, error) {
+	slog.Info("Getting product by SKU", "sku", sku)
+	sku = normalizeSKU(sku)
+	if sku == "" {
+		return nil, InvalidArgument("sku", "is required")
+	}
+	return live(uc.repo.FindBySKU(ctx, sku))
+}
+
+// SetCategorySKUPrefix sets the prefix of the SKUs generated for products
+// filed under a category and its subcategories that set none, an empty
+// prefix inherits the one of the parent
+func (uc *ProductUseCase) SetCategorySKUPrefix(ctx context.Context, id, prefix string) (*Category, error) {
+	slog.Info("Setting category SKU prefix", "id", id, "prefix", prefix)
+	if id == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	prefix = normalizeSKU(prefix)
+	if prefix != "" && !validSKUPrefix(prefix) {
+		return nil, InvalidArgument("sku_prefix", "must be 1 to %d letters or digits", maxSKUPrefixLength)
+	}
+
+	uc.categoryMu.Lock()
+	defer uc.categoryMu.Unlock()
+
+	c, err := uc.repo.FindCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.SKUPrefix = prefix
+	c.UpdatedAt = time.Now()
+	if err := uc.repo.SaveCategory(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
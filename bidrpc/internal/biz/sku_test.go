@@ -0,0 +1,82 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSKUCheckCharacter(t *testing.T) {
+	body := "CHE00042"
+	check := skuCheckCharacter(body)
+	// every single substituted character changes the check character
+	for i := range len(body) {
+		for _, c := range []byte(skuAlphabet) {
+			if c == body[i] {
+				continue
+			}
+			typo := body[:i] + string(c) + body[i+1:]
+			if skuCheckCharacter(typo) == check {
+				t.Errorf("%s and %s share the check character %c", body, typo, check)
+			}
+		}
+	}
+	if got := (SKUFormat{Prefix: "CHE", Digits: 3}).format("CHE", 1234); got != "CHE-1234" {
+		t.Errorf("a counter wider than the digits should not be cut, got %s", got)
+	}
+}
+
+func TestProductUseCase_SKUs(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	if err := uc.SetSKUFormat(SKUFormat{Prefix: "bf-", Digits: 0}); !hasViolation(err, "prefix") || !hasViolation(err, "digits") {
+		t.Errorf("expected prefix and digits violations, got %v", err)
+	}
+	if err := uc.SetSKUFormat(SKUFormat{Prefix: "bf", Digits: 4, Check: true}); err != nil {
+		t.Fatalf("SetSKUFormat failed: %v", err)
+	}
+	dairy, _ := uc.CreateCategory(ctx, "Dairy", "")
+	cheese, _ := uc.CreateCategory(ctx, "Cheese", dairy.ID)
+	if _, err := uc.SetCategorySKUPrefix(ctx, dairy.ID, "dai ry"); !hasViolation(err, "sku_prefix") {
+		t.Errorf("a prefix with a space should fail, got %v", err)
+	}
+	if _, err := uc.SetCategorySKUPrefix(ctx, dairy.ID, "dai"); err != nil {
+		t.Fatalf("SetCategorySKUPrefix failed: %v", err)
+	}
+
+	// subcategories inherit the prefix, uncategorized products take the
+	// format's
+	brie, _ := uc.CreateProduct(ctx, ProductInput{Name: "brie", Price: usd(100), CategoryID: cheese.ID})
+	milk, _ := uc.CreateProduct(ctx, ProductInput{Name: "milk", Price: usd(100), CategoryID: dairy.ID})
+	salt, _ := uc.CreateProduct(ctx, ProductInput{Name: "salt", Price: usd(100)})
+	if brie.SKU != "DAI-0001-"+string(skuCheckCharacter("DAI0001")) || milk.SKU != uc.skuFormat.format("DAI", 2) || salt.SKU != uc.skuFormat.format("BF", 1) {
+		t.Errorf("expected DAI-0001, DAI-0002 and BF-0001, got %s, %s and %s", brie.SKU, milk.SKU, salt.SKU)
+	}
+
+	// a caller may choose a SKU, the counter skips it
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(100), SKU: "bad sku"}); !hasViolation(err, "sku") {
+		t.Errorf("a malformed SKU should fail, got %v", err)
+	}
+	next := uc.skuFormat.format("BF", 2)
+	chosen, err := uc.CreateProduct(ctx, ProductInput{Name: "pepper", Price: usd(100), SKU: " " + next + " "})
+	if err != nil || chosen.SKU != next {
+		t.Fatalf("expected the chosen SKU %s, got %+v, %v", next, chosen, err)
+	}
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(100), SKU: next}); !hasViolation(err, "sku") {
+		t.Errorf("a SKU in use should fail, got %v", err)
+	}
+	if p, _ := uc.CreateProduct(ctx, ProductInput{Name: "sugar", Price: usd(100)}); p.SKU != uc.skuFormat.format("BF", 3) {
+		t.Errorf("expected the counter to skip the chosen SKU, got %s", p.SKU)
+	}
+
+	// products are addressable by SKU, in any case
+	for _, get := range []func(context.Context, string) (*Product, error){uc.GetProduct, uc.GetProductBySKU} {
+		if p, err := get(ctx, "bf-0002-"+next[len(next)-1:]); err != nil || p.ID != chosen.ID {
+			t.Errorf("expected the pepper, got %+v, %v", p, err)
+		}
+	}
+	if _, err := uc.GetProductBySKU(ctx, "BF-9999"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
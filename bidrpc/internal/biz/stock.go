@@ -0,0 +1,285 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MovementKind classifies why the stock of a product changed
+type MovementKind string
+
+const (
+	// MovementReceipt adds goods that arrived
+	MovementReceipt MovementKind = "receipt"
+	// MovementAdjustment corrects the stock in either direction, e.g. after a count
+	MovementAdjustment MovementKind = "adjustment"
+	// MovementDispatch removes goods that left for a customer
+	MovementDispatch MovementKind = "dispatch"
+	// MovementWriteOff removes damaged, expired or lost goods
+	MovementWriteOff MovementKind = "write_off"
+	// MovementTransfer moves goods between warehouses, it is recorded by
+	// TransferStock only
+	MovementTransfer MovementKind = "transfer"
+)
+
+// systemActor records movements the service makes on its own behalf
+const systemActor = "system"
+
+// ParseMovementKind validates a movement kind name
+func ParseMovementKind(s string) (MovementKind, error) {
+	switch k := MovementKind(strings.ToLower(s)); k {
+	case MovementReceipt, MovementAdjustment, MovementDispatch, MovementWriteOff, MovementTransfer:
+		return k, nil
+	case "":
+		return "", InvalidArgument("kind", "is required")
+	default:
+		return "", InvalidArgument("kind", "unknown kind %q", s)
+	}
+}
+
+// StockMovement is an immutable ledger entry recording one change of the
+// on-hand quantity of a product. The quantity of a product is the balance
+// of its latest movement.
+type StockMovement struct {
+	ID          string
+	ProductID   string
+	WarehouseID string
+	// ToWarehouseID is the warehouse receiving a transfer
+	ToWarehouseID string
+	Kind          MovementKind
+	// Delta is the signed change of the on-hand quantity in WarehouseID, a
+	// transfer adds -Delta to ToWarehouseID
+	Delta int32
+	// Lots splits Delta by the lots it was received into or taken out of,
+	// stock not tracked by lot is left out
+	Lots []LotQuantity
+	// Balance is the on-hand quantity across all warehouses after the movement
+	Balance int32
+	Reason  string
+	Actor   string
+	// Version is the product version the movement produced, it orders the
+	// ledger of a product
+	Version   int64
+	CreatedAt time.Time
+}
+
+// MovementInput is a caller supplied stock movement. Quantity is the amount
+// received, dispatched or written off, or the signed delta of an adjustment.
+// An empty Warehouse books it in the default warehouse. A Lot receives the
+// stock into that lot or takes it out of it, without one stock is received
+// untracked and taken first expiry first out.
+type MovementInput struct {
+	Kind      string
+	Quantity  int32
+	Reason    string
+	Actor     string
+	Warehouse string
+	Lot       Lot
+}
+
+// delta validates in and returns the kind and signed delta it applies
+func (in MovementInput) delta() (MovementKind, int32, error) {
+	kind, err := ParseMovementKind(in.Kind)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if kind == MovementTransfer {
+		errs = append(errs, InvalidArgument("kind", "transfers are recorded with TransferStock"))
+	}
+	switch {
+	case kind == MovementAdjustment && in.Quantity == 0:
+		errs = append(errs, InvalidArgument("quantity", "must not be zero"))
+	case kind != MovementAdjustment && in.Quantity <= 0:
+		errs = append(errs, InvalidArgument("quantity", "must be positive"))
+	}
+	if (kind == MovementAdjustment || kind == MovementWriteOff) && strings.TrimSpace(in.Reason) == "" {
+		errs = append(errs, InvalidArgument("reason", "is required for %s", kind))
+	}
+	if strings.TrimSpace(in.Actor) == "" {
+		errs = append(errs, InvalidArgument("actor", "is required"))
+	}
+	errs = append(errs, in.Lot.validate(kind))
+	if err := InvalidArguments(errs...); err != nil {
+		return "", 0, err
+	}
+
+	if kind == MovementDispatch || kind == MovementWriteOff {
+		return kind, -in.Quantity, nil
+	}
+	return kind, in.Quantity, nil
+}
+
+// StockLedger stores the stock movements of products
+type StockLedger interface {
+	// RecordMovement stores product together with the movement m that
+	// produced its quantity. A product at version 1 is inserted, otherwise
+	// the stored version must be product.Version-1 or it fails with
+	// ErrVersionConflict like Update. Deleting a product drops its ledger.
+	RecordMovement(ctx context.Context, product *Product, m *StockMovement) error
+	// FindMovements returns at most limit movements of a product with a
+	// version above afterVersion, oldest first
+	FindMovements(ctx context.Context, productID string, afterVersion int64, limit int32) ([]*StockMovement, error)
+}
+
+// MovementPage is one page of a product's ledger
+type MovementPage struct {
+	Movements []*StockMovement
+	// NextPageToken addresses the following page, empty on the last page
+	NextPageToken string
+}
+
+// movementToken is the position in a product's ledger a page starts after
+type movementToken struct {
+	ProductID string `json:"p"`
+	Version   int64  `json:"v"`
+}
+
+func (t movementToken) encode() string {
+	buf, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+func decodeMovementToken(token string) (movementToken, error) {
+	var t movementToken
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(buf, &t)
+	}
+	if err != nil || t.ProductID == "" || t.Version <= 0 {
+		return movementToken{}, InvalidArgument("page_token", "is malformed")
+	}
+	return t, nil
+}
+
+// newMovement returns a movement of delta against the stock of product in a
+// warehouse, failing when it would take reserved stock or overflow. Stock is
+// received into lot when it is set and taken out of it, or else first expiry
+// first out, where dispatches skip the expired lots.
+func newMovement(product *Product, warehouseID string, kind MovementKind, delta int32, lot Lot, reason, actor string) (*StockMovement, error) {
+	level := product.Levels()[warehouseID]
+	if delta < 0 && level.Quantity+delta < level.Reserved {
+		return nil, InvalidArgument("quantity", "exceeds the available stock of %d in warehouse %s", level.Available(), warehouseID)
+	}
+	if int64(product.Quantity)+int64(delta) > math.MaxInt32 {
+		return nil, InvalidArgument("quantity", "would overflow the on-hand stock")
+	}
+	now := time.Now()
+	var lots []LotQuantity
+	switch {
+	case delta > 0 && lot.Code != "":
+		if err := product.receiveLot(warehouseID, lot, delta, now); err != nil {
+			return nil, err
+		}
+		lots = []LotQuantity{{LotCode: lot.Code, Quantity: delta}}
+	case delta < 0:
+		var err error
+		if lots, err = product.allocate(warehouseID, lot.Code, -delta, kind == MovementDispatch, now); err != nil {
+			return nil, err
+		}
+		product.takeLots(warehouseID, lots)
+	}
+	return bookMovement(product, warehouseID, kind, delta, lots, reason, actor), nil
+}
+
+// bookMovement applies delta to the stock level of product in a warehouse
+// and returns the movement recording it, the caller updated the batches
+func bookMovement(product *Product, warehouseID string, kind MovementKind, delta int32, lots []LotQuantity, reason, actor string) *StockMovement {
+	product.moveStock(warehouseID, delta, 0)
+	return &StockMovement{
+		ID:          uuid.New().String(),
+		ProductID:   product.ID,
+		WarehouseID: warehouseID,
+		Kind:        kind,
+		Delta:       delta,
+		Lots:        lots,
+		Balance:     product.Quantity,
+		Reason:      strings.TrimSpace(reason),
+		Actor:       strings.TrimSpace(actor),
+	}
+}
+
+// RecordStockMovement books a movement against the stock of a product and
+// returns it with the updated product.
+// A non-zero expectedVersion makes it fail with ErrVersionConflict unless
+// the stored product still has that version.
+func (uc *ProductUseCase) RecordStockMovement(ctx context.Context, productID string, in MovementInput, expectedVersion int64) (*StockMovement, *Product, error) {
+	slog.Info("Recording stock movement", "productID", productID, "kind", in.Kind, "quantity", in.Quantity,
+		"reason", in.Reason, "actor", in.Actor, "expectedVersion", expectedVersion)
+	if productID == "" {
+		return nil, nil, InvalidArgument("product_id", "is required")
+	}
+	if expectedVersion < 0 {
+		return nil, nil, InvalidArgument("expected_version", "must not be negative")
+	}
+	kind, delta, err := in.delta()
+	if err != nil {
+		return nil, nil, err
+	}
+	warehouseID := cmp.Or(in.Warehouse, DefaultWarehouseID)
+	if err := uc.checkWarehouse(ctx, "warehouse_id", warehouseID); err != nil {
+		return nil, nil, err
+	}
+
+	var m *StockMovement
+	product, err := uc.write(ctx, productID, expectedVersion, func(p *Product) (productWrite, error) {
+		var err error
+		m, err = newMovement(p, warehouseID, kind, delta, in.Lot, in.Reason, in.Actor)
+		return productWrite{movement: m}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, product, nil
+}
+
+// ListStockMovements returns a page of a product's ledger, oldest first
+func (uc *ProductUseCase) ListStockMovements(ctx context.Context, productID string, pageSize int32, pageToken string) (*MovementPage, error) {
+	slog.Info("Listing stock movements", "productID", productID, "pageSize", pageSize, "pageToken", pageToken)
+	if productID == "" {
+		return nil, InvalidArgument("product_id", "is required")
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	var after int64
+	if pageToken != "" {
+		t, err := decodeMovementToken(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		if t.ProductID != productID {
+			return nil, InvalidArgument("page_token", "was issued for another product")
+		}
+		after = t.Version
+	}
+
+	if _, err := live(uc.repo.FindByID(ctx, productID)); err != nil {
+		return nil, err
+	}
+	// fetch one extra movement to learn whether another page follows
+	movements, err := uc.repo.FindMovements(ctx, productID, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MovementPage{Movements: movements}
+	if int32(len(movements)) > pageSize {
+		page.Movements = movements[:pageSize]
+		last := page.Movements[pageSize-1]
+		page.NextPageToken = movementToken{ProductID: productID, Version: last.Version}.encode()
+	}
+	return page, nil
+}
@@ -0,0 +1,107 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProductUseCase_StockMovements(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	p, err := uc.CreateProduct(ctx, ProductInput{Name: "flour", Description: "25kg sack", Price: usd(1250), Quantity: 10})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+
+	moves := []MovementInput{
+		{Kind: "receipt", Quantity: 5, Actor: "alice"},
+		{Kind: "dispatch", Quantity: 8, Actor: "bob"},
+		{Kind: "write_off", Quantity: 1, Reason: "torn sack", Actor: "bob"},
+		{Kind: "adjustment", Quantity: -2, Reason: "stock count", Actor: "carol"},
+	}
+	for _, in := range moves {
+		if _, _, err := uc.RecordStockMovement(ctx, p.ID, in, 0); err != nil {
+			t.Fatalf("RecordStockMovement(%+v) failed: %v", in, err)
+		}
+	}
+
+	got, _ := uc.GetProduct(ctx, p.ID)
+	if got.Quantity != 4 {
+		t.Errorf("quantity should be derived from the ledger: want 4, got %d", got.Quantity)
+	}
+
+	// setting the quantity directly books the difference
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Quantity: ptr[int32](7)}, 0); err != nil {
+		t.Fatalf("UpdateProduct failed: %v", err)
+	}
+
+	page, err := uc.ListStockMovements(ctx, p.ID, 4, "")
+	if err != nil {
+		t.Fatalf("ListStockMovements failed: %v", err)
+	}
+	if len(page.Movements) != 4 || page.NextPageToken == "" {
+		t.Fatalf("expected a full first page and a token, got %d movements, token %q", len(page.Movements), page.NextPageToken)
+	}
+	if first := page.Movements[0]; first.Kind != MovementReceipt || first.Delta != 10 || first.Reason != "opening stock" {
+		t.Errorf("the opening stock should be the first receipt, got %+v", first)
+	}
+	if m := page.Movements[1]; m.Actor != "alice" || m.Balance != 15 {
+		t.Errorf("unexpected second movement %+v", m)
+	}
+	if m := page.Movements[2]; m.Delta != -8 {
+		t.Errorf("a dispatch should remove stock, got delta %d", m.Delta)
+	}
+
+	page, err = uc.ListStockMovements(ctx, p.ID, 4, page.NextPageToken)
+	if err != nil {
+		t.Fatalf("ListStockMovements with token failed: %v", err)
+	}
+	if len(page.Movements) != 2 || page.NextPageToken != "" {
+		t.Fatalf("expected the last 2 movements, got %d, token %q", len(page.Movements), page.NextPageToken)
+	}
+	if last := page.Movements[1]; last.Kind != MovementAdjustment || last.Delta != 3 || last.Balance != 7 {
+		t.Errorf("update should have booked an adjustment of 3, got %+v", last)
+	}
+}
+
+func TestProductUseCase_StockMovementValidation(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "salt", Price: usd(100), Quantity: 2})
+
+	tests := []struct {
+		in    MovementInput
+		field string
+	}{
+		{MovementInput{Kind: "gift", Quantity: 1, Actor: "a"}, "kind"},
+		{MovementInput{Kind: "receipt", Quantity: -1, Actor: "a"}, "quantity"},
+		{MovementInput{Kind: "adjustment", Quantity: 0, Reason: "x", Actor: "a"}, "quantity"},
+		{MovementInput{Kind: "write_off", Quantity: 1, Actor: "a"}, "reason"},
+		{MovementInput{Kind: "receipt", Quantity: 1}, "actor"},
+		{MovementInput{Kind: "dispatch", Quantity: 3, Actor: "a"}, "quantity"},
+	}
+	for _, tt := range tests {
+		_, _, err := uc.RecordStockMovement(ctx, p.ID, tt.in, 0)
+		if !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%+v should fail with ErrInvalidInput, got %v", tt.in, err)
+			continue
+		}
+		if v := FieldViolations(err); len(v) != 1 || v[0].Field != tt.field {
+			t.Errorf("%+v should violate %s, got %v", tt.in, tt.field, v)
+		}
+	}
+
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "receipt", Quantity: 1, Actor: "a"}, 9); KindOf(err) != KindPreconditionFailed {
+		t.Errorf("a stale expected version should fail its precondition, got %v", err)
+	}
+	if _, err := uc.ListStockMovements(ctx, "missing", 0, ""); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+	other, _ := uc.CreateProduct(ctx, ProductInput{Name: "sugar", Price: usd(100)})
+	token := movementToken{ProductID: p.ID, Version: 1}.encode()
+	if _, err := uc.ListStockMovements(ctx, other.ID, 0, token); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("a token of another product should be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Supplier is a company products are purchased from
+type Supplier struct {
+	ID          string
+	Name        string
+	ContactName string
+	Email       string
+	Phone       string
+	// LeadTimeDays is the usual number of days from ordering to delivery
+	LeadTimeDays int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// ProductSupplier links a product to a supplier it is sourced from
+type ProductSupplier struct {
+	ProductID  string
+	SupplierID string
+	// SupplierSKU is the supplier's own code for the product
+	SupplierSKU string
+	// CostPrice is what the supplier charges per unit
+	CostPrice Money
+	// Preferred marks the supplier to order from first, a product has at
+	// most one preferred supplier
+	Preferred bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SupplierRepo stores suppliers and their links to products
+type SupplierRepo interface {
+	// SaveSupplier inserts or replaces s
+	SaveSupplier(ctx context.Context, s *Supplier) error
+	// FindSupplier fails with ErrSupplierNotFound for an unknown id
+	FindSupplier(ctx context.Context, id string) (*Supplier, error)
+	// FindSuppliers returns every supplier ordered by name
+	FindSuppliers(ctx context.Context) ([]*Supplier, error)
+	// DeleteSupplier fails with ErrSupplierNotFound for an unknown id and
+	// with ErrSupplierInUse while products are linked to it
+	DeleteSupplier(ctx context.Context, id string) error
+	// SaveProductSupplier inserts or replaces the link between l.ProductID
+	// and l.SupplierID, a preferred link clears the flag on the other links
+	// of the product. It fails with ErrProductNotFound or
+	// ErrSupplierNotFound when either does not exist, or the product is in
+	// the trash.
+	SaveProductSupplier(ctx context.Context, l *ProductSupplier) error
+	// FindProductSuppliers returns the links of a product, the preferred one
+	// first and the others by supplier ID. It fails with ErrProductNotFound
+	// for an unknown or deleted product.
+	FindProductSuppliers(ctx context.Context, productID string) ([]*ProductSupplier, error)
+	// DeleteProductSupplier fails with ErrProductSupplierNotFound when the
+	// product is not linked to the supplier
+	DeleteProductSupplier(ctx context.Context, productID, supplierID string) error
+}
+
+// SupplierInput is the caller supplied part of a supplier
+type SupplierInput struct {
+	Name         string
+	ContactName  string
+	Email        string
+	Phone        string
+	LeadTimeDays int32
+}
+
+func (in SupplierInput) validate() error {
+	var errs []error
+	if strings.TrimSpace(in.Name) == "" {
+		errs = append(errs, InvalidArgument("name", "is required"))
+	}
+	if email := strings.TrimSpace(in.Email); email != "" {
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			errs = append(errs, InvalidArgument("email", "is not a valid email address"))
+		}
+	}
+	if in.LeadTimeDays < 0 {
+		errs = append(errs, InvalidArgument("lead_time_days", "must not be negative"))
+	}
+	return InvalidArguments(errs...)
+}
+
+// apply copies the input onto s
+func (in SupplierInput) apply(s *Supplier) {
+	s.Name = strings.TrimSpace(in.Name)
+	s.ContactName = strings.TrimSpace(in.ContactName)
+	s.Email = strings.TrimSpace(in.Email)
+	s.Phone = strings.TrimSpace(in.Phone)
+	s.LeadTimeDays = in.LeadTimeDays
+}
+
+// ProductSupplierInput is a caller supplied link between a product and a
+// supplier
+type ProductSupplierInput struct {
+	ProductID   string
+	SupplierID  string
+	SupplierSKU string
+	CostPrice   Money
+	Preferred   bool
+}
+
+func (in ProductSupplierInput) validate() error {
+	var errs []error
+	if in.ProductID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if in.SupplierID == "" {
+		errs = append(errs, InvalidArgument("supplier_id", "is required"))
+	}
+	errs = append(errs, in.CostPrice.validate("cost_price"))
+	return InvalidArguments(errs...)
+}
+
+// SupplierUseCase handles suppliers and where products are sourced from
+type SupplierUseCase struct {
+	repo ProductRepo
+}
+
+// NewSupplierUseCase creates a new supplier use case
+func NewSupplierUseCase(repo ProductRepo) *SupplierUseCase {
+	return &SupplierUseCase{repo: repo}
+}
+
+// CreateSupplier creates a new supplier
+func (uc *SupplierUseCase) CreateSupplier(ctx context.Context, in SupplierInput) (*Supplier, error) {
+	slog.Info("Creating supplier", "name", in.Name, "email", in.Email, "leadTimeDays", in.LeadTimeDays)
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	s := &Supplier{ID: uuid.New().String(), CreatedAt: now, UpdatedAt: now}
+	in.apply(s)
+	if err := uc.repo.SaveSupplier(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (uc *SupplierUseCase) GetSupplier(ctx context.Context, id string) (*Supplier, error) {
+	slog.Info("Getting supplier", "id", id)
+	if id == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	return uc.repo.FindSupplier(ctx, id)
+}
+
+// ListSuppliers retrieves every supplier ordered by name
+func (uc *SupplierUseCase) ListSuppliers(ctx context.Context) ([]*Supplier, error) {
+	slog.Info("Listing suppliers")
+	return uc.repo.FindSuppliers(ctx)
+}
+
+// UpdateSupplier replaces the details of a supplier
+func (uc *SupplierUseCase) UpdateSupplier(ctx context.Context, id string, in SupplierInput) (*Supplier, error) {
+	slog.Info("Updating supplier", "id", id, "name", in.Name, "email", in.Email, "leadTimeDays", in.LeadTimeDays)
+	var errs []error
+	if id == "" {
+		errs = append(errs, InvalidArgument("id", "is required"))
+	}
+	if err := InvalidArguments(append(errs, in.validate())...); err != nil {
+		return nil, err
+	}
+
+	s, err := uc.repo.FindSupplier(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	in.apply(s)
+	s.UpdatedAt = time.Now()
+	if err := uc.repo.SaveSupplier(ctx, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// DeleteSupplier deletes a supplier no product is linked to
+func (uc *SupplierUseCase) DeleteSupplier(ctx context.Context, id string) error {
+	slog.Info("Deleting supplier", "id", id)
+	if id == "" {
+		return InvalidArgument("id", "is required")
+	}
+	return uc.repo.DeleteSupplier(ctx, id)
+}
+
+// LinkProductSupplier records that a product is sourced from a supplier, or
+// updates the SKU, cost price and preference of an existing link. Marking a
+// supplier preferred unmarks the product's previously preferred one.
+func (uc *SupplierUseCase) LinkProductSupplier(ctx context.Context, in ProductSupplierInput) (*ProductSupplier, error) {
+	slog.Info("Linking product supplier", "productID", in.ProductID, "supplierID", in.SupplierID,
+		"supplierSKU", in.SupplierSKU, "costPrice", in.CostPrice, "preferred", in.Preferred)
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := live(uc.repo.FindByID(ctx, in.ProductID)); err != nil {
+		return nil, err
+	}
+	links, err := uc.repo.FindProductSuppliers(ctx, in.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	l := &ProductSupplier{
+		ProductID:   in.ProductID,
+		SupplierID:  in.SupplierID,
+		SupplierSKU: strings.TrimSpace(in.SupplierSKU),
+		CostPrice:   in.CostPrice,
+		Preferred:   in.Preferred,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	// relinking keeps when the product was first sourced from the supplier
+	for _, old := range links {
+		if old.SupplierID == in.SupplierID {
+			l.CreatedAt = old.CreatedAt
+		}
+	}
+
+	err = uc.repo.SaveProductSupplier(ctx, l)
+	if errors.Is(err, ErrSupplierNotFound) {
+		return nil, InvalidArgument("supplier_id", "unknown supplier %q", in.SupplierID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ListProductSuppliers retrieves the suppliers of a product, the preferred
+// one first
+func (uc *SupplierUseCase) ListProductSuppliers(ctx context.Context, productID string) ([]*ProductSupplier, error) {
+	slog.Info("Listing product suppliers", "productID", productID)
+	if productID == "" {
+		return nil, InvalidArgument("product_id", "is required")
+	}
+	if _, err := live(uc.repo.FindByID(ctx, productID)); err != nil {
+		return nil, err
+	}
+	return uc.repo.FindProductSuppliers(ctx, productID)
+}
+
+// UnlinkProductSupplier removes a supplier from the sources of a product
+func (uc *SupplierUseCase) UnlinkProductSupplier(ctx context.Context, productID, supplierID string) error {
+	slog.Info("Unlinking product supplier", "productID", productID, "supplierID", supplierID)
+	var errs []error
+	if productID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if supplierID == "" {
+		errs = append(errs, InvalidArgument("supplier_id", "is required"))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return err
+	}
+	return uc.repo.DeleteProductSupplier(ctx, productID, supplierID)
+}
@@ -0,0 +1,117 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSupplierUseCase_Suppliers(t *testing.T) {
+	uc := NewSupplierUseCase(newMockProductRepo())
+	ctx := context.Background()
+
+	acme, err := uc.CreateSupplier(ctx, SupplierInput{Name: " Acme Dairy ", Email: "orders@acme.test", LeadTimeDays: 3})
+	if err != nil {
+		t.Fatalf("CreateSupplier failed: %v", err)
+	}
+	if acme.Name != "Acme Dairy" || acme.LeadTimeDays != 3 {
+		t.Errorf("unexpected supplier %+v", acme)
+	}
+	_, err = uc.CreateSupplier(ctx, SupplierInput{Email: "Acme <orders@acme.test>", LeadTimeDays: -1})
+	if v := FieldViolations(err); len(v) != 3 || v[0].Field != "name" || v[1].Field != "email" || v[2].Field != "lead_time_days" {
+		t.Errorf("expected name, email and lead_time_days violations, got %v", err)
+	}
+
+	updated, err := uc.UpdateSupplier(ctx, acme.ID, SupplierInput{Name: "Acme", ContactName: "Jo", LeadTimeDays: 5})
+	if err != nil || updated.Name != "Acme" || updated.Email != "" || updated.CreatedAt != acme.CreatedAt {
+		t.Errorf("UpdateSupplier should replace the details, got %+v, %v", updated, err)
+	}
+	if _, err := uc.GetSupplier(ctx, "missing"); !errors.Is(err, ErrSupplierNotFound) || KindOf(err) != KindNotFound {
+		t.Errorf("expected ErrSupplierNotFound, got %v", err)
+	}
+	if all, err := uc.ListSuppliers(ctx); err != nil || len(all) != 1 {
+		t.Errorf("expected 1 supplier, got %+v, %v", all, err)
+	}
+	if err := uc.DeleteSupplier(ctx, acme.ID); err != nil {
+		t.Errorf("DeleteSupplier failed: %v", err)
+	}
+}
+
+func TestSupplierUseCase_ProductSuppliers(t *testing.T) {
+	repo := newMockProductRepo()
+	products := NewProductUseCase(repo, nil)
+	uc := NewSupplierUseCase(repo)
+	ctx := context.Background()
+
+	p, _ := products.CreateProduct(ctx, ProductInput{Name: "brie", Price: usd(400)})
+	acme, _ := uc.CreateSupplier(ctx, SupplierInput{Name: "Acme"})
+	best, _ := uc.CreateSupplier(ctx, SupplierInput{Name: "Best Foods"})
+
+	first, err := uc.LinkProductSupplier(ctx, ProductSupplierInput{ProductID: p.ID, SupplierID: acme.ID, SupplierSKU: "AC-1", CostPrice: usd(250), Preferred: true})
+	if err != nil {
+		t.Fatalf("LinkProductSupplier failed: %v", err)
+	}
+	if _, err := uc.LinkProductSupplier(ctx, ProductSupplierInput{ProductID: p.ID, SupplierID: best.ID, CostPrice: usd(220)}); err != nil {
+		t.Fatalf("LinkProductSupplier failed: %v", err)
+	}
+	links, err := uc.ListProductSuppliers(ctx, p.ID)
+	if err != nil || len(links) != 2 || links[0].SupplierID != acme.ID || !links[0].Preferred || links[1].Preferred {
+		t.Fatalf("expected acme preferred and best foods, got %+v, %v", links, err)
+	}
+
+	// preferring another supplier unmarks the previous one, relinking keeps
+	// the creation time
+	relinked, err := uc.LinkProductSupplier(ctx, ProductSupplierInput{ProductID: p.ID, SupplierID: acme.ID, SupplierSKU: "AC-2", CostPrice: usd(240)})
+	if err != nil || relinked.CreatedAt != first.CreatedAt || relinked.SupplierSKU != "AC-2" {
+		t.Errorf("relinking should update the link, got %+v, %v", relinked, err)
+	}
+	uc.LinkProductSupplier(ctx, ProductSupplierInput{ProductID: p.ID, SupplierID: best.ID, CostPrice: usd(220), Preferred: true})
+	links, _ = uc.ListProductSuppliers(ctx, p.ID)
+	if len(links) != 2 || links[0].SupplierID != best.ID || !links[0].Preferred || links[1].Preferred {
+		t.Errorf("expected best foods as the only preferred supplier, got %+v", links)
+	}
+
+	if err := uc.DeleteSupplier(ctx, acme.ID); !errors.Is(err, ErrSupplierInUse) || KindOf(err) != KindInvalidState {
+		t.Errorf("a linked supplier should not be deletable, got %v", err)
+	}
+	if err := uc.UnlinkProductSupplier(ctx, p.ID, acme.ID); err != nil {
+		t.Fatalf("UnlinkProductSupplier failed: %v", err)
+	}
+	if err := uc.UnlinkProductSupplier(ctx, p.ID, acme.ID); !errors.Is(err, ErrProductSupplierNotFound) {
+		t.Errorf("unlinking twice should fail with ErrProductSupplierNotFound, got %v", err)
+	}
+	if err := uc.DeleteSupplier(ctx, acme.ID); err != nil {
+		t.Errorf("an unlinked supplier should be deletable, got %v", err)
+	}
+
+	tests := []struct {
+		in    ProductSupplierInput
+		field string
+	}{
+		{ProductSupplierInput{SupplierID: best.ID, CostPrice: usd(100)}, "product_id"},
+		{ProductSupplierInput{ProductID: p.ID, CostPrice: usd(100)}, "supplier_id"},
+		{ProductSupplierInput{ProductID: p.ID, SupplierID: "missing", CostPrice: usd(100)}, "supplier_id"},
+		{ProductSupplierInput{ProductID: p.ID, SupplierID: best.ID, CostPrice: Money{Amount: 100}}, "cost_price.currency_code"},
+		{ProductSupplierInput{ProductID: p.ID, SupplierID: best.ID, CostPrice: usd(-100)}, "cost_price"},
+	}
+	for _, tt := range tests {
+		_, err := uc.LinkProductSupplier(ctx, tt.in)
+		if v := FieldViolations(err); len(v) != 1 || v[0].Field != tt.field {
+			t.Errorf("%+v should violate %s, got %v", tt.in, tt.field, err)
+		}
+	}
+	if _, err := uc.ListProductSuppliers(ctx, "missing"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+
+	// a product in the trash is not found, as on every other endpoint
+	if err := products.DeleteProduct(ctx, p.ID, 0); err != nil {
+		t.Fatalf("DeleteProduct failed: %v", err)
+	}
+	if _, err := uc.LinkProductSupplier(ctx, ProductSupplierInput{ProductID: p.ID, SupplierID: best.ID, CostPrice: usd(220)}); KindOf(err) != KindNotFound {
+		t.Errorf("linking a deleted product should fail with NotFound, got %v", err)
+	}
+	if _, err := uc.ListProductSuppliers(ctx, p.ID); KindOf(err) != KindNotFound {
+		t.Errorf("listing the suppliers of a deleted product should fail with NotFound, got %v", err)
+	}
+}
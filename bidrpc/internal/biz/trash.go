@@ -0,0 +1,120 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// live hides a product in the trash from a lookup, it is not found
+func live(p *Product, err error) (*Product, error) {
+	if err == nil && p.Deleted() {
+		return nil, ErrProductNotFound
+	}
+	return p, err
+}
+
+// RestoreProduct moves a deleted product out of the trash, with the stock,
+// ledger, SKU, barcodes, supplier links and price tiers it had. A variant
+// is only restored while its parent is live.
+// A non-zero expectedVersion makes the restore fail with ErrVersionConflict
+// unless the stored product still has that version.
+func (uc *ProductUseCase) RestoreProduct(ctx context.Context, id string, expectedVersion int64) (*Product, error) {
+	slog.Info("Restoring product", "id", id, "expectedVersion", expectedVersion)
+	if err := validateTarget(id, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	p, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != 0 && p.Version != expectedVersion {
+		return nil, PreconditionFailed(id, expectedVersion)
+	}
+	if !p.Deleted() {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotDeleted, id)
+	}
+	if p.ParentID != "" {
+		parent, err := uc.repo.FindByID(ctx, p.ParentID)
+		switch {
+		case errors.Is(err, ErrProductNotFound):
+			return nil, fmt.Errorf("%w: %s was purged", ErrParentDeleted, p.ParentID)
+		case err != nil:
+			return nil, err
+		case parent.Deleted():
+			return nil, fmt.Errorf("%w: restore %s first", ErrParentDeleted, p.ParentID)
+		}
+	}
+
+	p.DeletedAt = time.Time{}
+	p.Version++
+	p.UpdatedAt = time.Now()
+	err = uc.repo.Update(ctx, p)
+	if errors.Is(err, ErrVersionConflict) {
+		// restored or purged since it was read
+		return nil, PreconditionFailed(id, p.Version-1)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if uc.index != nil {
+		uc.index.Index(p)
+	}
+	return p, nil
+}
+
+// PurgeDeletedProducts permanently deletes the products deleted before
+// cutoff, with their ledgers, supplier links and price tiers, and returns
+// how many it purged
+func (uc *ProductUseCase) PurgeDeletedProducts(ctx context.Context, cutoff time.Time) (int, error) {
+	var n int
+	for {
+		// a deleted product is not written again until it is restored, so
+		// the trash in update order is in deletion order
+		deleted, _, err := uc.repo.FindAll(ctx, ListQuery{Limit: sweepBatch, SortBy: SortByUpdatedAt, Deleted: true})
+		if err != nil {
+			return n, err
+		}
+		for _, p := range deleted {
+			if !p.DeletedAt.Before(cutoff) {
+				return n, nil
+			}
+			err := uc.repo.Delete(ctx, p.ID, p.Version)
+			// restored or purged in the meantime
+			if errors.Is(err, ErrVersionConflict) || errors.Is(err, ErrProductNotFound) {
+				continue
+			}
+			if err != nil {
+				return n, err
+			}
+			n++
+		}
+		if len(deleted) < sweepBatch {
+			return n, nil
+		}
+	}
+}
+
+// SweepDeletedProducts purges the products deleted longer than retention
+// ago every interval until ctx is done
+func (uc *ProductUseCase) SweepDeletedProducts(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := uc.PurgeDeletedProducts(ctx, now.Add(-retention))
+			if err != nil && ctx.Err() == nil {
+				slog.Error("Purging deleted products failed", "error", err)
+			}
+			if n > 0 {
+				slog.Info("Purged deleted products", "count", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProductUseCase_Trash(t *testing.T) {
+	repo := newMockProductRepo()
+	uc := NewProductUseCase(repo, nil)
+	ctx := context.Background()
+
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "milk", Price: usd(100), Quantity: 10, Barcodes: []Barcode{{GTIN: "5449000000996"}}})
+	r, _, _ := uc.ReserveStock(ctx, p.ID, "", 4, 0, "order-1")
+	if err := uc.DeleteProduct(ctx, p.ID, p.Version); KindOf(err) != KindPreconditionFailed {
+		t.Errorf("a stale version should fail, got %v", err)
+	}
+	current, _ := uc.GetProduct(ctx, p.ID)
+	if got, _ := repo.FindReservation(ctx, r.ID); got.Status != ReservationHeld || current.Reserved != 4 {
+		t.Errorf("a failed delete should keep the reservations, got %s and %d reserved", got.Status, current.Reserved)
+	}
+
+	// the reservations are released in the write that deletes the product
+	if err := uc.DeleteProduct(ctx, p.ID, current.Version); err != nil {
+		t.Fatalf("DeleteProduct failed: %v", err)
+	}
+	if got, _ := repo.FindReservation(ctx, r.ID); got.Status != ReservationReleased {
+		t.Errorf("deleting should release the reservations, got %s", got.Status)
+	}
+	if got := repo.products[p.ID]; got.Version != current.Version+1 || got.Reserved != 0 || !got.Deleted() {
+		t.Errorf("expected one write releasing the stock, got %+v", got)
+	}
+	if _, err := uc.GetProduct(ctx, p.ID); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a deleted product should not be found, got %v", err)
+	}
+	if _, err := uc.GetProductBySKU(ctx, p.SKU); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a deleted product should not be found by SKU, got %v", err)
+	}
+	if _, _, err := uc.GetProductByBarcode(ctx, "5449000000996"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a deleted product should not be found by barcode, got %v", err)
+	}
+	name := "oat milk"
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Name: &name}, 0); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a deleted product should not be updated, got %v", err)
+	}
+	if err := uc.DeleteProduct(ctx, p.ID, 0); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a deleted product should not be deleted again, got %v", err)
+	}
+	// it holds on to its barcodes and SKU, so it can be restored
+	if _, err := uc.CreateProduct(ctx, ProductInput{Name: "x", Price: usd(100), Barcodes: []Barcode{{GTIN: "5449000000996"}}}); !hasViolation(err, "barcodes") {
+		t.Errorf("the barcode of a deleted product should stay taken, got %v", err)
+	}
+
+	live, _ := uc.ListProducts(ctx, ListOptions{})
+	trash, _ := uc.ListProducts(ctx, ListOptions{Deleted: true})
+	if live.Total != 0 || trash.Total != 1 || !trash.Products[0].Deleted() {
+		t.Errorf("expected the product in the trash only, got %d live and %d deleted", live.Total, trash.Total)
+	}
+
+	restored, err := uc.RestoreProduct(ctx, p.ID, 0)
+	if err != nil || restored.Deleted() || restored.Quantity != 10 || restored.Reserved != 0 {
+		t.Fatalf("expected the product back with its stock, got %+v, %v", restored, err)
+	}
+	if _, err := uc.RestoreProduct(ctx, p.ID, 0); !errors.Is(err, ErrProductNotDeleted) || KindOf(err) != KindInvalidState {
+		t.Errorf("restoring a live product should conflict, got %v", err)
+	}
+	if got, _, err := uc.GetProductByBarcode(ctx, "5449000000996"); err != nil || got.ID != p.ID {
+		t.Errorf("expected the restored product, got %+v, %v", got, err)
+	}
+
+	// a variant comes back after its parent
+	v, _ := uc.CreateProduct(ctx, ProductInput{Name: "milk 1l", Price: usd(100), ParentID: p.ID})
+	uc.DeleteProduct(ctx, v.ID, 0)
+	uc.DeleteProduct(ctx, p.ID, 0)
+	if _, err := uc.RestoreProduct(ctx, v.ID, 0); !errors.Is(err, ErrParentDeleted) {
+		t.Errorf("restoring a variant of a deleted product should fail, got %v", err)
+	}
+
+	// only what was deleted before the cutoff is purged
+	if n, err := uc.PurgeDeletedProducts(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Errorf("expected nothing purged, got %d, %v", n, err)
+	}
+	if n, err := uc.PurgeDeletedProducts(ctx, time.Now()); err != nil || n != 2 {
+		t.Errorf("expected both products purged, got %d, %v", n, err)
+	}
+	if _, err := uc.RestoreProduct(ctx, p.ID, 0); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("a purged product should be gone, got %v", err)
+	}
+}
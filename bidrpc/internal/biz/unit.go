@@ -0,0 +1,162 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultBaseUnit is the base unit of products that do not name one,
+	// their stock is counted in pieces
+	DefaultBaseUnit = "each"
+
+	// maxUnitConversions bounds the alternate units of a product
+	maxUnitConversions = 16
+)
+
+// UnitsOfMeasure is how the stock of a product is counted. Quantity, stock
+// levels, movements and reservations are whole numbers of Base, quantities
+// in the alternate units of Conversions are converted to it.
+type UnitsOfMeasure struct {
+	// Base is the smallest unit the product is handled in, e.g. each or g.
+	// Empty is DefaultBaseUnit.
+	Base        string
+	Conversions []UnitConversion
+}
+
+// UnitConversion defines an alternate unit of a product as Factor base
+// units, e.g. a case of 12 each or a kg of 1000 g
+type UnitConversion struct {
+	Code   string
+	Factor float64
+	// Fractional units are weights and the like: a quantity in them may have
+	// a fraction and is rounded to the nearest base unit. Other units are
+	// counted, their quantities and factors are whole numbers.
+	Fractional bool
+}
+
+// Measure is a quantity in a unit of a product, an empty Unit is the
+// product's base unit
+type Measure struct {
+	Value float64
+	Unit  string
+}
+
+// BaseUnit returns the base unit, DefaultBaseUnit for products stored
+// before units existed
+func (u UnitsOfMeasure) BaseUnit() string {
+	return cmp.Or(u.Base, DefaultBaseUnit)
+}
+
+// factor returns the base units in one unit of code and whether the unit
+// takes fractions. ok is false for a unit the product does not define.
+func (u UnitsOfMeasure) factor(code string) (factor float64, fractional, ok bool) {
+	if code == "" || code == u.BaseUnit() {
+		return 1, false, true
+	}
+	for _, c := range u.Conversions {
+		if c.Code == code {
+			return c.Factor, c.Fractional, true
+		}
+	}
+	return 0, false, false
+}
+
+// ToBase converts m to whole base units, reporting an invalid m as field
+func (u UnitsOfMeasure) ToBase(field string, m Measure) (int32, error) {
+	factor, fractional, ok := u.factor(m.Unit)
+	if !ok {
+		return 0, InvalidArgument(field+".unit", "%q is not a unit of the product, it is counted in %s", m.Unit, strings.Join(u.codes(), ", "))
+	}
+	unit := cmp.Or(m.Unit, u.BaseUnit())
+	if math.IsNaN(m.Value) || math.IsInf(m.Value, 0) {
+		return 0, InvalidArgument(field+".value", "is not a number")
+	}
+	if !fractional && m.Value != math.Trunc(m.Value) {
+		return 0, InvalidArgument(field+".value", "must be a whole number of %s", unit)
+	}
+	base := math.Round(m.Value * factor)
+	if math.Abs(base) > math.MaxInt32 {
+		return 0, InvalidArgument(field+".value", "is out of range")
+	}
+	return int32(base), nil
+}
+
+// codes returns the base unit followed by the alternate units
+func (u UnitsOfMeasure) codes() []string {
+	codes := []string{u.BaseUnit()}
+	for _, c := range u.Conversions {
+		codes = append(codes, c.Code)
+	}
+	return codes
+}
+
+// normalize returns u with a base unit and its conversions ordered by
+// factor, the order they are listed in
+func (u UnitsOfMeasure) normalize() UnitsOfMeasure {
+	out := UnitsOfMeasure{Base: u.BaseUnit(), Conversions: slices.Clone(u.Conversions)}
+	slices.SortFunc(out.Conversions, func(a, b UnitConversion) int {
+		return cmp.Or(cmp.Compare(a.Factor, b.Factor), strings.Compare(a.Code, b.Code))
+	})
+	return out
+}
+
+// validate checks u as the units of a product reported as field
+func (u UnitsOfMeasure) validate(field string) error {
+	var errs []error
+	if u.Base != "" && !validUnitCode(u.Base) {
+		errs = append(errs, InvalidArgument(field+".base_unit", "must be 1 to 16 lowercase letters, digits or underscores"))
+	}
+	if len(u.Conversions) > maxUnitConversions {
+		errs = append(errs, InvalidArgument(field+".conversions", "must not be more than %d", maxUnitConversions))
+	}
+	seen := map[string]bool{u.BaseUnit(): true}
+	for i, c := range u.Conversions {
+		f := fmt.Sprintf("%s.conversions[%d]", field, i)
+		switch {
+		case !validUnitCode(c.Code):
+			errs = append(errs, InvalidArgument(f+".code", "must be 1 to 16 lowercase letters, digits or underscores"))
+		case seen[c.Code]:
+			errs = append(errs, InvalidArgument(f+".code", "repeats %s", c.Code))
+		}
+		seen[c.Code] = true
+		switch {
+		case math.IsNaN(c.Factor) || c.Factor <= 0 || c.Factor > math.MaxInt32:
+			errs = append(errs, InvalidArgument(f+".factor", "must be positive and at most %d", math.MaxInt32))
+		case !c.Fractional && (c.Factor < 2 || c.Factor != math.Trunc(c.Factor)):
+			errs = append(errs, InvalidArgument(f+".factor", "must be a whole number of at least 2 for a counted unit"))
+		}
+	}
+	return InvalidArguments(errs...)
+}
+
+// validUnitCode reports whether s has the form of a unit code such as kg or
+// inner_pack
+func validUnitCode(s string) bool {
+	if len(s) == 0 || len(s) > 16 {
+		return false
+	}
+	for i := range len(s) {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < '0' || s[i] > '9') && s[i] != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// ToBaseQuantity converts m to base units of a product, for the operations
+// that take a quantity in any unit of the product
+func (uc *ProductUseCase) ToBaseQuantity(ctx context.Context, productID, field string, m Measure) (int32, error) {
+	if productID == "" {
+		return 0, InvalidArgument("product_id", "is required")
+	}
+	p, err := live(uc.repo.FindByID(ctx, productID))
+	if err != nil {
+		return 0, err
+	}
+	return p.Units.ToBase(field, m)
+}
@@ -0,0 +1,91 @@
+package biz
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUnitsOfMeasure_ToBase(t *testing.T) {
+	units := UnitsOfMeasure{Base: "g", Conversions: []UnitConversion{{Code: "kg", Factor: 1000, Fractional: true}, {Code: "sack", Factor: 25000}}}
+	tests := []struct {
+		m     Measure
+		want  int32
+		field string
+	}{
+		{Measure{Value: 250}, 250, ""},
+		{Measure{Value: 250, Unit: "g"}, 250, ""},
+		{Measure{Value: 1.5, Unit: "kg"}, 1500, ""},
+		{Measure{Value: 0.0004, Unit: "kg"}, 0, ""},
+		{Measure{Value: -2, Unit: "sack"}, -50000, ""},
+		{Measure{Value: 1.5, Unit: "sack"}, 0, "measure.value"},
+		{Measure{Value: 2.5}, 0, "measure.value"},
+		{Measure{Value: 1, Unit: "case"}, 0, "measure.unit"},
+		{Measure{Value: 1e9, Unit: "kg"}, 0, "measure.value"},
+	}
+	for _, tt := range tests {
+		got, err := units.ToBase("measure", tt.m)
+		if tt.field != "" {
+			if !hasViolation(err, tt.field) {
+				t.Errorf("ToBase(%+v): expected a %s violation, got %d, %v", tt.m, tt.field, got, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ToBase(%+v) = %d, %v, want %d", tt.m, got, err, tt.want)
+		}
+	}
+}
+
+func TestProductUseCase_Units(t *testing.T) {
+	repo := newMockProductRepo()
+	uc := NewProductUseCase(repo, nil)
+	ctx := context.Background()
+
+	_, err := uc.CreateProduct(ctx, ProductInput{Name: "milk", Price: usd(100), Units: UnitsOfMeasure{Base: "Each", Conversions: []UnitConversion{
+		{Code: "case", Factor: 12.5}, {Code: "case", Factor: 6}, {Code: "x", Factor: 1}, {Code: "kg", Factor: -1, Fractional: true},
+	}}})
+	for _, field := range []string{"units.base_unit", "units.conversions[0].factor", "units.conversions[1].code", "units.conversions[2].factor", "units.conversions[3].factor"} {
+		if !hasViolation(err, field) {
+			t.Errorf("expected a %s violation, got %v", field, err)
+		}
+	}
+
+	p, err := uc.CreateProduct(ctx, ProductInput{Name: "milk", Price: usd(100), Quantity: 24, Units: UnitsOfMeasure{Conversions: []UnitConversion{{Code: "case", Factor: 12}, {Code: "inner", Factor: 6}}}})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+	if p.Units.Base != DefaultBaseUnit || p.Units.Conversions[0].Code != "inner" || p.Units.Conversions[1].Code != "case" {
+		t.Errorf("expected each with inner before case, got %+v", p.Units)
+	}
+	if q, err := uc.ToBaseQuantity(ctx, p.ID, "measure", Measure{Value: 2, Unit: "case"}); err != nil || q != 24 {
+		t.Errorf("2 cases should be 24 each, got %d, %v", q, err)
+	}
+
+	// the base unit is fixed while the product holds stock
+	grams := UnitsOfMeasure{Base: "g", Conversions: []UnitConversion{{Code: "kg", Factor: 1000, Fractional: true}}}
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Units: &grams}, 0); !hasViolation(err, "units.base_unit") {
+		t.Errorf("changing the base unit of stock on hand should fail, got %v", err)
+	}
+	cases := UnitsOfMeasure{Conversions: []UnitConversion{{Code: "case", Factor: 24}}}
+	if got, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Units: &cases}, 0); err != nil || len(got.Units.Conversions) != 1 || got.Quantity != 24 {
+		t.Errorf("the conversions should be replaced, got %+v, %v", got, err)
+	}
+	empty, _ := uc.CreateProduct(ctx, ProductInput{Name: "flour", Price: usd(100)})
+	if got, err := uc.UpdateProduct(ctx, empty.ID, ProductPatch{Units: &grams}, 0); err != nil || got.Units.BaseUnit() != "g" {
+		t.Errorf("a product without stock may change its base unit, got %+v, %v", got, err)
+	}
+
+	f, err := ParseFilter(`quantity >= 1case AND quantity < "1.5 case"`)
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+	if got, _ := uc.GetProduct(ctx, p.ID); !f.Match(got) {
+		t.Errorf("24 each are one case of 24, %q should match", f)
+	}
+	if got, _ := uc.GetProduct(ctx, empty.ID); f.Match(got) {
+		t.Errorf("a product without cases should not match %q", f)
+	}
+	if _, err := ParseFilter(`quantity >= 2Case`); !hasViolation(err, "filter") {
+		t.Errorf("a malformed unit should fail, got %v", err)
+	}
+}
@@ -0,0 +1,287 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultWarehouseID is the warehouse holding stock that is booked without
+// naming one, including all stock recorded before warehouses existed
+const DefaultWarehouseID = "main"
+
+// Warehouse is a depot holding stock
+type Warehouse struct {
+	ID        string
+	Name      string
+	Address   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// StockLevel is the stock of a product in one warehouse
+type StockLevel struct {
+	// Quantity is the stock on hand
+	Quantity int32
+	// Reserved is the part of Quantity held by open reservations
+	Reserved int32
+}
+
+// Available returns the stock on hand that is not reserved
+func (l StockLevel) Available() int32 {
+	return l.Quantity - l.Reserved
+}
+
+// WarehouseRepo stores warehouses
+type WarehouseRepo interface {
+	// SaveWarehouse inserts or replaces w
+	SaveWarehouse(ctx context.Context, w *Warehouse) error
+	// FindWarehouse fails with ErrWarehouseNotFound for an unknown id
+	FindWarehouse(ctx context.Context, id string) (*Warehouse, error)
+	// FindWarehouses returns every warehouse ordered by name
+	FindWarehouses(ctx context.Context) ([]*Warehouse, error)
+	// DeleteWarehouse fails with ErrWarehouseNotFound for an unknown id
+	DeleteWarehouse(ctx context.Context, id string) error
+}
+
+// Levels returns the stock levels of p by warehouse ID. A product stored
+// before warehouses existed holds all its stock in the default warehouse.
+// The map must not be modified.
+func (p *Product) Levels() map[string]StockLevel {
+	if len(p.Stock) == 0 && (p.Quantity != 0 || p.Reserved != 0) {
+		return map[string]StockLevel{DefaultWarehouseID: {Quantity: p.Quantity, Reserved: p.Reserved}}
+	}
+	return p.Stock
+}
+
+// moveStock changes the stock level in a warehouse and the totals by the
+// given deltas. The levels are copied first since p may share them with the
+// stored product.
+func (p *Product) moveStock(warehouseID string, quantity, reserved int32) {
+	levels := maps.Clone(p.Levels())
+	if levels == nil {
+		levels = make(map[string]StockLevel)
+	}
+	l := levels[warehouseID]
+	l.Quantity += quantity
+	l.Reserved += reserved
+	if l == (StockLevel{}) {
+		delete(levels, warehouseID)
+	} else {
+		levels[warehouseID] = l
+	}
+	p.Stock = levels
+	p.Quantity += quantity
+	p.Reserved += reserved
+}
+
+// TransferInput is a caller supplied transfer of stock between warehouses.
+// Stock is taken out of Lot when it is set, or else first expiry first out,
+// and arrives in the same lots.
+type TransferInput struct {
+	From     string
+	To       string
+	Quantity int32
+	Reason   string
+	Actor    string
+	Lot      string
+}
+
+func (in TransferInput) validate() error {
+	var errs []error
+	if in.From == "" {
+		errs = append(errs, InvalidArgument("from_warehouse_id", "is required"))
+	}
+	if in.To == "" {
+		errs = append(errs, InvalidArgument("to_warehouse_id", "is required"))
+	}
+	if in.From != "" && in.From == in.To {
+		errs = append(errs, InvalidArgument("to_warehouse_id", "must differ from from_warehouse_id"))
+	}
+	if in.Quantity <= 0 {
+		errs = append(errs, InvalidArgument("quantity", "must be positive"))
+	}
+	if strings.TrimSpace(in.Actor) == "" {
+		errs = append(errs, InvalidArgument("actor", "is required"))
+	}
+	errs = append(errs, Lot{Code: in.Lot}.validate(MovementTransfer))
+	return InvalidArguments(errs...)
+}
+
+// TransferStock moves stock of a product from one warehouse to another as a
+// single ledger entry, the product's total quantity is unchanged.
+// A non-zero expectedVersion makes it fail with ErrVersionConflict unless
+// the stored product still has that version.
+func (uc *ProductUseCase) TransferStock(ctx context.Context, productID string, in TransferInput, expectedVersion int64) (*StockMovement, *Product, error) {
+	slog.Info("Transferring stock", "productID", productID, "from", in.From, "to", in.To, "quantity", in.Quantity,
+		"reason", in.Reason, "actor", in.Actor, "expectedVersion", expectedVersion)
+	var errs []error
+	if productID == "" {
+		errs = append(errs, InvalidArgument("product_id", "is required"))
+	}
+	if expectedVersion < 0 {
+		errs = append(errs, InvalidArgument("expected_version", "must not be negative"))
+	}
+	if err := InvalidArguments(append(errs, in.validate())...); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.checkWarehouse(ctx, "from_warehouse_id", in.From); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.checkWarehouse(ctx, "to_warehouse_id", in.To); err != nil {
+		return nil, nil, err
+	}
+
+	var m *StockMovement
+	product, err := uc.write(ctx, productID, expectedVersion, func(p *Product) (productWrite, error) {
+		from := p.Levels()[in.From]
+		if in.Quantity > from.Available() {
+			return productWrite{}, InvalidArgument("quantity", "exceeds the available stock of %d in warehouse %s", from.Available(), in.From)
+		}
+		lots, err := p.allocate(in.From, in.Lot, in.Quantity, false, time.Now())
+		if err != nil {
+			return productWrite{}, err
+		}
+		p.moveLots(in.From, in.To, lots)
+		p.moveStock(in.From, -in.Quantity, 0)
+		p.moveStock(in.To, in.Quantity, 0)
+		m = &StockMovement{
+			ID:            uuid.New().String(),
+			ProductID:     p.ID,
+			WarehouseID:   in.From,
+			ToWarehouseID: in.To,
+			Kind:          MovementTransfer,
+			Delta:         -in.Quantity,
+			Lots:          lots,
+			Balance:       p.Quantity,
+			Reason:        strings.TrimSpace(in.Reason),
+			Actor:         strings.TrimSpace(in.Actor),
+		}
+		return productWrite{movement: m}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, product, nil
+}
+
+// checkWarehouse fails with an invalid field when warehouse id does not
+// exist. The default warehouse always exists.
+func (uc *ProductUseCase) checkWarehouse(ctx context.Context, field, id string) error {
+	if id == DefaultWarehouseID {
+		return nil
+	}
+	_, err := uc.repo.FindWarehouse(ctx, id)
+	if errors.Is(err, ErrWarehouseNotFound) {
+		return InvalidArgument(field, "unknown warehouse %q", id)
+	}
+	return err
+}
+
+// EnsureDefaultWarehouse stores the default warehouse unless it exists
+func (uc *ProductUseCase) EnsureDefaultWarehouse(ctx context.Context) error {
+	_, err := uc.repo.FindWarehouse(ctx, DefaultWarehouseID)
+	if !errors.Is(err, ErrWarehouseNotFound) {
+		return err
+	}
+	now := time.Now()
+	return uc.repo.SaveWarehouse(ctx, &Warehouse{ID: DefaultWarehouseID, Name: "Main", CreatedAt: now, UpdatedAt: now})
+}
+
+// CreateWarehouse creates a new warehouse
+func (uc *ProductUseCase) CreateWarehouse(ctx context.Context, name, address string) (*Warehouse, error) {
+	slog.Info("Creating warehouse", "name", name, "address", address)
+	if strings.TrimSpace(name) == "" {
+		return nil, InvalidArgument("name", "is required")
+	}
+
+	now := time.Now()
+	w := &Warehouse{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(name),
+		Address:   strings.TrimSpace(address),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.repo.SaveWarehouse(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// GetWarehouse retrieves a warehouse by ID
+func (uc *ProductUseCase) GetWarehouse(ctx context.Context, id string) (*Warehouse, error) {
+	slog.Info("Getting warehouse", "id", id)
+	if id == "" {
+		return nil, InvalidArgument("id", "is required")
+	}
+	return uc.repo.FindWarehouse(ctx, id)
+}
+
+// ListWarehouses retrieves every warehouse ordered by name
+func (uc *ProductUseCase) ListWarehouses(ctx context.Context) ([]*Warehouse, error) {
+	slog.Info("Listing warehouses")
+	return uc.repo.FindWarehouses(ctx)
+}
+
+// UpdateWarehouse replaces the name and address of a warehouse
+func (uc *ProductUseCase) UpdateWarehouse(ctx context.Context, id, name, address string) (*Warehouse, error) {
+	slog.Info("Updating warehouse", "id", id, "name", name, "address", address)
+	var errs []error
+	if id == "" {
+		errs = append(errs, InvalidArgument("id", "is required"))
+	}
+	if strings.TrimSpace(name) == "" {
+		errs = append(errs, InvalidArgument("name", "is required"))
+	}
+	if err := InvalidArguments(errs...); err != nil {
+		return nil, err
+	}
+
+	w, err := uc.repo.FindWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	w.Name = strings.TrimSpace(name)
+	w.Address = strings.TrimSpace(address)
+	w.UpdatedAt = time.Now()
+	if err := uc.repo.SaveWarehouse(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// DeleteWarehouse deletes a warehouse that holds no stock. The default
+// warehouse cannot be deleted.
+func (uc *ProductUseCase) DeleteWarehouse(ctx context.Context, id string) error {
+	slog.Info("Deleting warehouse", "id", id)
+	if id == "" {
+		return InvalidArgument("id", "is required")
+	}
+	if id == DefaultWarehouseID {
+		return InvalidArgument("id", "the default warehouse cannot be deleted")
+	}
+	if _, err := uc.repo.FindWarehouse(ctx, id); err != nil {
+		return err
+	}
+
+	// products in the trash get their stock back when restored
+	_, stocked, err := uc.repo.FindAll(ctx, ListQuery{Warehouse: id, Limit: 1})
+	if err != nil {
+		return err
+	}
+	_, trashed, err := uc.repo.FindAll(ctx, ListQuery{Warehouse: id, Limit: 1, Deleted: true})
+	if err != nil {
+		return err
+	}
+	if stocked += trashed; stocked > 0 {
+		return fmt.Errorf("%w: %d products have stock in warehouse %s", ErrWarehouseInUse, stocked, id)
+	}
+	return uc.repo.DeleteWarehouse(ctx, id)
+}
@@ -0,0 +1,127 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProductUseCase_Warehouses(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+
+	if err := uc.EnsureDefaultWarehouse(ctx); err != nil {
+		t.Fatalf("EnsureDefaultWarehouse failed: %v", err)
+	}
+	north, err := uc.CreateWarehouse(ctx, "North depot", "1 Harbour St")
+	if err != nil {
+		t.Fatalf("CreateWarehouse failed: %v", err)
+	}
+	if _, err := uc.CreateWarehouse(ctx, " ", ""); KindOf(err) != KindInvalidArgument {
+		t.Errorf("a warehouse without a name should be rejected, got %v", err)
+	}
+
+	all, err := uc.ListWarehouses(ctx)
+	if err != nil || len(all) != 2 || all[0].ID != DefaultWarehouseID || all[1].ID != north.ID {
+		t.Fatalf("expected the default and north warehouses by name, got %+v, %v", all, err)
+	}
+	renamed, err := uc.UpdateWarehouse(ctx, north.ID, "North", "2 Harbour St")
+	if err != nil || renamed.Name != "North" || renamed.CreatedAt != north.CreatedAt {
+		t.Errorf("UpdateWarehouse should replace name and address only, got %+v, %v", renamed, err)
+	}
+	if _, err := uc.GetWarehouse(ctx, "missing"); !errors.Is(err, ErrWarehouseNotFound) {
+		t.Errorf("expected ErrWarehouseNotFound, got %v", err)
+	}
+
+	// a warehouse holding stock cannot be deleted, nor the default one
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "flour", Price: usd(100)})
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "receipt", Quantity: 2, Actor: "a", Warehouse: north.ID}, 0); err != nil {
+		t.Fatalf("RecordStockMovement failed: %v", err)
+	}
+	if err := uc.DeleteWarehouse(ctx, north.ID); !errors.Is(err, ErrWarehouseInUse) || KindOf(err) != KindInvalidState {
+		t.Errorf("expected ErrWarehouseInUse, got %v", err)
+	}
+	if err := uc.DeleteWarehouse(ctx, DefaultWarehouseID); KindOf(err) != KindInvalidArgument {
+		t.Errorf("the default warehouse should not be deletable, got %v", err)
+	}
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "dispatch", Quantity: 2, Actor: "a", Warehouse: north.ID}, 0); err != nil {
+		t.Fatalf("RecordStockMovement failed: %v", err)
+	}
+	if err := uc.DeleteWarehouse(ctx, north.ID); err != nil {
+		t.Errorf("an empty warehouse should be deletable, got %v", err)
+	}
+	if _, _, err := uc.RecordStockMovement(ctx, p.ID, MovementInput{Kind: "receipt", Quantity: 1, Actor: "a", Warehouse: north.ID}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("booking into a deleted warehouse should fail, got %v", err)
+	}
+}
+
+func TestProductUseCase_TransferStock(t *testing.T) {
+	uc := NewProductUseCase(newMockProductRepo(), nil)
+	ctx := context.Background()
+	north, _ := uc.CreateWarehouse(ctx, "North", "")
+	south, _ := uc.CreateWarehouse(ctx, "South", "")
+
+	p, _ := uc.CreateProduct(ctx, ProductInput{Name: "flour", Price: usd(100), Quantity: 10})
+	if _, _, err := uc.ReserveStock(ctx, p.ID, "", 4, 0, "order-1"); err != nil {
+		t.Fatalf("ReserveStock failed: %v", err)
+	}
+
+	// reserved stock stays behind
+	in := TransferInput{From: DefaultWarehouseID, To: north.ID, Quantity: 7, Actor: "alice"}
+	if _, _, err := uc.TransferStock(ctx, p.ID, in, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("transferring reserved stock should fail, got %v", err)
+	}
+	in.Quantity = 6
+	m, got, err := uc.TransferStock(ctx, p.ID, in, 0)
+	if err != nil {
+		t.Fatalf("TransferStock failed: %v", err)
+	}
+	if m.Kind != MovementTransfer || m.WarehouseID != DefaultWarehouseID || m.ToWarehouseID != north.ID || m.Delta != -6 || m.Balance != 10 {
+		t.Errorf("unexpected transfer movement %+v", m)
+	}
+	want := map[string]StockLevel{DefaultWarehouseID: {Quantity: 4, Reserved: 4}, north.ID: {Quantity: 6}}
+	if got.Quantity != 10 || got.Reserved != 4 || len(got.Stock) != 2 || got.Stock[DefaultWarehouseID] != want[DefaultWarehouseID] || got.Stock[north.ID] != want[north.ID] {
+		t.Errorf("expected %v with 10 in total, got %v with %d", want, got.Stock, got.Quantity)
+	}
+
+	// a product spread over several warehouses has no single quantity to set
+	if _, err := uc.UpdateProduct(ctx, p.ID, ProductPatch{Quantity: ptr[int32](3)}, 0); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("setting the quantity of a product in 2 warehouses should fail, got %v", err)
+	}
+
+	page, err := uc.ListProducts(ctx, ListOptions{Warehouse: north.ID})
+	if err != nil || page.Total != 1 {
+		t.Errorf("the product should be listed in the north warehouse, got %+v, %v", page, err)
+	}
+	if page, _ := uc.ListProducts(ctx, ListOptions{Warehouse: south.ID}); page.Total != 0 {
+		t.Errorf("nothing is stocked in the south warehouse, got %d", page.Total)
+	}
+
+	tests := []struct {
+		in    TransferInput
+		field string
+	}{
+		{TransferInput{To: north.ID, Quantity: 1, Actor: "a"}, "from_warehouse_id"},
+		{TransferInput{From: north.ID, To: north.ID, Quantity: 1, Actor: "a"}, "to_warehouse_id"},
+		{TransferInput{From: north.ID, To: "nowhere", Quantity: 1, Actor: "a"}, "to_warehouse_id"},
+		{TransferInput{From: north.ID, To: south.ID, Actor: "a"}, "quantity"},
+		{TransferInput{From: north.ID, To: south.ID, Quantity: 1}, "actor"},
+	}
+	for _, tt := range tests {
+		_, _, err := uc.TransferStock(ctx, p.ID, tt.in, 0)
+		if v := FieldViolations(err); len(v) != 1 || v[0].Field != tt.field {
+			t.Errorf("%+v should violate %s, got %v", tt.in, tt.field, err)
+		}
+	}
+}
+
+func TestProduct_LevelsOfLegacyProduct(t *testing.T) {
+	p := &Product{Quantity: 5, Reserved: 2}
+	if l := p.Levels(); len(l) != 1 || l[DefaultWarehouseID] != (StockLevel{Quantity: 5, Reserved: 2}) {
+		t.Errorf("stock stored before warehouses should be in the default warehouse, got %v", l)
+	}
+	p.moveStock("north", 1, 0)
+	if p.Quantity != 6 || len(p.Stock) != 2 || p.Stock[DefaultWarehouseID].Quantity != 5 {
+		t.Errorf("moving stock should keep the legacy levels, got %v", p.Stock)
+	}
+}
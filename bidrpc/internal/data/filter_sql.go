@@ -0,0 +1,83 @@
+package data
+
+import (
+	"strings"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// filterColumns maps filter fields to SQL expressions comparable with the
+// values of a biz.FilterCompare: text lower-cased, prices in major units,
+// times in unix nanoseconds.
+// Parent IDs are lower-case UUIDs and compare as stored, which keeps their
+// index usable. Attributes have no column and are matched in memory, so is
+// the description: SQLite's lower() folds ASCII only and would miss
+// "ÉCLAIR" for "éclair".
+var filterColumns = map[string]string{
+	"id":         "lower(id)",
+	"name":       "name_lower",
+	"parent_id":  "parent_id",
+	"price":      "price_major",
+	"quantity":   "quantity",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+var sqlOps = map[string]string{
+	"=":  "=",
+	":":  "=",
+	"!=": "<>",
+	"<":  "<",
+	"<=": "<=",
+	">":  ">",
+	">=": ">=",
+}
+
+// sqlFilter translates a filter expression into a WHERE condition.
+// ok is false when part of the expression cannot be expressed in SQL.
+func sqlFilter(e biz.FilterExpr) (cond string, args []any, ok bool) {
+	switch e := e.(type) {
+	case *biz.FilterAnd:
+		return sqlJoin(e.Terms, " AND ")
+	case *biz.FilterOr:
+		return sqlJoin(e.Terms, " OR ")
+	case *biz.FilterNot:
+		cond, args, ok := sqlFilter(e.Term)
+		return "NOT (" + cond + ")", args, ok
+	case *biz.FilterCompare:
+		column, known := filterColumns[e.Field]
+		if !known {
+			return "", nil, false
+		}
+		switch e.Kind {
+		case biz.FilterText:
+			if e.Op == ":" {
+				return column + ` LIKE ? ESCAPE '\'`, []any{"%" + likeEscaper.Replace(e.Text) + "%"}, true
+			}
+			return column + " " + sqlOps[e.Op] + " ?", []any{e.Text}, true
+		case biz.FilterNumber:
+			// units of measure are per product
+			if e.Unit != "" {
+				return "", nil, false
+			}
+			return column + " " + sqlOps[e.Op] + " ?", []any{e.Number}, true
+		case biz.FilterTime:
+			return column + " " + sqlOps[e.Op] + " ?", []any{e.Time.UnixNano()}, true
+		}
+	}
+	return "", nil, false
+}
+
+func sqlJoin(terms []biz.FilterExpr, sep string) (string, []any, bool) {
+	conds := make([]string, len(terms))
+	var args []any
+	for i, t := range terms {
+		cond, a, ok := sqlFilter(t)
+		if !ok {
+			return "", nil, false
+		}
+		conds[i] = cond
+		args = append(args, a...)
+	}
+	return "(" + strings.Join(conds, sep) + ")", args, true
+}
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+func TestProductRepos_Filter(t *testing.T) {
+	filters := []string{
+		`quantity < 1`,
+		`price >= 1 AND price < 3`,
+		`name:an OR quantity = 1`,
+		`NOT name:"apple"`,
+		`name = banana`,
+		`description:TEST AND updated_at > 2000-01-01`,
+		`id:"id-1" OR id = "id-8"`,
+		`description:"éclair"`,
+	}
+
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		products := seedSortable(t, repo)
+		ctx := context.Background()
+		// prices in other currencies compare in their own major units
+		for id, price := range map[string]biz.Money{"yen": {Amount: 2, Currency: "JPY"}, "dinar": {Amount: 1500, Currency: "BHD"}} {
+			p := newTestProduct(id)
+			p.Price = price
+			if id == "yen" {
+				p.Description = "Pâte à choux ÉCLAIR"
+			}
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			products = append(products, p)
+		}
+
+		for _, src := range filters {
+			f, err := biz.ParseFilter(src)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) failed: %v", src, err)
+			}
+			q := biz.ListQuery{Filter: f, SortBy: biz.SortByPrice, Limit: 100}
+
+			var want []string
+			for _, p := range products {
+				if q.Match(p) {
+					want = append(want, p.ID)
+				}
+			}
+
+			got, total, err := repo.FindAll(ctx, q)
+			if err != nil {
+				t.Fatalf("FindAll(%q) failed: %v", src, err)
+			}
+			var ids []string
+			for _, p := range got {
+				ids = append(ids, p.ID)
+			}
+			slices.Sort(want)
+			slices.Sort(ids)
+			if int(total) != len(want) || !slices.Equal(ids, want) {
+				t.Errorf("FindAll(%q) = %v (total %d), want %v", src, ids, total, want)
+			}
+		}
+	})
+}
+
+// opaqueExpr is a filter node the SQL translation does not know
+type opaqueExpr struct{}
+
+func (opaqueExpr) Match(p *biz.Product) bool {
+	return strings.HasSuffix(p.ID, "1") || strings.HasSuffix(p.ID, "2")
+}
+
+func TestProductSQL_FilterFallback(t *testing.T) {
+	r := newTestProductSQL(t)
+	seedSortable(t, r)
+	ctx := context.Background()
+
+	q := biz.ListQuery{Filter: &biz.Filter{Expr: opaqueExpr{}}, SortBy: biz.SortByName, Limit: 1}
+	first, total, err := r.FindAll(ctx, q)
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if total != 2 || len(first) != 1 {
+		t.Fatalf("expected 1 of 2 products, got %d of %d", len(first), total)
+	}
+
+	q.After = biz.NewCursor(first[0], q)
+	second, _, err := r.FindAll(ctx, q)
+	if err != nil {
+		t.Fatalf("FindAll after cursor failed: %v", err)
+	}
+	if len(second) != 1 || second[0].ID == first[0].ID {
+		t.Errorf("expected the other product on the second page, got %v", second)
+	}
+}
@@ -0,0 +1,13 @@
+CREATE TABLE products (
+    id          TEXT PRIMARY KEY,
+    name        TEXT    NOT NULL,
+    name_lower  TEXT    NOT NULL,
+    description TEXT    NOT NULL DEFAULT '',
+    price       REAL    NOT NULL DEFAULT 0,
+    quantity    INTEGER NOT NULL DEFAULT 0,
+    created_at  INTEGER NOT NULL,
+    updated_at  INTEGER NOT NULL
+);
+
+CREATE INDEX idx_products_name_lower ON products (name_lower);
+CREATE INDEX idx_products_created_at ON products (created_at);
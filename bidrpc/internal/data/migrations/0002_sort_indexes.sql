@@ -0,0 +1,3 @@
+CREATE INDEX idx_products_price ON products (price, id);
+CREATE INDEX idx_products_quantity ON products (quantity, id);
+CREATE INDEX idx_products_updated_at ON products (updated_at, id);
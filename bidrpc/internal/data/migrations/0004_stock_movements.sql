@@ -0,0 +1,12 @@
+CREATE TABLE stock_movements (
+    id         TEXT PRIMARY KEY,
+    product_id TEXT    NOT NULL,
+    version    INTEGER NOT NULL,
+    kind       TEXT    NOT NULL,
+    delta      INTEGER NOT NULL,
+    balance    INTEGER NOT NULL,
+    reason     TEXT    NOT NULL DEFAULT '',
+    actor      TEXT    NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    UNIQUE (product_id, version)
+);
@@ -0,0 +1,15 @@
+ALTER TABLE products ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE reservations (
+    id         TEXT PRIMARY KEY,
+    product_id TEXT    NOT NULL,
+    quantity   INTEGER NOT NULL,
+    status     TEXT    NOT NULL,
+    actor      TEXT    NOT NULL DEFAULT '',
+    expires_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_reservations_product ON reservations (product_id);
+CREATE INDEX idx_reservations_expiry ON reservations (status, expires_at);
@@ -0,0 +1,25 @@
+CREATE TABLE warehouses (
+    id         TEXT PRIMARY KEY,
+    name       TEXT    NOT NULL,
+    address    TEXT    NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE TABLE product_stock (
+    product_id   TEXT    NOT NULL,
+    warehouse_id TEXT    NOT NULL,
+    quantity     INTEGER NOT NULL,
+    reserved     INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, warehouse_id)
+);
+
+CREATE INDEX idx_product_stock_warehouse ON product_stock (warehouse_id);
+
+-- stock booked before warehouses existed is held by the default warehouse
+INSERT INTO product_stock (product_id, warehouse_id, quantity, reserved)
+SELECT id, 'main', quantity, reserved FROM products WHERE quantity <> 0 OR reserved <> 0;
+
+ALTER TABLE stock_movements ADD COLUMN warehouse_id TEXT NOT NULL DEFAULT 'main';
+ALTER TABLE stock_movements ADD COLUMN to_warehouse_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE reservations ADD COLUMN warehouse_id TEXT NOT NULL DEFAULT 'main';
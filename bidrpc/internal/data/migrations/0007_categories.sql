@@ -0,0 +1,13 @@
+CREATE TABLE categories (
+    id         TEXT PRIMARY KEY,
+    name       TEXT    NOT NULL,
+    parent_id  TEXT    NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_categories_parent ON categories (parent_id);
+
+ALTER TABLE products ADD COLUMN category_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_products_category ON products (category_id);
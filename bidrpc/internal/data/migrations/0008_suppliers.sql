@@ -0,0 +1,23 @@
+CREATE TABLE suppliers (
+    id             TEXT PRIMARY KEY,
+    name           TEXT    NOT NULL,
+    contact_name   TEXT    NOT NULL DEFAULT '',
+    email          TEXT    NOT NULL DEFAULT '',
+    phone          TEXT    NOT NULL DEFAULT '',
+    lead_time_days INTEGER NOT NULL DEFAULT 0,
+    created_at     INTEGER NOT NULL,
+    updated_at     INTEGER NOT NULL
+);
+
+CREATE TABLE product_suppliers (
+    product_id   TEXT    NOT NULL,
+    supplier_id  TEXT    NOT NULL,
+    supplier_sku TEXT    NOT NULL DEFAULT '',
+    cost_price   REAL    NOT NULL DEFAULT 0,
+    preferred    INTEGER NOT NULL DEFAULT 0,
+    created_at   INTEGER NOT NULL,
+    updated_at   INTEGER NOT NULL,
+    PRIMARY KEY (product_id, supplier_id)
+);
+
+CREATE INDEX idx_product_suppliers_supplier ON product_suppliers (supplier_id);
@@ -0,0 +1,13 @@
+-- amounts become exact minor units of a currency, the REAL amounts stored
+-- before carried none and are read as USD
+ALTER TABLE products ADD COLUMN price_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN price_currency TEXT NOT NULL DEFAULT 'USD';
+UPDATE products SET price_amount = CAST(round(price * 100) AS INTEGER);
+DROP INDEX idx_products_price;
+ALTER TABLE products DROP COLUMN price;
+CREATE INDEX idx_products_price ON products (price_amount, id);
+
+ALTER TABLE product_suppliers ADD COLUMN cost_price_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE product_suppliers ADD COLUMN cost_price_currency TEXT NOT NULL DEFAULT 'USD';
+UPDATE product_suppliers SET cost_price_amount = CAST(round(cost_price * 100) AS INTEGER);
+ALTER TABLE product_suppliers DROP COLUMN cost_price;
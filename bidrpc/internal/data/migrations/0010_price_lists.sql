@@ -0,0 +1,25 @@
+CREATE TABLE price_lists (
+    id         TEXT PRIMARY KEY,
+    name       TEXT    NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+-- a customer buys on at most one price list
+CREATE TABLE price_list_customers (
+    customer_id   TEXT PRIMARY KEY,
+    price_list_id TEXT NOT NULL
+);
+
+CREATE INDEX idx_price_list_customers_list ON price_list_customers (price_list_id);
+
+CREATE TABLE price_tiers (
+    price_list_id  TEXT    NOT NULL,
+    product_id     TEXT    NOT NULL,
+    min_quantity   INTEGER NOT NULL,
+    price_amount   INTEGER NOT NULL,
+    price_currency TEXT    NOT NULL,
+    PRIMARY KEY (price_list_id, product_id, min_quantity)
+);
+
+CREATE INDEX idx_price_tiers_product ON price_tiers (product_id);
@@ -0,0 +1,32 @@
+CREATE TABLE auctions (
+    id                     TEXT PRIMARY KEY,
+    product_id             TEXT    NOT NULL,
+    warehouse_id           TEXT    NOT NULL,
+    quantity               INTEGER NOT NULL,
+    reserve_price_amount   INTEGER NOT NULL,
+    reserve_price_currency TEXT    NOT NULL,
+    opens_at               INTEGER NOT NULL,
+    closes_at              INTEGER NOT NULL,
+    status                 TEXT    NOT NULL,
+    reservation_id         TEXT    NOT NULL,
+    winning_bid_id         TEXT    NOT NULL DEFAULT '',
+    winner_id              TEXT    NOT NULL DEFAULT '',
+    winning_price_amount   INTEGER NOT NULL DEFAULT 0,
+    winning_price_currency TEXT    NOT NULL DEFAULT '',
+    outcome                TEXT    NOT NULL DEFAULT '',
+    created_at             INTEGER NOT NULL,
+    updated_at             INTEGER NOT NULL
+);
+
+CREATE INDEX idx_auctions_closing ON auctions (status, closes_at);
+
+-- a bidder has one bid per auction
+CREATE TABLE bids (
+    auction_id   TEXT    NOT NULL,
+    bidder_id    TEXT    NOT NULL,
+    id           TEXT    NOT NULL,
+    amount       INTEGER NOT NULL,
+    currency     TEXT    NOT NULL,
+    submitted_at INTEGER NOT NULL,
+    PRIMARY KEY (auction_id, bidder_id)
+);
@@ -0,0 +1,11 @@
+-- stock is counted in a base unit, products stored before units existed
+-- are counted in pieces
+ALTER TABLE products ADD COLUMN base_unit TEXT NOT NULL DEFAULT 'each';
+
+CREATE TABLE product_units (
+    product_id TEXT    NOT NULL,
+    code       TEXT    NOT NULL,
+    factor     REAL    NOT NULL,
+    fractional INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, code)
+);
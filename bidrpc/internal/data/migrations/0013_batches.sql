@@ -0,0 +1,36 @@
+-- stock tracked by lot, expires_at is 0 for a lot that does not expire
+CREATE TABLE product_batches (
+    product_id   TEXT    NOT NULL,
+    warehouse_id TEXT    NOT NULL,
+    lot_code     TEXT    NOT NULL,
+    expires_at   INTEGER NOT NULL DEFAULT 0,
+    received_at  INTEGER NOT NULL,
+    quantity     INTEGER NOT NULL,
+    reserved     INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (product_id, warehouse_id, lot_code)
+);
+
+CREATE INDEX idx_product_batches_expiry ON product_batches (expires_at);
+
+-- the split of movements and reservations by lot, in allocation order
+CREATE TABLE movement_lots (
+    movement_id TEXT    NOT NULL,
+    product_id  TEXT    NOT NULL,
+    position    INTEGER NOT NULL,
+    lot_code    TEXT    NOT NULL,
+    quantity    INTEGER NOT NULL,
+    PRIMARY KEY (movement_id, position)
+);
+
+CREATE INDEX idx_movement_lots_product ON movement_lots (product_id);
+
+CREATE TABLE reservation_lots (
+    reservation_id TEXT    NOT NULL,
+    product_id     TEXT    NOT NULL,
+    position       INTEGER NOT NULL,
+    lot_code       TEXT    NOT NULL,
+    quantity       INTEGER NOT NULL,
+    PRIMARY KEY (reservation_id, position)
+);
+
+CREATE INDEX idx_reservation_lots_product ON reservation_lots (product_id);
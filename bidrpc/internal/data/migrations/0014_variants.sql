@@ -0,0 +1,30 @@
+-- a variant points at its parent product, standalone products and parents
+-- have no parent
+ALTER TABLE products ADD COLUMN parent_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_products_parent ON products (parent_id);
+
+CREATE TABLE product_attributes (
+    product_id TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    value      TEXT NOT NULL,
+    PRIMARY KEY (product_id, name)
+);
+
+CREATE TABLE category_attributes (
+    category_id TEXT    NOT NULL,
+    position    INTEGER NOT NULL,
+    name        TEXT    NOT NULL,
+    type        TEXT    NOT NULL,
+    required    INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (category_id, position)
+);
+
+-- the allowed values of enum attributes
+CREATE TABLE category_attribute_values (
+    category_id TEXT    NOT NULL,
+    name        TEXT    NOT NULL,
+    position    INTEGER NOT NULL,
+    value       TEXT    NOT NULL,
+    PRIMARY KEY (category_id, name, position)
+);
@@ -0,0 +1,27 @@
+-- food labelling: allergen declarations, nutrition facts per 100 g and
+-- dietary tags. Undeclared allergens and nutrition have no row.
+CREATE TABLE product_allergens (
+    product_id TEXT NOT NULL,
+    allergen   TEXT NOT NULL,
+    status     TEXT NOT NULL,
+    PRIMARY KEY (product_id, allergen)
+);
+
+CREATE TABLE product_nutrition (
+    product_id    TEXT PRIMARY KEY,
+    energy_kj     REAL NOT NULL,
+    energy_kcal   REAL NOT NULL,
+    fat           REAL NOT NULL,
+    saturated_fat REAL NOT NULL,
+    carbohydrate  REAL NOT NULL,
+    sugars        REAL NOT NULL,
+    fibre         REAL NOT NULL,
+    protein       REAL NOT NULL,
+    salt          REAL NOT NULL
+);
+
+CREATE TABLE product_dietary_tags (
+    product_id TEXT NOT NULL,
+    tag        TEXT NOT NULL,
+    PRIMARY KEY (product_id, tag)
+);
@@ -0,0 +1,10 @@
+-- GTIN barcodes of products and their packs, stored as GTIN-14. The primary
+-- key is the lookup index and keeps a barcode on one product. An empty unit
+-- is the base unit of the product.
+CREATE TABLE product_barcodes (
+    gtin       TEXT PRIMARY KEY,
+    product_id TEXT NOT NULL,
+    unit       TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_product_barcodes_product ON product_barcodes (product_id);
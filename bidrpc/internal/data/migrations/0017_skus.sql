@@ -0,0 +1,15 @@
+-- SKU codes of products, products stored before SKUs existed have none.
+-- The partial index keeps a SKU on one product and is the lookup index.
+ALTER TABLE products ADD COLUMN sku TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX idx_products_sku ON products (sku) WHERE sku <> '';
+
+-- the last SKU number given out by prefix
+CREATE TABLE sku_counters (
+    prefix TEXT PRIMARY KEY,
+    value  INTEGER NOT NULL
+);
+
+-- the prefix of the SKUs generated for products of a category, empty
+-- inherits the prefix of the parent
+ALTER TABLE categories ADD COLUMN sku_prefix TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- products deleted to the trash keep their rows until they are purged, 0
+-- is a live product. The partial index serves the trash listing and purge.
+ALTER TABLE products ADD COLUMN deleted_at INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_products_deleted ON products (deleted_at) WHERE deleted_at <> 0;
@@ -0,0 +1,15 @@
+-- prices sort and filter in major units, so 1.500 BHD comes before
+-- 10.00 USD. The column is written with every product, the backfill divides
+-- by the minor units of the currencies that have other than 2 digits.
+ALTER TABLE products ADD COLUMN price_major REAL NOT NULL DEFAULT 0;
+UPDATE products SET price_major = price_amount / CASE price_currency
+    WHEN 'BIF' THEN 1e0 WHEN 'CLP' THEN 1e0 WHEN 'DJF' THEN 1e0 WHEN 'GNF' THEN 1e0 WHEN 'ISK' THEN 1e0
+    WHEN 'JPY' THEN 1e0 WHEN 'KMF' THEN 1e0 WHEN 'KRW' THEN 1e0 WHEN 'PYG' THEN 1e0 WHEN 'RWF' THEN 1e0
+    WHEN 'UGX' THEN 1e0 WHEN 'UYI' THEN 1e0 WHEN 'VND' THEN 1e0 WHEN 'VUV' THEN 1e0 WHEN 'XAF' THEN 1e0
+    WHEN 'XOF' THEN 1e0 WHEN 'XPF' THEN 1e0
+    WHEN 'BHD' THEN 1e3 WHEN 'IQD' THEN 1e3 WHEN 'JOD' THEN 1e3 WHEN 'KWD' THEN 1e3 WHEN 'LYD' THEN 1e3
+    WHEN 'OMR' THEN 1e3 WHEN 'TND' THEN 1e3
+    WHEN 'CLF' THEN 1e4 WHEN 'UYW' THEN 1e4
+    ELSE 1e2 END;
+DROP INDEX idx_products_price;
+CREATE INDEX idx_products_price ON products (price_major, id);
@@ -1,68 +1,485 @@
 package data
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/athxx/bidfood/bidrpc/internal/biz"
 )
 
-// ProductData implements ProductRepo using in-memory storage
+const (
+	snapshotFile = "data.json"
+	ledgerFile   = "movements.json"
+	holdsFile    = "reservations.json"
+	depotsFile   = "warehouses.json"
+	taxonomyFile = "categories.json"
+	vendorsFile  = "suppliers.json"
+	sourcingFile = "product_suppliers.json"
+	contractFile = "price_lists.json"
+	tiersFile    = "price_tiers.json"
+	lotsFile     = "auctions.json"
+	bidsFile     = "bids.json"
+	countersFile = "sku_counters.json"
+	walFile      = "data.wal"
+
+	// defaultCompactEvery is the number of logged writes after which the
+	// write-ahead log is folded into a fresh snapshot
+	defaultCompactEvery = 1000
+)
+
+// ProductData implements ProductRepo using in-memory storage backed by a
+// snapshot file and a write-ahead log.
+//
+// Every write is appended to the log and synced before it is applied in
+// memory, so an acknowledged write survives a crash. The log is periodically
+// compacted into the snapshot, which is replaced atomically. Stock movements,
+// reservations, warehouses, categories, suppliers, supplier links, price
+// lists, price tiers, auctions, bids and SKU counters are snapshotted to
+// files of their own next to the products.
 type ProductData struct {
 	mu       sync.RWMutex
 	products map[string]*biz.Product
-	path     string
+	// barcodes indexes the products by GTIN-14 and skus by SKU, they are
+	// built from the products when they are loaded
+	barcodes     map[string]string
+	skus         map[string]string
+	movements    map[string][]*biz.StockMovement
+	reservations map[string]*biz.Reservation
+	warehouses   map[string]*biz.Warehouse
+	categories   map[string]*biz.Category
+	suppliers    map[string]*biz.Supplier
+	// sourcing holds the supplier links by product ID
+	sourcing   map[string][]*biz.ProductSupplier
+	priceLists map[string]*biz.PriceList
+	// tiers holds the price tiers of every list by product ID
+	tiers    map[string][]*biz.PriceTier
+	auctions map[string]*biz.Auction
+	// bids holds the bids by auction ID
+	bids map[string][]*biz.Bid
+	// skuCounters holds the last SKU number given out by prefix
+	skuCounters  map[string]int64
+	path         string
+	ledgerPath   string
+	holdsPath    string
+	depotsPath   string
+	taxonomyPath string
+	vendorsPath  string
+	sourcingPath string
+	contractPath string
+	tiersPath    string
+	lotsPath     string
+	bidsPath     string
+	countersPath string
+	wal          *wal
+	compactEvery int
 }
 
-// NewProductData creates a new in-memory product repository
-func NewProductData() biz.ProductRepo {
-	return &ProductData{
-		products: make(map[string]*biz.Product),
-		path:     "./data.json",
+// NewProductData opens the product repository stored in dir, replaying the
+// write-ahead log on top of the last snapshot
+func NewProductData(dir string) (*ProductData, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	d := &ProductData{
+		products:     make(map[string]*biz.Product),
+		barcodes:     make(map[string]string),
+		skus:         make(map[string]string),
+		movements:    make(map[string][]*biz.StockMovement),
+		reservations: make(map[string]*biz.Reservation),
+		warehouses:   make(map[string]*biz.Warehouse),
+		categories:   make(map[string]*biz.Category),
+		suppliers:    make(map[string]*biz.Supplier),
+		sourcing:     make(map[string][]*biz.ProductSupplier),
+		priceLists:   make(map[string]*biz.PriceList),
+		tiers:        make(map[string][]*biz.PriceTier),
+		auctions:     make(map[string]*biz.Auction),
+		bids:         make(map[string][]*biz.Bid),
+		skuCounters:  make(map[string]int64),
+		path:         filepath.Join(dir, snapshotFile),
+		ledgerPath:   filepath.Join(dir, ledgerFile),
+		holdsPath:    filepath.Join(dir, holdsFile),
+		depotsPath:   filepath.Join(dir, depotsFile),
+		taxonomyPath: filepath.Join(dir, taxonomyFile),
+		vendorsPath:  filepath.Join(dir, vendorsFile),
+		sourcingPath: filepath.Join(dir, sourcingFile),
+		contractPath: filepath.Join(dir, contractFile),
+		tiersPath:    filepath.Join(dir, tiersFile),
+		lotsPath:     filepath.Join(dir, lotsFile),
+		bidsPath:     filepath.Join(dir, bidsFile),
+		countersPath: filepath.Join(dir, countersFile),
+		compactEvery: defaultCompactEvery,
+	}
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+	for _, p := range d.products {
+		d.index(nil, p)
 	}
-}
 
-func (d *ProductData) get() error {
-	f, err := os.ReadFile(d.path)
+	w, err := openWAL(filepath.Join(dir, walFile), d.apply)
 	if err != nil {
+		return nil, err
+	}
+	d.wal = w
+
+	return d, nil
+}
+
+// load reads the snapshot files, a missing snapshot is an empty store
+func (d *ProductData) load() error {
+	if err := loadSnapshot(d.path, &d.products); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.ledgerPath, &d.movements); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.holdsPath, &d.reservations); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.depotsPath, &d.warehouses); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.taxonomyPath, &d.categories); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.vendorsPath, &d.suppliers); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.sourcingPath, &d.sourcing); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.contractPath, &d.priceLists); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.tiersPath, &d.tiers); err != nil {
+		return err
+	}
+	if err := loadSnapshot(d.lotsPath, &d.auctions); err != nil {
 		return err
 	}
-	var records map[string]*biz.Product
+	if err := loadSnapshot(d.bidsPath, &d.bids); err != nil {
+		return err
+	}
+	return loadSnapshot(d.countersPath, &d.skuCounters)
+}
 
-	err = json.Unmarshal(f, &records)
+// loadSnapshot decodes the snapshot at path into dst, leaving dst as it is
+// when the file does not exist
+func loadSnapshot[M ~map[string]V, V any](path string, dst *M) error {
+	f, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
-	d.products = records
+
+	var records M
+	if err = json.Unmarshal(f, &records); err != nil {
+		return err
+	}
+	if records != nil {
+		*dst = records
+	}
 	return nil
 }
 
-func (d *ProductData) set() error {
+// apply replays a logged record into the in-memory map
+func (d *ProductData) apply(rec walRecord) error {
+	switch rec.Op {
+	case opPut:
+		var p biz.Product
+		if err := json.Unmarshal(rec.Value, &p); err != nil {
+			return err
+		}
+		d.index(d.products[rec.Key], &p)
+		d.products[rec.Key] = &p
+	case opDelete:
+		d.index(d.products[rec.Key], nil)
+		delete(d.products, rec.Key)
+		delete(d.movements, rec.Key)
+		delete(d.sourcing, rec.Key)
+		delete(d.tiers, rec.Key)
+		for id, r := range d.reservations {
+			if r.ProductID == rec.Key {
+				delete(d.reservations, id)
+			}
+		}
+	case opMovement:
+		var m biz.StockMovement
+		if err := json.Unmarshal(rec.Value, &m); err != nil {
+			return err
+		}
+		// the snapshot may already hold movements logged before a crash
+		// interrupted compaction
+		ledger := d.movements[rec.Key]
+		if n := len(ledger); n > 0 && ledger[n-1].Version >= m.Version {
+			return nil
+		}
+		d.movements[rec.Key] = append(ledger, &m)
+	case opReservation:
+		var r biz.Reservation
+		if err := json.Unmarshal(rec.Value, &r); err != nil {
+			return err
+		}
+		d.reservations[rec.Key] = &r
+	case opWarehouse:
+		var w biz.Warehouse
+		if err := json.Unmarshal(rec.Value, &w); err != nil {
+			return err
+		}
+		d.warehouses[rec.Key] = &w
+	case opDeleteWarehouse:
+		delete(d.warehouses, rec.Key)
+	case opCategory:
+		var c biz.Category
+		if err := json.Unmarshal(rec.Value, &c); err != nil {
+			return err
+		}
+		d.categories[rec.Key] = &c
+	case opSupplier:
+		var s biz.Supplier
+		if err := json.Unmarshal(rec.Value, &s); err != nil {
+			return err
+		}
+		d.suppliers[rec.Key] = &s
+	case opDeleteSupplier:
+		delete(d.suppliers, rec.Key)
+	case opProductSuppliers:
+		var links []*biz.ProductSupplier
+		if err := json.Unmarshal(rec.Value, &links); err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			delete(d.sourcing, rec.Key)
+		} else {
+			d.sourcing[rec.Key] = links
+		}
+	case opPriceList:
+		var l biz.PriceList
+		if err := json.Unmarshal(rec.Value, &l); err != nil {
+			return err
+		}
+		d.priceLists[rec.Key] = &l
+	case opPriceTiers:
+		var tiers []*biz.PriceTier
+		if err := json.Unmarshal(rec.Value, &tiers); err != nil {
+			return err
+		}
+		if len(tiers) == 0 {
+			delete(d.tiers, rec.Key)
+		} else {
+			d.tiers[rec.Key] = tiers
+		}
+	case opAuction:
+		var a biz.Auction
+		if err := json.Unmarshal(rec.Value, &a); err != nil {
+			return err
+		}
+		d.auctions[rec.Key] = &a
+	case opBid:
+		var b biz.Bid
+		if err := json.Unmarshal(rec.Value, &b); err != nil {
+			return err
+		}
+		bids := slices.DeleteFunc(d.bids[rec.Key], func(old *biz.Bid) bool { return old.BidderID == b.BidderID })
+		bids = append(bids, &b)
+		slices.SortFunc(bids, compareBids)
+		d.bids[rec.Key] = bids
+	case opSKUCounter:
+		var n int64
+		if err := json.Unmarshal(rec.Value, &n); err != nil {
+			return err
+		}
+		// counters never go back, not even replaying over a newer snapshot
+		d.skuCounters[rec.Key] = max(d.skuCounters[rec.Key], n)
+	}
+	return nil
+}
+
+// write logs recs as one batch, applies them and compacts the log when it
+// grew large enough. Callers must hold the write lock.
+func (d *ProductData) write(recs ...walRecord) error {
+	if err := d.wal.append(recs...); err != nil {
+		return err
+	}
+	for _, rec := range recs {
+		if err := d.apply(rec); err != nil {
+			return err
+		}
+	}
+	if d.wal.n >= d.compactEvery {
+		if err := d.compact(); err != nil {
+			// the write itself is already durable in the log
+			slog.Error("Compacting product write-ahead log failed", "error", err)
+		}
+	}
+	return nil
+}
 
+// compact writes the current state to the snapshot and empties the log.
+// A crash between the two steps is harmless because replaying puts and
+// deletes on top of a snapshot that already contains them is idempotent.
+func (d *ProductData) compact() error {
 	buf, err := json.MarshalIndent(d.products, "", "  ")
 	if err != nil {
 		return err
 	}
-	fmt.Println("--------------------------")
-	if err = os.WriteFile(d.path, buf, os.ModePerm); err != nil {
+	if err := writeFileAtomic(d.path, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.movements, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.ledgerPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.reservations, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.holdsPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.warehouses, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.depotsPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.categories, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.taxonomyPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.suppliers, "", "  ")
+	if err != nil {
 		return err
 	}
+	if err := writeFileAtomic(d.vendorsPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.sourcing, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.sourcingPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.priceLists, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.contractPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.tiers, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.tiersPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.auctions, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.lotsPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.bids, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.bidsPath, buf); err != nil {
+		return err
+	}
+	buf, err = json.MarshalIndent(d.skuCounters, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(d.countersPath, buf); err != nil {
+		return err
+	}
+	return d.wal.reset()
+}
 
-	return nil
+// Close compacts the log and releases the underlying files
+func (d *ProductData) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	err := d.compact()
+	if cerr := d.wal.close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// index replaces the barcode and SKU index entries of old, which is nil for
+// a new product, with those of product, which is nil when it is deleted
+func (d *ProductData) index(old, product *biz.Product) {
+	if old != nil {
+		for _, b := range old.Barcodes {
+			delete(d.barcodes, b.GTIN)
+		}
+		delete(d.skus, old.SKU)
+	}
+	if product != nil {
+		for _, b := range product.Barcodes {
+			d.barcodes[b.GTIN] = product.ID
+		}
+		if product.SKU != "" {
+			d.skus[product.SKU] = product.ID
+		}
+	}
+}
+
+// putRecord returns the record storing product, failing with ErrSKUInUse
+// when its SKU is another product's and with ErrBarcodeInUse when one of
+// its barcodes is on another product. Callers must hold the write lock.
+func (d *ProductData) putRecord(product *biz.Product) (walRecord, error) {
+	if id, ok := d.skus[product.SKU]; ok && id != product.ID {
+		return walRecord{}, fmt.Errorf("%w: %s is the SKU of product %s", biz.ErrSKUInUse, product.SKU, id)
+	}
+	for _, b := range product.Barcodes {
+		if id, ok := d.barcodes[b.GTIN]; ok && id != product.ID {
+			return walRecord{}, fmt.Errorf("%w: %s is a barcode of product %s", biz.ErrBarcodeInUse, b.GTIN, id)
+		}
+	}
+	buf, err := json.Marshal(product)
+	if err != nil {
+		return walRecord{}, err
+	}
+	return walRecord{Op: opPut, Key: product.ID, Value: buf}, nil
 }
 
-// Save saves a product to the in-memory store
+// Save saves a product to the store
 func (d *ProductData) Save(ctx context.Context, product *biz.Product) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	d.products[product.ID] = product
-	fmt.Println(d.set()) // save data into json file
-	return nil
+	rec, err := d.putRecord(product)
+	if err != nil {
+		return err
+	}
+	return d.write(rec)
 }
 
 // FindByID finds a product by ID
@@ -70,98 +487,723 @@ func (d *ProductData) FindByID(ctx context.Context, id string) (*biz.Product, er
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	if len(d.products) == 0 {
-		d.get()
-	}
-
 	product, exists := d.products[id]
 	if !exists {
 		return nil, biz.ErrProductNotFound
 	}
 
 	// Return a copy to avoid race conditions
-	return &biz.Product{
-		ID:          product.ID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
-	}, nil
+	clone := *product
+	return &clone, nil
 }
 
-// FindAll finds all products with pagination and filtering
-func (d *ProductData) FindAll(ctx context.Context, page, pageSize int32, nameFilter string) ([]*biz.Product, int32, error) {
+// FindByBarcode finds the product carrying a GTIN-14
+func (d *ProductData) FindByBarcode(ctx context.Context, gtin string) (*biz.Product, error) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	if len(d.products) == 0 {
-		d.get()
+
+	id, ok := d.barcodes[gtin]
+	if !ok {
+		return nil, biz.ErrProductNotFound
 	}
+	clone := *d.products[id]
+	return &clone, nil
+}
+
+// FindBySKU finds a product by SKU
+func (d *ProductData) FindBySKU(ctx context.Context, sku string) (*biz.Product, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	id, ok := d.skus[sku]
+	if !ok {
+		return nil, biz.ErrProductNotFound
+	}
+	clone := *d.products[id]
+	return &clone, nil
+}
+
+// NextSKUNumber increments the SKU counter of prefix and returns it
+func (d *ProductData) NextSKUNumber(ctx context.Context, prefix string) (int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	n := d.skuCounters[prefix] + 1
+	buf, err := json.Marshal(n)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.write(walRecord{Op: opSKUCounter, Key: prefix, Value: buf}); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// FindAll finds all products with pagination, filtering and sorting
+func (d *ProductData) FindAll(ctx context.Context, q biz.ListQuery) ([]*biz.Product, int32, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 
 	var filtered []*biz.Product
 
-	// Apply name filter
+	// Apply filter
 	for _, product := range d.products {
-		if nameFilter == "" || strings.Contains(strings.ToLower(product.Name), strings.ToLower(nameFilter)) {
-			filtered = append(filtered, &biz.Product{
-				ID:          product.ID,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-				Quantity:    product.Quantity,
-				CreatedAt:   product.CreatedAt,
-				UpdatedAt:   product.UpdatedAt,
-			})
+		if q.Match(product) {
+			clone := *product
+			filtered = append(filtered, &clone)
 		}
 	}
 
-	total := int32(len(filtered))
+	// Map iteration order is random, sort for stable pages
+	slices.SortFunc(filtered, q.Compare)
+
+	return q.Window(filtered), int32(len(filtered)), nil
+}
 
-	// Apply pagination
-	start := (page - 1) * pageSize
-	end := start + pageSize
+// Update updates an existing product
+func (d *ProductData) Update(ctx context.Context, product *biz.Product) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	if start > total {
-		return []*biz.Product{}, total, nil
+	old, exists := d.products[product.ID]
+	if !exists {
+		return biz.ErrProductNotFound
+	}
+	if old.Version != product.Version-1 {
+		return biz.ErrVersionConflict
 	}
 
-	if end > total {
-		end = total
+	rec, err := d.putRecord(product)
+	if err != nil {
+		return err
 	}
+	return d.write(rec)
+}
+
+// Delete deletes a product by ID
+func (d *ProductData) Delete(ctx context.Context, id string, version int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	return filtered[start:end], total, nil
+	old, exists := d.products[id]
+	if !exists {
+		return biz.ErrProductNotFound
+	}
+	if version != 0 && old.Version != version {
+		return biz.ErrVersionConflict
+	}
+
+	return d.write(walRecord{Op: opDelete, Key: id})
 }
 
-// Update updates an existing product
-func (d *ProductData) Update(ctx context.Context, product *biz.Product) error {
+// RecordMovement stores product and appends m to its ledger in one logged batch
+func (d *ProductData) RecordMovement(ctx context.Context, product *biz.Product, m *biz.StockMovement) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	if len(d.products) == 0 {
-		d.get()
+
+	old, exists := d.products[product.ID]
+	switch {
+	case product.Version == 1 && exists:
+		return biz.ErrVersionConflict
+	case product.Version > 1 && !exists:
+		return biz.ErrProductNotFound
+	case product.Version > 1 && old.Version != product.Version-1:
+		return biz.ErrVersionConflict
+	}
+
+	put, err := d.putRecord(product)
+	if err != nil {
+		return err
+	}
+	buf, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return d.write(put, walRecord{Op: opMovement, Key: product.ID, Value: buf})
+}
+
+// FindMovements returns the ledger of a product after a version, oldest first
+func (d *ProductData) FindMovements(ctx context.Context, productID string, afterVersion int64, limit int32) ([]*biz.StockMovement, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	ledger := d.movements[productID]
+	i, _ := slices.BinarySearchFunc(ledger, afterVersion+1, func(m *biz.StockMovement, v int64) int {
+		return cmp.Compare(m.Version, v)
+	})
+	movements := []*biz.StockMovement{}
+	for _, m := range ledger[i:] {
+		if int32(len(movements)) == limit {
+			break
+		}
+		clone := *m
+		movements = append(movements, &clone)
 	}
+	return movements, nil
+}
+
+// SaveReservation stores product, r and, if given, m in one logged batch
+func (d *ProductData) SaveReservation(ctx context.Context, product *biz.Product, r *biz.Reservation, m *biz.StockMovement) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-	if _, exists := d.products[product.ID]; !exists {
+	old, exists := d.products[product.ID]
+	if !exists {
 		return biz.ErrProductNotFound
 	}
+	if old.Version != product.Version-1 {
+		return biz.ErrVersionConflict
+	}
 
-	d.products[product.ID] = product
-	return nil
+	put, err := d.putRecord(product)
+	if err != nil {
+		return err
+	}
+	recs := []walRecord{put}
+	if m != nil {
+		buf, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		recs = append(recs, walRecord{Op: opMovement, Key: product.ID, Value: buf})
+	}
+	buf, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	recs = append(recs, walRecord{Op: opReservation, Key: r.ID, Value: buf})
+	return d.write(recs...)
 }
 
-// Delete deletes a product by ID
-func (d *ProductData) Delete(ctx context.Context, id string) error {
+// SaveReservations appends the product and the reservations as one WAL
+// batch
+func (d *ProductData) SaveReservations(ctx context.Context, product *biz.Product, rs []*biz.Reservation) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old, exists := d.products[product.ID]
+	if !exists {
+		return biz.ErrProductNotFound
+	}
+	if old.Version != product.Version-1 {
+		return biz.ErrVersionConflict
+	}
+
+	put, err := d.putRecord(product)
+	if err != nil {
+		return err
+	}
+	recs := []walRecord{put}
+	for _, r := range rs {
+		buf, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		recs = append(recs, walRecord{Op: opReservation, Key: r.ID, Value: buf})
+	}
+	return d.write(recs...)
+}
+
+// FindReservation finds a reservation by ID
+func (d *ProductData) FindReservation(ctx context.Context, id string) (*biz.Reservation, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	r, exists := d.reservations[id]
+	if !exists {
+		return nil, biz.ErrReservationNotFound
+	}
+	clone := *r
+	return &clone, nil
+}
+
+// FindExpiredReservations returns held reservations expired at now, the
+// longest expired first
+func (d *ProductData) FindExpiredReservations(ctx context.Context, now time.Time, limit int32) ([]*biz.Reservation, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	expired := []*biz.Reservation{}
+	for _, r := range d.reservations {
+		if r.Status == biz.ReservationHeld && !r.ExpiresAt.After(now) {
+			clone := *r
+			expired = append(expired, &clone)
+		}
+	}
+	slices.SortFunc(expired, func(a, b *biz.Reservation) int {
+		return cmp.Or(a.ExpiresAt.Compare(b.ExpiresAt), cmp.Compare(a.ID, b.ID))
+	})
+	if int32(len(expired)) > limit {
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+// FindHeldReservations scans every reservation
+func (d *ProductData) FindHeldReservations(ctx context.Context, productID string) ([]*biz.Reservation, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	held := []*biz.Reservation{}
+	for _, r := range d.reservations {
+		if r.ProductID == productID && r.Status == biz.ReservationHeld {
+			clone := *r
+			held = append(held, &clone)
+		}
+	}
+	slices.SortFunc(held, func(a, b *biz.Reservation) int {
+		return cmp.Or(a.ExpiresAt.Compare(b.ExpiresAt), cmp.Compare(a.ID, b.ID))
+	})
+	return held, nil
+}
+
+// FindExpiringBatches scans the batches of every product
+func (d *ProductData) FindExpiringBatches(ctx context.Context, before time.Time, warehouseID string) ([]*biz.ExpiringBatch, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	batches := []*biz.ExpiringBatch{}
+	for _, p := range d.products {
+		batches = append(batches, expiringBatches(p, before, warehouseID)...)
+	}
+	return batches, nil
+}
+
+// expiringBatches returns the batches of p that expire before before, in
+// warehouseID unless it is empty. Deleted products have none.
+func expiringBatches(p *biz.Product, before time.Time, warehouseID string) []*biz.ExpiringBatch {
+	if p.Deleted() {
+		return nil
+	}
+	var out []*biz.ExpiringBatch
+	for _, b := range p.Batches {
+		if !b.ExpiresAt.IsZero() && b.ExpiresAt.Before(before) && (warehouseID == "" || b.WarehouseID == warehouseID) {
+			out = append(out, &biz.ExpiringBatch{ProductID: p.ID, ProductName: p.Name, Batch: b})
+		}
+	}
+	return out
+}
+
+// SaveWarehouse inserts or replaces a warehouse
+func (d *ProductData) SaveWarehouse(ctx context.Context, w *biz.Warehouse) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	buf, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opWarehouse, Key: w.ID, Value: buf})
+}
+
+// FindWarehouse finds a warehouse by ID
+func (d *ProductData) FindWarehouse(ctx context.Context, id string) (*biz.Warehouse, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	w, exists := d.warehouses[id]
+	if !exists {
+		return nil, biz.ErrWarehouseNotFound
+	}
+	clone := *w
+	return &clone, nil
+}
+
+// FindWarehouses returns every warehouse ordered by name
+func (d *ProductData) FindWarehouses(ctx context.Context) ([]*biz.Warehouse, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	warehouses := make([]*biz.Warehouse, 0, len(d.warehouses))
+	for _, w := range d.warehouses {
+		clone := *w
+		warehouses = append(warehouses, &clone)
+	}
+	slices.SortFunc(warehouses, compareWarehouses)
+	return warehouses, nil
+}
+
+// DeleteWarehouse deletes a warehouse by ID
+func (d *ProductData) DeleteWarehouse(ctx context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.warehouses[id]; !exists {
+		return biz.ErrWarehouseNotFound
+	}
+	return d.write(walRecord{Op: opDeleteWarehouse, Key: id})
+}
+
+// SaveCategory inserts or replaces a category
+func (d *ProductData) SaveCategory(ctx context.Context, c *biz.Category) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	buf, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opCategory, Key: c.ID, Value: buf})
+}
+
+// FindCategory finds a category by ID
+func (d *ProductData) FindCategory(ctx context.Context, id string) (*biz.Category, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	c, exists := d.categories[id]
+	if !exists {
+		return nil, biz.ErrCategoryNotFound
+	}
+	clone := *c
+	return &clone, nil
+}
+
+// FindCategories returns every category ordered by name
+func (d *ProductData) FindCategories(ctx context.Context) ([]*biz.Category, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	categories := make([]*biz.Category, 0, len(d.categories))
+	for _, c := range d.categories {
+		clone := *c
+		categories = append(categories, &clone)
+	}
+	slices.SortFunc(categories, compareCategories)
+	return categories, nil
+}
+
+// SaveSupplier inserts or replaces a supplier
+func (d *ProductData) SaveSupplier(ctx context.Context, s *biz.Supplier) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	if len(d.products) == 0 {
-		d.get()
+
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return err
 	}
+	return d.write(walRecord{Op: opSupplier, Key: s.ID, Value: buf})
+}
 
-	if _, exists := d.products[id]; !exists {
+// FindSupplier finds a supplier by ID
+func (d *ProductData) FindSupplier(ctx context.Context, id string) (*biz.Supplier, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	s, exists := d.suppliers[id]
+	if !exists {
+		return nil, biz.ErrSupplierNotFound
+	}
+	clone := *s
+	return &clone, nil
+}
+
+// FindSuppliers returns every supplier ordered by name
+func (d *ProductData) FindSuppliers(ctx context.Context) ([]*biz.Supplier, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	suppliers := make([]*biz.Supplier, 0, len(d.suppliers))
+	for _, s := range d.suppliers {
+		clone := *s
+		suppliers = append(suppliers, &clone)
+	}
+	slices.SortFunc(suppliers, compareSuppliers)
+	return suppliers, nil
+}
+
+// DeleteSupplier deletes a supplier no product is linked to
+func (d *ProductData) DeleteSupplier(ctx context.Context, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.suppliers[id]; !exists {
+		return biz.ErrSupplierNotFound
+	}
+	for _, links := range d.sourcing {
+		for _, l := range links {
+			if l.SupplierID == id {
+				return biz.ErrSupplierInUse
+			}
+		}
+	}
+	return d.write(walRecord{Op: opDeleteSupplier, Key: id})
+}
+
+// SaveProductSupplier inserts or replaces a supplier link of a product
+func (d *ProductData) SaveProductSupplier(ctx context.Context, l *biz.ProductSupplier) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if p, exists := d.products[l.ProductID]; !exists || p.Deleted() {
 		return biz.ErrProductNotFound
 	}
+	if _, exists := d.suppliers[l.SupplierID]; !exists {
+		return biz.ErrSupplierNotFound
+	}
+	links := []*biz.ProductSupplier{l}
+	for _, old := range d.sourcing[l.ProductID] {
+		if old.SupplierID == l.SupplierID {
+			continue
+		}
+		clone := *old
+		clone.Preferred = clone.Preferred && !l.Preferred
+		links = append(links, &clone)
+	}
+	return d.writeProductSuppliers(l.ProductID, links)
+}
 
-	delete(d.products, id)
-	d.set()
-	return nil
+// FindProductSuppliers returns the supplier links of a product, the
+// preferred one first
+func (d *ProductData) FindProductSuppliers(ctx context.Context, productID string) ([]*biz.ProductSupplier, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if p, exists := d.products[productID]; !exists || p.Deleted() {
+		return nil, biz.ErrProductNotFound
+	}
+	links := make([]*biz.ProductSupplier, 0, len(d.sourcing[productID]))
+	for _, l := range d.sourcing[productID] {
+		clone := *l
+		links = append(links, &clone)
+	}
+	return links, nil
+}
+
+// DeleteProductSupplier removes a supplier link of a product
+func (d *ProductData) DeleteProductSupplier(ctx context.Context, productID, supplierID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	links := d.sourcing[productID]
+	i := slices.IndexFunc(links, func(l *biz.ProductSupplier) bool { return l.SupplierID == supplierID })
+	if i < 0 {
+		return biz.ErrProductSupplierNotFound
+	}
+	return d.writeProductSuppliers(productID, slices.Concat(links[:i], links[i+1:]))
+}
+
+// writeProductSuppliers logs links as the complete, sorted supplier links of
+// a product. Callers must hold the write lock.
+func (d *ProductData) writeProductSuppliers(productID string, links []*biz.ProductSupplier) error {
+	slices.SortFunc(links, compareProductSuppliers)
+	buf, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opProductSuppliers, Key: productID, Value: buf})
+}
+
+// SavePriceList inserts or replaces a price list
+func (d *ProductData) SavePriceList(ctx context.Context, l *biz.PriceList) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	buf, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opPriceList, Key: l.ID, Value: buf})
+}
+
+// FindPriceList finds a price list by ID
+func (d *ProductData) FindPriceList(ctx context.Context, id string) (*biz.PriceList, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	l, exists := d.priceLists[id]
+	if !exists {
+		return nil, biz.ErrPriceListNotFound
+	}
+	return clonePriceList(l), nil
+}
+
+// FindPriceLists returns every price list ordered by name
+func (d *ProductData) FindPriceLists(ctx context.Context) ([]*biz.PriceList, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	lists := make([]*biz.PriceList, 0, len(d.priceLists))
+	for _, l := range d.priceLists {
+		lists = append(lists, clonePriceList(l))
+	}
+	slices.SortFunc(lists, comparePriceLists)
+	return lists, nil
+}
+
+// SavePriceTiers replaces the tiers of a product on a price list, logging the
+// product's tiers on every list as one record
+func (d *ProductData) SavePriceTiers(ctx context.Context, priceListID, productID string, tiers []*biz.PriceTier) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.priceLists[priceListID]; !exists {
+		return biz.ErrPriceListNotFound
+	}
+	if _, exists := d.products[productID]; !exists {
+		return biz.ErrProductNotFound
+	}
+	all := slices.DeleteFunc(slices.Clone(d.tiers[productID]), func(t *biz.PriceTier) bool { return t.PriceListID == priceListID })
+	all = append(all, tiers...)
+	slices.SortFunc(all, comparePriceTiers)
+	buf, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opPriceTiers, Key: productID, Value: buf})
+}
+
+// FindPriceTiers returns the tiers of a product on a price list ordered by
+// minimum quantity
+func (d *ProductData) FindPriceTiers(ctx context.Context, priceListID, productID string) ([]*biz.PriceTier, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	tiers := []*biz.PriceTier{}
+	for _, t := range d.tiers[productID] {
+		if t.PriceListID == priceListID {
+			clone := *t
+			tiers = append(tiers, &clone)
+		}
+	}
+	return tiers, nil
+}
+
+// SaveAuction inserts or replaces an auction
+func (d *ProductData) SaveAuction(ctx context.Context, a *biz.Auction) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	buf, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opAuction, Key: a.ID, Value: buf})
+}
+
+// FindAuction finds an auction by ID
+func (d *ProductData) FindAuction(ctx context.Context, id string) (*biz.Auction, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	a, exists := d.auctions[id]
+	if !exists {
+		return nil, biz.ErrAuctionNotFound
+	}
+	clone := *a
+	return &clone, nil
+}
+
+// FindAuctions returns every auction ordered by closing time
+func (d *ProductData) FindAuctions(ctx context.Context) ([]*biz.Auction, error) {
+	return d.findAuctions(func(a *biz.Auction) bool { return true }, math.MaxInt32), nil
+}
+
+// FindDueAuctions returns at most limit open auctions that closed at or
+// before now
+func (d *ProductData) FindDueAuctions(ctx context.Context, now time.Time, limit int32) ([]*biz.Auction, error) {
+	due := func(a *biz.Auction) bool { return a.Status == biz.AuctionOpen && !a.ClosesAt.After(now) }
+	return d.findAuctions(due, limit), nil
+}
+
+// findAuctions returns at most limit auctions matching keep ordered by
+// closing time
+func (d *ProductData) findAuctions(keep func(a *biz.Auction) bool, limit int32) []*biz.Auction {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	auctions := []*biz.Auction{}
+	for _, a := range d.auctions {
+		if keep(a) {
+			clone := *a
+			auctions = append(auctions, &clone)
+		}
+	}
+	slices.SortFunc(auctions, compareAuctions)
+	if int32(len(auctions)) > limit {
+		auctions = auctions[:limit]
+	}
+	return auctions
+}
+
+// SaveBid inserts or replaces the bid of a bidder on an auction
+func (d *ProductData) SaveBid(ctx context.Context, b *biz.Bid) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, exists := d.auctions[b.AuctionID]; !exists {
+		return biz.ErrAuctionNotFound
+	}
+	buf, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return d.write(walRecord{Op: opBid, Key: b.AuctionID, Value: buf})
+}
+
+// FindBids returns the bids on an auction ordered by bidder
+func (d *ProductData) FindBids(ctx context.Context, auctionID string) ([]*biz.Bid, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	bids := make([]*biz.Bid, len(d.bids[auctionID]))
+	for i, b := range d.bids[auctionID] {
+		clone := *b
+		bids[i] = &clone
+	}
+	return bids, nil
+}
+
+// clonePriceList copies l including its customers
+func clonePriceList(l *biz.PriceList) *biz.PriceList {
+	clone := *l
+	clone.CustomerIDs = slices.Clone(l.CustomerIDs)
+	return &clone
+}
+
+// compareWarehouses orders warehouses by name, then ID
+func compareWarehouses(a, b *biz.Warehouse) int {
+	return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+}
+
+// compareCategories orders categories by name, then ID
+func compareCategories(a, b *biz.Category) int {
+	return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+}
+
+// compareSuppliers orders suppliers by name, then ID
+func compareSuppliers(a, b *biz.Supplier) int {
+	return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+}
+
+// comparePriceLists orders price lists by name, then ID
+func comparePriceLists(a, b *biz.PriceList) int {
+	return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+}
+
+// comparePriceTiers orders price tiers by price list, then minimum quantity
+func comparePriceTiers(a, b *biz.PriceTier) int {
+	return cmp.Or(strings.Compare(a.PriceListID, b.PriceListID), cmp.Compare(a.MinQuantity, b.MinQuantity))
+}
+
+// compareAuctions orders auctions by closing time, then ID
+func compareAuctions(a, b *biz.Auction) int {
+	return cmp.Or(a.ClosesAt.Compare(b.ClosesAt), strings.Compare(a.ID, b.ID))
+}
+
+// compareBids orders bids by bidder
+func compareBids(a, b *biz.Bid) int {
+	return strings.Compare(a.BidderID, b.BidderID)
+}
+
+// compareProductSuppliers orders the preferred supplier link first, then
+// by supplier ID
+func compareProductSuppliers(a, b *biz.ProductSupplier) int {
+	if a.Preferred != b.Preferred {
+		if a.Preferred {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.SupplierID, b.SupplierID)
 }
@@ -0,0 +1,1425 @@
+package data
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	bucketProducts = []byte("products")
+	bucketMeta     = []byte("meta")
+	keyCount       = []byte("count")
+	// bucketMovements holds the stock ledgers keyed `product id | 0x00 | version`
+	bucketMovements = []byte("movements")
+	// bucketReservations holds reservations by ID, bucketHeld indexes the
+	// held ones by `expiry | 0x00 | id` and bucketProductReservations lists
+	// them per product as `product id | 0x00 | id`
+	bucketReservations        = []byte("reservations")
+	bucketHeld                = []byte("idx_held_expiry")
+	bucketProductReservations = []byte("idx_product_reservations")
+	bucketWarehouses          = []byte("warehouses")
+	bucketCategories          = []byte("categories")
+	bucketSuppliers           = []byte("suppliers")
+	// bucketProductSuppliers holds supplier links keyed
+	// `product id | 0x00 | supplier id`, bucketSupplierProducts indexes them
+	// as `supplier id | 0x00 | product id`
+	bucketProductSuppliers = []byte("product_suppliers")
+	bucketSupplierProducts = []byte("idx_supplier_products")
+	bucketPriceLists       = []byte("price_lists")
+	// bucketPriceTiers holds the tiers of a product on a price list, as one
+	// record keyed `product id | 0x00 | price list id`
+	bucketPriceTiers = []byte("price_tiers")
+	// bucketAuctions holds auctions by ID, bucketOpenAuctions indexes the
+	// open ones by `closing time | 0x00 | id`
+	bucketAuctions     = []byte("auctions")
+	bucketOpenAuctions = []byte("idx_open_auctions")
+	// bucketBids holds bids keyed `auction id | 0x00 | bidder id`
+	bucketBids = []byte("bids")
+	// bucketBatchExpiry indexes the products holding lots that expire by
+	// `expiry | 0x00 | product id`, one entry per expiry date of a product
+	bucketBatchExpiry = []byte("idx_batch_expiry")
+	// bucketBarcodes maps the GTIN-14 barcodes to the products carrying them
+	bucketBarcodes = []byte("idx_barcodes")
+	// bucketSKUs maps the SKUs to the products, bucketSKUCounters holds the
+	// last SKU number given out by prefix
+	bucketSKUs        = []byte("idx_skus")
+	bucketSKUCounters = []byte("sku_counters")
+	// bucketDeleted lists the products in the trash by id. They have no
+	// entries in the sort indexes nor in bucketBatchExpiry and are left out
+	// of the count.
+	bucketDeleted = []byte("idx_deleted")
+)
+
+// kvIndex is a secondary index bucket whose keys are `sort key | 0x00 | id`,
+// so a cursor walks the products in index order without touching the records
+type kvIndex struct {
+	bucket []byte
+	key    func(p *biz.Product) []byte
+}
+
+var (
+	idxName = kvIndex{[]byte("idx_name"), func(p *biz.Product) []byte {
+		return []byte(strings.ToLower(p.Name))
+	}}
+	// idxPrice keys the price in major units, the value the price filter
+	// compares, so prices in different currencies sort by worth
+	idxPrice = kvIndex{[]byte("idx_price_major"), func(p *biz.Product) []byte {
+		return encodeFloat(p.Price.Float())
+	}}
+	idxQuantity = kvIndex{[]byte("idx_quantity"), func(p *biz.Product) []byte {
+		return binary.BigEndian.AppendUint32(nil, uint32(p.Quantity)^(1<<31))
+	}}
+	idxCreatedAt = kvIndex{[]byte("idx_created_at"), func(p *biz.Product) []byte {
+		return encodeTime(p.CreatedAt)
+	}}
+	idxUpdatedAt = kvIndex{[]byte("idx_updated_at"), func(p *biz.Product) []byte {
+		return encodeTime(p.UpdatedAt)
+	}}
+
+	kvIndexes = []kvIndex{idxName, idxPrice, idxQuantity, idxCreatedAt, idxUpdatedAt}
+
+	// retiredIndexes are dropped on open, idx_price keyed prices by a float
+	// of unknown currency and idx_price_amount by minor units
+	retiredIndexes = [][]byte{[]byte("idx_price"), []byte("idx_price_amount")}
+
+	// sortIndexes maps sort fields to the index walking them in order
+	sortIndexes = map[biz.SortField]kvIndex{
+		biz.SortByName:      idxName,
+		biz.SortByPrice:     idxPrice,
+		biz.SortByQuantity:  idxQuantity,
+		biz.SortByCreatedAt: idxCreatedAt,
+		biz.SortByUpdatedAt: idxUpdatedAt,
+	}
+)
+
+// entry returns the index key of p
+func (idx kvIndex) entry(p *biz.Product) []byte {
+	k := idx.key(p)
+	k = append(k, 0)
+	return append(k, p.ID...)
+}
+
+// splitEntry returns the sort key and product id of an index key
+func splitEntry(k []byte) ([]byte, string) {
+	sep := bytes.LastIndexByte(k, 0)
+	return k[:sep], string(k[sep+1:])
+}
+
+// encodeFloat encodes f so byte order matches numeric order: positive
+// numbers get the sign bit set, negative ones have every bit flipped
+func encodeFloat(f float64) []byte {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		bits = ^bits
+	} else {
+		bits |= 1 << 63
+	}
+	return binary.BigEndian.AppendUint64(nil, bits)
+}
+
+// encodeTime encodes t so byte order matches chronological order
+func encodeTime(t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(t.UnixNano())^(1<<63))
+}
+
+// ProductKV implements ProductRepo on an embedded bbolt database.
+// Products are stored as JSON by ID with a secondary index per sort field,
+// so listings are served in order by walking the matching index.
+type ProductKV struct {
+	db *bolt.DB
+}
+
+// NewProductKV opens (or creates) the bbolt database at path
+func NewProductKV(path string) (*ProductKV, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketProducts, bucketMeta, bucketMovements, bucketReservations, bucketHeld, bucketProductReservations, bucketWarehouses, bucketCategories,
+			bucketSuppliers, bucketProductSuppliers, bucketSupplierProducts, bucketPriceLists, bucketPriceTiers,
+			bucketAuctions, bucketOpenAuctions, bucketBids, bucketBatchExpiry, bucketBarcodes,
+			bucketSKUs, bucketSKUCounters, bucketDeleted} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		for _, name := range retiredIndexes {
+			if tx.Bucket(name) == nil {
+				continue
+			}
+			if err := tx.DeleteBucket(name); err != nil {
+				return err
+			}
+		}
+		for _, idx := range kvIndexes {
+			if tx.Bucket(idx.bucket) != nil {
+				continue
+			}
+			// an index added after products were stored must be backfilled
+			b, err := tx.CreateBucket(idx.bucket)
+			if err != nil {
+				return err
+			}
+			err = tx.Bucket(bucketProducts).ForEach(func(_, v []byte) error {
+				var p biz.Product
+				if err := json.Unmarshal(v, &p); err != nil {
+					return err
+				}
+				if p.Deleted() {
+					return nil
+				}
+				return b.Put(idx.entry(&p), nil)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &ProductKV{db: db}, nil
+}
+
+// Close closes the database
+func (r *ProductKV) Close() error {
+	return r.db.Close()
+}
+
+// get loads a product inside tx, nil if it does not exist
+func (r *ProductKV) get(tx *bolt.Tx, id string) (*biz.Product, error) {
+	buf := tx.Bucket(bucketProducts).Get([]byte(id))
+	if buf == nil {
+		return nil, nil
+	}
+	var p biz.Product
+	if err := json.Unmarshal(buf, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// put writes product and its index entries, replacing old if it is not nil.
+// It fails with ErrSKUInUse when the SKU of product is another one's and
+// with ErrBarcodeInUse when a barcode of product is on another one.
+func (r *ProductKV) put(tx *bolt.Tx, old, product *biz.Product) error {
+	skus := tx.Bucket(bucketSKUs)
+	if product.SKU != "" {
+		if id := skus.Get([]byte(product.SKU)); id != nil && string(id) != product.ID {
+			return fmt.Errorf("%w: %s is the SKU of product %s", biz.ErrSKUInUse, product.SKU, id)
+		}
+	}
+	barcodes := tx.Bucket(bucketBarcodes)
+	for _, b := range product.Barcodes {
+		if id := barcodes.Get([]byte(b.GTIN)); id != nil && string(id) != product.ID {
+			return fmt.Errorf("%w: %s is a barcode of product %s", biz.ErrBarcodeInUse, b.GTIN, id)
+		}
+	}
+	if old != nil {
+		for _, b := range old.Barcodes {
+			if err := barcodes.Delete([]byte(b.GTIN)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, b := range product.Barcodes {
+		if err := barcodes.Put([]byte(b.GTIN), []byte(product.ID)); err != nil {
+			return err
+		}
+	}
+	if old != nil && old.SKU != "" {
+		if err := skus.Delete([]byte(old.SKU)); err != nil {
+			return err
+		}
+	}
+	if product.SKU != "" {
+		if err := skus.Put([]byte(product.SKU), []byte(product.ID)); err != nil {
+			return err
+		}
+	}
+
+	if old != nil {
+		if err := r.unindex(tx, old); err != nil {
+			return err
+		}
+	}
+	if err := r.reindex(tx, product); err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(product)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(bucketProducts).Put([]byte(product.ID), buf); err != nil {
+		return err
+	}
+	var delta int32
+	if !product.Deleted() {
+		delta++
+	}
+	if old != nil && !old.Deleted() {
+		delta--
+	}
+	if delta != 0 {
+		return r.addCount(tx, delta)
+	}
+	return nil
+}
+
+// reindex adds the sort index and batch expiry entries of a live p, the
+// trash entry of a deleted one
+func (r *ProductKV) reindex(tx *bolt.Tx, p *biz.Product) error {
+	if p.Deleted() {
+		return tx.Bucket(bucketDeleted).Put([]byte(p.ID), nil)
+	}
+	for _, idx := range kvIndexes {
+		if err := tx.Bucket(idx.bucket).Put(idx.entry(p), nil); err != nil {
+			return err
+		}
+	}
+	for _, k := range batchExpiryKeys(p) {
+		if err := tx.Bucket(bucketBatchExpiry).Put(k, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindex removes the entries reindex added for p
+func (r *ProductKV) unindex(tx *bolt.Tx, p *biz.Product) error {
+	if p.Deleted() {
+		return tx.Bucket(bucketDeleted).Delete([]byte(p.ID))
+	}
+	for _, idx := range kvIndexes {
+		if err := tx.Bucket(idx.bucket).Delete(idx.entry(p)); err != nil {
+			return err
+		}
+	}
+	for _, k := range batchExpiryKeys(p) {
+		if err := tx.Bucket(bucketBatchExpiry).Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchExpiryKeys returns the bucketBatchExpiry entries of p
+func batchExpiryKeys(p *biz.Product) [][]byte {
+	var keys [][]byte
+	for _, b := range p.Batches {
+		if b.ExpiresAt.IsZero() {
+			continue
+		}
+		k := append(append(encodeTime(b.ExpiresAt), 0), p.ID...)
+		if !slices.ContainsFunc(keys, func(seen []byte) bool { return bytes.Equal(seen, k) }) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (r *ProductKV) count(tx *bolt.Tx) int32 {
+	buf := tx.Bucket(bucketMeta).Get(keyCount)
+	if buf == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint64(buf))
+}
+
+func (r *ProductKV) addCount(tx *bolt.Tx, delta int32) error {
+	n := r.count(tx) + delta
+	return tx.Bucket(bucketMeta).Put(keyCount, binary.BigEndian.AppendUint64(nil, uint64(n)))
+}
+
+// Save saves a product
+func (r *ProductKV) Save(ctx context.Context, product *biz.Product) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		old, err := r.get(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		return r.put(tx, old, product)
+	})
+}
+
+// FindByID finds a product by ID
+func (r *ProductKV) FindByID(ctx context.Context, id string) (*biz.Product, error) {
+	var product *biz.Product
+	err := r.db.View(func(tx *bolt.Tx) (err error) {
+		product, err = r.get(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, biz.ErrProductNotFound
+	}
+	return product, nil
+}
+
+// FindByBarcode finds the product carrying a GTIN-14
+func (r *ProductKV) FindByBarcode(ctx context.Context, gtin string) (*biz.Product, error) {
+	var product *biz.Product
+	err := r.db.View(func(tx *bolt.Tx) (err error) {
+		id := tx.Bucket(bucketBarcodes).Get([]byte(gtin))
+		if id == nil {
+			return nil
+		}
+		product, err = r.get(tx, string(id))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, biz.ErrProductNotFound
+	}
+	return product, nil
+}
+
+// FindBySKU finds a product by SKU
+func (r *ProductKV) FindBySKU(ctx context.Context, sku string) (*biz.Product, error) {
+	var product *biz.Product
+	err := r.db.View(func(tx *bolt.Tx) (err error) {
+		id := tx.Bucket(bucketSKUs).Get([]byte(sku))
+		if id == nil {
+			return nil
+		}
+		product, err = r.get(tx, string(id))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if product == nil {
+		return nil, biz.ErrProductNotFound
+	}
+	return product, nil
+}
+
+// NextSKUNumber increments the SKU counter of prefix and returns it
+func (r *ProductKV) NextSKUNumber(ctx context.Context, prefix string) (int64, error) {
+	var n uint64
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketSKUCounters)
+		if buf := b.Get([]byte(prefix)); buf != nil {
+			n = binary.BigEndian.Uint64(buf)
+		}
+		n++
+		return b.Put([]byte(prefix), binary.BigEndian.AppendUint64(nil, n))
+	})
+	return int64(n), err
+}
+
+// FindAll walks the index of the sort field in the requested direction.
+// Keyset pages seek straight to the cursor, an O(log n) lookup, and the walk
+// stops as soon as the page is full. The total is the stored count when
+// nothing is filtered. The name filter is matched against the name index
+// keys, so counting its matches scans the index but never decodes a
+// product; a filter expression, the warehouse and the categories are
+// evaluated on the decoded products, and counting them decodes every
+// product on every page. The trash is listed from bucketDeleted and sorted
+// in memory.
+func (r *ProductKV) FindAll(ctx context.Context, q biz.ListQuery) ([]*biz.Product, int32, error) {
+	if q.Deleted {
+		return r.findDeleted(q)
+	}
+	idx, ok := sortIndexes[q.SortBy]
+	if !ok {
+		idx = sortIndexes[biz.DefaultSortField]
+	}
+	byName := bytes.Equal(idx.bucket, idxName.bucket)
+	needle := []byte(strings.ToLower(q.NameFilter))
+	skip := q.Offset
+	if q.After != nil {
+		skip = 0
+	}
+	decode := q.Filter != nil || q.Warehouse != "" || q.Categories != nil
+	products := []*biz.Product{}
+	var total int32
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		switch {
+		case decode:
+			n, err := r.countMatches(tx, q)
+			if err != nil {
+				return err
+			}
+			total = n
+		case len(needle) == 0:
+			total = r.count(tx)
+		default:
+			total = countNameMatches(tx, needle)
+		}
+
+		c := tx.Bucket(idx.bucket).Cursor()
+		first, next := c.First, c.Next
+		if q.Desc {
+			first, next = c.Last, c.Prev
+		}
+		if q.After != nil {
+			first = func() ([]byte, []byte) { return seekAfter(c, idx.entry(q.After.Pivot()), q.Desc) }
+		}
+
+		for k, _ := first(); k != nil && int32(len(products)) < q.Limit; k, _ = next() {
+			key, id := splitEntry(k)
+			if !decode && (len(needle) == 0 || byName) {
+				// the key alone decides, skip without decoding
+				if len(needle) > 0 && !bytes.Contains(key, needle) {
+					continue
+				}
+				if skip > 0 {
+					skip--
+					continue
+				}
+			}
+
+			p, err := r.get(tx, id)
+			if err != nil {
+				return err
+			}
+			if !q.Match(p) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			products = append(products, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return products, total, nil
+}
+
+// findDeleted serves FindAll for the products in the trash
+func (r *ProductKV) findDeleted(q biz.ListQuery) ([]*biz.Product, int32, error) {
+	var matched []*biz.Product
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketDeleted).ForEach(func(k, _ []byte) error {
+			p, err := r.get(tx, string(k))
+			if err != nil {
+				return err
+			}
+			if p != nil && q.Match(p) {
+				matched = append(matched, p)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	slices.SortFunc(matched, q.Compare)
+	return q.Window(matched), int32(len(matched)), nil
+}
+
+// countNameMatches counts the products whose lower-cased name contains needle
+func countNameMatches(tx *bolt.Tx, needle []byte) int32 {
+	var n int32
+	c := tx.Bucket(idxName.bucket).Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		if name, _ := splitEntry(k); bytes.Contains(name, needle) {
+			n++
+		}
+	}
+	return n
+}
+
+// countMatches counts the products matching q by decoding every product
+func (r *ProductKV) countMatches(tx *bolt.Tx, q biz.ListQuery) (int32, error) {
+	var n int32
+	err := tx.Bucket(bucketProducts).ForEach(func(_, v []byte) error {
+		var p biz.Product
+		if err := json.Unmarshal(v, &p); err != nil {
+			return err
+		}
+		if q.Match(&p) {
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// seekAfter positions c on the first key strictly after pivot in walk order
+func seekAfter(c *bolt.Cursor, pivot []byte, desc bool) ([]byte, []byte) {
+	k, v := c.Seek(pivot)
+	if !desc {
+		if k != nil && bytes.Equal(k, pivot) {
+			return c.Next()
+		}
+		return k, v
+	}
+	if k == nil {
+		return c.Last()
+	}
+	return c.Prev()
+}
+
+// Update updates an existing product
+func (r *ProductKV) Update(ctx context.Context, product *biz.Product) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		old, err := r.get(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return biz.ErrProductNotFound
+		}
+		if old.Version != product.Version-1 {
+			return biz.ErrVersionConflict
+		}
+		return r.put(tx, old, product)
+	})
+}
+
+// Delete deletes a product by ID
+func (r *ProductKV) Delete(ctx context.Context, id string, version int64) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		old, err := r.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return biz.ErrProductNotFound
+		}
+		if version != 0 && old.Version != version {
+			return biz.ErrVersionConflict
+		}
+		if err := r.unindex(tx, old); err != nil {
+			return err
+		}
+		for _, b := range old.Barcodes {
+			if err := tx.Bucket(bucketBarcodes).Delete([]byte(b.GTIN)); err != nil {
+				return err
+			}
+		}
+		if old.SKU != "" {
+			if err := tx.Bucket(bucketSKUs).Delete([]byte(old.SKU)); err != nil {
+				return err
+			}
+		}
+		if err := tx.Bucket(bucketProducts).Delete([]byte(id)); err != nil {
+			return err
+		}
+		if !old.Deleted() {
+			if err := r.addCount(tx, -1); err != nil {
+				return err
+			}
+		}
+
+		prefix := movementKey(id, 0)[:len(id)+1]
+		c := tx.Bucket(bucketMovements).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+
+		c = tx.Bucket(bucketProductReservations).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			res, err := r.getReservation(tx, string(k[len(prefix):]))
+			if err != nil {
+				return err
+			}
+			if res != nil {
+				if err := tx.Bucket(bucketHeld).Delete(heldKey(res)); err != nil {
+					return err
+				}
+				if err := tx.Bucket(bucketReservations).Delete([]byte(res.ID)); err != nil {
+					return err
+				}
+			}
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+
+		c = tx.Bucket(bucketProductSuppliers).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := tx.Bucket(bucketSupplierProducts).Delete(linkKey(string(k[len(prefix):]), id)); err != nil {
+				return err
+			}
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+
+		c = tx.Bucket(bucketPriceTiers).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Seek(prefix) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// movementKey returns the ledger key of a product's movement at version
+func movementKey(productID string, version int64) []byte {
+	k := append([]byte(productID), 0)
+	return binary.BigEndian.AppendUint64(k, uint64(version))
+}
+
+// RecordMovement stores product and appends m to its ledger in one transaction
+func (r *ProductKV) RecordMovement(ctx context.Context, product *biz.Product, m *biz.StockMovement) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		old, err := r.get(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case product.Version == 1 && old != nil:
+			return biz.ErrVersionConflict
+		case product.Version > 1 && old == nil:
+			return biz.ErrProductNotFound
+		case product.Version > 1 && old.Version != product.Version-1:
+			return biz.ErrVersionConflict
+		}
+		if err := r.put(tx, old, product); err != nil {
+			return err
+		}
+		return putMovement(tx, m)
+	})
+}
+
+func putMovement(tx *bolt.Tx, m *biz.StockMovement) error {
+	buf, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucketMovements).Put(movementKey(m.ProductID, m.Version), buf)
+}
+
+// FindMovements seeks to the first movement after afterVersion and walks the
+// product's ledger in version order
+func (r *ProductKV) FindMovements(ctx context.Context, productID string, afterVersion int64, limit int32) ([]*biz.StockMovement, error) {
+	movements := []*biz.StockMovement{}
+	prefix := movementKey(productID, 0)[:len(productID)+1]
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketMovements).Cursor()
+		for k, v := c.Seek(movementKey(productID, afterVersion+1)); k != nil && bytes.HasPrefix(k, prefix) && int32(len(movements)) < limit; k, v = c.Next() {
+			var m biz.StockMovement
+			if err := json.Unmarshal(v, &m); err != nil {
+				return err
+			}
+			movements = append(movements, &m)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// heldKey returns the expiry index key of a reservation
+func heldKey(res *biz.Reservation) []byte {
+	k := append(encodeTime(res.ExpiresAt), 0)
+	return append(k, res.ID...)
+}
+
+// getReservation loads a reservation inside tx, nil if it does not exist
+func (r *ProductKV) getReservation(tx *bolt.Tx, id string) (*biz.Reservation, error) {
+	buf := tx.Bucket(bucketReservations).Get([]byte(id))
+	if buf == nil {
+		return nil, nil
+	}
+	var res biz.Reservation
+	if err := json.Unmarshal(buf, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SaveReservation stores product, res and, if given, m in one transaction,
+// keeping res in the expiry index only while it is held
+func (r *ProductKV) SaveReservation(ctx context.Context, product *biz.Product, res *biz.Reservation, m *biz.StockMovement) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		old, err := r.get(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return biz.ErrProductNotFound
+		}
+		if old.Version != product.Version-1 {
+			return biz.ErrVersionConflict
+		}
+		if err := r.put(tx, old, product); err != nil {
+			return err
+		}
+		if m != nil {
+			if err := putMovement(tx, m); err != nil {
+				return err
+			}
+		}
+		return putReservation(tx, res)
+	})
+}
+
+// SaveReservations stores the product and the reservations in one
+// transaction
+func (r *ProductKV) SaveReservations(ctx context.Context, product *biz.Product, rs []*biz.Reservation) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		old, err := r.get(tx, product.ID)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			return biz.ErrProductNotFound
+		}
+		if old.Version != product.Version-1 {
+			return biz.ErrVersionConflict
+		}
+		if err := r.put(tx, old, product); err != nil {
+			return err
+		}
+		for _, res := range rs {
+			if err := putReservation(tx, res); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// putReservation writes res and its index entries inside tx
+func putReservation(tx *bolt.Tx, res *biz.Reservation) error {
+	held := tx.Bucket(bucketHeld)
+	var err error
+	if res.Status == biz.ReservationHeld {
+		err = held.Put(heldKey(res), nil)
+	} else {
+		err = held.Delete(heldKey(res))
+	}
+	if err != nil {
+		return err
+	}
+	byProduct := append(append([]byte(res.ProductID), 0), res.ID...)
+	if err := tx.Bucket(bucketProductReservations).Put(byProduct, nil); err != nil {
+		return err
+	}
+	buf, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucketReservations).Put([]byte(res.ID), buf)
+}
+
+// FindReservation finds a reservation by ID
+func (r *ProductKV) FindReservation(ctx context.Context, id string) (*biz.Reservation, error) {
+	var res *biz.Reservation
+	err := r.db.View(func(tx *bolt.Tx) (err error) {
+		res, err = r.getReservation(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, biz.ErrReservationNotFound
+	}
+	return res, nil
+}
+
+// FindExpiredReservations walks the expiry index from the longest expired
+// reservation up to now
+func (r *ProductKV) FindExpiredReservations(ctx context.Context, now time.Time, limit int32) ([]*biz.Reservation, error) {
+	expired := []*biz.Reservation{}
+	end := encodeTime(now)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketHeld).Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k[:len(end)], end) <= 0 && int32(len(expired)) < limit; k, _ = c.Next() {
+			res, err := r.getReservation(tx, string(k[len(end)+1:]))
+			if err != nil {
+				return err
+			}
+			if res != nil {
+				expired = append(expired, res)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
+
+// FindHeldReservations walks the reservations of the product
+func (r *ProductKV) FindHeldReservations(ctx context.Context, productID string) ([]*biz.Reservation, error) {
+	held := []*biz.Reservation{}
+	prefix := append([]byte(productID), 0)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketProductReservations).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			res, err := r.getReservation(tx, string(k[len(prefix):]))
+			if err != nil {
+				return err
+			}
+			if res != nil && res.Status == biz.ReservationHeld {
+				held = append(held, res)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(held, func(a, b *biz.Reservation) int {
+		return cmp.Or(a.ExpiresAt.Compare(b.ExpiresAt), cmp.Compare(a.ID, b.ID))
+	})
+	return held, nil
+}
+
+// FindExpiringBatches walks the expiry index up to before and decodes each
+// product it passes once
+func (r *ProductKV) FindExpiringBatches(ctx context.Context, before time.Time, warehouseID string) ([]*biz.ExpiringBatch, error) {
+	batches := []*biz.ExpiringBatch{}
+	end := encodeTime(before)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		seen := make(map[string]bool)
+		c := tx.Bucket(bucketBatchExpiry).Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k[:len(end)], end) < 0; k, _ = c.Next() {
+			id := string(k[len(end)+1:])
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			p, err := r.get(tx, id)
+			if err != nil {
+				return err
+			}
+			if p != nil {
+				batches = append(batches, expiringBatches(p, before, warehouseID)...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return batches, nil
+}
+
+// SaveWarehouse inserts or replaces a warehouse
+func (r *ProductKV) SaveWarehouse(ctx context.Context, w *biz.Warehouse) error {
+	buf, err := json.Marshal(w)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketWarehouses).Put([]byte(w.ID), buf)
+	})
+}
+
+// FindWarehouse finds a warehouse by ID
+func (r *ProductKV) FindWarehouse(ctx context.Context, id string) (*biz.Warehouse, error) {
+	var w *biz.Warehouse
+	err := r.db.View(func(tx *bolt.Tx) error {
+		buf := tx.Bucket(bucketWarehouses).Get([]byte(id))
+		if buf == nil {
+			return biz.ErrWarehouseNotFound
+		}
+		w = &biz.Warehouse{}
+		return json.Unmarshal(buf, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// FindWarehouses returns every warehouse ordered by name
+func (r *ProductKV) FindWarehouses(ctx context.Context) ([]*biz.Warehouse, error) {
+	warehouses := []*biz.Warehouse{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketWarehouses).ForEach(func(_, v []byte) error {
+			var w biz.Warehouse
+			if err := json.Unmarshal(v, &w); err != nil {
+				return err
+			}
+			warehouses = append(warehouses, &w)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(warehouses, compareWarehouses)
+	return warehouses, nil
+}
+
+// DeleteWarehouse deletes a warehouse by ID
+func (r *ProductKV) DeleteWarehouse(ctx context.Context, id string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketWarehouses)
+		if b.Get([]byte(id)) == nil {
+			return biz.ErrWarehouseNotFound
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// SaveCategory inserts or replaces a category
+func (r *ProductKV) SaveCategory(ctx context.Context, c *biz.Category) error {
+	buf, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketCategories).Put([]byte(c.ID), buf)
+	})
+}
+
+// FindCategory finds a category by ID
+func (r *ProductKV) FindCategory(ctx context.Context, id string) (*biz.Category, error) {
+	var c *biz.Category
+	err := r.db.View(func(tx *bolt.Tx) error {
+		buf := tx.Bucket(bucketCategories).Get([]byte(id))
+		if buf == nil {
+			return biz.ErrCategoryNotFound
+		}
+		c = &biz.Category{}
+		return json.Unmarshal(buf, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// FindCategories returns every category ordered by name
+func (r *ProductKV) FindCategories(ctx context.Context) ([]*biz.Category, error) {
+	categories := []*biz.Category{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketCategories).ForEach(func(_, v []byte) error {
+			var c biz.Category
+			if err := json.Unmarshal(v, &c); err != nil {
+				return err
+			}
+			categories = append(categories, &c)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(categories, compareCategories)
+	return categories, nil
+}
+
+// SaveSupplier inserts or replaces a supplier
+func (r *ProductKV) SaveSupplier(ctx context.Context, s *biz.Supplier) error {
+	buf, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSuppliers).Put([]byte(s.ID), buf)
+	})
+}
+
+// FindSupplier finds a supplier by ID
+func (r *ProductKV) FindSupplier(ctx context.Context, id string) (*biz.Supplier, error) {
+	var s *biz.Supplier
+	err := r.db.View(func(tx *bolt.Tx) error {
+		buf := tx.Bucket(bucketSuppliers).Get([]byte(id))
+		if buf == nil {
+			return biz.ErrSupplierNotFound
+		}
+		s = &biz.Supplier{}
+		return json.Unmarshal(buf, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// FindSuppliers returns every supplier ordered by name
+func (r *ProductKV) FindSuppliers(ctx context.Context) ([]*biz.Supplier, error) {
+	suppliers := []*biz.Supplier{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketSuppliers).ForEach(func(_, v []byte) error {
+			var s biz.Supplier
+			if err := json.Unmarshal(v, &s); err != nil {
+				return err
+			}
+			suppliers = append(suppliers, &s)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(suppliers, compareSuppliers)
+	return suppliers, nil
+}
+
+// DeleteSupplier deletes a supplier no product is linked to
+func (r *ProductKV) DeleteSupplier(ctx context.Context, id string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketSuppliers)
+		if b.Get([]byte(id)) == nil {
+			return biz.ErrSupplierNotFound
+		}
+		prefix := linkKey(id, "")
+		if k, _ := tx.Bucket(bucketSupplierProducts).Cursor().Seek(prefix); k != nil && bytes.HasPrefix(k, prefix) {
+			return biz.ErrSupplierInUse
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// linkKey returns the key of the pair in bucketProductSuppliers,
+// bucketPriceTiers and bucketBids or, with the arguments swapped, in
+// bucketSupplierProducts
+func linkKey(a, b string) []byte {
+	return append(append([]byte(a), 0), b...)
+}
+
+// SaveProductSupplier inserts or replaces a supplier link of a product, a
+// preferred link clears the flag on the others in the same transaction
+func (r *ProductKV) SaveProductSupplier(ctx context.Context, l *biz.ProductSupplier) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		p, err := r.get(tx, l.ProductID)
+		if err != nil {
+			return err
+		}
+		if p == nil || p.Deleted() {
+			return biz.ErrProductNotFound
+		}
+		if tx.Bucket(bucketSuppliers).Get([]byte(l.SupplierID)) == nil {
+			return biz.ErrSupplierNotFound
+		}
+
+		links := tx.Bucket(bucketProductSuppliers)
+		if l.Preferred {
+			others, err := productSuppliers(tx, l.ProductID)
+			if err != nil {
+				return err
+			}
+			for _, old := range others {
+				if !old.Preferred || old.SupplierID == l.SupplierID {
+					continue
+				}
+				old.Preferred = false
+				buf, err := json.Marshal(old)
+				if err != nil {
+					return err
+				}
+				if err := links.Put(linkKey(old.ProductID, old.SupplierID), buf); err != nil {
+					return err
+				}
+			}
+		}
+
+		buf, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		if err := links.Put(linkKey(l.ProductID, l.SupplierID), buf); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketSupplierProducts).Put(linkKey(l.SupplierID, l.ProductID), nil)
+	})
+}
+
+// productSuppliers loads the supplier links of a product inside tx
+func productSuppliers(tx *bolt.Tx, productID string) ([]*biz.ProductSupplier, error) {
+	links := []*biz.ProductSupplier{}
+	prefix := linkKey(productID, "")
+	c := tx.Bucket(bucketProductSuppliers).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var l biz.ProductSupplier
+		if err := json.Unmarshal(v, &l); err != nil {
+			return nil, err
+		}
+		links = append(links, &l)
+	}
+	return links, nil
+}
+
+// FindProductSuppliers returns the supplier links of a product, the
+// preferred one first
+func (r *ProductKV) FindProductSuppliers(ctx context.Context, productID string) ([]*biz.ProductSupplier, error) {
+	var links []*biz.ProductSupplier
+	err := r.db.View(func(tx *bolt.Tx) error {
+		p, err := r.get(tx, productID)
+		if err != nil {
+			return err
+		}
+		if p == nil || p.Deleted() {
+			return biz.ErrProductNotFound
+		}
+		links, err = productSuppliers(tx, productID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(links, compareProductSuppliers)
+	return links, nil
+}
+
+// DeleteProductSupplier removes a supplier link of a product
+func (r *ProductKV) DeleteProductSupplier(ctx context.Context, productID, supplierID string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		links := tx.Bucket(bucketProductSuppliers)
+		key := linkKey(productID, supplierID)
+		if links.Get(key) == nil {
+			return biz.ErrProductSupplierNotFound
+		}
+		if err := links.Delete(key); err != nil {
+			return err
+		}
+		return tx.Bucket(bucketSupplierProducts).Delete(linkKey(supplierID, productID))
+	})
+}
+
+// SavePriceList inserts or replaces a price list
+func (r *ProductKV) SavePriceList(ctx context.Context, l *biz.PriceList) error {
+	buf, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketPriceLists).Put([]byte(l.ID), buf)
+	})
+}
+
+// FindPriceList finds a price list by ID
+func (r *ProductKV) FindPriceList(ctx context.Context, id string) (*biz.PriceList, error) {
+	var l *biz.PriceList
+	err := r.db.View(func(tx *bolt.Tx) error {
+		buf := tx.Bucket(bucketPriceLists).Get([]byte(id))
+		if buf == nil {
+			return biz.ErrPriceListNotFound
+		}
+		l = &biz.PriceList{}
+		return json.Unmarshal(buf, l)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// FindPriceLists returns every price list ordered by name
+func (r *ProductKV) FindPriceLists(ctx context.Context) ([]*biz.PriceList, error) {
+	lists := []*biz.PriceList{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketPriceLists).ForEach(func(_, v []byte) error {
+			var l biz.PriceList
+			if err := json.Unmarshal(v, &l); err != nil {
+				return err
+			}
+			lists = append(lists, &l)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(lists, comparePriceLists)
+	return lists, nil
+}
+
+// SavePriceTiers replaces the tiers of a product on a price list
+func (r *ProductKV) SavePriceTiers(ctx context.Context, priceListID, productID string, tiers []*biz.PriceTier) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketPriceLists).Get([]byte(priceListID)) == nil {
+			return biz.ErrPriceListNotFound
+		}
+		p, err := r.get(tx, productID)
+		if err != nil {
+			return err
+		}
+		if p == nil {
+			return biz.ErrProductNotFound
+		}
+
+		b := tx.Bucket(bucketPriceTiers)
+		if len(tiers) == 0 {
+			return b.Delete(linkKey(productID, priceListID))
+		}
+		buf, err := json.Marshal(tiers)
+		if err != nil {
+			return err
+		}
+		return b.Put(linkKey(productID, priceListID), buf)
+	})
+}
+
+// FindPriceTiers returns the tiers of a product on a price list ordered by
+// minimum quantity
+func (r *ProductKV) FindPriceTiers(ctx context.Context, priceListID, productID string) ([]*biz.PriceTier, error) {
+	tiers := []*biz.PriceTier{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		buf := tx.Bucket(bucketPriceTiers).Get(linkKey(productID, priceListID))
+		if buf == nil {
+			return nil
+		}
+		return json.Unmarshal(buf, &tiers)
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(tiers, comparePriceTiers)
+	return tiers, nil
+}
+
+// openKey returns the closing time index key of an auction
+func openKey(a *biz.Auction) []byte {
+	k := append(encodeTime(a.ClosesAt), 0)
+	return append(k, a.ID...)
+}
+
+// SaveAuction inserts or replaces an auction, indexing it while it is open
+func (r *ProductKV) SaveAuction(ctx context.Context, a *biz.Auction) error {
+	buf, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		open := tx.Bucket(bucketOpenAuctions)
+		if a.Status == biz.AuctionOpen {
+			err = open.Put(openKey(a), nil)
+		} else {
+			err = open.Delete(openKey(a))
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(bucketAuctions).Put([]byte(a.ID), buf)
+	})
+}
+
+// FindAuction finds an auction by ID
+func (r *ProductKV) FindAuction(ctx context.Context, id string) (*biz.Auction, error) {
+	var a *biz.Auction
+	err := r.db.View(func(tx *bolt.Tx) (err error) {
+		a, err = getAuction(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// getAuction reads an auction, failing with ErrAuctionNotFound when it does
+// not exist
+func getAuction(tx *bolt.Tx, id string) (*biz.Auction, error) {
+	buf := tx.Bucket(bucketAuctions).Get([]byte(id))
+	if buf == nil {
+		return nil, biz.ErrAuctionNotFound
+	}
+	var a biz.Auction
+	if err := json.Unmarshal(buf, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// FindAuctions returns every auction ordered by closing time
+func (r *ProductKV) FindAuctions(ctx context.Context) ([]*biz.Auction, error) {
+	auctions := []*biz.Auction{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketAuctions).ForEach(func(_, v []byte) error {
+			var a biz.Auction
+			if err := json.Unmarshal(v, &a); err != nil {
+				return err
+			}
+			auctions = append(auctions, &a)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(auctions, compareAuctions)
+	return auctions, nil
+}
+
+// FindDueAuctions walks the open auction index from the longest closed
+// auction up to now
+func (r *ProductKV) FindDueAuctions(ctx context.Context, now time.Time, limit int32) ([]*biz.Auction, error) {
+	due := []*biz.Auction{}
+	end := encodeTime(now)
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketOpenAuctions).Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k[:len(end)], end) <= 0 && int32(len(due)) < limit; k, _ = c.Next() {
+			a, err := getAuction(tx, string(k[len(end)+1:]))
+			if err != nil {
+				return err
+			}
+			due = append(due, a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+// SaveBid inserts or replaces the bid of a bidder on an auction
+func (r *ProductKV) SaveBid(ctx context.Context, b *biz.Bid) error {
+	buf, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return r.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(bucketAuctions).Get([]byte(b.AuctionID)) == nil {
+			return biz.ErrAuctionNotFound
+		}
+		return tx.Bucket(bucketBids).Put(linkKey(b.AuctionID, b.BidderID), buf)
+	})
+}
+
+// FindBids returns the bids on an auction ordered by bidder
+func (r *ProductKV) FindBids(ctx context.Context, auctionID string) ([]*biz.Bid, error) {
+	bids := []*biz.Bid{}
+	prefix := linkKey(auctionID, "")
+	err := r.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketBids).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var b biz.Bid
+			if err := json.Unmarshal(v, &b); err != nil {
+				return err
+			}
+			bids = append(bids, &b)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bids, nil
+}
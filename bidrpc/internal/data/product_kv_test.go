@@ -0,0 +1,198 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestProductKV(t *testing.T) *ProductKV {
+	t.Helper()
+	r, err := NewProductKV(filepath.Join(t.TempDir(), "test.bolt"))
+	if err != nil {
+		t.Fatalf("NewProductKV failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestKVIndex_Encoding(t *testing.T) {
+	amounts := []int64{-1050, -100, 0, 1, 100, 99999}
+	for i := 1; i < len(amounts); i++ {
+		a, b := &biz.Product{Price: biz.Money{Amount: amounts[i-1]}}, &biz.Product{Price: biz.Money{Amount: amounts[i]}}
+		if bytes.Compare(idxPrice.key(a), idxPrice.key(b)) >= 0 {
+			t.Errorf("price %d should sort before %d", amounts[i-1], amounts[i])
+		}
+	}
+
+	prices := []biz.Money{{Amount: 1500, Currency: "BHD"}, {Amount: 250, Currency: "EUR"}, {Amount: 1000, Currency: "USD"}, {Amount: 500, Currency: "JPY"}}
+	for i := 1; i < len(prices); i++ {
+		a, b := &biz.Product{Price: prices[i-1]}, &biz.Product{Price: prices[i]}
+		if bytes.Compare(idxPrice.key(a), idxPrice.key(b)) >= 0 {
+			t.Errorf("price %v should sort before %v", prices[i-1], prices[i])
+		}
+	}
+
+	now := time.Now()
+	if bytes.Compare(encodeTime(now), encodeTime(now.Add(time.Nanosecond))) >= 0 {
+		t.Error("encodeTime should preserve chronological order")
+	}
+}
+
+func TestProductKV_FindAll_PaginationAndFilter(t *testing.T) {
+	r := newTestProductKV(t)
+	ctx := context.Background()
+	for i := 1; i <= 15; i++ {
+		p := newTestProduct("p" + string(rune(i+'0')))
+		p.Name = "Product" + string(rune(i+'0'))
+		if i%2 == 0 {
+			p.Name = "Special" + p.Name
+		}
+		if err := r.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	products, total, err := r.FindAll(ctx, biz.ListQuery{Limit: 3, NameFilter: "special"})
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if total != 7 {
+		t.Errorf("Expected 7 products with 'Special' in name, got %d", total)
+	}
+	if len(products) != 3 {
+		t.Errorf("Expected 3 products on page 1, got %d", len(products))
+	}
+
+	products, _, err = r.FindAll(ctx, biz.ListQuery{Offset: 6, Limit: 3, NameFilter: "Special"})
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if len(products) != 1 {
+		t.Errorf("Expected 1 product on page 3, got %d", len(products))
+	}
+
+	// without a filter the pages are read straight off the name index
+	products, total, err = r.FindAll(ctx, biz.ListQuery{Offset: 5, Limit: 5, SortBy: biz.SortByName})
+	if err != nil || total != 15 || len(products) != 5 {
+		t.Fatalf("FindAll failed: err=%v total=%d len=%d", err, total, len(products))
+	}
+	for i := 1; i < len(products); i++ {
+		if products[i-1].Name > products[i].Name {
+			t.Errorf("products not ordered by name: %q before %q", products[i-1].Name, products[i].Name)
+		}
+	}
+}
+
+// TestProductKV_FindAll_IndexOnly shows which listings are served from the
+// indexes: a corrupt record off the first page is only read by the listings
+// that decode every product to count the matches.
+func TestProductKV_FindAll_IndexOnly(t *testing.T) {
+	r := newTestProductKV(t)
+	ctx := context.Background()
+	base := time.Now()
+	var products []*biz.Product
+	for i := range 20 {
+		p := newTestProduct(fmt.Sprintf("p%02d", i))
+		p.Name = fmt.Sprintf("Product %02d", i)
+		p.CreatedAt = base.Add(time.Duration(i) * time.Second)
+		if err := r.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		products = append(products, p)
+	}
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketProducts).Put([]byte("p19"), []byte("not json"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter, err := biz.ParseFilter("quantity >= 0")
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+	byName := biz.ListQuery{SortBy: biz.SortByName, Limit: 5}
+
+	tests := []struct {
+		name      string
+		q         biz.ListQuery
+		total     int32
+		indexOnly bool
+	}{
+		{"sort only", biz.ListQuery{Limit: 5}, 20, true},
+		{"offset", biz.ListQuery{SortBy: biz.SortByName, Offset: 5, Limit: 5}, 20, true},
+		{"keyset", biz.ListQuery{SortBy: biz.SortByName, After: biz.NewCursor(products[4], byName), Limit: 5}, 20, true},
+		// the name index is scanned to count, its keys decide the match
+		{"name filter", biz.ListQuery{SortBy: biz.SortByName, NameFilter: "product 1", Limit: 5}, 10, true},
+		{"name filter by creation", biz.ListQuery{NameFilter: "product", Limit: 5}, 20, true},
+		// a filter expression, warehouse or category is counted on every
+		// decoded product, whatever the page
+		{"filter", biz.ListQuery{Filter: filter, Limit: 5}, 0, false},
+		{"warehouse", biz.ListQuery{Warehouse: biz.DefaultWarehouseID, Limit: 5}, 0, false},
+		{"categories", biz.ListQuery{Categories: []string{""}, Limit: 5}, 0, false},
+	}
+	for _, tt := range tests {
+		page, total, err := r.FindAll(ctx, tt.q)
+		switch {
+		case tt.indexOnly && err != nil:
+			t.Errorf("%s: FindAll should not read the records off the page, got %v", tt.name, err)
+		case tt.indexOnly && (total != tt.total || len(page) != 5):
+			t.Errorf("%s: FindAll = %d products (total %d), want 5 (total %d)", tt.name, len(page), total, tt.total)
+		case !tt.indexOnly && err == nil:
+			t.Errorf("%s: FindAll should decode every product to count the matches", tt.name)
+		}
+	}
+}
+
+func TestProductKV_CRUD(t *testing.T) {
+	r := newTestProductKV(t)
+	ctx := context.Background()
+	p := newTestProduct("p1")
+
+	if err := r.Save(ctx, p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if got.ID != p.ID {
+		t.Errorf("FindByID returned wrong product: got %v, want %v", got.ID, p.ID)
+	}
+
+	// renaming must move the name index entry
+	p.Name = "Updated Name"
+	p.Version++
+	if err := r.Update(ctx, p); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	ps, total, err := r.FindAll(ctx, biz.ListQuery{Limit: 10, NameFilter: "test product"})
+	if err != nil || total != 0 || len(ps) != 0 {
+		t.Errorf("stale index entry after rename: total=%d err=%v", total, err)
+	}
+	ps, total, err = r.FindAll(ctx, biz.ListQuery{Limit: 10, NameFilter: "updated"})
+	if err != nil || total != 1 || len(ps) != 1 {
+		t.Errorf("FindAll returned wrong count: got %d, want 1", total)
+	}
+
+	if err := r.Delete(ctx, p.ID, 0); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := r.FindByID(ctx, p.ID); err == nil {
+		t.Errorf("FindByID should fail after delete")
+	}
+	if _, total, _ := r.FindAll(ctx, biz.ListQuery{Limit: 10}); total != 0 {
+		t.Errorf("expected empty store after delete, got %d", total)
+	}
+	if err := r.Delete(ctx, p.ID, 0); err == nil {
+		t.Errorf("Delete should fail for a missing product")
+	}
+}
@@ -0,0 +1,1631 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// ProductSQL implements ProductRepo on top of database/sql.
+// Queries use `?` placeholders and are tested against SQLite.
+type ProductSQL struct {
+	db *sql.DB
+}
+
+// NewProductSQL creates a SQL product repository, migrating the schema to the
+// latest version first
+func NewProductSQL(ctx context.Context, db *sql.DB) (*ProductSQL, error) {
+	if err := migrate(ctx, db); err != nil {
+		return nil, err
+	}
+	return &ProductSQL{db: db}, nil
+}
+
+const productColumns = `id, sku, name, description, price_amount, price_currency, quantity, reserved, category_id, base_unit, parent_id, version,
+    created_at, updated_at, deleted_at`
+
+// scanner is satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// dbtx is satisfied by both *sql.DB and *sql.Tx
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func scanProduct(row scanner) (*biz.Product, error) {
+	var (
+		p                               biz.Product
+		createdAt, updatedAt, deletedAt int64
+	)
+	if err := row.Scan(&p.ID, &p.SKU, &p.Name, &p.Description, &p.Price.Amount, &p.Price.Currency, &p.Quantity, &p.Reserved, &p.CategoryID, &p.Units.Base, &p.ParentID, &p.Version,
+		&createdAt, &updatedAt, &deletedAt); err != nil {
+		return nil, err
+	}
+	p.CreatedAt = time.Unix(0, createdAt)
+	p.UpdatedAt = time.Unix(0, updatedAt)
+	p.DeletedAt = timeOrZero(deletedAt)
+	return &p, nil
+}
+
+// Save inserts a new product
+func (r *ProductSQL) Save(ctx context.Context, product *biz.Product) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return insertProduct(ctx, tx, product)
+	})
+}
+
+// insertProduct inserts product, failing with ErrSKUInUse when its SKU is
+// another product's. The SKU is fixed at creation, updateProduct leaves it.
+func insertProduct(ctx context.Context, db dbtx, product *biz.Product) error {
+	if product.SKU != "" {
+		var owner string
+		err := db.QueryRowContext(ctx, `SELECT id FROM products WHERE sku = ?`, product.SKU).Scan(&owner)
+		if err == nil {
+			return fmt.Errorf("%w: %s is the SKU of product %s", biz.ErrSKUInUse, product.SKU, owner)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+	}
+	if _, err := db.ExecContext(ctx,
+		`INSERT INTO products (id, sku, name, name_lower, description, price_amount, price_currency, price_major, quantity, reserved, category_id,
+    base_unit, parent_id, version, created_at, updated_at, deleted_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		product.ID, product.SKU, product.Name, strings.ToLower(product.Name), product.Description,
+		product.Price.Amount, product.Price.Currency, product.Price.Float(), product.Quantity, product.Reserved, product.CategoryID, product.Units.BaseUnit(),
+		product.ParentID, product.Version, product.CreatedAt.UnixNano(), product.UpdatedAt.UnixNano(), unixNanoOrZero(product.DeletedAt)); err != nil {
+		return err
+	}
+	if err := saveUnits(ctx, db, product); err != nil {
+		return err
+	}
+	if err := saveAttributes(ctx, db, product); err != nil {
+		return err
+	}
+	if err := saveLabelling(ctx, db, product); err != nil {
+		return err
+	}
+	if err := saveBarcodes(ctx, db, product); err != nil {
+		return err
+	}
+	return saveStock(ctx, db, product)
+}
+
+// saveAttributes replaces the attributes of product
+func saveAttributes(ctx context.Context, db dbtx, product *biz.Product) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM product_attributes WHERE product_id = ?`, product.ID); err != nil {
+		return err
+	}
+	for name, value := range product.Attributes {
+		_, err := db.ExecContext(ctx, `INSERT INTO product_attributes (product_id, name, value) VALUES (?, ?, ?)`, product.ID, name, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveUnits replaces the alternate units of measure of product
+func saveUnits(ctx context.Context, db dbtx, product *biz.Product) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM product_units WHERE product_id = ?`, product.ID); err != nil {
+		return err
+	}
+	for _, c := range product.Units.Conversions {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO product_units (product_id, code, factor, fractional) VALUES (?, ?, ?, ?)`,
+			product.ID, c.Code, c.Factor, c.Fractional)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveLabelling replaces the allergen declarations, nutrition facts and
+// dietary tags of product
+func saveLabelling(ctx context.Context, db dbtx, product *biz.Product) error {
+	for _, table := range []string{"product_allergens", "product_nutrition", "product_dietary_tags"} {
+		if _, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE product_id = ?`, product.ID); err != nil {
+			return err
+		}
+	}
+	for allergen, status := range product.Allergens {
+		_, err := db.ExecContext(ctx, `INSERT INTO product_allergens (product_id, allergen, status) VALUES (?, ?, ?)`,
+			product.ID, string(allergen), string(status))
+		if err != nil {
+			return err
+		}
+	}
+	if n := product.Nutrition; n.Declared() {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO product_nutrition (product_id, energy_kj, energy_kcal, fat, saturated_fat, carbohydrate, sugars, fibre, protein, salt)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			product.ID, n.EnergyKJ, n.EnergyKcal, n.Fat, n.SaturatedFat, n.Carbohydrate, n.Sugars, n.Fibre, n.Protein, n.Salt)
+		if err != nil {
+			return err
+		}
+	}
+	for _, tag := range product.DietaryTags {
+		if _, err := db.ExecContext(ctx, `INSERT INTO product_dietary_tags (product_id, tag) VALUES (?, ?)`, product.ID, string(tag)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveBarcodes replaces the barcodes of product, failing with
+// ErrBarcodeInUse for a barcode of another product
+func saveBarcodes(ctx context.Context, db dbtx, product *biz.Product) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM product_barcodes WHERE product_id = ?`, product.ID); err != nil {
+		return err
+	}
+	for _, b := range product.Barcodes {
+		var owner string
+		err := db.QueryRowContext(ctx, `SELECT product_id FROM product_barcodes WHERE gtin = ?`, b.GTIN).Scan(&owner)
+		if err == nil {
+			return fmt.Errorf("%w: %s is a barcode of product %s", biz.ErrBarcodeInUse, b.GTIN, owner)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if _, err := db.ExecContext(ctx, `INSERT INTO product_barcodes (gtin, product_id, unit) VALUES (?, ?, ?)`, b.GTIN, product.ID, b.Unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveStock replaces the stock levels of product by warehouse and its
+// batches
+func saveStock(ctx context.Context, db dbtx, product *biz.Product) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM product_stock WHERE product_id = ?`, product.ID); err != nil {
+		return err
+	}
+	for warehouseID, l := range product.Levels() {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO product_stock (product_id, warehouse_id, quantity, reserved) VALUES (?, ?, ?, ?)`,
+			product.ID, warehouseID, l.Quantity, l.Reserved)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, `DELETE FROM product_batches WHERE product_id = ?`, product.ID); err != nil {
+		return err
+	}
+	for _, b := range product.Batches {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO product_batches (product_id, warehouse_id, lot_code, expires_at, received_at, quantity, reserved) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			product.ID, b.WarehouseID, b.LotCode, unixNanoOrZero(b.ExpiresAt), b.ReceivedAt.UnixNano(), b.Quantity, b.Reserved)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unixNanoOrZero stores the zero time as 0
+func unixNanoOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// timeOrZero reads a time stored by unixNanoOrZero
+func timeOrZero(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// stockBatch bounds the number of products whose stock is loaded per query,
+// staying below the SQLite limit on bound parameters
+const stockBatch = 500
+
+// loadStock sets the stock levels, units of measure, attributes, labelling
+// and batches of products
+func loadStock(ctx context.Context, db dbtx, products []*biz.Product) error {
+	return eachBatch(products, func(byID map[string]*biz.Product, in string, args []any) error {
+		rows, err := db.QueryContext(ctx,
+			`SELECT product_id, warehouse_id, quantity, reserved FROM product_stock WHERE product_id IN `+in, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var (
+				productID, warehouseID string
+				l                      biz.StockLevel
+			)
+			if err := rows.Scan(&productID, &warehouseID, &l.Quantity, &l.Reserved); err != nil {
+				rows.Close()
+				return err
+			}
+			p := byID[productID]
+			if p.Stock == nil {
+				p.Stock = make(map[string]biz.StockLevel)
+			}
+			p.Stock[warehouseID] = l
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		// ordered the way biz orders conversions
+		rows, err = db.QueryContext(ctx,
+			`SELECT product_id, code, factor, fractional FROM product_units WHERE product_id IN `+in+` ORDER BY factor, code`, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var (
+				productID string
+				c         biz.UnitConversion
+			)
+			if err := rows.Scan(&productID, &c.Code, &c.Factor, &c.Fractional); err != nil {
+				rows.Close()
+				return err
+			}
+			p := byID[productID]
+			p.Units.Conversions = append(p.Units.Conversions, c)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		rows, err = db.QueryContext(ctx, `SELECT product_id, name, value FROM product_attributes WHERE product_id IN `+in, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var productID, name, value string
+			if err := rows.Scan(&productID, &name, &value); err != nil {
+				rows.Close()
+				return err
+			}
+			p := byID[productID]
+			if p.Attributes == nil {
+				p.Attributes = make(biz.Attributes)
+			}
+			p.Attributes[name] = value
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		if err := loadLabelling(ctx, db, byID, in, args); err != nil {
+			return err
+		}
+
+		rows, err = db.QueryContext(ctx, `SELECT product_id, gtin, unit FROM product_barcodes WHERE product_id IN `+in+` ORDER BY gtin`, args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var (
+				productID string
+				b         biz.Barcode
+			)
+			if err := rows.Scan(&productID, &b.GTIN, &b.Unit); err != nil {
+				rows.Close()
+				return err
+			}
+			p := byID[productID]
+			p.Barcodes = append(p.Barcodes, b)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		// in FEFO order, lots without expiry last
+		rows, err = db.QueryContext(ctx,
+			`SELECT product_id, `+batchColumns+` FROM product_batches WHERE product_id IN `+in+`
+ORDER BY warehouse_id, expires_at = 0, expires_at, received_at, lot_code`, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var productID string
+			b, err := scanBatch(rows, &productID)
+			if err != nil {
+				return err
+			}
+			p := byID[productID]
+			p.Batches = append(p.Batches, b)
+		}
+		return rows.Err()
+	})
+}
+
+// loadLabelling sets the allergen declarations, nutrition facts and dietary
+// tags of the products byID, whose IDs in binds args
+func loadLabelling(ctx context.Context, db dbtx, byID map[string]*biz.Product, in string, args []any) error {
+	rows, err := db.QueryContext(ctx, `SELECT product_id, allergen, status FROM product_allergens WHERE product_id IN `+in, args...)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var productID, allergen, status string
+		if err := rows.Scan(&productID, &allergen, &status); err != nil {
+			rows.Close()
+			return err
+		}
+		p := byID[productID]
+		if p.Allergens == nil {
+			p.Allergens = make(biz.Allergens)
+		}
+		p.Allergens[biz.Allergen(allergen)] = biz.AllergenStatus(status)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.QueryContext(ctx,
+		`SELECT product_id, energy_kj, energy_kcal, fat, saturated_fat, carbohydrate, sugars, fibre, protein, salt FROM product_nutrition
+WHERE product_id IN `+in, args...)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			productID string
+			n         biz.Nutrition
+		)
+		if err := rows.Scan(&productID, &n.EnergyKJ, &n.EnergyKcal, &n.Fat, &n.SaturatedFat, &n.Carbohydrate, &n.Sugars, &n.Fibre, &n.Protein, &n.Salt); err != nil {
+			rows.Close()
+			return err
+		}
+		byID[productID].Nutrition = n
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT product_id, tag FROM product_dietary_tags WHERE product_id IN `+in+` ORDER BY tag`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var productID, tag string
+		if err := rows.Scan(&productID, &tag); err != nil {
+			return err
+		}
+		p := byID[productID]
+		p.DietaryTags = append(p.DietaryTags, biz.DietaryTag(tag))
+	}
+	return rows.Err()
+}
+
+const batchColumns = `warehouse_id, lot_code, expires_at, received_at, quantity, reserved`
+
+// scanBatch scans the batchColumns following the leading dest
+func scanBatch(row scanner, dest ...any) (biz.Batch, error) {
+	var (
+		b                     biz.Batch
+		expiresAt, receivedAt int64
+	)
+	if err := row.Scan(append(dest, &b.WarehouseID, &b.LotCode, &expiresAt, &receivedAt, &b.Quantity, &b.Reserved)...); err != nil {
+		return biz.Batch{}, err
+	}
+	b.ExpiresAt = timeOrZero(expiresAt)
+	b.ReceivedAt = time.Unix(0, receivedAt)
+	return b, nil
+}
+
+// saveLots replaces the split by lot of the movement or reservation id in
+// table
+func saveLots(ctx context.Context, db dbtx, table, column, id, productID string, lots []biz.LotQuantity) error {
+	if _, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE `+column+` = ?`, id); err != nil {
+		return err
+	}
+	for i, l := range lots {
+		_, err := db.ExecContext(ctx,
+			`INSERT INTO `+table+` (`+column+`, product_id, position, lot_code, quantity) VALUES (?, ?, ?, ?, ?)`,
+			id, productID, i, l.LotCode, l.Quantity)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadLots returns the splits by lot of the movements or reservations ids in
+// table, there are at most a page of them
+func loadLots(ctx context.Context, db dbtx, table, column string, ids []string) (map[string][]biz.LotQuantity, error) {
+	lots := make(map[string][]biz.LotQuantity)
+	if len(ids) == 0 {
+		return lots, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := db.QueryContext(ctx,
+		`SELECT `+column+`, lot_code, quantity FROM `+table+` WHERE `+column+` IN (?`+strings.Repeat(`, ?`, len(ids)-1)+`) ORDER BY `+column+`, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id string
+			l  biz.LotQuantity
+		)
+		if err := rows.Scan(&id, &l.LotCode, &l.Quantity); err != nil {
+			return nil, err
+		}
+		lots[id] = append(lots[id], l)
+	}
+	return lots, rows.Err()
+}
+
+// eachBatch calls fn for products in batches of stockBatch, with the
+// products of the batch by ID and the `(?, ...)` list binding args to their
+// IDs
+func eachBatch(products []*biz.Product, fn func(byID map[string]*biz.Product, in string, args []any) error) error {
+	for start := 0; start < len(products); start += stockBatch {
+		batch := products[start:min(start+stockBatch, len(products))]
+		byID := make(map[string]*biz.Product, len(batch))
+		args := make([]any, len(batch))
+		for i, p := range batch {
+			byID[p.ID] = p
+			args[i] = p.ID
+		}
+		if err := fn(byID, `(?`+strings.Repeat(`, ?`, len(batch)-1)+`)`, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindByID finds a product by ID
+func (r *ProductSQL) FindByID(ctx context.Context, id string) (*biz.Product, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = ?`, id)
+	p, err := scanProduct(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadStock(ctx, r.db, []*biz.Product{p}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// FindByBarcode finds the product carrying a GTIN-14
+func (r *ProductSQL) FindByBarcode(ctx context.Context, gtin string) (*biz.Product, error) {
+	var id string
+	err := r.db.QueryRowContext(ctx, `SELECT product_id FROM product_barcodes WHERE gtin = ?`, gtin).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.FindByID(ctx, id)
+}
+
+// FindBySKU finds a product by SKU
+func (r *ProductSQL) FindBySKU(ctx context.Context, sku string) (*biz.Product, error) {
+	if sku == "" {
+		return nil, biz.ErrProductNotFound
+	}
+	row := r.db.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE sku = ?`, sku)
+	p, err := scanProduct(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := loadStock(ctx, r.db, []*biz.Product{p}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NextSKUNumber increments the SKU counter of prefix and returns it
+func (r *ProductSQL) NextSKUNumber(ctx context.Context, prefix string) (int64, error) {
+	var n int64
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO sku_counters (prefix, value) VALUES (?, 1) ON CONFLICT (prefix) DO UPDATE SET value = value + 1`, prefix); err != nil {
+			return err
+		}
+		return tx.QueryRowContext(ctx, `SELECT value FROM sku_counters WHERE prefix = ?`, prefix).Scan(&n)
+	})
+	return n, err
+}
+
+// likeEscaper escapes LIKE wildcards so the filter matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// sortColumns maps sort fields to the columns ordering them
+var sortColumns = map[biz.SortField]string{
+	biz.SortByName:      "name_lower",
+	biz.SortByPrice:     "price_major",
+	biz.SortByQuantity:  "quantity",
+	biz.SortByCreatedAt: "created_at",
+	biz.SortByUpdatedAt: "updated_at",
+}
+
+// sortValue returns the column value of the sort key carried by a cursor
+func sortValue(c *biz.Cursor) any {
+	switch c.SortBy {
+	case biz.SortByName:
+		return strings.ToLower(c.Name)
+	case biz.SortByPrice:
+		return c.Pivot().Price.Float()
+	case biz.SortByQuantity:
+		return c.Quantity
+	default:
+		return c.Time
+	}
+}
+
+// FindAll finds all products with pagination, filtering and sorting, all done by the database.
+// Keyset pages seek with a (sort column, id) comparison served by the sort indexes.
+func (r *ProductSQL) FindAll(ctx context.Context, q biz.ListQuery) ([]*biz.Product, int32, error) {
+	var (
+		conds = []string{`deleted_at = 0`}
+		args  []any
+	)
+	if q.Deleted {
+		conds[0] = `deleted_at <> 0`
+	}
+	if q.NameFilter != "" {
+		conds = append(conds, `name_lower LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(strings.ToLower(q.NameFilter))+"%")
+	}
+	if q.Warehouse != "" {
+		conds = append(conds, `id IN (SELECT product_id FROM product_stock WHERE warehouse_id = ?)`)
+		args = append(args, q.Warehouse)
+	}
+	if q.Categories != nil {
+		conds = append(conds, `category_id IN (?`+strings.Repeat(`, ?`, len(q.Categories)-1)+`)`)
+		for _, id := range q.Categories {
+			args = append(args, id)
+		}
+	}
+	if q.Filter != nil {
+		cond, fargs, ok := sqlFilter(q.Filter.Expr)
+		if !ok {
+			return r.findAllInMemory(ctx, q, conds, args)
+		}
+		conds = append(conds, cond)
+		args = append(args, fargs...)
+	}
+
+	var total int32
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM products`+whereClause(conds), args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := sortColumns[q.SortBy]
+	if !ok {
+		column = sortColumns[biz.DefaultSortField]
+	}
+	dir, cmp := " ASC", ">"
+	if q.Desc {
+		dir, cmp = " DESC", "<"
+	}
+	orderBy := ` ORDER BY ` + column + dir + `, id` + dir
+
+	offset := q.Offset
+	if q.After != nil {
+		key := sortValue(q.After)
+		conds = append(conds, `(`+column+` `+cmp+` ? OR (`+column+` = ? AND id `+cmp+` ?))`)
+		args = append(args, key, key, q.After.ID)
+		offset = 0
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+productColumns+` FROM products`+whereClause(conds)+orderBy+` LIMIT ? OFFSET ?`,
+		append(args, q.Limit, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	products := []*biz.Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		products = append(products, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if err := loadStock(ctx, r.db, products); err != nil {
+		return nil, 0, err
+	}
+	return products, total, nil
+}
+
+// findAllInMemory serves filters that cannot be pushed down: the rows
+// matching the SQL conditions are filtered, sorted and paged in memory
+func (r *ProductSQL) findAllInMemory(ctx context.Context, q biz.ListQuery, conds []string, args []any) ([]*biz.Product, int32, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+productColumns+` FROM products`+whereClause(conds), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var all []*biz.Product
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		all = append(all, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	// the stock decides the warehouse match
+	if err := loadStock(ctx, r.db, all); err != nil {
+		return nil, 0, err
+	}
+
+	var matched []*biz.Product
+	for _, p := range all {
+		if q.Match(p) {
+			matched = append(matched, p)
+		}
+	}
+
+	slices.SortFunc(matched, q.Compare)
+	return q.Window(matched), int32(len(matched)), nil
+}
+
+// whereClause joins conditions with AND
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(conds, ` AND `)
+}
+
+// Update updates an existing product, the version check and the write are a
+// single statement
+func (r *ProductSQL) Update(ctx context.Context, product *biz.Product) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return updateProduct(ctx, tx, product)
+	})
+}
+
+func updateProduct(ctx context.Context, db dbtx, product *biz.Product) error {
+	res, err := db.ExecContext(ctx,
+		`UPDATE products SET name = ?, name_lower = ?, description = ?, price_amount = ?, price_currency = ?, price_major = ?, quantity = ?,
+    reserved = ?, category_id = ?, base_unit = ?, version = ?, updated_at = ?, deleted_at = ?
+WHERE id = ? AND version = ?`,
+		product.Name, strings.ToLower(product.Name), product.Description, product.Price.Amount, product.Price.Currency, product.Price.Float(),
+		product.Quantity, product.Reserved,
+		product.CategoryID, product.Units.BaseUnit(), product.Version, product.UpdatedAt.UnixNano(), unixNanoOrZero(product.DeletedAt), product.ID, product.Version-1)
+	if err != nil {
+		return err
+	}
+	if err := requireAffected(ctx, db, res, product.ID); err != nil {
+		return err
+	}
+	if err := saveUnits(ctx, db, product); err != nil {
+		return err
+	}
+	if err := saveAttributes(ctx, db, product); err != nil {
+		return err
+	}
+	if err := saveLabelling(ctx, db, product); err != nil {
+		return err
+	}
+	if err := saveBarcodes(ctx, db, product); err != nil {
+		return err
+	}
+	return saveStock(ctx, db, product)
+}
+
+// Delete deletes a product with its stock, ledger, reservations and supplier
+// links by ID
+func (r *ProductSQL) Delete(ctx context.Context, id string, version int64) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM products WHERE id = ? AND (? = 0 OR version = ?)`, id, version, version)
+		if err != nil {
+			return err
+		}
+		if err := requireAffected(ctx, tx, res, id); err != nil {
+			return err
+		}
+		for _, table := range []string{"product_stock", "product_units", "product_attributes", "product_allergens", "product_nutrition",
+			"product_dietary_tags", "product_barcodes", "product_batches", "stock_movements", "movement_lots", "reservations", "reservation_lots", "product_suppliers",
+			"price_tiers"} {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE product_id = ?`, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RecordMovement inserts or updates product and appends m to its ledger in
+// one transaction
+func (r *ProductSQL) RecordMovement(ctx context.Context, product *biz.Product, m *biz.StockMovement) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if product.Version == 1 {
+			if err := insertProduct(ctx, tx, product); err != nil {
+				if !errors.Is(err, biz.ErrBarcodeInUse) && productExists(ctx, tx, product.ID) {
+					return biz.ErrVersionConflict
+				}
+				return err
+			}
+		} else if err := updateProduct(ctx, tx, product); err != nil {
+			return err
+		}
+		return insertMovement(ctx, tx, m)
+	})
+}
+
+func insertMovement(ctx context.Context, db dbtx, m *biz.StockMovement) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO stock_movements (id, product_id, warehouse_id, to_warehouse_id, version, kind, delta, balance, reason, actor, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		m.ID, m.ProductID, m.WarehouseID, m.ToWarehouseID, m.Version, string(m.Kind), m.Delta, m.Balance, m.Reason, m.Actor, m.CreatedAt.UnixNano())
+	if err != nil {
+		return err
+	}
+	return saveLots(ctx, db, "movement_lots", "movement_id", m.ID, m.ProductID, m.Lots)
+}
+
+// FindMovements returns the ledger of a product after a version, oldest first
+func (r *ProductSQL) FindMovements(ctx context.Context, productID string, afterVersion int64, limit int32) ([]*biz.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, product_id, warehouse_id, to_warehouse_id, version, kind, delta, balance, reason, actor, created_at FROM stock_movements
+WHERE product_id = ? AND version > ? ORDER BY version LIMIT ?`,
+		productID, afterVersion, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements := []*biz.StockMovement{}
+	var ids []string
+	for rows.Next() {
+		var (
+			m         biz.StockMovement
+			createdAt int64
+		)
+		if err := rows.Scan(&m.ID, &m.ProductID, &m.WarehouseID, &m.ToWarehouseID, &m.Version, &m.Kind, &m.Delta, &m.Balance, &m.Reason, &m.Actor, &createdAt); err != nil {
+			return nil, err
+		}
+		m.CreatedAt = time.Unix(0, createdAt)
+		movements = append(movements, &m)
+		ids = append(ids, m.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	lots, err := loadLots(ctx, r.db, "movement_lots", "movement_id", ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range movements {
+		m.Lots = lots[m.ID]
+	}
+	return movements, nil
+}
+
+const reservationColumns = `id, product_id, warehouse_id, quantity, status, actor, expires_at, created_at, updated_at`
+
+func scanReservation(row scanner) (*biz.Reservation, error) {
+	var (
+		r                               biz.Reservation
+		expiresAt, createdAt, updatedAt int64
+	)
+	if err := row.Scan(&r.ID, &r.ProductID, &r.WarehouseID, &r.Quantity, &r.Status, &r.Actor, &expiresAt, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	r.ExpiresAt = time.Unix(0, expiresAt)
+	r.CreatedAt = time.Unix(0, createdAt)
+	r.UpdatedAt = time.Unix(0, updatedAt)
+	return &r, nil
+}
+
+// SaveReservation updates product, upserts r and appends m, if given, to the
+// ledger in one transaction
+func (r *ProductSQL) SaveReservation(ctx context.Context, product *biz.Product, res *biz.Reservation, m *biz.StockMovement) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := updateProduct(ctx, tx, product); err != nil {
+			return err
+		}
+		if m != nil {
+			if err := insertMovement(ctx, tx, m); err != nil {
+				return err
+			}
+		}
+		return saveReservation(ctx, tx, res)
+	})
+}
+
+// SaveReservations stores the product and the reservations in one
+// transaction
+func (r *ProductSQL) SaveReservations(ctx context.Context, product *biz.Product, rs []*biz.Reservation) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if err := updateProduct(ctx, tx, product); err != nil {
+			return err
+		}
+		for _, res := range rs {
+			if err := saveReservation(ctx, tx, res); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// saveReservation inserts res or updates its status
+func saveReservation(ctx context.Context, tx *sql.Tx, res *biz.Reservation) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO reservations (`+reservationColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET status = excluded.status, updated_at = excluded.updated_at`,
+		res.ID, res.ProductID, res.WarehouseID, res.Quantity, string(res.Status), res.Actor,
+		res.ExpiresAt.UnixNano(), res.CreatedAt.UnixNano(), res.UpdatedAt.UnixNano())
+	if err != nil {
+		return err
+	}
+	return saveLots(ctx, tx, "reservation_lots", "reservation_id", res.ID, res.ProductID, res.Lots)
+}
+
+// FindReservation finds a reservation by ID
+func (r *ProductSQL) FindReservation(ctx context.Context, id string) (*biz.Reservation, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+reservationColumns+` FROM reservations WHERE id = ?`, id)
+	res, err := scanReservation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	lots, err := loadLots(ctx, r.db, "reservation_lots", "reservation_id", []string{id})
+	if err != nil {
+		return nil, err
+	}
+	res.Lots = lots[id]
+	return res, nil
+}
+
+// FindExpiredReservations returns held reservations expired at now, the
+// longest expired first, served by the (status, expires_at) index
+func (r *ProductSQL) FindExpiredReservations(ctx context.Context, now time.Time, limit int32) ([]*biz.Reservation, error) {
+	return r.findReservations(ctx, `status = ? AND expires_at <= ? ORDER BY expires_at, id LIMIT ?`, string(biz.ReservationHeld), now.UnixNano(), limit)
+}
+
+// FindHeldReservations returns the held reservations of a product
+func (r *ProductSQL) FindHeldReservations(ctx context.Context, productID string) ([]*biz.Reservation, error) {
+	return r.findReservations(ctx, `product_id = ? AND status = ? ORDER BY expires_at, id`, productID, string(biz.ReservationHeld))
+}
+
+// findReservations returns the reservations matching where with their lots
+func (r *ProductSQL) findReservations(ctx context.Context, where string, args ...any) ([]*biz.Reservation, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+reservationColumns+` FROM reservations WHERE `+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := []*biz.Reservation{}
+	var ids []string
+	for rows.Next() {
+		res, err := scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, res)
+		ids = append(ids, res.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	lots, err := loadLots(ctx, r.db, "reservation_lots", "reservation_id", ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range found {
+		res.Lots = lots[res.ID]
+	}
+	return found, nil
+}
+
+// FindExpiringBatches returns the batches expiring before before, served by
+// the expires_at index
+func (r *ProductSQL) FindExpiringBatches(ctx context.Context, before time.Time, warehouseID string) ([]*biz.ExpiringBatch, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT p.id, p.name, b.warehouse_id, b.lot_code, b.expires_at, b.received_at, b.quantity, b.reserved
+FROM product_batches b JOIN products p ON p.id = b.product_id
+WHERE p.deleted_at = 0 AND b.expires_at <> 0 AND b.expires_at < ? AND (? = '' OR b.warehouse_id = ?)`,
+		before.UnixNano(), warehouseID, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []*biz.ExpiringBatch{}
+	for rows.Next() {
+		var e biz.ExpiringBatch
+		if e.Batch, err = scanBatch(rows, &e.ProductID, &e.ProductName); err != nil {
+			return nil, err
+		}
+		batches = append(batches, &e)
+	}
+	return batches, rows.Err()
+}
+
+// SaveWarehouse inserts or replaces a warehouse
+func (r *ProductSQL) SaveWarehouse(ctx context.Context, w *biz.Warehouse) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO warehouses (id, name, address, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET name = excluded.name, address = excluded.address, updated_at = excluded.updated_at`,
+		w.ID, w.Name, w.Address, w.CreatedAt.UnixNano(), w.UpdatedAt.UnixNano())
+	return err
+}
+
+func scanWarehouse(row scanner) (*biz.Warehouse, error) {
+	var (
+		w                    biz.Warehouse
+		createdAt, updatedAt int64
+	)
+	if err := row.Scan(&w.ID, &w.Name, &w.Address, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	w.CreatedAt = time.Unix(0, createdAt)
+	w.UpdatedAt = time.Unix(0, updatedAt)
+	return &w, nil
+}
+
+// FindWarehouse finds a warehouse by ID
+func (r *ProductSQL) FindWarehouse(ctx context.Context, id string) (*biz.Warehouse, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, name, address, created_at, updated_at FROM warehouses WHERE id = ?`, id)
+	w, err := scanWarehouse(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrWarehouseNotFound
+	}
+	return w, err
+}
+
+// FindWarehouses returns every warehouse ordered by name
+func (r *ProductSQL) FindWarehouses(ctx context.Context) ([]*biz.Warehouse, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, address, created_at, updated_at FROM warehouses ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	warehouses := []*biz.Warehouse{}
+	for rows.Next() {
+		w, err := scanWarehouse(rows)
+		if err != nil {
+			return nil, err
+		}
+		warehouses = append(warehouses, w)
+	}
+	return warehouses, rows.Err()
+}
+
+// DeleteWarehouse deletes a warehouse by ID
+func (r *ProductSQL) DeleteWarehouse(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM warehouses WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return biz.ErrWarehouseNotFound
+	}
+	return nil
+}
+
+// SaveCategory inserts or replaces a category and its attribute definitions
+func (r *ProductSQL) SaveCategory(ctx context.Context, c *biz.Category) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO categories (id, name, parent_id, sku_prefix, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET name = excluded.name, parent_id = excluded.parent_id, sku_prefix = excluded.sku_prefix, updated_at = excluded.updated_at`,
+			c.ID, c.Name, c.ParentID, c.SKUPrefix, c.CreatedAt.UnixNano(), c.UpdatedAt.UnixNano()); err != nil {
+			return err
+		}
+		for _, table := range []string{"category_attributes", "category_attribute_values"} {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE category_id = ?`, c.ID); err != nil {
+				return err
+			}
+		}
+		for i, d := range c.Attributes {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO category_attributes (category_id, position, name, type, required) VALUES (?, ?, ?, ?, ?)`,
+				c.ID, i, d.Name, string(d.Type), d.Required); err != nil {
+				return err
+			}
+			for j, v := range d.Values {
+				if _, err := tx.ExecContext(ctx,
+					`INSERT INTO category_attribute_values (category_id, name, position, value) VALUES (?, ?, ?, ?)`,
+					c.ID, d.Name, j, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// FindCategory finds a category by ID
+func (r *ProductSQL) FindCategory(ctx context.Context, id string) (*biz.Category, error) {
+	categories, err := r.findCategories(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 {
+		return nil, biz.ErrCategoryNotFound
+	}
+	return categories[0], nil
+}
+
+// FindCategories returns every category ordered by name
+func (r *ProductSQL) FindCategories(ctx context.Context) ([]*biz.Category, error) {
+	return r.findCategories(ctx, "")
+}
+
+// findCategories loads the category id, or every category when id is empty,
+// ordered by name with their attribute definitions
+func (r *ProductSQL) findCategories(ctx context.Context, id string) ([]*biz.Category, error) {
+	var where, attributesWhere string
+	var args []any
+	if id != "" {
+		where, attributesWhere, args = ` WHERE id = ?`, ` WHERE category_id = ?`, []any{id}
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, parent_id, sku_prefix, created_at, updated_at FROM categories`+where+` ORDER BY name, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []*biz.Category{}
+	byID := make(map[string]*biz.Category)
+	for rows.Next() {
+		var (
+			c                    biz.Category
+			createdAt, updatedAt int64
+		)
+		if err := rows.Scan(&c.ID, &c.Name, &c.ParentID, &c.SKUPrefix, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		c.CreatedAt = time.Unix(0, createdAt)
+		c.UpdatedAt = time.Unix(0, updatedAt)
+		categories = append(categories, &c)
+		byID[c.ID] = &c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	defs, err := r.db.QueryContext(ctx, `SELECT category_id, name, type, required FROM category_attributes`+attributesWhere+` ORDER BY category_id, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer defs.Close()
+	for defs.Next() {
+		var (
+			categoryID, typ string
+			d               biz.AttributeDef
+		)
+		if err := defs.Scan(&categoryID, &d.Name, &typ, &d.Required); err != nil {
+			return nil, err
+		}
+		d.Type = biz.AttributeType(typ)
+		if c, ok := byID[categoryID]; ok {
+			c.Attributes = append(c.Attributes, d)
+		}
+	}
+	if err := defs.Err(); err != nil {
+		return nil, err
+	}
+	defs.Close()
+
+	values, err := r.db.QueryContext(ctx, `SELECT category_id, name, value FROM category_attribute_values`+attributesWhere+` ORDER BY category_id, name, position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Close()
+	for values.Next() {
+		var categoryID, name, value string
+		if err := values.Scan(&categoryID, &name, &value); err != nil {
+			return nil, err
+		}
+		c, ok := byID[categoryID]
+		if !ok {
+			continue
+		}
+		if i := slices.IndexFunc(c.Attributes, func(d biz.AttributeDef) bool { return d.Name == name }); i >= 0 {
+			c.Attributes[i].Values = append(c.Attributes[i].Values, value)
+		}
+	}
+	return categories, values.Err()
+}
+
+// SaveSupplier inserts or replaces a supplier
+func (r *ProductSQL) SaveSupplier(ctx context.Context, s *biz.Supplier) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO suppliers (id, name, contact_name, email, phone, lead_time_days, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET name = excluded.name, contact_name = excluded.contact_name, email = excluded.email,
+    phone = excluded.phone, lead_time_days = excluded.lead_time_days, updated_at = excluded.updated_at`,
+		s.ID, s.Name, s.ContactName, s.Email, s.Phone, s.LeadTimeDays, s.CreatedAt.UnixNano(), s.UpdatedAt.UnixNano())
+	return err
+}
+
+const supplierColumns = `id, name, contact_name, email, phone, lead_time_days, created_at, updated_at`
+
+func scanSupplier(row scanner) (*biz.Supplier, error) {
+	var (
+		s                    biz.Supplier
+		createdAt, updatedAt int64
+	)
+	if err := row.Scan(&s.ID, &s.Name, &s.ContactName, &s.Email, &s.Phone, &s.LeadTimeDays, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	s.CreatedAt = time.Unix(0, createdAt)
+	s.UpdatedAt = time.Unix(0, updatedAt)
+	return &s, nil
+}
+
+// FindSupplier finds a supplier by ID
+func (r *ProductSQL) FindSupplier(ctx context.Context, id string) (*biz.Supplier, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+supplierColumns+` FROM suppliers WHERE id = ?`, id)
+	s, err := scanSupplier(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrSupplierNotFound
+	}
+	return s, err
+}
+
+// FindSuppliers returns every supplier ordered by name
+func (r *ProductSQL) FindSuppliers(ctx context.Context) ([]*biz.Supplier, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+supplierColumns+` FROM suppliers ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := []*biz.Supplier{}
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, s)
+	}
+	return suppliers, rows.Err()
+}
+
+// DeleteSupplier deletes a supplier no product is linked to
+func (r *ProductSQL) DeleteSupplier(ctx context.Context, id string) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		var linked bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM product_suppliers WHERE supplier_id = ?)`, id).Scan(&linked); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM suppliers WHERE id = ?`, id)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		switch {
+		case n == 0:
+			return biz.ErrSupplierNotFound
+		case linked:
+			// rolls the delete back
+			return biz.ErrSupplierInUse
+		}
+		return nil
+	})
+}
+
+// SaveProductSupplier inserts or replaces a supplier link of a product, a
+// preferred link clears the flag on the others in the same transaction
+func (r *ProductSQL) SaveProductSupplier(ctx context.Context, l *biz.ProductSupplier) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if !productLive(ctx, tx, l.ProductID) {
+			return biz.ErrProductNotFound
+		}
+		var supplier bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM suppliers WHERE id = ?)`, l.SupplierID).Scan(&supplier); err != nil {
+			return err
+		}
+		if !supplier {
+			return biz.ErrSupplierNotFound
+		}
+		if l.Preferred {
+			if _, err := tx.ExecContext(ctx, `UPDATE product_suppliers SET preferred = 0 WHERE product_id = ? AND supplier_id <> ?`,
+				l.ProductID, l.SupplierID); err != nil {
+				return err
+			}
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO product_suppliers (product_id, supplier_id, supplier_sku, cost_price_amount, cost_price_currency, preferred,
+    created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (product_id, supplier_id) DO UPDATE SET supplier_sku = excluded.supplier_sku,
+    cost_price_amount = excluded.cost_price_amount, cost_price_currency = excluded.cost_price_currency,
+    preferred = excluded.preferred, updated_at = excluded.updated_at`,
+			l.ProductID, l.SupplierID, l.SupplierSKU, l.CostPrice.Amount, l.CostPrice.Currency, l.Preferred, l.CreatedAt.UnixNano(), l.UpdatedAt.UnixNano())
+		return err
+	})
+}
+
+// FindProductSuppliers returns the supplier links of a product, the
+// preferred one first
+func (r *ProductSQL) FindProductSuppliers(ctx context.Context, productID string) ([]*biz.ProductSupplier, error) {
+	if !productLive(ctx, r.db, productID) {
+		return nil, biz.ErrProductNotFound
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT product_id, supplier_id, supplier_sku, cost_price_amount, cost_price_currency, preferred, created_at, updated_at
+FROM product_suppliers
+WHERE product_id = ? ORDER BY preferred DESC, supplier_id`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := []*biz.ProductSupplier{}
+	for rows.Next() {
+		var (
+			l                    biz.ProductSupplier
+			createdAt, updatedAt int64
+		)
+		if err := rows.Scan(&l.ProductID, &l.SupplierID, &l.SupplierSKU, &l.CostPrice.Amount, &l.CostPrice.Currency, &l.Preferred, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		l.CreatedAt = time.Unix(0, createdAt)
+		l.UpdatedAt = time.Unix(0, updatedAt)
+		links = append(links, &l)
+	}
+	return links, rows.Err()
+}
+
+// DeleteProductSupplier removes a supplier link of a product
+func (r *ProductSQL) DeleteProductSupplier(ctx context.Context, productID, supplierID string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM product_suppliers WHERE product_id = ? AND supplier_id = ?`, productID, supplierID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return biz.ErrProductSupplierNotFound
+	}
+	return nil
+}
+
+// SavePriceList inserts or replaces a price list and its customers
+func (r *ProductSQL) SavePriceList(ctx context.Context, l *biz.PriceList) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO price_lists (id, name, created_at, updated_at) VALUES (?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET name = excluded.name, updated_at = excluded.updated_at`,
+			l.ID, l.Name, l.CreatedAt.UnixNano(), l.UpdatedAt.UnixNano()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM price_list_customers WHERE price_list_id = ?`, l.ID); err != nil {
+			return err
+		}
+		for _, c := range l.CustomerIDs {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO price_list_customers (customer_id, price_list_id) VALUES (?, ?)`, c, l.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindPriceList finds a price list by ID
+func (r *ProductSQL) FindPriceList(ctx context.Context, id string) (*biz.PriceList, error) {
+	lists, err := r.findPriceLists(ctx, ` WHERE id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(lists) == 0 {
+		return nil, biz.ErrPriceListNotFound
+	}
+	return lists[0], nil
+}
+
+// FindPriceLists returns every price list ordered by name
+func (r *ProductSQL) FindPriceLists(ctx context.Context) ([]*biz.PriceList, error) {
+	return r.findPriceLists(ctx, ``)
+}
+
+// findPriceLists loads the price lists matching where, ordered by name, with
+// their customers
+func (r *ProductSQL) findPriceLists(ctx context.Context, where string, args ...any) ([]*biz.PriceList, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, created_at, updated_at FROM price_lists`+where+` ORDER BY name, id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := []*biz.PriceList{}
+	byID := make(map[string]*biz.PriceList)
+	for rows.Next() {
+		var (
+			l                    biz.PriceList
+			createdAt, updatedAt int64
+		)
+		if err := rows.Scan(&l.ID, &l.Name, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		l.CreatedAt = time.Unix(0, createdAt)
+		l.UpdatedAt = time.Unix(0, updatedAt)
+		lists = append(lists, &l)
+		byID[l.ID] = &l
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	customers, err := r.db.QueryContext(ctx, `SELECT customer_id, price_list_id FROM price_list_customers ORDER BY customer_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer customers.Close()
+	for customers.Next() {
+		var customerID, listID string
+		if err := customers.Scan(&customerID, &listID); err != nil {
+			return nil, err
+		}
+		if l, ok := byID[listID]; ok {
+			l.CustomerIDs = append(l.CustomerIDs, customerID)
+		}
+	}
+	return lists, customers.Err()
+}
+
+// SavePriceTiers replaces the tiers of a product on a price list
+func (r *ProductSQL) SavePriceTiers(ctx context.Context, priceListID, productID string, tiers []*biz.PriceTier) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		var one int
+		if err := tx.QueryRowContext(ctx, `SELECT 1 FROM price_lists WHERE id = ?`, priceListID).Scan(&one); errors.Is(err, sql.ErrNoRows) {
+			return biz.ErrPriceListNotFound
+		} else if err != nil {
+			return err
+		}
+		if !productExists(ctx, tx, productID) {
+			return biz.ErrProductNotFound
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM price_tiers WHERE price_list_id = ? AND product_id = ?`, priceListID, productID); err != nil {
+			return err
+		}
+		for _, t := range tiers {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO price_tiers (price_list_id, product_id, min_quantity, price_amount, price_currency) VALUES (?, ?, ?, ?, ?)`,
+				priceListID, productID, t.MinQuantity, t.Price.Amount, t.Price.Currency); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindPriceTiers returns the tiers of a product on a price list ordered by
+// minimum quantity
+func (r *ProductSQL) FindPriceTiers(ctx context.Context, priceListID, productID string) ([]*biz.PriceTier, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT price_list_id, product_id, min_quantity, price_amount, price_currency FROM price_tiers
+WHERE price_list_id = ? AND product_id = ? ORDER BY min_quantity`, priceListID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := []*biz.PriceTier{}
+	for rows.Next() {
+		var t biz.PriceTier
+		if err := rows.Scan(&t.PriceListID, &t.ProductID, &t.MinQuantity, &t.Price.Amount, &t.Price.Currency); err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, &t)
+	}
+	return tiers, rows.Err()
+}
+
+const auctionColumns = `id, product_id, warehouse_id, quantity, reserve_price_amount, reserve_price_currency, opens_at, closes_at,
+    status, reservation_id, winning_bid_id, winner_id, winning_price_amount, winning_price_currency, outcome, created_at, updated_at`
+
+func scanAuction(row scanner) (*biz.Auction, error) {
+	var (
+		a                                       biz.Auction
+		opensAt, closesAt, createdAt, updatedAt int64
+	)
+	if err := row.Scan(&a.ID, &a.ProductID, &a.WarehouseID, &a.Quantity, &a.ReservePrice.Amount, &a.ReservePrice.Currency, &opensAt, &closesAt,
+		&a.Status, &a.ReservationID, &a.WinningBidID, &a.WinnerID, &a.WinningPrice.Amount, &a.WinningPrice.Currency, &a.Outcome, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	a.OpensAt = time.Unix(0, opensAt)
+	a.ClosesAt = time.Unix(0, closesAt)
+	a.CreatedAt = time.Unix(0, createdAt)
+	a.UpdatedAt = time.Unix(0, updatedAt)
+	return &a, nil
+}
+
+// SaveAuction inserts or replaces an auction
+func (r *ProductSQL) SaveAuction(ctx context.Context, a *biz.Auction) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO auctions (`+auctionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (id) DO UPDATE SET status = excluded.status, winning_bid_id = excluded.winning_bid_id, winner_id = excluded.winner_id,
+    winning_price_amount = excluded.winning_price_amount, winning_price_currency = excluded.winning_price_currency,
+    outcome = excluded.outcome, updated_at = excluded.updated_at`,
+		a.ID, a.ProductID, a.WarehouseID, a.Quantity, a.ReservePrice.Amount, a.ReservePrice.Currency, a.OpensAt.UnixNano(), a.ClosesAt.UnixNano(),
+		string(a.Status), a.ReservationID, a.WinningBidID, a.WinnerID, a.WinningPrice.Amount, a.WinningPrice.Currency, a.Outcome,
+		a.CreatedAt.UnixNano(), a.UpdatedAt.UnixNano())
+	return err
+}
+
+// FindAuction finds an auction by ID
+func (r *ProductSQL) FindAuction(ctx context.Context, id string) (*biz.Auction, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+auctionColumns+` FROM auctions WHERE id = ?`, id)
+	a, err := scanAuction(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, biz.ErrAuctionNotFound
+	}
+	return a, err
+}
+
+// FindAuctions returns every auction ordered by closing time
+func (r *ProductSQL) FindAuctions(ctx context.Context) ([]*biz.Auction, error) {
+	return r.findAuctions(ctx, `SELECT `+auctionColumns+` FROM auctions ORDER BY closes_at, id`)
+}
+
+// FindDueAuctions returns open auctions closed at now, the longest closed
+// first, served by the (status, closes_at) index
+func (r *ProductSQL) FindDueAuctions(ctx context.Context, now time.Time, limit int32) ([]*biz.Auction, error) {
+	return r.findAuctions(ctx, `SELECT `+auctionColumns+` FROM auctions
+WHERE status = ? AND closes_at <= ? ORDER BY closes_at, id LIMIT ?`,
+		string(biz.AuctionOpen), now.UnixNano(), limit)
+}
+
+func (r *ProductSQL) findAuctions(ctx context.Context, query string, args ...any) ([]*biz.Auction, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	auctions := []*biz.Auction{}
+	for rows.Next() {
+		a, err := scanAuction(rows)
+		if err != nil {
+			return nil, err
+		}
+		auctions = append(auctions, a)
+	}
+	return auctions, rows.Err()
+}
+
+// SaveBid inserts or replaces the bid of a bidder on an auction
+func (r *ProductSQL) SaveBid(ctx context.Context, b *biz.Bid) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		var one int
+		if err := tx.QueryRowContext(ctx, `SELECT 1 FROM auctions WHERE id = ?`, b.AuctionID).Scan(&one); errors.Is(err, sql.ErrNoRows) {
+			return biz.ErrAuctionNotFound
+		} else if err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO bids (auction_id, bidder_id, id, amount, currency, submitted_at) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (auction_id, bidder_id) DO UPDATE SET id = excluded.id, amount = excluded.amount, currency = excluded.currency,
+    submitted_at = excluded.submitted_at`,
+			b.AuctionID, b.BidderID, b.ID, b.Amount.Amount, b.Amount.Currency, b.SubmittedAt.UnixNano())
+		return err
+	})
+}
+
+// FindBids returns the bids on an auction ordered by bidder
+func (r *ProductSQL) FindBids(ctx context.Context, auctionID string) ([]*biz.Bid, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, auction_id, bidder_id, amount, currency, submitted_at FROM bids WHERE auction_id = ? ORDER BY bidder_id`, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bids := []*biz.Bid{}
+	for rows.Next() {
+		var (
+			b           biz.Bid
+			submittedAt int64
+		)
+		if err := rows.Scan(&b.ID, &b.AuctionID, &b.BidderID, &b.Amount.Amount, &b.Amount.Currency, &submittedAt); err != nil {
+			return nil, err
+		}
+		b.SubmittedAt = time.Unix(0, submittedAt)
+		bids = append(bids, &b)
+	}
+	return bids, rows.Err()
+}
+
+// inTx runs fn in a transaction, committing when it returns nil
+func (r *ProductSQL) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// productExists reports whether a product with id is stored
+func productExists(ctx context.Context, db dbtx, id string) bool {
+	var one int
+	return db.QueryRowContext(ctx, `SELECT 1 FROM products WHERE id = ?`, id).Scan(&one) == nil
+}
+
+// productLive reports whether a product with id is stored and not in the
+// trash
+func productLive(ctx context.Context, db dbtx, id string) bool {
+	var one int
+	return db.QueryRowContext(ctx, `SELECT 1 FROM products WHERE id = ? AND deleted_at = 0`, id).Scan(&one) == nil
+}
+
+// requireAffected maps a statement that touched no rows to ErrProductNotFound,
+// or to ErrVersionConflict when the product exists with another version
+func requireAffected(ctx context.Context, db dbtx, res sql.Result, id string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	var exists int
+	err = db.QueryRowContext(ctx, `SELECT 1 FROM products WHERE id = ?`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return biz.ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return biz.ErrVersionConflict
+}
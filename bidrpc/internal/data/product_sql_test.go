@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestProductSQL(t *testing.T) *ProductSQL {
+	t.Helper()
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	r, err := NewProductSQL(context.Background(), db)
+	if err != nil {
+		t.Fatalf("NewProductSQL failed: %v", err)
+	}
+	return r
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	r := newTestProductSQL(t)
+	ctx := context.Background()
+
+	if err := migrate(ctx, r.db); err != nil {
+		t.Fatalf("second migrate failed: %v", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	var version int
+	if err := r.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != migrations[len(migrations)-1].version {
+		t.Errorf("expected schema version %d, got %d", migrations[len(migrations)-1].version, version)
+	}
+}
+
+func TestMigrate_LegacyPrices(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "legacy.db"))
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	// a database last migrated before amounts became minor units
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL, applied_at INTEGER NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range migrations {
+		if m.name == "money" {
+			break
+		}
+		if _, err := db.Exec(m.sql); err != nil {
+			t.Fatalf("migration %d failed: %v", m.version, err)
+		}
+		if _, err := db.Exec(`INSERT INTO schema_migrations VALUES (?, ?, 0)`, m.version, m.name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.Exec(`INSERT INTO products (id, name, name_lower, price, created_at, updated_at) VALUES ('p1', 'Brie', 'brie', 999.99, 1, 1)`); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewProductSQL(ctx, db)
+	if err != nil {
+		t.Fatalf("NewProductSQL failed: %v", err)
+	}
+	p, err := r.FindByID(ctx, "p1")
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if p.Price != (biz.Money{Amount: 99999, Currency: "USD"}) {
+		t.Errorf("legacy price should become 99999 USD cents, got %v", p.Price)
+	}
+	var major float64
+	if err := db.QueryRow(`SELECT price_major FROM products WHERE id = 'p1'`).Scan(&major); err != nil || major != p.Price.Float() {
+		t.Errorf("price_major should be backfilled with %v, got %v, %v", p.Price.Float(), major, err)
+	}
+}
+
+func TestMigrate_PriceMajorBackfill(t *testing.T) {
+	db, err := sql.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "legacy.db"))
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	// a database last migrated before prices sorted in major units
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, name TEXT NOT NULL, applied_at INTEGER NOT NULL)`); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range migrations {
+		if m.name == "price_major" {
+			break
+		}
+		if _, err := db.Exec(m.sql); err != nil {
+			t.Fatalf("migration %d failed: %v", m.version, err)
+		}
+		if _, err := db.Exec(`INSERT INTO schema_migrations VALUES (?, ?, 0)`, m.version, m.name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prices := []biz.Money{{Amount: 500, Currency: "JPY"}, {Amount: 1500, Currency: "BHD"}, {Amount: 60000, Currency: "CLF"}, {Amount: 1000, Currency: "USD"}}
+	for i, price := range prices {
+		if _, err := db.Exec(`INSERT INTO products (id, name, name_lower, price_amount, price_currency, created_at, updated_at) VALUES (?, 'x', 'x', ?, ?, 1, 1)`,
+			i, price.Amount, price.Currency); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := NewProductSQL(ctx, db); err != nil {
+		t.Fatalf("NewProductSQL failed: %v", err)
+	}
+	for i, price := range prices {
+		var major float64
+		if err := db.QueryRow(`SELECT price_major FROM products WHERE id = ?`, i).Scan(&major); err != nil || major != price.Float() {
+			t.Errorf("price_major of %v = %v, %v, want %v", price, major, err, price.Float())
+		}
+	}
+}
+
+func TestProductSQL_FindAll_PaginationAndFilter(t *testing.T) {
+	r := newTestProductSQL(t)
+	ctx := context.Background()
+	for i := 1; i <= 15; i++ {
+		p := newTestProduct("p" + string(rune(i+'0')))
+		p.Name = "Product" + string(rune(i+'0'))
+		if i%2 == 0 {
+			p.Name = "Special" + p.Name
+		}
+		if err := r.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+
+	products, total, err := r.FindAll(ctx, biz.ListQuery{Limit: 3, NameFilter: "special"})
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if total != 7 {
+		t.Errorf("Expected 7 products with 'Special' in name, got %d", total)
+	}
+	if len(products) != 3 {
+		t.Errorf("Expected 3 products on page 1, got %d", len(products))
+	}
+
+	products, _, err = r.FindAll(ctx, biz.ListQuery{Offset: 6, Limit: 3, NameFilter: "Special"})
+	if err != nil {
+		t.Fatalf("FindAll failed: %v", err)
+	}
+	if len(products) != 1 {
+		t.Errorf("Expected 1 product on page 3, got %d", len(products))
+	}
+
+	// LIKE wildcards in the filter are matched literally
+	_, total, err = r.FindAll(ctx, biz.ListQuery{Limit: 10, NameFilter: "%"})
+	if err != nil || total != 0 {
+		t.Errorf("Expected no match for '%%', got %d (%v)", total, err)
+	}
+}
+
+func TestProductSQL_CRUD(t *testing.T) {
+	r := newTestProductSQL(t)
+	ctx := context.Background()
+	p := newTestProduct("p1")
+
+	if err := r.Save(ctx, p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.FindByID(ctx, p.ID)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if got.ID != p.ID || !got.CreatedAt.Equal(p.CreatedAt) {
+		t.Errorf("FindByID returned wrong product: got %+v, want %+v", got, p)
+	}
+
+	p.Name = "Updated Name"
+	p.Version++
+	if err := r.Update(ctx, p); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ = r.FindByID(ctx, p.ID)
+	if got.Name != "Updated Name" {
+		t.Errorf("Update did not update name: got %v", got.Name)
+	}
+
+	if err := r.Delete(ctx, p.ID, 0); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := r.FindByID(ctx, p.ID); err == nil {
+		t.Errorf("FindByID should fail after delete")
+	}
+	if err := r.Update(ctx, p); err == nil {
+		t.Errorf("Update should fail for a missing product")
+	}
+	if err := r.Delete(ctx, p.ID, 0); err == nil {
+		t.Errorf("Delete should fail for a missing product")
+	}
+}
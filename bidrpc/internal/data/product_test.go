@@ -2,6 +2,11 @@ package data
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"testing"
 	"time"
 
@@ -13,16 +18,27 @@ func newTestProduct(id string) *biz.Product {
 		ID:          id,
 		Name:        "Test Product " + id,
 		Description: "A test product",
-		Price:       10.0,
+		Price:       biz.Money{Amount: 1000, Currency: "USD"},
 		Quantity:    5,
+		Version:     1,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 }
 
+func newTestProductData(t *testing.T) *ProductData {
+	t.Helper()
+	d, err := NewProductData(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	return d
+}
+
 // Test FindAll pagination and filter
 func TestProductData_FindAll_PaginationAndFilter(t *testing.T) {
-	d := NewProductData()
+	d := newTestProductData(t)
 	ctx := context.Background()
 	// Add multiple products
 	for i := 1; i <= 15; i++ {
@@ -37,7 +53,7 @@ func TestProductData_FindAll_PaginationAndFilter(t *testing.T) {
 	}
 
 	// Test filter for "Special" and pagination (page 1, pageSize 3)
-	products, total, err := d.FindAll(ctx, 1, 3, "Special")
+	products, total, err := d.FindAll(ctx, biz.ListQuery{Limit: 3, NameFilter: "Special"})
 	if err != nil {
 		t.Fatalf("FindAll failed: %v", err)
 	}
@@ -49,7 +65,7 @@ func TestProductData_FindAll_PaginationAndFilter(t *testing.T) {
 	}
 
 	// Test page 3 (should have 1 product)
-	products, _, err = d.FindAll(ctx, 3, 3, "Special")
+	products, _, err = d.FindAll(ctx, biz.ListQuery{Offset: 6, Limit: 3, NameFilter: "Special"})
 	if err != nil {
 		t.Fatalf("FindAll failed: %v", err)
 	}
@@ -59,7 +75,7 @@ func TestProductData_FindAll_PaginationAndFilter(t *testing.T) {
 }
 
 func TestProductData_CRUD(t *testing.T) {
-	d := NewProductData()
+	d := newTestProductData(t)
 	ctx := context.Background()
 	p := newTestProduct("p1")
 
@@ -79,6 +95,7 @@ func TestProductData_CRUD(t *testing.T) {
 
 	// Test Update
 	p.Name = "Updated Name"
+	p.Version++
 	if err := d.Update(ctx, p); err != nil {
 		t.Fatalf("Update failed: %v", err)
 	}
@@ -88,7 +105,7 @@ func TestProductData_CRUD(t *testing.T) {
 	}
 
 	// Test FindAll
-	ps, total, err := d.FindAll(ctx, 1, 10, "Updated")
+	ps, total, err := d.FindAll(ctx, biz.ListQuery{Limit: 10, NameFilter: "Updated"})
 	if err != nil {
 		t.Fatalf("FindAll failed: %v", err)
 	}
@@ -97,7 +114,7 @@ func TestProductData_CRUD(t *testing.T) {
 	}
 
 	// Test Delete
-	if err := d.Delete(ctx, p.ID); err != nil {
+	if err := d.Delete(ctx, p.ID, 0); err != nil {
 		t.Fatalf("Delete failed: %v", err)
 	}
 	_, err = d.FindByID(ctx, p.ID)
@@ -105,3 +122,1136 @@ func TestProductData_CRUD(t *testing.T) {
 		t.Errorf("FindByID should fail after delete")
 	}
 }
+
+// forEachRepo runs fn against a fresh instance of every ProductRepo implementation
+func forEachRepo(t *testing.T, fn func(t *testing.T, repo biz.ProductRepo)) {
+	repos := map[string]func(t *testing.T) biz.ProductRepo{
+		"file": func(t *testing.T) biz.ProductRepo { return newTestProductData(t) },
+		"sql":  func(t *testing.T) biz.ProductRepo { return newTestProductSQL(t) },
+		"kv":   func(t *testing.T) biz.ProductRepo { return newTestProductKV(t) },
+	}
+	for _, name := range []string{"file", "sql", "kv"} {
+		t.Run(name, func(t *testing.T) { fn(t, repos[name](t)) })
+	}
+}
+
+// seedSortable saves products with colliding sort keys to exercise tie-breaking
+func seedSortable(t *testing.T, repo biz.ProductRepo) []*biz.Product {
+	t.Helper()
+	base := time.Now().Truncate(time.Second)
+	var products []*biz.Product
+	for i := 0; i < 9; i++ {
+		p := newTestProduct(fmt.Sprintf("id-%d", (i*7)%9))
+		p.Name = []string{"apple", "Banana", "cherry"}[i%3]
+		p.Price = biz.Money{Amount: int64(i%4) * 100, Currency: "USD"}
+		p.Quantity = int32(i % 2)
+		p.CreatedAt = base.Add(time.Duration(i%5) * time.Minute)
+		p.UpdatedAt = base.Add(time.Duration(i%3) * time.Hour)
+		if err := repo.Save(context.Background(), p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		products = append(products, p)
+	}
+	return products
+}
+
+func TestProductRepos_SortOrder(t *testing.T) {
+	fields := []biz.SortField{biz.SortByName, biz.SortByPrice, biz.SortByQuantity, biz.SortByCreatedAt, biz.SortByUpdatedAt}
+
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		products := seedSortable(t, repo)
+		ctx := context.Background()
+
+		for _, field := range fields {
+			for _, desc := range []bool{false, true} {
+				q := biz.ListQuery{SortBy: field, Desc: desc, Limit: 4}
+				want := slices.Clone(products)
+				slices.SortFunc(want, q.Compare)
+
+				// walk every page and compare with the reference order
+				var got []*biz.Product
+				for q.Offset = 0; q.Offset < int32(len(products)); q.Offset += q.Limit {
+					page, total, err := repo.FindAll(ctx, q)
+					if err != nil {
+						t.Fatalf("FindAll(%s, desc=%v) failed: %v", field, desc, err)
+					}
+					if total != int32(len(products)) {
+						t.Fatalf("FindAll(%s, desc=%v) total %d, want %d", field, desc, total, len(products))
+					}
+					got = append(got, page...)
+				}
+
+				// and again following keyset cursors
+				var keyset []*biz.Product
+				for q.Offset, q.After = 0, nil; ; {
+					page, total, err := repo.FindAll(ctx, q)
+					if err != nil {
+						t.Fatalf("FindAll(%s, desc=%v, after) failed: %v", field, desc, err)
+					}
+					if total != int32(len(products)) {
+						t.Fatalf("FindAll(%s, desc=%v, after) total %d, want %d", field, desc, total, len(products))
+					}
+					if len(page) == 0 {
+						break
+					}
+					keyset = append(keyset, page...)
+					q.After = biz.NewCursor(page[len(page)-1], q)
+				}
+
+				for name, got := range map[string][]*biz.Product{"offset": got, "keyset": keyset} {
+					if len(got) != len(want) {
+						t.Fatalf("FindAll(%s, desc=%v) %s returned %d products, want %d", field, desc, name, len(got), len(want))
+					}
+					for i := range want {
+						if got[i].ID != want[i].ID {
+							t.Errorf("FindAll(%s, desc=%v) %s [%d] = %s, want %s", field, desc, name, i, got[i].ID, want[i].ID)
+						}
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestProductRepos_PriceSortAcrossCurrencies(t *testing.T) {
+	prices := map[string]biz.Money{
+		"dinar":  {Amount: 1500, Currency: "BHD"},
+		"euro":   {Amount: 250, Currency: "EUR"},
+		"unidad": {Amount: 60000, Currency: "CLF"},
+		"dollar": {Amount: 1000, Currency: "USD"},
+		"yen":    {Amount: 500, Currency: "JPY"},
+	}
+
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		for id, price := range prices {
+			p := newTestProduct(id)
+			p.Price = price
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		// 1.500 BHD < 2.50 EUR < 6.0000 CLF < 10.00 USD < 500 JPY, in the
+		// major units the price filter compares
+		f, err := biz.ParseFilter("price > 5")
+		if err != nil {
+			t.Fatalf("ParseFilter failed: %v", err)
+		}
+		tests := []struct {
+			q    biz.ListQuery
+			want []string
+		}{
+			{biz.ListQuery{SortBy: biz.SortByPrice, Limit: 2}, []string{"dinar", "euro", "unidad", "dollar", "yen"}},
+			{biz.ListQuery{SortBy: biz.SortByPrice, Desc: true, Limit: 2}, []string{"yen", "dollar", "unidad", "euro", "dinar"}},
+			{biz.ListQuery{SortBy: biz.SortByPrice, Filter: f, Limit: 2}, []string{"unidad", "dollar", "yen"}},
+		}
+		for _, tt := range tests {
+			var got []string
+			for q := tt.q; ; {
+				page, _, err := repo.FindAll(ctx, q)
+				if err != nil {
+					t.Fatalf("FindAll failed: %v", err)
+				}
+				if len(page) == 0 {
+					break
+				}
+				for _, p := range page {
+					got = append(got, p.ID)
+				}
+				q.After = biz.NewCursor(page[len(page)-1], q)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FindAll(desc=%v, filter=%v) = %v, want %v", tt.q.Desc, tt.q.Filter != nil, got, tt.want)
+			}
+		}
+	})
+}
+
+func TestProductRepos_Versions(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		p := newTestProduct("v1")
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		stale := *p
+		p.Version = 2
+		if err := repo.Update(ctx, p); err != nil {
+			t.Fatalf("Update to version 2 failed: %v", err)
+		}
+		stale.Version = 2
+		stale.Name = "stale"
+		if err := repo.Update(ctx, &stale); !errors.Is(err, biz.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict for a stale update, got %v", err)
+		}
+		if got, _ := repo.FindByID(ctx, p.ID); got.Version != 2 || got.Name == "stale" {
+			t.Errorf("stale update was applied: %+v", got)
+		}
+
+		if err := repo.Delete(ctx, p.ID, 1); !errors.Is(err, biz.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict for a stale delete, got %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID, 2); err != nil {
+			t.Errorf("Delete with current version failed: %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID, 2); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+	})
+}
+
+// recordTestMovement advances p by delta and records the movement
+func recordTestMovement(t *testing.T, repo biz.StockLedger, p *biz.Product, delta int32) {
+	t.Helper()
+	if p.Version > 0 {
+		p.Version++
+	} else {
+		p.Version = 1
+	}
+	p.Quantity += delta
+	m := &biz.StockMovement{
+		ID:        fmt.Sprintf("%s-m%d", p.ID, p.Version),
+		ProductID: p.ID,
+		Kind:      biz.MovementAdjustment,
+		Delta:     delta,
+		Balance:   p.Quantity,
+		Reason:    "count",
+		Actor:     "tester",
+		Version:   p.Version,
+		CreatedAt: time.Now(),
+	}
+	if err := repo.RecordMovement(context.Background(), p, m); err != nil {
+		t.Fatalf("RecordMovement at version %d failed: %v", p.Version, err)
+	}
+}
+
+func TestProductRepos_Ledger(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		p := newTestProduct("l1")
+		p.Quantity, p.Version = 0, 0
+		for _, delta := range []int32{5, -2, 4, -1} {
+			recordTestMovement(t, repo, p, delta)
+		}
+		other := newTestProduct("l2")
+		other.Quantity, other.Version = 0, 0
+		recordTestMovement(t, repo, other, 9)
+
+		got, err := repo.FindByID(ctx, p.ID)
+		if err != nil || got.Quantity != 6 || got.Version != 4 {
+			t.Fatalf("product should be at quantity 6 and version 4, got %+v, %v", got, err)
+		}
+
+		movements, err := repo.FindMovements(ctx, p.ID, 1, 2)
+		if err != nil {
+			t.Fatalf("FindMovements failed: %v", err)
+		}
+		if len(movements) != 2 || movements[0].Version != 2 || movements[1].Version != 3 || movements[1].Balance != 7 {
+			t.Errorf("expected the movements at versions 2 and 3, got %+v", movements)
+		}
+
+		// a stale product must not be recorded, nor a product created twice
+		stale := *p
+		stale.Version = 3
+		if err := repo.RecordMovement(ctx, &stale, &biz.StockMovement{ID: "stale", ProductID: p.ID, Version: 3}); !errors.Is(err, biz.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict for a stale movement, got %v", err)
+		}
+		dup := newTestProduct("l2")
+		if err := repo.RecordMovement(ctx, dup, &biz.StockMovement{ID: "dup", ProductID: dup.ID, Version: 1}); !errors.Is(err, biz.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict for a second create, got %v", err)
+		}
+		if all, _ := repo.FindMovements(ctx, p.ID, 0, 100); len(all) != 4 {
+			t.Errorf("expected 4 movements, got %d", len(all))
+		}
+
+		// deleting a product drops its ledger only
+		if err := repo.Delete(ctx, p.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if all, _ := repo.FindMovements(ctx, p.ID, 0, 100); len(all) != 0 {
+			t.Errorf("ledger should be gone with the product, got %d movements", len(all))
+		}
+		if all, _ := repo.FindMovements(ctx, other.ID, 0, 100); len(all) != 1 {
+			t.Errorf("other ledgers should be kept, got %d movements", len(all))
+		}
+	})
+}
+
+// saveTestReservation bumps p and stores it together with res
+func saveTestReservation(t *testing.T, repo biz.ReservationRepo, p *biz.Product, res *biz.Reservation, m *biz.StockMovement) {
+	t.Helper()
+	p.Version++
+	res.UpdatedAt = time.Now()
+	if err := repo.SaveReservation(context.Background(), p, res, m); err != nil {
+		t.Fatalf("SaveReservation of %s at version %d failed: %v", res.ID, p.Version, err)
+	}
+}
+
+func TestProductRepos_Reservations(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		p := newTestProduct("r1")
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		other := newTestProduct("r2")
+		if err := repo.Save(ctx, other); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		now := time.Now()
+		hold := func(id string, product *biz.Product, expiresIn time.Duration) *biz.Reservation {
+			res := &biz.Reservation{ID: id, ProductID: product.ID, Quantity: 1, Status: biz.ReservationHeld,
+				Actor: "order-" + id, ExpiresAt: now.Add(expiresIn), CreatedAt: now}
+			product.Reserved++
+			saveTestReservation(t, repo, product, res, nil)
+			return res
+		}
+		late := hold("a", p, -time.Minute)
+		early := hold("b", p, -time.Hour)
+		hold("c", p, time.Hour)
+		closed := hold("d", other, -2*time.Hour)
+
+		got, err := repo.FindByID(ctx, p.ID)
+		if err != nil || got.Reserved != 3 || got.Version != 4 {
+			t.Fatalf("product should have 3 reserved at version 4, got %+v, %v", got, err)
+		}
+		res, err := repo.FindReservation(ctx, late.ID)
+		if err != nil || res.Actor != "order-a" || res.Status != biz.ReservationHeld || !res.ExpiresAt.Equal(late.ExpiresAt) {
+			t.Fatalf("unexpected reservation %+v, %v", res, err)
+		}
+		if _, err := repo.FindReservation(ctx, "missing"); !errors.Is(err, biz.ErrReservationNotFound) {
+			t.Errorf("expected ErrReservationNotFound, got %v", err)
+		}
+
+		// committing closes the reservation and records the dispatch
+		closed.Status = biz.ReservationCommitted
+		other.Reserved--
+		other.Quantity--
+		m := &biz.StockMovement{ID: "d-m", ProductID: other.ID, Kind: biz.MovementDispatch, Delta: -1,
+			Balance: other.Quantity, Actor: "order-d", Version: other.Version + 1, CreatedAt: now}
+		saveTestReservation(t, repo, other, closed, m)
+		if all, _ := repo.FindMovements(ctx, other.ID, 0, 100); len(all) != 1 || all[0].ID != "d-m" {
+			t.Errorf("the dispatch should be in the ledger, got %+v", all)
+		}
+
+		expired, err := repo.FindExpiredReservations(ctx, now, 10)
+		if err != nil {
+			t.Fatalf("FindExpiredReservations failed: %v", err)
+		}
+		if len(expired) != 2 || expired[0].ID != early.ID || expired[1].ID != late.ID {
+			t.Errorf("expected the held reservations b and a, got %+v", expired)
+		}
+		if expired, _ := repo.FindExpiredReservations(ctx, now, 1); len(expired) != 1 {
+			t.Errorf("expected the limit to apply, got %d", len(expired))
+		}
+
+		// a stale product must not be stored with a reservation
+		stale := *p
+		if err := repo.SaveReservation(ctx, &stale, late, nil); !errors.Is(err, biz.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict, got %v", err)
+		}
+
+		// deleting a product drops its reservations only
+		if err := repo.Delete(ctx, p.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if _, err := repo.FindReservation(ctx, early.ID); !errors.Is(err, biz.ErrReservationNotFound) {
+			t.Errorf("reservations should be gone with the product, got %v", err)
+		}
+		if expired, _ := repo.FindExpiredReservations(ctx, now, 10); len(expired) != 0 {
+			t.Errorf("expected no expired reservations left, got %+v", expired)
+		}
+		if _, err := repo.FindReservation(ctx, closed.ID); err != nil {
+			t.Errorf("other reservations should be kept, got %v", err)
+		}
+	})
+}
+
+func TestProductRepos_Warehouses(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Now()
+		for _, w := range []*biz.Warehouse{
+			{ID: "w2", Name: "South", CreatedAt: now, UpdatedAt: now},
+			{ID: "w1", Name: "North", Address: "1 Harbour St", CreatedAt: now, UpdatedAt: now},
+		} {
+			if err := repo.SaveWarehouse(ctx, w); err != nil {
+				t.Fatalf("SaveWarehouse failed: %v", err)
+			}
+		}
+		renamed := &biz.Warehouse{ID: "w2", Name: "East", CreatedAt: now, UpdatedAt: now.Add(time.Minute)}
+		if err := repo.SaveWarehouse(ctx, renamed); err != nil {
+			t.Fatalf("SaveWarehouse failed: %v", err)
+		}
+
+		all, err := repo.FindWarehouses(ctx)
+		if err != nil || len(all) != 2 || all[0].Name != "East" || all[1].Address != "1 Harbour St" {
+			t.Fatalf("expected East and North, got %+v, %v", all, err)
+		}
+		if _, err := repo.FindWarehouse(ctx, "missing"); !errors.Is(err, biz.ErrWarehouseNotFound) {
+			t.Errorf("expected ErrWarehouseNotFound, got %v", err)
+		}
+		if err := repo.DeleteWarehouse(ctx, "w2"); err != nil {
+			t.Fatalf("DeleteWarehouse failed: %v", err)
+		}
+		if err := repo.DeleteWarehouse(ctx, "w2"); !errors.Is(err, biz.ErrWarehouseNotFound) {
+			t.Errorf("deleting twice should fail with ErrWarehouseNotFound, got %v", err)
+		}
+	})
+}
+
+func TestProductRepos_StockByWarehouse(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		stocked := newTestProduct("s1")
+		stocked.Quantity, stocked.Reserved = 7, 1
+		stocked.Stock = map[string]biz.StockLevel{"w1": {Quantity: 4, Reserved: 1}, "w2": {Quantity: 3}}
+		if err := repo.Save(ctx, stocked); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		elsewhere := newTestProduct("s2")
+		elsewhere.Stock = map[string]biz.StockLevel{"w2": {Quantity: 5}}
+		if err := repo.Save(ctx, elsewhere); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, stocked.ID)
+		if err != nil || len(got.Stock) != 2 || got.Stock["w1"] != stocked.Stock["w1"] || got.Stock["w2"] != stocked.Stock["w2"] {
+			t.Fatalf("expected the stock levels to round trip, got %+v, %v", got, err)
+		}
+
+		products, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Warehouse: "w1"})
+		if err != nil || total != 1 || len(products) != 1 || products[0].ID != stocked.ID {
+			t.Errorf("only s1 is stocked in w1, got %d products, total %d, %v", len(products), total, err)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Warehouse: "w2"}); total != 2 {
+			t.Errorf("both products are stocked in w2, got %d", total)
+		}
+		filter, _ := biz.ParseFilter("quantity > 6")
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Warehouse: "w2", Filter: filter}); total != 1 {
+			t.Errorf("the warehouse should combine with a filter, got %d", total)
+		}
+
+		// a transfer moves the stock between warehouses
+		got.Version++
+		got.Stock = map[string]biz.StockLevel{"w1": {Quantity: 1, Reserved: 1}, "w3": {Quantity: 6}}
+		m := &biz.StockMovement{ID: "t1", ProductID: got.ID, WarehouseID: "w2", ToWarehouseID: "w3", Kind: biz.MovementTransfer,
+			Delta: -3, Balance: 7, Actor: "tester", Version: got.Version, CreatedAt: time.Now()}
+		if err := repo.RecordMovement(ctx, got, m); err != nil {
+			t.Fatalf("RecordMovement failed: %v", err)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Warehouse: "w2"}); total != 1 {
+			t.Errorf("s1 left w2, got %d products there", total)
+		}
+		movements, _ := repo.FindMovements(ctx, got.ID, 0, 10)
+		if len(movements) != 1 || movements[0].WarehouseID != "w2" || movements[0].ToWarehouseID != "w3" {
+			t.Errorf("expected the transfer between w2 and w3, got %+v", movements)
+		}
+	})
+}
+
+func TestProductRepos_Units(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		cases := newTestProduct("u1")
+		cases.Quantity = 30
+		cases.Units = biz.UnitsOfMeasure{Base: "each", Conversions: []biz.UnitConversion{{Code: "inner", Factor: 6}, {Code: "case", Factor: 12}}}
+		if err := repo.Save(ctx, cases); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		legacy := newTestProduct("u2")
+		legacy.Quantity = 30
+		if err := repo.Save(ctx, legacy); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, cases.ID)
+		if err != nil || got.Units.BaseUnit() != "each" || !slices.Equal(got.Units.Conversions, cases.Units.Conversions) {
+			t.Fatalf("expected the units to round trip, got %+v, %v", got, err)
+		}
+		if got, _ := repo.FindByID(ctx, legacy.ID); got.Units.BaseUnit() != biz.DefaultBaseUnit || len(got.Units.Conversions) != 0 {
+			t.Errorf("a product without units should be counted in pieces, got %+v", got.Units)
+		}
+
+		// 30 each are 2.5 cases, only the product with cases can match
+		for filter, want := range map[string]int32{"quantity >= 2case": 1, "quantity > 3case": 0, "quantity >= 30each": 2} {
+			f, _ := biz.ParseFilter(filter)
+			if _, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Filter: f}); err != nil || total != want {
+				t.Errorf("%s: expected %d products, got %d, %v", filter, want, total, err)
+			}
+		}
+
+		got.Version++
+		got.Units = biz.UnitsOfMeasure{Base: "g", Conversions: []biz.UnitConversion{{Code: "kg", Factor: 1000, Fractional: true}}}
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if got, _ := repo.FindByID(ctx, cases.ID); got.Units.BaseUnit() != "g" || len(got.Units.Conversions) != 1 || !got.Units.Conversions[0].Fractional {
+			t.Errorf("expected the units to be replaced, got %+v", got.Units)
+		}
+	})
+}
+
+func TestProductRepos_Batches(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Unix(1_800_000_000, 0)
+		p := newTestProduct("b1")
+		p.Quantity, p.Reserved = 10, 2
+		p.Stock = map[string]biz.StockLevel{"main": {Quantity: 8, Reserved: 2}, "north": {Quantity: 2}}
+		p.Batches = []biz.Batch{
+			{WarehouseID: "main", LotCode: "A", ExpiresAt: now.AddDate(0, 0, 2), ReceivedAt: now, Quantity: 3, Reserved: 2},
+			{WarehouseID: "main", LotCode: "B", ReceivedAt: now, Quantity: 4},
+			{WarehouseID: "north", LotCode: "A", ExpiresAt: now.AddDate(0, 0, 2), ReceivedAt: now, Quantity: 2},
+		}
+		m := &biz.StockMovement{ID: "m1", ProductID: p.ID, WarehouseID: "main", Kind: biz.MovementReceipt, Delta: 10, Balance: 10,
+			Lots: []biz.LotQuantity{{LotCode: "B", Quantity: 4}, {LotCode: "A", Quantity: 5}}, Version: 1, CreatedAt: now}
+		if err := repo.RecordMovement(ctx, p, m); err != nil {
+			t.Fatalf("RecordMovement failed: %v", err)
+		}
+		other := newTestProduct("b2")
+		other.Batches = []biz.Batch{{WarehouseID: "main", LotCode: "C", ExpiresAt: now.AddDate(0, 0, 1), ReceivedAt: now, Quantity: 5}}
+		if err := repo.Save(ctx, other); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, p.ID)
+		if err != nil || len(got.Batches) != 3 {
+			t.Fatalf("expected 3 batches, got %+v, %v", got, err)
+		}
+		for i, b := range got.Batches {
+			if want := p.Batches[i]; b.LotCode != want.LotCode || b.WarehouseID != want.WarehouseID || !b.ExpiresAt.Equal(want.ExpiresAt) ||
+				!b.ReceivedAt.Equal(want.ReceivedAt) || b.Quantity != want.Quantity || b.Reserved != want.Reserved {
+				t.Errorf("batch %d: expected %+v, got %+v", i, want, b)
+			}
+		}
+		if ms, _ := repo.FindMovements(ctx, p.ID, 0, 10); len(ms) != 1 || !slices.Equal(ms[0].Lots, m.Lots) {
+			t.Errorf("expected the movement lots in order, got %+v", ms)
+		}
+
+		p.Version++
+		res := &biz.Reservation{ID: "r1", ProductID: p.ID, WarehouseID: "main", Quantity: 2, Status: biz.ReservationHeld,
+			Lots: []biz.LotQuantity{{LotCode: "A", Quantity: 2}}, ExpiresAt: now, CreatedAt: now}
+		if err := repo.SaveReservation(ctx, p, res, nil); err != nil {
+			t.Fatalf("SaveReservation failed: %v", err)
+		}
+		if got, err := repo.FindReservation(ctx, res.ID); err != nil || !slices.Equal(got.Lots, res.Lots) {
+			t.Errorf("expected the reservation lots, got %+v, %v", got, err)
+		}
+		if expired, _ := repo.FindExpiredReservations(ctx, now, 10); len(expired) != 1 || !slices.Equal(expired[0].Lots, res.Lots) {
+			t.Errorf("expected the expired reservation with its lots, got %+v", expired)
+		}
+
+		expiring, err := repo.FindExpiringBatches(ctx, now.AddDate(0, 0, 2).Add(time.Second), "")
+		if err != nil || len(expiring) != 3 {
+			t.Fatalf("expected the three dated batches, got %+v, %v", expiring, err)
+		}
+		if expiring, _ := repo.FindExpiringBatches(ctx, now.AddDate(0, 0, 2), "main"); len(expiring) != 1 || expiring[0].LotCode != "C" || expiring[0].ProductName != other.Name {
+			t.Errorf("only lot C expires before day 2, got %+v", expiring)
+		}
+		if expiring, _ := repo.FindExpiringBatches(ctx, now.AddDate(1, 0, 0), "north"); len(expiring) != 1 || expiring[0].ProductID != p.ID {
+			t.Errorf("expected lot A in the north, got %+v", expiring)
+		}
+
+		// the batches go with the product
+		if err := repo.Delete(ctx, other.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if expiring, _ := repo.FindExpiringBatches(ctx, now.AddDate(1, 0, 0), ""); len(expiring) != 2 {
+			t.Errorf("expected the batches of the deleted product gone, got %+v", expiring)
+		}
+	})
+}
+
+func TestProductRepos_Categories(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Now()
+		for _, c := range []*biz.Category{
+			{ID: "c1", Name: "Chilled", CreatedAt: now, UpdatedAt: now},
+			{ID: "c2", Name: "Dairy", ParentID: "c1", CreatedAt: now, UpdatedAt: now},
+			{ID: "c3", Name: "Cheese", ParentID: "c2", CreatedAt: now, UpdatedAt: now},
+		} {
+			if err := repo.SaveCategory(ctx, c); err != nil {
+				t.Fatalf("SaveCategory failed: %v", err)
+			}
+		}
+		moved := &biz.Category{ID: "c3", Name: "Cheese", CreatedAt: now, UpdatedAt: now.Add(time.Minute)}
+		if err := repo.SaveCategory(ctx, moved); err != nil {
+			t.Fatalf("SaveCategory failed: %v", err)
+		}
+
+		all, err := repo.FindCategories(ctx)
+		if err != nil || len(all) != 3 || all[0].ID != "c3" || all[0].ParentID != "" || all[2].ParentID != "c1" {
+			t.Fatalf("expected Cheese, Chilled and Dairy, got %+v, %v", all, err)
+		}
+		if _, err := repo.FindCategory(ctx, "missing"); !errors.Is(err, biz.ErrCategoryNotFound) {
+			t.Errorf("expected ErrCategoryNotFound, got %v", err)
+		}
+
+		for id, category := range map[string]string{"p1": "c2", "p2": "c3", "p3": ""} {
+			p := newTestProduct(id)
+			p.CategoryID = category
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+		got, err := repo.FindByID(ctx, "p1")
+		if err != nil || got.CategoryID != "c2" {
+			t.Fatalf("expected the category to round trip, got %+v, %v", got, err)
+		}
+
+		products, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Categories: []string{"c2", "c3"}})
+		if err != nil || total != 2 || len(products) != 2 {
+			t.Errorf("expected p1 and p2, got %d products, total %d, %v", len(products), total, err)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Categories: []string{"c1"}}); total != 0 {
+			t.Errorf("nothing is filed directly under c1, got %d", total)
+		}
+
+		got.Version++
+		got.CategoryID = ""
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Categories: []string{"c2"}}); total != 0 {
+			t.Errorf("p1 was uncategorized, got %d products in c2", total)
+		}
+	})
+}
+
+func TestProductRepos_Variants(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Now()
+		cheese := &biz.Category{ID: "c1", Name: "Cheese", CreatedAt: now, UpdatedAt: now, Attributes: []biz.AttributeDef{
+			{Name: "size_g", Type: biz.AttributeNumber, Required: true},
+			{Name: "flavour", Type: biz.AttributeEnum, Values: []string{"mild", "mature", "smoked"}},
+			{Name: "vegan", Type: biz.AttributeBoolean},
+		}}
+		if err := repo.SaveCategory(ctx, cheese); err != nil {
+			t.Fatalf("SaveCategory failed: %v", err)
+		}
+		got, err := repo.FindCategory(ctx, "c1")
+		if err != nil || !reflect.DeepEqual(got.Attributes, cheese.Attributes) {
+			t.Fatalf("expected the attribute definitions to round trip, got %+v, %v", got, err)
+		}
+		cheese.Attributes = cheese.Attributes[:1]
+		if err := repo.SaveCategory(ctx, cheese); err != nil {
+			t.Fatalf("SaveCategory failed: %v", err)
+		}
+		if all, _ := repo.FindCategories(ctx); len(all) != 1 || len(all[0].Attributes) != 1 {
+			t.Errorf("expected the definitions to be replaced, got %+v", all)
+		}
+
+		parent := newTestProduct("cheddar")
+		parent.CategoryID = "c1"
+		if err := repo.Save(ctx, parent); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		for id, size := range map[string]string{"cheddar-200g": "200", "cheddar-1kg": "1000", "cheddar-5kg": "5000"} {
+			p := newTestProduct(id)
+			p.CategoryID, p.ParentID, p.Attributes = "c1", parent.ID, biz.Attributes{"size_g": size}
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+
+		variant, err := repo.FindByID(ctx, "cheddar-1kg")
+		if err != nil || variant.ParentID != parent.ID || variant.Attributes["size_g"] != "1000" {
+			t.Fatalf("expected the parent and attributes to round trip, got %+v, %v", variant, err)
+		}
+		for filter, want := range map[string]int32{`parent_id = "cheddar"`: 3, `attributes.size_g >= 1000`: 2, `parent_id = "cheddar" AND attributes.size_g < 1000`: 1} {
+			f, _ := biz.ParseFilter(filter)
+			if _, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Filter: f}); err != nil || total != want {
+				t.Errorf("%s: expected %d products, got %d, %v", filter, want, total, err)
+			}
+		}
+
+		variant.Version++
+		variant.Attributes = biz.Attributes{"size_g": "1200"}
+		if err := repo.Update(ctx, variant); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if got, _ := repo.FindByID(ctx, variant.ID); len(got.Attributes) != 1 || got.Attributes["size_g"] != "1200" {
+			t.Errorf("expected the attributes to be replaced, got %+v", got.Attributes)
+		}
+		if err := repo.Delete(ctx, variant.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		f, _ := biz.ParseFilter(`attributes.size_g = 1200`)
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Filter: f}); total != 0 {
+			t.Errorf("the deleted variant should be gone, got %d", total)
+		}
+	})
+}
+
+func TestProductRepos_Labelling(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		p := newTestProduct("l1")
+		p.Allergens = biz.Allergens{biz.AllergenMilk: biz.AllergenContains, biz.AllergenPeanut: biz.AllergenFreeFrom}
+		p.Nutrition = biz.Nutrition{EnergyKJ: 1700, EnergyKcal: 406, Fat: 33, SaturatedFat: 21, Carbohydrate: 0.1, Protein: 25, Salt: 1.8}
+		p.DietaryTags = []biz.DietaryTag{biz.DietaryHalal, biz.DietaryVegetarian}
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := repo.Save(ctx, newTestProduct("l2")); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, p.ID)
+		if err != nil || !maps.Equal(got.Allergens, p.Allergens) || got.Nutrition != p.Nutrition || !slices.Equal(got.DietaryTags, p.DietaryTags) {
+			t.Fatalf("expected the labelling to round trip, got %+v, %v", got, err)
+		}
+		if got, _ := repo.FindByID(ctx, "l2"); got.Allergens != nil || got.Nutrition.Declared() || got.DietaryTags != nil {
+			t.Errorf("a product without labelling should declare nothing, got %+v", got)
+		}
+		for filter, want := range map[string]int32{"allergen = milk": 1, "NOT allergen = milk": 1, "free_from = peanut AND dietary = halal": 1, "dietary = vegan": 0} {
+			f, _ := biz.ParseFilter(filter)
+			if _, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Filter: f}); err != nil || total != want {
+				t.Errorf("%s: expected %d products, got %d, %v", filter, want, total, err)
+			}
+		}
+
+		got.Version++
+		got.Allergens, got.Nutrition, got.DietaryTags = nil, biz.Nutrition{}, []biz.DietaryTag{biz.DietaryKosher}
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if got, _ := repo.FindByID(ctx, p.ID); got.Allergens != nil || got.Nutrition.Declared() || len(got.DietaryTags) != 1 {
+			t.Errorf("expected the labelling to be replaced, got %+v", got)
+		}
+	})
+}
+
+func TestProductRepos_Barcodes(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		p := newTestProduct("b1")
+		p.Barcodes = []biz.Barcode{{GTIN: "05449000000996"}, {GTIN: "15449000000993", Unit: "case"}}
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		got, err := repo.FindByBarcode(ctx, "15449000000993")
+		if err != nil || got.ID != p.ID || !slices.Equal(got.Barcodes, p.Barcodes) {
+			t.Fatalf("expected b1 with its barcodes, got %+v, %v", got, err)
+		}
+		if _, err := repo.FindByBarcode(ctx, "00000096385074"); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+
+		// a barcode stays on one product, however the product is written
+		other := newTestProduct("b2")
+		other.Barcodes = []biz.Barcode{{GTIN: "05449000000996"}}
+		if err := repo.Save(ctx, other); !errors.Is(err, biz.ErrBarcodeInUse) {
+			t.Errorf("Save: expected ErrBarcodeInUse, got %v", err)
+		}
+		m := &biz.StockMovement{ID: "m1", ProductID: other.ID, WarehouseID: biz.DefaultWarehouseID, Version: 1, Kind: biz.MovementReceipt, Delta: 1, Balance: 1}
+		if err := repo.RecordMovement(ctx, other, m); !errors.Is(err, biz.ErrBarcodeInUse) {
+			t.Errorf("RecordMovement: expected ErrBarcodeInUse, got %v", err)
+		}
+
+		got.Version++
+		got.Barcodes = got.Barcodes[1:]
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if err := repo.Save(ctx, other); err != nil {
+			t.Fatalf("a released barcode should be free, got %v", err)
+		}
+		if got, err := repo.FindByBarcode(ctx, "05449000000996"); err != nil || got.ID != other.ID {
+			t.Errorf("expected b2, got %+v, %v", got, err)
+		}
+		if err := repo.Delete(ctx, p.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if _, err := repo.FindByBarcode(ctx, "15449000000993"); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("the barcodes of a deleted product should go, got %v", err)
+		}
+	})
+}
+
+func TestProductRepos_SKUs(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		p := newTestProduct("s1")
+		p.SKU = "CHE-00001-X"
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		if err := repo.Save(ctx, newTestProduct("s2")); err != nil {
+			t.Fatalf("a product without a SKU should save, got %v", err)
+		}
+		got, err := repo.FindBySKU(ctx, "CHE-00001-X")
+		if err != nil || got.ID != p.ID || got.SKU != p.SKU {
+			t.Fatalf("expected s1 with its SKU, got %+v, %v", got, err)
+		}
+		if _, err := repo.FindBySKU(ctx, ""); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("an empty SKU should find nothing, got %v", err)
+		}
+
+		other := newTestProduct("s3")
+		other.SKU = p.SKU
+		if err := repo.Save(ctx, other); !errors.Is(err, biz.ErrSKUInUse) {
+			t.Errorf("Save: expected ErrSKUInUse, got %v", err)
+		}
+		m := &biz.StockMovement{ID: "m1", ProductID: other.ID, WarehouseID: biz.DefaultWarehouseID, Version: 1, Kind: biz.MovementReceipt, Delta: 1, Balance: 1}
+		if err := repo.RecordMovement(ctx, other, m); !errors.Is(err, biz.ErrSKUInUse) {
+			t.Errorf("RecordMovement: expected ErrSKUInUse, got %v", err)
+		}
+
+		// counters are kept per prefix
+		for _, want := range []struct {
+			prefix string
+			n      int64
+		}{{"CHE", 1}, {"CHE", 2}, {"SKU", 1}, {"CHE", 3}} {
+			if n, err := repo.NextSKUNumber(ctx, want.prefix); err != nil || n != want.n {
+				t.Errorf("NextSKUNumber(%s): expected %d, got %d, %v", want.prefix, want.n, n, err)
+			}
+		}
+
+		if err := repo.Delete(ctx, p.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if _, err := repo.FindBySKU(ctx, p.SKU); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("the SKU of a deleted product should go, got %v", err)
+		}
+		if err := repo.Save(ctx, other); err != nil {
+			t.Errorf("a released SKU should be free, got %v", err)
+		}
+	})
+}
+
+func TestProductRepos_Trash(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Unix(1_800_000_000, 0)
+		for _, id := range []string{"t1", "t2", "t3"} {
+			p := newTestProduct(id)
+			p.Batches = []biz.Batch{{WarehouseID: "main", LotCode: "A", ExpiresAt: now, ReceivedAt: now, Quantity: 5}}
+			if err := repo.Save(ctx, p); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+		}
+		p, _ := repo.FindByID(ctx, "t2")
+		res := &biz.Reservation{ID: "r1", ProductID: p.ID, Quantity: 1, Status: biz.ReservationHeld, ExpiresAt: now, CreatedAt: now}
+		p.Reserved = 1
+		saveTestReservation(t, repo, p, res, nil)
+		if held, err := repo.FindHeldReservations(ctx, p.ID); err != nil || len(held) != 1 || held[0].ID != res.ID {
+			t.Fatalf("expected the held reservation r1, got %+v, %v", held, err)
+		}
+		if held, _ := repo.FindHeldReservations(ctx, "t1"); len(held) != 0 {
+			t.Errorf("t1 holds no reservations, got %+v", held)
+		}
+
+		// deleting to the trash releases the reservations in the same write
+		res.Status = biz.ReservationReleased
+		p.Reserved, p.Version, p.DeletedAt = 0, p.Version+1, now
+		stale := *p
+		stale.Version--
+		if err := repo.SaveReservations(ctx, &stale, []*biz.Reservation{res}); !errors.Is(err, biz.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict, got %v", err)
+		}
+		if held, _ := repo.FindHeldReservations(ctx, p.ID); len(held) != 1 {
+			t.Errorf("a failed write should keep r1 held, got %+v", held)
+		}
+		if err := repo.SaveReservations(ctx, p, []*biz.Reservation{res}); err != nil {
+			t.Fatalf("SaveReservations failed: %v", err)
+		}
+		if held, _ := repo.FindHeldReservations(ctx, p.ID); len(held) != 0 {
+			t.Errorf("expected r1 released, got %+v", held)
+		}
+		if got, err := repo.FindReservation(ctx, res.ID); err != nil || got.Status != biz.ReservationReleased {
+			t.Errorf("expected r1 released, got %+v, %v", got, err)
+		}
+		if got, err := repo.FindByID(ctx, p.ID); err != nil || !got.Deleted() || !got.DeletedAt.Equal(now) {
+			t.Fatalf("expected t2 in the trash, got %+v, %v", got, err)
+		}
+		live, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, SortBy: biz.SortByName})
+		if err != nil || total != 2 || len(live) != 2 || live[0].ID != "t1" || live[1].ID != "t3" {
+			t.Errorf("expected t1 and t3 listed, got %d %+v, %v", total, live, err)
+		}
+		if live, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, NameFilter: "product t"}); total != 2 || len(live) != 2 {
+			t.Errorf("expected the name filter to count the live products only, got %d %+v", total, live)
+		}
+		trash, total, err := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Deleted: true})
+		if err != nil || total != 1 || len(trash) != 1 || trash[0].ID != p.ID {
+			t.Errorf("expected t2 in the trash listing, got %d %+v, %v", total, trash, err)
+		}
+		if expiring, _ := repo.FindExpiringBatches(ctx, now.Add(time.Hour), ""); len(expiring) != 2 {
+			t.Errorf("expected the batches of the live products only, got %+v", expiring)
+		}
+		if err := repo.SaveSupplier(ctx, &biz.Supplier{ID: "s1", Name: "Acme", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("SaveSupplier failed: %v", err)
+		}
+		l := &biz.ProductSupplier{ProductID: p.ID, SupplierID: "s1", CreatedAt: now, UpdatedAt: now}
+		if err := repo.SaveProductSupplier(ctx, l); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("linking a supplier to a deleted product should fail, got %v", err)
+		}
+		if _, err := repo.FindProductSuppliers(ctx, p.ID); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound for a deleted product, got %v", err)
+		}
+
+		// restoring brings it back
+		p.Version, p.DeletedAt = p.Version+1, time.Time{}
+		if err := repo.Update(ctx, p); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10}); total != 3 {
+			t.Errorf("expected 3 live products after the restore, got %d", total)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Deleted: true}); total != 0 {
+			t.Errorf("expected an empty trash, got %d", total)
+		}
+		if expiring, _ := repo.FindExpiringBatches(ctx, now.Add(time.Hour), ""); len(expiring) != 3 {
+			t.Errorf("expected the batches of t2 back, got %+v", expiring)
+		}
+
+		// purging a deleted product removes it for good
+		p.Version, p.DeletedAt = p.Version+1, now
+		if err := repo.Update(ctx, p); err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+		if err := repo.Delete(ctx, p.ID, p.Version); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if _, err := repo.FindByID(ctx, p.ID); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10, Deleted: true}); total != 0 {
+			t.Errorf("expected an empty trash, got %d", total)
+		}
+		if _, total, _ := repo.FindAll(ctx, biz.ListQuery{Limit: 10}); total != 2 {
+			t.Errorf("expected 2 live products, got %d", total)
+		}
+	})
+}
+
+func TestProductRepos_Suppliers(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Now()
+		for _, s := range []*biz.Supplier{
+			{ID: "s2", Name: "Best Foods", CreatedAt: now, UpdatedAt: now},
+			{ID: "s1", Name: "Acme", Email: "orders@acme.test", LeadTimeDays: 3, CreatedAt: now, UpdatedAt: now},
+		} {
+			if err := repo.SaveSupplier(ctx, s); err != nil {
+				t.Fatalf("SaveSupplier failed: %v", err)
+			}
+		}
+		all, err := repo.FindSuppliers(ctx)
+		if err != nil || len(all) != 2 || all[0].ID != "s1" || all[0].LeadTimeDays != 3 || all[0].Email != "orders@acme.test" {
+			t.Fatalf("expected Acme and Best Foods, got %+v, %v", all, err)
+		}
+		if _, err := repo.FindSupplier(ctx, "missing"); !errors.Is(err, biz.ErrSupplierNotFound) {
+			t.Errorf("expected ErrSupplierNotFound, got %v", err)
+		}
+
+		p := newTestProduct("p1")
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		link := func(supplierID string, preferred bool) {
+			t.Helper()
+			l := &biz.ProductSupplier{ProductID: p.ID, SupplierID: supplierID, SupplierSKU: "sku-" + supplierID, CostPrice: biz.Money{Amount: 150, Currency: "EUR"},
+				Preferred: preferred, CreatedAt: now, UpdatedAt: now}
+			if err := repo.SaveProductSupplier(ctx, l); err != nil {
+				t.Fatalf("SaveProductSupplier(%s) failed: %v", supplierID, err)
+			}
+		}
+		link("s2", true)
+		link("s1", false)
+		links, err := repo.FindProductSuppliers(ctx, p.ID)
+		if err != nil || len(links) != 2 || links[0].SupplierID != "s2" || !links[0].Preferred || links[1].SupplierSKU != "sku-s1" || links[1].CostPrice != (biz.Money{Amount: 150, Currency: "EUR"}) {
+			t.Fatalf("expected s2 preferred and s1, got %+v, %v", links, err)
+		}
+		link("s1", true)
+		links, _ = repo.FindProductSuppliers(ctx, p.ID)
+		if len(links) != 2 || links[0].SupplierID != "s1" || !links[0].Preferred || links[1].Preferred {
+			t.Errorf("s1 should be the only preferred supplier, got %+v", links)
+		}
+
+		missing := &biz.ProductSupplier{ProductID: p.ID, SupplierID: "missing", CreatedAt: now, UpdatedAt: now}
+		if err := repo.SaveProductSupplier(ctx, missing); !errors.Is(err, biz.ErrSupplierNotFound) {
+			t.Errorf("linking an unknown supplier should fail, got %v", err)
+		}
+		missing = &biz.ProductSupplier{ProductID: "missing", SupplierID: "s1", CreatedAt: now, UpdatedAt: now}
+		if err := repo.SaveProductSupplier(ctx, missing); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("linking an unknown product should fail, got %v", err)
+		}
+
+		if err := repo.DeleteSupplier(ctx, "s2"); !errors.Is(err, biz.ErrSupplierInUse) {
+			t.Errorf("deleting a linked supplier should fail, got %v", err)
+		}
+		if err := repo.DeleteProductSupplier(ctx, p.ID, "s2"); err != nil {
+			t.Fatalf("DeleteProductSupplier failed: %v", err)
+		}
+		if err := repo.DeleteProductSupplier(ctx, p.ID, "s2"); !errors.Is(err, biz.ErrProductSupplierNotFound) {
+			t.Errorf("expected ErrProductSupplierNotFound, got %v", err)
+		}
+		if err := repo.DeleteSupplier(ctx, "s2"); err != nil {
+			t.Errorf("DeleteSupplier failed: %v", err)
+		}
+
+		// deleting the product drops its links, freeing the supplier
+		if err := repo.Delete(ctx, p.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if err := repo.DeleteSupplier(ctx, "s1"); err != nil {
+			t.Errorf("the supplier of a deleted product should be deletable, got %v", err)
+		}
+		if _, err := repo.FindProductSuppliers(ctx, p.ID); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+	})
+}
+
+func TestProductRepos_PriceLists(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Now()
+		for _, l := range []*biz.PriceList{
+			{ID: "l2", Name: "Wholesale", CustomerIDs: []string{"c2", "c3"}, CreatedAt: now, UpdatedAt: now},
+			{ID: "l1", Name: "Restaurants", CustomerIDs: []string{"c1"}, CreatedAt: now, UpdatedAt: now},
+		} {
+			if err := repo.SavePriceList(ctx, l); err != nil {
+				t.Fatalf("SavePriceList failed: %v", err)
+			}
+		}
+		all, err := repo.FindPriceLists(ctx)
+		if err != nil || len(all) != 2 || all[0].ID != "l1" || !slices.Equal(all[1].CustomerIDs, []string{"c2", "c3"}) {
+			t.Fatalf("expected Restaurants and Wholesale, got %+v, %v", all, err)
+		}
+		l2 := &biz.PriceList{ID: "l2", Name: "Wholesale", CustomerIDs: []string{"c3"}, CreatedAt: now, UpdatedAt: now}
+		if err := repo.SavePriceList(ctx, l2); err != nil {
+			t.Fatalf("SavePriceList failed: %v", err)
+		}
+		got, err := repo.FindPriceList(ctx, "l2")
+		if err != nil || !slices.Equal(got.CustomerIDs, []string{"c3"}) {
+			t.Errorf("saving should replace the customers, got %+v, %v", got, err)
+		}
+		if _, err := repo.FindPriceList(ctx, "missing"); !errors.Is(err, biz.ErrPriceListNotFound) {
+			t.Errorf("expected ErrPriceListNotFound, got %v", err)
+		}
+
+		p := newTestProduct("p1")
+		if err := repo.Save(ctx, p); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		tiers := []*biz.PriceTier{
+			{PriceListID: "l1", ProductID: p.ID, MinQuantity: 1, Price: biz.Money{Amount: 900, Currency: "EUR"}},
+			{PriceListID: "l1", ProductID: p.ID, MinQuantity: 10, Price: biz.Money{Amount: 800, Currency: "EUR"}},
+		}
+		if err := repo.SavePriceTiers(ctx, "l1", p.ID, tiers); err != nil {
+			t.Fatalf("SavePriceTiers failed: %v", err)
+		}
+		wholesale := []*biz.PriceTier{{PriceListID: "l2", ProductID: p.ID, MinQuantity: 10, Price: biz.Money{Amount: 700, Currency: "EUR"}}}
+		if err := repo.SavePriceTiers(ctx, "l2", p.ID, wholesale); err != nil {
+			t.Fatalf("SavePriceTiers failed: %v", err)
+		}
+		found, err := repo.FindPriceTiers(ctx, "l1", p.ID)
+		if err != nil || len(found) != 2 || found[0].MinQuantity != 1 || found[1].Price != (biz.Money{Amount: 800, Currency: "EUR"}) {
+			t.Fatalf("expected the 1 and 10 tiers, got %+v, %v", found, err)
+		}
+
+		if err := repo.SavePriceTiers(ctx, "l1", p.ID, tiers[:1]); err != nil {
+			t.Fatalf("SavePriceTiers failed: %v", err)
+		}
+		if found, _ := repo.FindPriceTiers(ctx, "l1", p.ID); len(found) != 1 || found[0].MinQuantity != 1 {
+			t.Errorf("saving should replace the tiers, got %+v", found)
+		}
+		if found, _ := repo.FindPriceTiers(ctx, "l2", p.ID); len(found) != 1 || found[0].MinQuantity != 10 {
+			t.Errorf("the tiers of another list should be kept, got %+v", found)
+		}
+		if err := repo.SavePriceTiers(ctx, "l1", p.ID, nil); err != nil {
+			t.Fatalf("SavePriceTiers failed: %v", err)
+		}
+		if found, err := repo.FindPriceTiers(ctx, "l1", p.ID); err != nil || len(found) != 0 {
+			t.Errorf("saving no tiers should remove them, got %+v, %v", found, err)
+		}
+
+		if err := repo.SavePriceTiers(ctx, "missing", p.ID, tiers); !errors.Is(err, biz.ErrPriceListNotFound) {
+			t.Errorf("expected ErrPriceListNotFound, got %v", err)
+		}
+		if err := repo.SavePriceTiers(ctx, "l1", "missing", tiers); !errors.Is(err, biz.ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+
+		// deleting the product drops its tiers on every list
+		if err := repo.Delete(ctx, p.ID, 0); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if found, err := repo.FindPriceTiers(ctx, "l2", p.ID); err != nil || len(found) != 0 {
+			t.Errorf("the tiers of a deleted product should be gone, got %+v, %v", found, err)
+		}
+	})
+}
+
+func TestProductRepos_Auctions(t *testing.T) {
+	forEachRepo(t, func(t *testing.T, repo biz.ProductRepo) {
+		ctx := context.Background()
+		now := time.Now()
+		auction := func(id string, closesIn time.Duration) *biz.Auction {
+			return &biz.Auction{ID: id, ProductID: "p1", WarehouseID: biz.DefaultWarehouseID, Quantity: 4, ReservePrice: biz.Money{Amount: 6000, Currency: "USD"},
+				OpensAt: now.Add(-time.Hour), ClosesAt: now.Add(closesIn), Status: biz.AuctionOpen, ReservationID: "r-" + id, CreatedAt: now, UpdatedAt: now}
+		}
+		for _, a := range []*biz.Auction{auction("a3", time.Hour), auction("a2", -time.Minute), auction("a1", -time.Hour)} {
+			if err := repo.SaveAuction(ctx, a); err != nil {
+				t.Fatalf("SaveAuction failed: %v", err)
+			}
+		}
+		all, err := repo.FindAuctions(ctx)
+		if err != nil || len(all) != 3 || all[0].ID != "a1" || all[2].ID != "a3" || all[2].ReservePrice != (biz.Money{Amount: 6000, Currency: "USD"}) {
+			t.Fatalf("expected a1, a2, a3 by closing time, got %+v, %v", all, err)
+		}
+		due, err := repo.FindDueAuctions(ctx, now, 10)
+		if err != nil || len(due) != 2 || due[0].ID != "a1" || due[1].ID != "a2" {
+			t.Errorf("expected a1 and a2 due, got %+v, %v", due, err)
+		}
+		if due, _ := repo.FindDueAuctions(ctx, now, 1); len(due) != 1 || due[0].ID != "a1" {
+			t.Errorf("expected the limit to apply, got %+v", due)
+		}
+
+		// a closed auction is no longer due
+		a1 := auction("a1", -time.Hour)
+		a1.Status, a1.WinnerID, a1.WinningPrice, a1.Outcome = biz.AuctionAwarded, "bistro", biz.Money{Amount: 7200, Currency: "USD"}, "won by bistro"
+		if err := repo.SaveAuction(ctx, a1); err != nil {
+			t.Fatalf("SaveAuction failed: %v", err)
+		}
+		if due, _ := repo.FindDueAuctions(ctx, now, 10); len(due) != 1 || due[0].ID != "a2" {
+			t.Errorf("expected only a2 due, got %+v", due)
+		}
+		got, err := repo.FindAuction(ctx, "a1")
+		if err != nil || got.Status != biz.AuctionAwarded || got.WinnerID != "bistro" || got.WinningPrice.Amount != 7200 || !got.ClosesAt.Equal(a1.ClosesAt) {
+			t.Errorf("expected the awarded auction, got %+v, %v", got, err)
+		}
+		if _, err := repo.FindAuction(ctx, "missing"); !errors.Is(err, biz.ErrAuctionNotFound) {
+			t.Errorf("expected ErrAuctionNotFound, got %v", err)
+		}
+
+		bid := func(bidder string, amount int64) *biz.Bid {
+			return &biz.Bid{ID: "b-" + bidder, AuctionID: "a3", BidderID: bidder, Amount: biz.Money{Amount: amount, Currency: "USD"}, SubmittedAt: now}
+		}
+		for _, b := range []*biz.Bid{bid("harbour", 6500), bid("bistro", 6000), bid("bistro", 7000)} {
+			if err := repo.SaveBid(ctx, b); err != nil {
+				t.Fatalf("SaveBid failed: %v", err)
+			}
+		}
+		bids, err := repo.FindBids(ctx, "a3")
+		if err != nil || len(bids) != 2 || bids[0].BidderID != "bistro" || bids[0].Amount.Amount != 7000 || !bids[1].SubmittedAt.Equal(now) {
+			t.Errorf("expected the replaced bistro bid and harbour, got %+v, %v", bids, err)
+		}
+		if bids, err := repo.FindBids(ctx, "a2"); err != nil || len(bids) != 0 {
+			t.Errorf("expected no bids on a2, got %+v, %v", bids, err)
+		}
+		missing := bid("bistro", 100)
+		missing.AuctionID = "missing"
+		if err := repo.SaveBid(ctx, missing); !errors.Is(err, biz.ErrAuctionNotFound) {
+			t.Errorf("bidding on an unknown auction should fail, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,317 @@
+package data
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+const (
+	// BM25 parameters
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// nameWeight counts a term in the name this many times as one in the description
+	nameWeight = 2
+	// fuzzyPenalty scales the score of terms matched by edit distance
+	fuzzyPenalty = 0.5
+	// snippetWords is the size of the description window around the first match
+	snippetWords = 20
+)
+
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"with": true,
+}
+
+// token is a word of a field, start and end are byte offsets into the field
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits s into lower-cased, stemmed words, dropping stopwords
+func tokenize(s string) []token {
+	var tokens []token
+	for _, t := range splitWords(s) {
+		if !stopwords[t.term] {
+			t.term = stem(t.term)
+			tokens = append(tokens, t)
+		}
+	}
+	return tokens
+}
+
+// splitWords splits s into lower-cased runs of letters and digits
+func splitWords(s string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, token{term: strings.ToLower(s[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{term: strings.ToLower(s[start:]), start: start, end: len(s)})
+	}
+	return tokens
+}
+
+// searchDoc is the indexed form of a product
+type searchDoc struct {
+	name, description string
+	// terms holds the weighted frequency of each term in the document
+	terms  map[string]float64
+	length float64
+}
+
+// SearchIndex is an in-memory inverted index implementing biz.ProductIndex.
+// Documents are ranked with BM25, with name matches weighted above
+// description matches; query terms missing from the index fall back to
+// terms within a small edit distance.
+type SearchIndex struct {
+	mu       sync.RWMutex
+	docs     map[string]*searchDoc
+	postings map[string]map[string]float64
+	totalLen float64
+}
+
+// NewSearchIndex creates an empty search index
+func NewSearchIndex() *SearchIndex {
+	return &SearchIndex{
+		docs:     make(map[string]*searchDoc),
+		postings: make(map[string]map[string]float64),
+	}
+}
+
+// Index adds p to the index, replacing its previous version
+func (s *SearchIndex) Index(p *biz.Product) {
+	doc := &searchDoc{
+		name:        p.Name,
+		description: p.Description,
+		terms:       make(map[string]float64),
+	}
+	for _, t := range tokenize(p.Name) {
+		doc.terms[t.term] += nameWeight
+		doc.length += nameWeight
+	}
+	for _, t := range tokenize(p.Description) {
+		doc.terms[t.term]++
+		doc.length++
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(p.ID)
+	s.docs[p.ID] = doc
+	s.totalLen += doc.length
+	for term, tf := range doc.terms {
+		if s.postings[term] == nil {
+			s.postings[term] = make(map[string]float64)
+		}
+		s.postings[term][p.ID] = tf
+	}
+}
+
+// Remove drops the product with the given ID from the index
+func (s *SearchIndex) Remove(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(id)
+}
+
+func (s *SearchIndex) remove(id string) {
+	doc, ok := s.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		delete(s.postings[term], id)
+		if len(s.postings[term]) == 0 {
+			delete(s.postings, term)
+		}
+	}
+	s.totalLen -= doc.length
+	delete(s.docs, id)
+}
+
+// Search returns at most limit hits for query ordered by descending score,
+// ties broken by ID
+func (s *SearchIndex) Search(query string, limit int) []biz.SearchHit {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.docs) == 0 {
+		return nil
+	}
+
+	// each query term maps to the index terms it matches and their weight
+	matched := make(map[string]float64)
+	for _, t := range tokenize(query) {
+		if _, ok := s.postings[t.term]; ok {
+			matched[t.term] = max(matched[t.term], 1)
+			continue
+		}
+		for term := range s.expand(t.term) {
+			matched[term] = max(matched[term], fuzzyPenalty)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	n := float64(len(s.docs))
+	avgLen := s.totalLen / n
+	scores := make(map[string]float64)
+	for term, weight := range matched {
+		docs := s.postings[term]
+		df := float64(len(docs))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range docs {
+			norm := bm25K1 * (1 - bm25B + bm25B*s.docs[id].length/avgLen)
+			scores[id] += weight * idf * tf * (bm25K1 + 1) / (tf + norm)
+		}
+	}
+
+	hits := make([]biz.SearchHit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, biz.SearchHit{ID: id, Score: score})
+	}
+	slices.SortFunc(hits, func(a, b biz.SearchHit) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	for i := range hits {
+		doc := s.docs[hits[i].ID]
+		hits[i].NameHighlight = highlight(doc.name, matched, 0)
+		hits[i].DescriptionHighlight = highlight(doc.description, matched, snippetWords)
+	}
+	return hits
+}
+
+// expand returns the indexed terms close enough to term to count as a typo
+func (s *SearchIndex) expand(term string) map[string]bool {
+	dist := 0
+	switch n := len([]rune(term)); {
+	case n >= 8:
+		dist = 2
+	case n >= 4:
+		dist = 1
+	}
+	if dist == 0 {
+		return nil
+	}
+	out := make(map[string]bool)
+	for candidate := range s.postings {
+		if withinDistance(term, candidate, dist) {
+			out[candidate] = true
+		}
+	}
+	return out
+}
+
+// highlight wraps the words of s whose term is in terms in <em></em>.
+// With window > 0 only that many words around the first match are kept.
+// It returns "" when nothing matched.
+func highlight(s string, terms map[string]float64, window int) string {
+	// every word counts towards the window, stopwords included
+	tokens := splitWords(s)
+	for i := range tokens {
+		tokens[i].term = stem(tokens[i].term)
+	}
+	first := -1
+	for i, t := range tokens {
+		if _, ok := terms[t.term]; ok {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	from, to := 0, len(s)
+	prefix, suffix := "", ""
+	if window > 0 && len(tokens) > window {
+		lo := max(0, first-window/4)
+		hi := min(len(tokens), lo+window)
+		lo = max(0, hi-window)
+		if lo > 0 {
+			from, prefix = tokens[lo].start, "…"
+		}
+		if hi < len(tokens) {
+			to, suffix = tokens[hi-1].end, "…"
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	pos := from
+	for _, t := range tokens {
+		if t.start < from || t.end > to {
+			continue
+		}
+		if _, ok := terms[t.term]; !ok {
+			continue
+		}
+		b.WriteString(s[pos:t.start])
+		b.WriteString("<em>")
+		b.WriteString(s[t.start:t.end])
+		b.WriteString("</em>")
+		pos = t.end
+	}
+	b.WriteString(s[pos:to])
+	b.WriteString(suffix)
+	return b.String()
+}
+
+// withinDistance reports whether the Levenshtein distance between a and b is at most k
+func withinDistance(a, b string, k int) bool {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > k || -d > k {
+		return false
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			best = min(best, cur[j])
+		}
+		if best > k {
+			return false
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)] <= k
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"apples":    "apple",
+		"berries":   "berri",
+		"berry":     "berri",
+		"caresses":  "caress",
+		"smoked":    "smoke",
+		"smoking":   "smoke",
+		"hopping":   "hop",
+		"filleted":  "fillet",
+		"agreed":    "agree",
+		"bag":       "bag",
+		"crème":     "crème",
+		"sliced":    "slice",
+		"glass":     "glass",
+		"chickens":  "chicken",
+		"chopped":   "chop",
+		"falling":   "fall",
+		"happy":     "happi",
+		"feed":      "feed",
+		"processed": "process",
+	}
+	for word, want := range tests {
+		if got := stem(word); got != want {
+			t.Errorf("stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestSearchIndex_Ranking(t *testing.T) {
+	idx := NewSearchIndex()
+	idx.Index(&biz.Product{ID: "1", Name: "Chicken Breast", Description: "Fresh chicken breast fillets, skinless"})
+	idx.Index(&biz.Product{ID: "2", Name: "Beef Mince", Description: "Lean beef, good with chicken stock"})
+	idx.Index(&biz.Product{ID: "3", Name: "Smoked Salmon", Description: "Sliced smoked salmon from the Scottish coast"})
+	idx.Index(&biz.Product{ID: "4", Name: "Strawberries", Description: "Punnet of British strawberries"})
+
+	hits := idx.Search("chickens", 10)
+	if len(hits) != 2 || hits[0].ID != "1" || hits[1].ID != "2" {
+		t.Fatalf("expected products 1 and 2 in that order, got %+v", hits)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("name match should outrank description match: %v <= %v", hits[0].Score, hits[1].Score)
+	}
+	if hits[0].NameHighlight != "<em>Chicken</em> Breast" {
+		t.Errorf("unexpected name highlight %q", hits[0].NameHighlight)
+	}
+	if hits[1].NameHighlight != "" {
+		t.Errorf("non matching name should have no highlight, got %q", hits[1].NameHighlight)
+	}
+	if hits[1].DescriptionHighlight != "Lean beef, good with <em>chicken</em> stock" {
+		t.Errorf("unexpected description highlight %q", hits[1].DescriptionHighlight)
+	}
+
+	// stemming and typo tolerance
+	if hits := idx.Search("smoking salmn", 10); len(hits) != 1 || hits[0].ID != "3" {
+		t.Errorf("expected product 3, got %+v", hits)
+	}
+	if hits := idx.Search("strawbery", 10); len(hits) != 1 || hits[0].ID != "4" {
+		t.Errorf("expected product 4, got %+v", hits)
+	}
+	if hits := idx.Search("the of", 10); len(hits) != 0 {
+		t.Errorf("stopwords should match nothing, got %+v", hits)
+	}
+	if hits := idx.Search("chicken", 1); len(hits) != 1 {
+		t.Errorf("limit not applied, got %d hits", len(hits))
+	}
+}
+
+func TestSearchIndex_UpdateRemove(t *testing.T) {
+	idx := NewSearchIndex()
+	idx.Index(&biz.Product{ID: "1", Name: "Cheddar"})
+	idx.Index(&biz.Product{ID: "1", Name: "Brie"})
+
+	if hits := idx.Search("cheddar", 10); len(hits) != 0 {
+		t.Errorf("old version still indexed: %+v", hits)
+	}
+	if hits := idx.Search("brie", 10); len(hits) != 1 {
+		t.Errorf("new version not indexed: %+v", hits)
+	}
+
+	idx.Remove("1")
+	if hits := idx.Search("brie", 10); len(hits) != 0 {
+		t.Errorf("removed product still indexed: %+v", hits)
+	}
+	if len(idx.postings) != 0 || idx.totalLen != 0 {
+		t.Errorf("index not emptied: %d postings, length %v", len(idx.postings), idx.totalLen)
+	}
+}
+
+func TestSearchIndex_Snippet(t *testing.T) {
+	idx := NewSearchIndex()
+	desc := strings.Repeat("filler ", 30) + "organic oats " + strings.Repeat("more ", 30)
+	idx.Index(&biz.Product{ID: "1", Name: "Porridge", Description: desc})
+
+	hits := idx.Search("oats", 10)
+	if len(hits) != 1 {
+		t.Fatalf("expected one hit, got %+v", hits)
+	}
+	got := hits[0].DescriptionHighlight
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "organic <em>oats</em>") {
+		t.Errorf("unexpected snippet %q", got)
+	}
+	if words := len(strings.Fields(strings.Trim(got, "…"))); words != snippetWords {
+		t.Errorf("snippet has %d words, want %d", words, snippetWords)
+	}
+}
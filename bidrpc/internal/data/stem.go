@@ -0,0 +1,114 @@
+package data
+
+import "strings"
+
+// stem reduces an English word to its stem with step 1 of the Porter
+// algorithm, which folds plurals and -ed/-ing forms ("berries" and "berry"
+// both become "berri"). Words of three letters or less are kept as they are.
+func stem(w string) string {
+	if len(w) <= 3 || !isASCIILower(w) {
+		return w
+	}
+
+	// step 1a
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ies"):
+		w = w[:len(w)-2]
+	case strings.HasSuffix(w, "ss"):
+	case strings.HasSuffix(w, "s"):
+		w = w[:len(w)-1]
+	}
+
+	// step 1b
+	cleanup := false
+	switch {
+	case strings.HasSuffix(w, "eed"):
+		if measure(w[:len(w)-3]) > 0 {
+			w = w[:len(w)-1]
+		}
+	case strings.HasSuffix(w, "ed") && hasVowel(w[:len(w)-2]):
+		w, cleanup = w[:len(w)-2], true
+	case strings.HasSuffix(w, "ing") && hasVowel(w[:len(w)-3]):
+		w, cleanup = w[:len(w)-3], true
+	}
+	if cleanup {
+		switch {
+		case strings.HasSuffix(w, "at"), strings.HasSuffix(w, "bl"), strings.HasSuffix(w, "iz"):
+			w += "e"
+		case endsDoubleConsonant(w) && !strings.ContainsAny(w[len(w)-1:], "lsz"):
+			w = w[:len(w)-1]
+		case measure(w) == 1 && endsCVC(w):
+			w += "e"
+		}
+	}
+
+	// step 1c
+	if strings.HasSuffix(w, "y") && hasVowel(w[:len(w)-1]) {
+		w = w[:len(w)-1] + "i"
+	}
+	return w
+}
+
+func isASCIILower(w string) bool {
+	for i := 0; i < len(w); i++ {
+		if w[i] < 'a' || w[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isConsonant reports whether w[i] is a consonant, y counts as one only
+// when it follows a vowel or starts the word
+func isConsonant(w string, i int) bool {
+	switch w[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !isConsonant(w, i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences in w, the m of [C](VC)^m[V]
+func measure(w string) int {
+	m := 0
+	vowel := false
+	for i := range len(w) {
+		if isConsonant(w, i) {
+			if vowel {
+				m++
+			}
+			vowel = false
+		} else {
+			vowel = true
+		}
+	}
+	return m
+}
+
+func hasVowel(w string) bool {
+	for i := range len(w) {
+		if !isConsonant(w, i) {
+			return true
+		}
+	}
+	return false
+}
+
+func endsDoubleConsonant(w string) bool {
+	n := len(w)
+	return n >= 2 && w[n-1] == w[n-2] && isConsonant(w, n-1)
+}
+
+// endsCVC reports whether w ends consonant-vowel-consonant with the last
+// consonant not w, x or y, as in "hop"
+func endsCVC(w string) bool {
+	n := len(w)
+	if n < 3 || !isConsonant(w, n-1) || isConsonant(w, n-2) || !isConsonant(w, n-3) {
+		return false
+	}
+	return !strings.ContainsAny(w[n-1:], "wxy")
+}
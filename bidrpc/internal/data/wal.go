@@ -0,0 +1,248 @@
+package data
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// WAL operations
+const (
+	opPut    = "put"
+	opDelete = "delete"
+	// opMovement appends a stock movement to the ledger of product Key
+	opMovement = "movement"
+	// opReservation stores reservation Key
+	opReservation = "reservation"
+	// opWarehouse stores warehouse Key, opDeleteWarehouse removes it
+	opWarehouse       = "warehouse"
+	opDeleteWarehouse = "delete_warehouse"
+	// opCategory stores category Key
+	opCategory = "category"
+	// opSupplier stores supplier Key, opDeleteSupplier removes it
+	opSupplier       = "supplier"
+	opDeleteSupplier = "delete_supplier"
+	// opProductSuppliers replaces the supplier links of product Key
+	opProductSuppliers = "product_suppliers"
+	// opPriceList stores price list Key
+	opPriceList = "price_list"
+	// opPriceTiers replaces the price tiers of product Key on every list
+	opPriceTiers = "price_tiers"
+	// opAuction stores auction Key
+	opAuction = "auction"
+	// opBid stores a bid on auction Key, replacing the bidder's earlier one
+	opBid = "bid"
+	// opSKUCounter advances the SKU counter of prefix Key
+	opSKUCounter = "sku_counter"
+)
+
+// walRecord is a single logged mutation
+type walRecord struct {
+	Op    string          `json:"op"`
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// wal is an append-only write-ahead log.
+//
+// Every append is one frame holding a batch of records:
+//
+//	| length uint32 | crc32c uint32 | payload (JSON array of walRecord) |
+//
+// A frame is only applied on replay if it is complete and its checksum
+// matches, so a batch is either fully recovered or not at all. A torn tail
+// left by a crash is truncated away on open.
+type wal struct {
+	f    *os.File
+	path string
+	n    int // frames appended since the last reset
+	// err is set once a failed append could not be rolled back, every
+	// later append fails with it rather than land behind a torn frame
+	err error
+}
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+const walHeaderSize = 8
+
+// openWAL opens (or creates) the log at path and replays every intact frame
+func openWAL(path string, replay func(walRecord) error) (*wal, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &wal{f: f, path: path}
+	good, err := w.replay(replay)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if info.Size() > good {
+		slog.Warn("Truncating torn write-ahead log tail", "path", path, "offset", good, "size", info.Size())
+		if err := f.Truncate(good); err != nil {
+			f.Close()
+			return nil, err
+		}
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	if _, err := f.Seek(good, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// replay applies all intact frames and returns the offset after the last one
+func (w *wal) replay(apply func(walRecord) error) (int64, error) {
+	r := bufio.NewReader(w.f)
+	var offset int64
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return offset, nil
+		}
+		size := binary.LittleEndian.Uint32(header[0:4])
+		sum := binary.LittleEndian.Uint32(header[4:8])
+
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return offset, nil
+		}
+		if crc32.Checksum(payload, crcTable) != sum {
+			return offset, nil
+		}
+
+		var batch []walRecord
+		if err := json.Unmarshal(payload, &batch); err != nil {
+			return offset, nil
+		}
+		for _, rec := range batch {
+			if err := apply(rec); err != nil {
+				return offset, fmt.Errorf("replay %s: %w", w.path, err)
+			}
+		}
+		offset += walHeaderSize + int64(size)
+		w.n++
+	}
+}
+
+// append writes the records as one frame and syncs it to disk.
+// The records are durable once append returns nil. A failed append is
+// truncated away, so the frames appended after it are replayed.
+func (w *wal) append(recs ...walRecord) error {
+	if w.err != nil {
+		return w.err
+	}
+	payload, err := json.Marshal(recs)
+	if err != nil {
+		return err
+	}
+
+	frame := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.Checksum(payload, crcTable))
+	copy(frame[walHeaderSize:], payload)
+
+	offset, err := w.f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := w.f.Write(frame); err != nil {
+		return w.rollback(offset, err)
+	}
+	if err := w.f.Sync(); err != nil {
+		return w.rollback(offset, err)
+	}
+	w.n++
+	return nil
+}
+
+// rollback cuts the log back to offset after an append failed with cause.
+// When that fails too the log is broken for good.
+func (w *wal) rollback(offset int64, cause error) error {
+	err := w.f.Truncate(offset)
+	if err == nil {
+		_, err = w.f.Seek(offset, io.SeekStart)
+	}
+	if err == nil {
+		err = w.f.Sync()
+	}
+	if err != nil {
+		w.err = fmt.Errorf("write-ahead log %s is unusable after a failed append: %w", w.path, errors.Join(cause, err))
+		slog.Error("Rolling back write-ahead log append failed", "path", w.path, "offset", offset, "error", w.err)
+		return w.err
+	}
+	return cause
+}
+
+// reset discards every frame, called once their effects are in a snapshot
+func (w *wal) reset() error {
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.n = 0
+	return w.f.Sync()
+}
+
+// close closes the underlying file
+func (w *wal) close() error {
+	return w.f.Close()
+}
+
+// writeFileAtomic replaces path with buf so readers only ever observe the old
+// or the new content, never a partially written file
+func writeFileAtomic(path string, buf []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// persist the rename itself
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return err
+	}
+	return nil
+}
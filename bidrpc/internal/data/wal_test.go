@@ -0,0 +1,336 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// reopen simulates a crash: the files are closed without compacting
+func reopen(t *testing.T, d *ProductData, dir string) *ProductData {
+	t.Helper()
+	d.wal.close()
+	nd, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	t.Cleanup(func() { nd.Close() })
+	return nd
+}
+
+func TestProductData_ReplayAfterCrash(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, id := range []string{"p1", "p2", "p3"} {
+		if err := d.Save(ctx, newTestProduct(id)); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	p := newTestProduct("p2")
+	p.Name = "Updated Name"
+	p.Version++
+	if err := d.Update(ctx, p); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := d.Delete(ctx, "p3", 0); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	d = reopen(t, d, dir)
+
+	if _, err := d.FindByID(ctx, "p1"); err != nil {
+		t.Errorf("p1 lost after replay: %v", err)
+	}
+	got, err := d.FindByID(ctx, "p2")
+	if err != nil || got.Name != "Updated Name" {
+		t.Errorf("update lost after replay: %v, %+v", err, got)
+	}
+	if _, err := d.FindByID(ctx, "p3"); err == nil {
+		t.Error("delete lost after replay")
+	}
+}
+
+func TestProductData_TornTail(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := d.Save(ctx, newTestProduct("p1")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := d.Save(ctx, newTestProduct("p2")); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// chop the last frame in half as a crash mid-write would
+	path := filepath.Join(dir, walFile)
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-10); err != nil {
+		t.Fatal(err)
+	}
+
+	d = reopen(t, d, dir)
+
+	if _, err := d.FindByID(ctx, "p1"); err != nil {
+		t.Errorf("p1 lost after torn tail: %v", err)
+	}
+	if _, err := d.FindByID(ctx, "p2"); err == nil {
+		t.Error("torn frame should not be replayed")
+	}
+
+	// the log must stay appendable after truncation
+	if err := d.Save(ctx, newTestProduct("p3")); err != nil {
+		t.Fatalf("Save after recovery failed: %v", err)
+	}
+	d = reopen(t, d, dir)
+	if _, err := d.FindByID(ctx, "p3"); err != nil {
+		t.Errorf("p3 lost after recovery: %v", err)
+	}
+}
+
+func TestWAL_FailedAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), walFile)
+	w, err := openWAL(path, func(walRecord) error { return nil })
+	if err != nil {
+		t.Fatalf("openWAL failed: %v", err)
+	}
+	if err := w.append(walRecord{Op: opPut, Key: "p1"}); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+
+	// half a frame written before the disk filled up is cut away, the next
+	// append lands where it started
+	offset, _ := w.f.Seek(0, io.SeekCurrent)
+	if _, err := w.f.Write([]byte{0xff, 0xff, 0, 0, 1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	full := errors.New("no space left on device")
+	if err := w.rollback(offset, full); err != full {
+		t.Fatalf("expected the append error back, got %v", err)
+	}
+	if err := w.append(walRecord{Op: opPut, Key: "p2"}); err != nil {
+		t.Fatalf("append after the rollback failed: %v", err)
+	}
+	w.close()
+
+	var keys []string
+	w, err = openWAL(path, func(rec walRecord) error {
+		keys = append(keys, rec.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("openWAL failed: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "p1" || keys[1] != "p2" {
+		t.Errorf("expected both frames replayed, got %v", keys)
+	}
+
+	// a log that cannot be rolled back refuses further appends, a read-only
+	// file fails both the write and the truncation
+	w.close()
+	if w.f, err = os.Open(path); err != nil {
+		t.Fatal(err)
+	}
+	defer w.close()
+	if err := w.append(walRecord{Op: opPut, Key: "p3"}); err == nil {
+		t.Fatal("append to a read-only file should fail")
+	}
+	if w.err == nil {
+		t.Error("a failed rollback should break the log")
+	}
+	if err := w.append(walRecord{Op: opPut, Key: "p4"}); !errors.Is(err, w.err) {
+		t.Errorf("expected the broken log error, got %v", err)
+	}
+}
+
+func TestProductData_Compaction(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	d.compactEvery = 3
+	ctx := context.Background()
+
+	for _, id := range []string{"p1", "p2", "p3", "p4"} {
+		if err := d.Save(ctx, newTestProduct(id)); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if d.wal.n != 1 {
+		t.Errorf("expected 1 frame after compaction, got %d", d.wal.n)
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFile)); err != nil {
+		t.Fatalf("snapshot not written: %v", err)
+	}
+
+	d = reopen(t, d, dir)
+	_, total, err := d.FindAll(ctx, biz.ListQuery{Limit: 10})
+	if err != nil || total != 4 {
+		t.Errorf("expected 4 products after reopen, got %d (%v)", total, err)
+	}
+}
+
+func TestProductData_LedgerSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	ctx := context.Background()
+
+	p := newTestProduct("p1")
+	p.Quantity, p.Version = 0, 0
+	recordTestMovement(t, d, p, 3)
+	recordTestMovement(t, d, p, 2)
+
+	// replayed from the log
+	d = reopen(t, d, dir)
+	if got, _ := d.FindMovements(ctx, "p1", 0, 10); len(got) != 2 || got[1].Balance != 5 {
+		t.Fatalf("ledger lost after replay: %+v", got)
+	}
+
+	// loaded from the snapshot, and not doubled by the log replayed on top
+	// after a crash between writing the snapshots and resetting the log
+	recordTestMovement(t, d, p, -1)
+	raw, err := os.ReadFile(filepath.Join(dir, walFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.compact(); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+	d.wal.close()
+	if err := os.WriteFile(filepath.Join(dir, walFile), raw, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err = NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+
+	got, _ := d.FindMovements(ctx, "p1", 0, 10)
+	if len(got) != 3 || got[2].Balance != 4 {
+		t.Errorf("expected the 3 compacted movements, got %+v", got)
+	}
+}
+
+func TestProductData_ReservationsSurviveRestart(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	ctx := context.Background()
+
+	p := newTestProduct("p1")
+	if err := d.Save(ctx, p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	res := &biz.Reservation{ID: "r1", ProductID: p.ID, Quantity: 2, Status: biz.ReservationHeld, ExpiresAt: time.Now()}
+	p.Reserved = 2
+	saveTestReservation(t, d, p, res, nil)
+
+	// replayed from the log
+	d = reopen(t, d, dir)
+	if got, err := d.FindReservation(ctx, "r1"); err != nil || got.Quantity != 2 {
+		t.Fatalf("reservation lost after replay: %+v, %v", got, err)
+	}
+
+	// loaded from the snapshot
+	res.Status = biz.ReservationReleased
+	p.Reserved = 0
+	saveTestReservation(t, d, p, res, nil)
+	if err := d.compact(); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+	d = reopen(t, d, dir)
+	if got, err := d.FindReservation(ctx, "r1"); err != nil || got.Status != biz.ReservationReleased {
+		t.Errorf("expected the released reservation, got %+v, %v", got, err)
+	}
+	if expired, _ := d.FindExpiredReservations(ctx, time.Now(), 10); len(expired) != 0 {
+		t.Errorf("a released reservation must not expire, got %+v", expired)
+	}
+}
+
+func TestProductData_BarcodesAfterReplay(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	ctx := context.Background()
+
+	p := newTestProduct("p1")
+	p.Barcodes = []biz.Barcode{{GTIN: "05449000000996"}}
+	if err := d.Save(ctx, p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if err := d.compact(); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+	// the snapshot holds the first barcode, the log the replacement
+	p.Version++
+	p.Barcodes = []biz.Barcode{{GTIN: "00000096385074"}}
+	if err := d.Update(ctx, p); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	d = reopen(t, d, dir)
+
+	if _, err := d.FindByBarcode(ctx, "05449000000996"); err == nil {
+		t.Error("a replaced barcode is still indexed after replay")
+	}
+	if got, err := d.FindByBarcode(ctx, "00000096385074"); err != nil || got.ID != "p1" {
+		t.Errorf("barcode lost after replay: %v, %+v", err, got)
+	}
+}
+
+func TestProductData_SKUsAfterReplay(t *testing.T) {
+	dir := t.TempDir()
+	d, err := NewProductData(dir)
+	if err != nil {
+		t.Fatalf("NewProductData failed: %v", err)
+	}
+	ctx := context.Background()
+
+	p := newTestProduct("p1")
+	p.SKU = "SKU-00001-7"
+	if err := d.Save(ctx, p); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := d.NextSKUNumber(ctx, "SKU"); err != nil {
+		t.Fatalf("NextSKUNumber failed: %v", err)
+	}
+	if err := d.compact(); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+	// the snapshot holds the first number, the log the second
+	if _, err := d.NextSKUNumber(ctx, "SKU"); err != nil {
+		t.Fatalf("NextSKUNumber failed: %v", err)
+	}
+
+	d = reopen(t, d, dir)
+
+	if got, err := d.FindBySKU(ctx, "SKU-00001-7"); err != nil || got.ID != "p1" {
+		t.Errorf("SKU lost after replay: %v, %+v", err, got)
+	}
+	if n, err := d.NextSKUNumber(ctx, "SKU"); err != nil || n != 3 {
+		t.Errorf("expected the counter to go on at 3, got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// toAllergens reads allergen declarations, an allergen may appear once
+func toAllergens(decls []*pb.AllergenDeclaration) (biz.Allergens, error) {
+	if len(decls) == 0 {
+		return nil, nil
+	}
+	allergens := make(biz.Allergens, len(decls))
+	for _, d := range decls {
+		al := biz.Allergen(d.Allergen)
+		if _, ok := allergens[al]; ok {
+			return nil, biz.InvalidArgument("allergens", "repeats %s", d.Allergen)
+		}
+		allergens[al] = biz.AllergenStatus(d.Status)
+	}
+	return allergens, nil
+}
+
+// toAllergensProto converts allergen declarations in label order
+func toAllergensProto(allergens biz.Allergens) []*pb.AllergenDeclaration {
+	var out []*pb.AllergenDeclaration
+	for _, al := range allergens.Ordered() {
+		out = append(out, &pb.AllergenDeclaration{Allergen: string(al), Status: string(allergens[al])})
+	}
+	return out
+}
+
+// toNutrition reads nutrition facts, nil declares none
+func toNutrition(n *pb.Nutrition) biz.Nutrition {
+	if n == nil {
+		return biz.Nutrition{}
+	}
+	return biz.Nutrition{
+		EnergyKJ:     n.EnergyKj,
+		EnergyKcal:   n.EnergyKcal,
+		Fat:          n.Fat,
+		SaturatedFat: n.SaturatedFat,
+		Carbohydrate: n.Carbohydrate,
+		Sugars:       n.Sugars,
+		Fibre:        n.Fibre,
+		Protein:      n.Protein,
+		Salt:         n.Salt,
+	}
+}
+
+// toNutritionProto converts nutrition facts, nil when none are declared
+func toNutritionProto(n biz.Nutrition) *pb.Nutrition {
+	if !n.Declared() {
+		return nil
+	}
+	return &pb.Nutrition{
+		EnergyKj:     n.EnergyKJ,
+		EnergyKcal:   n.EnergyKcal,
+		Fat:          n.Fat,
+		SaturatedFat: n.SaturatedFat,
+		Carbohydrate: n.Carbohydrate,
+		Sugars:       n.Sugars,
+		Fibre:        n.Fibre,
+		Protein:      n.Protein,
+		Salt:         n.Salt,
+	}
+}
+
+// toDietaryTags reads dietary tags
+func toDietaryTags(tags []string) []biz.DietaryTag {
+	if len(tags) == 0 {
+		return nil
+	}
+	out := make([]biz.DietaryTag, len(tags))
+	for i, t := range tags {
+		out[i] = biz.DietaryTag(t)
+	}
+	return out
+}
+
+// toDietaryTagsProto converts dietary tags to their protobuf form
+func toDietaryTagsProto(tags []biz.DietaryTag) []string {
+	var out []string
+	for _, t := range tags {
+		out = append(out, string(t))
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"maps"
+	"slices"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// toAttributes reads attribute values, a name may appear once
+func toAttributes(values []*pb.AttributeValue) (biz.Attributes, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	attrs := make(biz.Attributes, len(values))
+	for _, v := range values {
+		if _, ok := attrs[v.Name]; ok {
+			return nil, biz.InvalidArgument("attributes", "repeats %s", v.Name)
+		}
+		attrs[v.Name] = v.Value
+	}
+	return attrs, nil
+}
+
+// toAttributesProto converts attribute values ordered by name
+func toAttributesProto(attrs biz.Attributes) []*pb.AttributeValue {
+	var out []*pb.AttributeValue
+	for _, name := range slices.Sorted(maps.Keys(attrs)) {
+		out = append(out, &pb.AttributeValue{Name: name, Value: attrs[name]})
+	}
+	return out
+}
+
+// toAttributeDefs reads attribute definitions
+func toAttributeDefs(defs []*pb.AttributeDefinition) []biz.AttributeDef {
+	out := make([]biz.AttributeDef, len(defs))
+	for i, d := range defs {
+		out[i] = biz.AttributeDef{Name: d.Name, Type: biz.AttributeType(d.Type), Values: d.AllowedValues, Required: d.Required}
+	}
+	return out
+}
+
+// toAttributeDefsProto converts attribute definitions to their protobuf form
+func toAttributeDefsProto(defs []biz.AttributeDef) []*pb.AttributeDefinition {
+	var out []*pb.AttributeDefinition
+	for _, d := range defs {
+		out = append(out, &pb.AttributeDefinition{Name: d.Name, Type: string(d.Type), AllowedValues: d.Values, Required: d.Required})
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// AuctionService implements the gRPC AuctionService
+type AuctionService struct {
+	pb.UnimplementedAuctionServiceServer
+	uc *biz.AuctionUseCase
+}
+
+// NewAuctionService creates a new auction service
+func NewAuctionService(uc *biz.AuctionUseCase) *AuctionService {
+	return &AuctionService{
+		uc: uc,
+	}
+}
+
+// CreateAuction puts a lot up for auction
+func (s *AuctionService) CreateAuction(ctx context.Context, req *pb.CreateAuctionRequest) (*pb.CreateAuctionResponse, error) {
+	in := biz.AuctionInput{
+		ProductID:    req.ProductId,
+		WarehouseID:  req.WarehouseId,
+		Quantity:     req.Quantity,
+		ReservePrice: biz.Money{Amount: req.ReservePrice.GetAmountMinor(), Currency: req.ReservePrice.GetCurrencyCode()},
+	}
+	if req.OpensAt != 0 {
+		in.OpensAt = time.Unix(req.OpensAt, 0)
+	}
+	if req.ClosesAt != 0 {
+		in.ClosesAt = time.Unix(req.ClosesAt, 0)
+	}
+	auction, err := s.uc.CreateAuction(ctx, in)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CreateAuctionResponse{
+		Auction: toAuctionProto(auction),
+	}, nil
+}
+
+// GetAuction retrieves an auction by ID
+func (s *AuctionService) GetAuction(ctx context.Context, req *pb.GetAuctionRequest) (*pb.GetAuctionResponse, error) {
+	auction, err := s.uc.GetAuction(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GetAuctionResponse{
+		Auction: toAuctionProto(auction),
+	}, nil
+}
+
+// ListAuctions lists the auctions ordered by closing time
+func (s *AuctionService) ListAuctions(ctx context.Context, req *pb.ListAuctionsRequest) (*pb.ListAuctionsResponse, error) {
+	status, err := biz.ParseAuctionStatus(req.Status)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	auctions, err := s.uc.ListAuctions(ctx, status)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbAuctions := make([]*pb.Auction, len(auctions))
+	for i, auction := range auctions {
+		pbAuctions[i] = toAuctionProto(auction)
+	}
+
+	return &pb.ListAuctionsResponse{
+		Auctions: pbAuctions,
+	}, nil
+}
+
+// SubmitBid places a sealed bid on an open auction
+func (s *AuctionService) SubmitBid(ctx context.Context, req *pb.SubmitBidRequest) (*pb.SubmitBidResponse, error) {
+	amount := biz.Money{Amount: req.Amount.GetAmountMinor(), Currency: req.Amount.GetCurrencyCode()}
+	bid, err := s.uc.SubmitBid(ctx, req.AuctionId, req.BidderId, amount)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.SubmitBidResponse{
+		Bid: toBidProto(bid),
+	}, nil
+}
+
+// ListBids lists the bids on a closed auction from best to worst
+func (s *AuctionService) ListBids(ctx context.Context, req *pb.ListBidsRequest) (*pb.ListBidsResponse, error) {
+	bids, err := s.uc.ListBids(ctx, req.AuctionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbBids := make([]*pb.Bid, len(bids))
+	for i, bid := range bids {
+		pbBids[i] = toBidProto(bid)
+	}
+
+	return &pb.ListBidsResponse{
+		Bids: pbBids,
+	}, nil
+}
+
+// toAuctionProto converts a biz auction to its protobuf form
+func toAuctionProto(a *biz.Auction) *pb.Auction {
+	out := &pb.Auction{
+		Id:            a.ID,
+		ProductId:     a.ProductID,
+		WarehouseId:   a.WarehouseID,
+		Quantity:      a.Quantity,
+		ReservePrice:  toMoneyProto(a.ReservePrice),
+		OpensAt:       a.OpensAt.Unix(),
+		ClosesAt:      a.ClosesAt.Unix(),
+		Status:        string(a.Status),
+		ReservationId: a.ReservationID,
+		WinningBidId:  a.WinningBidID,
+		WinnerId:      a.WinnerID,
+		Outcome:       a.Outcome,
+		CreatedAt:     a.CreatedAt.Unix(),
+		UpdatedAt:     a.UpdatedAt.Unix(),
+	}
+	if a.Status == biz.AuctionAwarded {
+		out.WinningPrice = toMoneyProto(a.WinningPrice)
+	}
+	return out
+}
+
+// toBidProto converts a biz bid to its protobuf form
+func toBidProto(b *biz.Bid) *pb.Bid {
+	return &pb.Bid{
+		Id:          b.ID,
+		AuctionId:   b.AuctionID,
+		BidderId:    b.BidderID,
+		Amount:      toMoneyProto(b.Amount),
+		SubmittedAt: b.SubmittedAt.Unix(),
+	}
+}
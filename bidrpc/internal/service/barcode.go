@@ -0,0 +1,42 @@
+package service
+
+import (
+	"cmp"
+	"context"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// GetProductByBarcode finds the product carrying a scanned GTIN
+func (s *ProductService) GetProductByBarcode(ctx context.Context, req *pb.GetProductByBarcodeRequest) (*pb.GetProductByBarcodeResponse, error) {
+	product, barcode, err := s.uc.GetProductByBarcode(ctx, req.Gtin)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.GetProductByBarcodeResponse{
+		Product: toProto(product),
+		Barcode: &pb.Barcode{Gtin: barcode.GTIN, Unit: cmp.Or(barcode.Unit, product.Units.BaseUnit())},
+	}, nil
+}
+
+// toBarcodes reads barcodes
+func toBarcodes(barcodes []*pb.Barcode) []biz.Barcode {
+	if len(barcodes) == 0 {
+		return nil
+	}
+	out := make([]biz.Barcode, len(barcodes))
+	for i, b := range barcodes {
+		out[i] = biz.Barcode{GTIN: b.Gtin, Unit: b.Unit}
+	}
+	return out
+}
+
+// toBarcodesProto converts barcodes to their protobuf form
+func toBarcodesProto(barcodes []biz.Barcode) []*pb.Barcode {
+	var out []*pb.Barcode
+	for _, b := range barcodes {
+		out = append(out, &pb.Barcode{Gtin: b.GTIN, Unit: b.Unit})
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// ListExpiringStock reports the lots expiring within a number of days,
+// soonest first
+func (s *ProductService) ListExpiringStock(ctx context.Context, req *pb.ListExpiringStockRequest) (*pb.ListExpiringStockResponse, error) {
+	now := time.Now()
+	batches, err := s.uc.ListExpiringStock(ctx, req.WithinDays, req.WarehouseId, now)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	stock := make([]*pb.ExpiringStock, len(batches))
+	for i, b := range batches {
+		stock[i] = &pb.ExpiringStock{
+			ProductId:   b.ProductID,
+			ProductName: b.ProductName,
+			Batch:       toBatchProto(b.Batch),
+			DaysLeft:    int32(math.Floor(b.ExpiresAt.Sub(now).Hours() / 24)),
+		}
+	}
+
+	return &pb.ListExpiringStockResponse{
+		Stock: stock,
+	}, nil
+}
+
+// toLot reads the lot of a stock request, expiresAt 0 is no expiry
+func toLot(code string, expiresAt int64) biz.Lot {
+	lot := biz.Lot{Code: code}
+	if expiresAt != 0 {
+		lot.ExpiresAt = time.Unix(expiresAt, 0)
+	}
+	return lot
+}
+
+// toBatchProto converts a batch to its protobuf form
+func toBatchProto(b biz.Batch) *pb.Batch {
+	out := &pb.Batch{
+		WarehouseId: b.WarehouseID,
+		LotCode:     b.LotCode,
+		ReceivedAt:  b.ReceivedAt.Unix(),
+		Quantity:    b.Quantity,
+		Reserved:    b.Reserved,
+		Available:   b.Available(),
+	}
+	if !b.ExpiresAt.IsZero() {
+		out.ExpiresAt = b.ExpiresAt.Unix()
+	}
+	return out
+}
+
+// toBatchesProto converts the batches of a product to their protobuf form
+func toBatchesProto(batches []biz.Batch) []*pb.Batch {
+	out := make([]*pb.Batch, len(batches))
+	for i, b := range batches {
+		out[i] = toBatchProto(b)
+	}
+	return out
+}
+
+// toLotsProto converts the split of a movement or reservation by lot
+func toLotsProto(lots []biz.LotQuantity) []*pb.LotQuantity {
+	out := make([]*pb.LotQuantity, len(lots))
+	for i, l := range lots {
+		out[i] = &pb.LotQuantity{LotCode: l.LotCode, Quantity: l.Quantity}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// CreateCategory creates a category in the product taxonomy
+func (s *ProductService) CreateCategory(ctx context.Context, req *pb.CreateCategoryRequest) (*pb.CreateCategoryResponse, error) {
+	c, err := s.uc.CreateCategory(ctx, req.Name, req.ParentId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CreateCategoryResponse{
+		Category: toCategoryProto(c),
+	}, nil
+}
+
+// MoveCategory moves a category with its subtree under another parent
+func (s *ProductService) MoveCategory(ctx context.Context, req *pb.MoveCategoryRequest) (*pb.MoveCategoryResponse, error) {
+	c, err := s.uc.MoveCategory(ctx, req.Id, req.ParentId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.MoveCategoryResponse{
+		Category: toCategoryProto(c),
+	}, nil
+}
+
+// SetCategoryAttributes replaces the attribute definitions of a category
+func (s *ProductService) SetCategoryAttributes(ctx context.Context, req *pb.SetCategoryAttributesRequest) (*pb.SetCategoryAttributesResponse, error) {
+	c, err := s.uc.SetCategoryAttributes(ctx, req.Id, toAttributeDefs(req.Attributes))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.SetCategoryAttributesResponse{
+		Category: toCategoryProto(c),
+	}, nil
+}
+
+// SetCategorySKUPrefix sets the prefix of the SKUs generated for the
+// products of a category
+func (s *ProductService) SetCategorySKUPrefix(ctx context.Context, req *pb.SetCategorySKUPrefixRequest) (*pb.SetCategorySKUPrefixResponse, error) {
+	c, err := s.uc.SetCategorySKUPrefix(ctx, req.Id, req.SkuPrefix)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.SetCategorySKUPrefixResponse{
+		Category: toCategoryProto(c),
+	}, nil
+}
+
+// ListCategories lists every category ordered by name
+func (s *ProductService) ListCategories(ctx context.Context, req *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	categories, err := s.uc.ListCategories(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbCategories := make([]*pb.Category, len(categories))
+	for i, c := range categories {
+		pbCategories[i] = toCategoryProto(c)
+	}
+
+	return &pb.ListCategoriesResponse{
+		Categories: pbCategories,
+	}, nil
+}
+
+// toCategoryProto converts a biz category to its protobuf form
+func toCategoryProto(c *biz.Category) *pb.Category {
+	return &pb.Category{
+		Id:         c.ID,
+		Name:       c.Name,
+		ParentId:   c.ParentID,
+		Attributes: toAttributeDefsProto(c.Attributes),
+		SkuPrefix:  c.SKUPrefix,
+		CreatedAt:  c.CreatedAt.Unix(),
+		UpdatedAt:  c.UpdatedAt.Unix(),
+	}
+}
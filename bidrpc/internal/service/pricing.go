@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// PricingService implements the gRPC PricingService
+type PricingService struct {
+	pb.UnimplementedPricingServiceServer
+	uc *biz.PricingUseCase
+}
+
+// NewPricingService creates a new pricing service
+func NewPricingService(uc *biz.PricingUseCase) *PricingService {
+	return &PricingService{
+		uc: uc,
+	}
+}
+
+// CreatePriceList creates a new price list
+func (s *PricingService) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.CreatePriceListResponse, error) {
+	list, err := s.uc.CreatePriceList(ctx, req.Name, req.CustomerIds)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CreatePriceListResponse{
+		PriceList: toPriceListProto(list),
+	}, nil
+}
+
+// GetPriceList retrieves a price list by ID
+func (s *PricingService) GetPriceList(ctx context.Context, req *pb.GetPriceListRequest) (*pb.GetPriceListResponse, error) {
+	list, err := s.uc.GetPriceList(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GetPriceListResponse{
+		PriceList: toPriceListProto(list),
+	}, nil
+}
+
+// ListPriceLists lists every price list ordered by name
+func (s *PricingService) ListPriceLists(ctx context.Context, req *pb.ListPriceListsRequest) (*pb.ListPriceListsResponse, error) {
+	lists, err := s.uc.ListPriceLists(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbLists := make([]*pb.PriceList, len(lists))
+	for i, list := range lists {
+		pbLists[i] = toPriceListProto(list)
+	}
+
+	return &pb.ListPriceListsResponse{
+		PriceLists: pbLists,
+	}, nil
+}
+
+// UpdatePriceList replaces the name and customers of a price list
+func (s *PricingService) UpdatePriceList(ctx context.Context, req *pb.UpdatePriceListRequest) (*pb.UpdatePriceListResponse, error) {
+	list, err := s.uc.UpdatePriceList(ctx, req.Id, req.Name, req.CustomerIds)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.UpdatePriceListResponse{
+		PriceList: toPriceListProto(list),
+	}, nil
+}
+
+// SetPriceTiers replaces the tiers of a product on a price list
+func (s *PricingService) SetPriceTiers(ctx context.Context, req *pb.SetPriceTiersRequest) (*pb.SetPriceTiersResponse, error) {
+	tiers := make([]*biz.PriceTier, len(req.Tiers))
+	for i, t := range req.Tiers {
+		tiers[i] = &biz.PriceTier{
+			MinQuantity: t.MinQuantity,
+			Price:       biz.Money{Amount: t.Price.GetAmountMinor(), Currency: t.Price.GetCurrencyCode()},
+		}
+	}
+	tiers, err := s.uc.SetPriceTiers(ctx, req.PriceListId, req.ProductId, tiers)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.SetPriceTiersResponse{
+		Tiers: toPriceTiersProto(tiers),
+	}, nil
+}
+
+// GetPriceTiers lists the tiers of a product on a price list
+func (s *PricingService) GetPriceTiers(ctx context.Context, req *pb.GetPriceTiersRequest) (*pb.GetPriceTiersResponse, error) {
+	tiers, err := s.uc.GetPriceTiers(ctx, req.PriceListId, req.ProductId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GetPriceTiersResponse{
+		Tiers: toPriceTiersProto(tiers),
+	}, nil
+}
+
+// QuotePrice resolves the effective price of a quantity of a product
+func (s *PricingService) QuotePrice(ctx context.Context, req *pb.QuotePriceRequest) (*pb.QuotePriceResponse, error) {
+	q, err := s.uc.QuotePrice(ctx, biz.QuoteRequest{
+		ProductID:   req.ProductId,
+		PriceListID: req.PriceListId,
+		CustomerID:  req.CustomerId,
+		Quantity:    req.Quantity,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.QuotePriceResponse{
+		Quote: &pb.PriceQuote{
+			ProductId:   q.ProductID,
+			PriceListId: q.PriceListID,
+			Quantity:    q.Quantity,
+			UnitPrice:   toMoneyProto(q.UnitPrice),
+			LinePrice:   toMoneyProto(q.LinePrice),
+			Rule:        string(q.Rule),
+			MinQuantity: q.MinQuantity,
+			Explanation: q.Explanation,
+		},
+	}, nil
+}
+
+// toPriceListProto converts a biz price list to its protobuf form
+func toPriceListProto(l *biz.PriceList) *pb.PriceList {
+	return &pb.PriceList{
+		Id:          l.ID,
+		Name:        l.Name,
+		CustomerIds: l.CustomerIDs,
+		CreatedAt:   l.CreatedAt.Unix(),
+		UpdatedAt:   l.UpdatedAt.Unix(),
+	}
+}
+
+// toPriceTiersProto converts biz price tiers to their protobuf form
+func toPriceTiersProto(tiers []*biz.PriceTier) []*pb.PriceTier {
+	out := make([]*pb.PriceTier, len(tiers))
+	for i, t := range tiers {
+		out[i] = &pb.PriceTier{
+			MinQuantity: t.MinQuantity,
+			Price:       toMoneyProto(t.Price),
+		}
+	}
+	return out
+}
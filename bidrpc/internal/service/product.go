@@ -2,9 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
 
 	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
 	"github.com/athxx/bidfood/bidrpc/internal/biz"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // ProductService implements the gRPC ProductService
@@ -22,69 +31,112 @@ func NewProductService(uc *biz.ProductUseCase) *ProductService {
 
 // CreateProduct creates a new product
 func (s *ProductService) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
-	product, err := s.uc.CreateProduct(ctx, req.Name, req.Description, req.Price, req.Quantity)
+	price, err := toMoney("price", req.PriceMoney, req.Price)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	units, quantity := toUnits(req.Units), req.Quantity
+	if req.Measure != nil {
+		if quantity, err = units.ToBase("measure", toMeasure(req.Measure)); err != nil {
+			return nil, toStatus(err)
+		}
+	}
+	attrs, err := toAttributes(req.Attributes)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	allergens, err := toAllergens(req.Allergens)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	product, err := s.uc.CreateProduct(ctx, biz.ProductInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       price,
+		Quantity:    quantity,
+		CategoryID:  req.CategoryId,
+		Units:       units,
+		ParentID:    req.ParentId,
+		Attributes:  attrs,
+		Allergens:   allergens,
+		Nutrition:   toNutrition(req.Nutrition),
+		DietaryTags: toDietaryTags(req.DietaryTags),
+		Barcodes:    toBarcodes(req.Barcodes),
+		SKU:         req.Sku,
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.CreateProductResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
-			CreatedAt:   product.CreatedAt.Unix(),
-			UpdatedAt:   product.UpdatedAt.Unix(),
-		},
+		Product: toProto(product),
 	}, nil
 }
 
-// GetProduct retrieves a product by ID
+// GetProduct retrieves a product by ID or SKU, a parent with its variants
 func (s *ProductService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.GetProductResponse, error) {
-	product, err := s.uc.GetProduct(ctx, req.Id)
+	var (
+		product *biz.Product
+		err     error
+	)
+	if req.Id == "" && req.Sku != "" {
+		product, err = s.uc.GetProductBySKU(ctx, req.Sku)
+	} else {
+		product, err = s.uc.GetProduct(ctx, req.Id)
+	}
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
+	}
+	pbProduct := toProto(product)
+	if product.ParentID == "" {
+		variants, err := s.uc.ListVariants(ctx, product.ID)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		for _, v := range variants {
+			pbProduct.Variants = append(pbProduct.Variants, toProto(v))
+		}
 	}
 
 	return &pb.GetProductResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
-			CreatedAt:   product.CreatedAt.Unix(),
-			UpdatedAt:   product.UpdatedAt.Unix(),
-		},
+		Product: pbProduct,
 	}, nil
 }
 
 // UpdateProduct updates an existing product
 func (s *ProductService) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
-	product, err := s.uc.UpdateProduct(ctx, req.Id, req.Name, req.Description, req.Price, req.Quantity)
+	patch, err := toPatch(req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if req.Measure != nil {
+		// in the units set together with it, or those of the product
+		var quantity int32
+		if patch.Units != nil {
+			quantity, err = patch.Units.ToBase("measure", toMeasure(req.Measure))
+		} else {
+			quantity, err = s.uc.ToBaseQuantity(ctx, req.Id, "measure", toMeasure(req.Measure))
+		}
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		patch.Quantity = &quantity
+	}
+	product, err := s.uc.UpdateProduct(ctx, req.Id, patch, req.ExpectedVersion)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.UpdateProductResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
-			CreatedAt:   product.CreatedAt.Unix(),
-			UpdatedAt:   product.UpdatedAt.Unix(),
-		},
+		Product: toProto(product),
 	}, nil
 }
 
 // DeleteProduct deletes a product by ID
 func (s *ProductService) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
-	err := s.uc.DeleteProduct(ctx, req.Id)
+	err := s.uc.DeleteProduct(ctx, req.Id, req.ExpectedVersion)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.DeleteProductResponse{
@@ -92,30 +144,503 @@ func (s *ProductService) DeleteProduct(ctx context.Context, req *pb.DeleteProduc
 	}, nil
 }
 
+// RestoreProduct moves a deleted product out of the trash
+func (s *ProductService) RestoreProduct(ctx context.Context, req *pb.RestoreProductRequest) (*pb.RestoreProductResponse, error) {
+	product, err := s.uc.RestoreProduct(ctx, req.Id, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.RestoreProductResponse{
+		Product: toProto(product),
+	}, nil
+}
+
 // ListProducts lists all products with pagination and filtering
 func (s *ProductService) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
-	products, total, err := s.uc.ListProducts(ctx, req.Page, req.PageSize, req.NameFilter)
+	page, err := s.uc.ListProducts(ctx, biz.ListOptions{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		PageToken:  req.PageToken,
+		NameFilter: req.NameFilter,
+		Filter:     req.Filter,
+		SortBy:     req.SortBy,
+		SortOrder:  req.SortOrder,
+		Warehouse:  req.WarehouseId,
+		Category:   req.CategoryId,
+	})
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
-	pbProducts := make([]*pb.Product, len(products))
-	for i, product := range products {
-		pbProducts[i] = &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-			Quantity:    product.Quantity,
-			CreatedAt:   product.CreatedAt.Unix(),
-			UpdatedAt:   product.UpdatedAt.Unix(),
-		}
+	pbProducts := make([]*pb.Product, len(page.Products))
+	for i, product := range page.Products {
+		pbProducts[i] = toProto(product)
 	}
 
 	return &pb.ListProductsResponse{
-		Products: pbProducts,
-		Total:    total,
-		Page:     req.Page,
-		PageSize: req.PageSize,
+		Products:      pbProducts,
+		Total:         page.Total,
+		Page:          req.Page,
+		PageSize:      req.PageSize,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// ListDeletedProducts lists the products in the trash with pagination and
+// filtering
+func (s *ProductService) ListDeletedProducts(ctx context.Context, req *pb.ListDeletedProductsRequest) (*pb.ListDeletedProductsResponse, error) {
+	page, err := s.uc.ListProducts(ctx, biz.ListOptions{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		PageToken:  req.PageToken,
+		NameFilter: req.NameFilter,
+		Filter:     req.Filter,
+		SortBy:     req.SortBy,
+		SortOrder:  req.SortOrder,
+		Deleted:    true,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbProducts := make([]*pb.Product, len(page.Products))
+	for i, product := range page.Products {
+		pbProducts[i] = toProto(product)
+	}
+
+	return &pb.ListDeletedProductsResponse{
+		Products:      pbProducts,
+		Total:         page.Total,
+		Page:          req.Page,
+		PageSize:      req.PageSize,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// SearchProducts runs a full-text search over product names and descriptions
+func (s *ProductService) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	hits, err := s.uc.SearchProducts(ctx, req.Query, req.PageSize)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	results := make([]*pb.SearchResult, len(hits))
+	for i, hit := range hits {
+		results[i] = &pb.SearchResult{
+			Product:              toProto(hit.Product),
+			Score:                hit.Score,
+			NameHighlight:        hit.NameHighlight,
+			DescriptionHighlight: hit.DescriptionHighlight,
+		}
+	}
+
+	return &pb.SearchProductsResponse{
+		Results: results,
+	}, nil
+}
+
+// RecordStockMovement books a stock movement against a product
+func (s *ProductService) RecordStockMovement(ctx context.Context, req *pb.RecordStockMovementRequest) (*pb.RecordStockMovementResponse, error) {
+	quantity, err := s.toQuantity(ctx, req.ProductId, req.Quantity, req.Measure)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	movement, product, err := s.uc.RecordStockMovement(ctx, req.ProductId, biz.MovementInput{
+		Kind:      req.Kind,
+		Quantity:  quantity,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		Warehouse: req.WarehouseId,
+		Lot:       toLot(req.LotCode, req.ExpiresAt),
+	}, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.RecordStockMovementResponse{
+		Movement: toMovementProto(movement),
+		Product:  toProto(product),
+	}, nil
+}
+
+// TransferStock moves stock of a product between warehouses
+func (s *ProductService) TransferStock(ctx context.Context, req *pb.TransferStockRequest) (*pb.TransferStockResponse, error) {
+	quantity, err := s.toQuantity(ctx, req.ProductId, req.Quantity, req.Measure)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	movement, product, err := s.uc.TransferStock(ctx, req.ProductId, biz.TransferInput{
+		From:     req.FromWarehouseId,
+		To:       req.ToWarehouseId,
+		Quantity: quantity,
+		Reason:   req.Reason,
+		Actor:    req.Actor,
+		Lot:      req.LotCode,
+	}, req.ExpectedVersion)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.TransferStockResponse{
+		Movement: toMovementProto(movement),
+		Product:  toProto(product),
+	}, nil
+}
+
+// ListStockMovements lists the stock ledger of a product, oldest first
+func (s *ProductService) ListStockMovements(ctx context.Context, req *pb.ListStockMovementsRequest) (*pb.ListStockMovementsResponse, error) {
+	page, err := s.uc.ListStockMovements(ctx, req.ProductId, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	movements := make([]*pb.StockMovement, len(page.Movements))
+	for i, m := range page.Movements {
+		movements[i] = toMovementProto(m)
+	}
+
+	return &pb.ListStockMovementsResponse{
+		Movements:     movements,
+		NextPageToken: page.NextPageToken,
 	}, nil
 }
+
+// ReserveStock holds available stock of a product for a while
+func (s *ProductService) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	// clamp before converting so an out of range TTL cannot overflow into a
+	// valid one, the use case rejects it
+	maxSeconds := int64(biz.MaxReservationTTL / time.Second)
+	ttl := time.Duration(min(max(req.TtlSeconds, -1), maxSeconds+1)) * time.Second
+	quantity, err := s.toQuantity(ctx, req.ProductId, req.Quantity, req.Measure)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	reservation, product, err := s.uc.ReserveStock(ctx, req.ProductId, req.WarehouseId, quantity, ttl, req.Actor)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ReserveStockResponse{
+		Reservation: toReservationProto(reservation),
+		Product:     toProto(product),
+	}, nil
+}
+
+// CommitReservation dispatches the stock held by a reservation
+func (s *ProductService) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+	reservation, movement, product, err := s.uc.CommitReservation(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CommitReservationResponse{
+		Reservation: toReservationProto(reservation),
+		Movement:    toMovementProto(movement),
+		Product:     toProto(product),
+	}, nil
+}
+
+// ReleaseReservation gives the stock held by a reservation back
+func (s *ProductService) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	reservation, product, err := s.uc.ReleaseReservation(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ReleaseReservationResponse{
+		Reservation: toReservationProto(reservation),
+		Product:     toProto(product),
+	}, nil
+}
+
+// toQuantity returns the quantity of a stock request in base units of the
+// product, converted from its measure when one is set
+func (s *ProductService) toQuantity(ctx context.Context, productID string, quantity int32, m *pb.Measure) (int32, error) {
+	if m == nil {
+		return quantity, nil
+	}
+	return s.uc.ToBaseQuantity(ctx, productID, "measure", toMeasure(m))
+}
+
+// toProto converts a biz product to its protobuf form
+func toProto(product *biz.Product) *pb.Product {
+	out := &pb.Product{
+		Id:          product.ID,
+		Sku:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price.Float(),
+		PriceMoney:  toMoneyProto(product.Price),
+		Quantity:    product.Quantity,
+		Reserved:    product.Reserved,
+		Available:   product.Available(),
+		Stock:       toStockProto(product.Levels()),
+		CategoryId:  product.CategoryID,
+		Units:       toUnitsProto(product.Units),
+		Batches:     toBatchesProto(product.Batches),
+		ParentId:    product.ParentID,
+		Attributes:  toAttributesProto(product.Attributes),
+		Allergens:   toAllergensProto(product.Allergens),
+		Nutrition:   toNutritionProto(product.Nutrition),
+		DietaryTags: toDietaryTagsProto(product.DietaryTags),
+		Barcodes:    toBarcodesProto(product.Barcodes),
+		Version:     product.Version,
+		CreatedAt:   product.CreatedAt.Unix(),
+		UpdatedAt:   product.UpdatedAt.Unix(),
+	}
+	if product.Deleted() {
+		out.DeletedAt = product.DeletedAt.Unix()
+	}
+	return out
+}
+
+// toMoney reads an amount from its Money field, or from the deprecated
+// double sent by older clients, which is in biz.LegacyCurrency
+func toMoney(field string, m *pb.Money, legacy float64) (biz.Money, error) {
+	if m != nil {
+		return biz.Money{Amount: m.AmountMinor, Currency: m.CurrencyCode}, nil
+	}
+	return biz.MoneyFromFloat(field, legacy, biz.LegacyCurrency)
+}
+
+// toMoneyProto converts a biz amount to its protobuf form
+func toMoneyProto(m biz.Money) *pb.Money {
+	return &pb.Money{
+		CurrencyCode: m.Currency,
+		AmountMinor:  m.Amount,
+	}
+}
+
+// toUnits reads units of measure, nil counts the product in
+// biz.DefaultBaseUnit
+func toUnits(u *pb.UnitsOfMeasure) biz.UnitsOfMeasure {
+	out := biz.UnitsOfMeasure{Base: u.GetBaseUnit()}
+	for _, c := range u.GetConversions() {
+		out.Conversions = append(out.Conversions, biz.UnitConversion{Code: c.Code, Factor: c.Factor, Fractional: c.Fractional})
+	}
+	return out
+}
+
+// toUnitsProto converts units of measure to their protobuf form
+func toUnitsProto(u biz.UnitsOfMeasure) *pb.UnitsOfMeasure {
+	out := &pb.UnitsOfMeasure{
+		BaseUnit:    u.BaseUnit(),
+		Conversions: make([]*pb.UnitConversion, len(u.Conversions)),
+	}
+	for i, c := range u.Conversions {
+		out.Conversions[i] = &pb.UnitConversion{Code: c.Code, Factor: c.Factor, Fractional: c.Fractional}
+	}
+	return out
+}
+
+func toMeasure(m *pb.Measure) biz.Measure {
+	return biz.Measure{Value: m.GetValue(), Unit: m.GetUnit()}
+}
+
+// toStockProto converts stock levels to their protobuf form, ordered by
+// warehouse ID
+func toStockProto(levels map[string]biz.StockLevel) []*pb.WarehouseStock {
+	stock := make([]*pb.WarehouseStock, 0, len(levels))
+	for _, id := range slices.Sorted(maps.Keys(levels)) {
+		l := levels[id]
+		stock = append(stock, &pb.WarehouseStock{
+			WarehouseId: id,
+			Quantity:    l.Quantity,
+			Reserved:    l.Reserved,
+			Available:   l.Available(),
+		})
+	}
+	return stock
+}
+
+// toMovementProto converts a biz stock movement to its protobuf form
+func toMovementProto(m *biz.StockMovement) *pb.StockMovement {
+	return &pb.StockMovement{
+		Id:            m.ID,
+		ProductId:     m.ProductID,
+		WarehouseId:   m.WarehouseID,
+		ToWarehouseId: m.ToWarehouseID,
+		Kind:          string(m.Kind),
+		Delta:         m.Delta,
+		Lots:          toLotsProto(m.Lots),
+		Balance:       m.Balance,
+		Reason:        m.Reason,
+		Actor:         m.Actor,
+		Version:       m.Version,
+		CreatedAt:     m.CreatedAt.Unix(),
+	}
+}
+
+// toReservationProto converts a biz reservation to its protobuf form
+func toReservationProto(r *biz.Reservation) *pb.Reservation {
+	return &pb.Reservation{
+		Id:          r.ID,
+		ProductId:   r.ProductID,
+		WarehouseId: r.WarehouseID,
+		Quantity:    r.Quantity,
+		Lots:        toLotsProto(r.Lots),
+		Status:      string(r.Status),
+		Actor:       r.Actor,
+		ExpiresAt:   r.ExpiresAt.Unix(),
+		CreatedAt:   r.CreatedAt.Unix(),
+		UpdatedAt:   r.UpdatedAt.Unix(),
+	}
+}
+
+// versionViolation is the PreconditionFailure violation type marking a
+// stale expected version, the gateway answers it with 412 and the other
+// failed preconditions with 409
+const versionViolation = "VERSION"
+
+// toStatus translates a domain error to a gRPC status, invalid fields are
+// attached as a BadRequest detail and a stale expected version as a
+// PreconditionFailure
+func toStatus(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	var code codes.Code
+	switch biz.KindOf(err) {
+	case biz.KindNotFound:
+		code = codes.NotFound
+	case biz.KindInvalidArgument:
+		code = codes.InvalidArgument
+	case biz.KindConflict:
+		code = codes.Aborted
+	case biz.KindAlreadyExists:
+		code = codes.AlreadyExists
+	case biz.KindInvalidState, biz.KindPreconditionFailed:
+		code = codes.FailedPrecondition
+	case biz.KindUnavailable:
+		code = codes.Unavailable
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	st := status.New(code, err.Error())
+	if biz.KindOf(err) == biz.KindPreconditionFailed {
+		pf := &errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        versionViolation,
+			Description: err.Error(),
+		}}}
+		if withDetails, err := st.WithDetails(pf); err == nil {
+			st = withDetails
+		}
+	}
+	violations := biz.FieldViolations(err)
+	if len(violations) == 0 {
+		return st.Err()
+	}
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	if withDetails, err := st.WithDetails(br); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// toPatch reads the fields to change from the update mask, or from field
+// presence when no mask is given
+func toPatch(req *pb.UpdateProductRequest) (biz.ProductPatch, error) {
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		patch := biz.ProductPatch{
+			Name:        req.Name,
+			Description: req.Description,
+			Quantity:    req.Quantity,
+			CategoryID:  req.CategoryId,
+		}
+		if req.Units != nil {
+			units := toUnits(req.Units)
+			patch.Units = &units
+		}
+		if len(req.Attributes) > 0 {
+			attrs, err := toAttributes(req.Attributes)
+			if err != nil {
+				return biz.ProductPatch{}, err
+			}
+			patch.Attributes = &attrs
+		}
+		if len(req.Allergens) > 0 {
+			allergens, err := toAllergens(req.Allergens)
+			if err != nil {
+				return biz.ProductPatch{}, err
+			}
+			patch.Allergens = &allergens
+		}
+		if req.Nutrition != nil {
+			nutrition := toNutrition(req.Nutrition)
+			patch.Nutrition = &nutrition
+		}
+		if len(req.DietaryTags) > 0 {
+			tags := toDietaryTags(req.DietaryTags)
+			patch.DietaryTags = &tags
+		}
+		if len(req.Barcodes) > 0 {
+			barcodes := toBarcodes(req.Barcodes)
+			patch.Barcodes = &barcodes
+		}
+		if req.PriceMoney != nil || req.Price != nil {
+			price, err := toMoney("price", req.PriceMoney, req.GetPrice())
+			if err != nil {
+				return biz.ProductPatch{}, err
+			}
+			patch.Price = &price
+		}
+		return patch, nil
+	}
+
+	var patch biz.ProductPatch
+	for _, path := range paths {
+		switch path {
+		case "name":
+			patch.Name = proto.String(req.GetName())
+		case "description":
+			patch.Description = proto.String(req.GetDescription())
+		case "price":
+			price, err := toMoney("price", req.PriceMoney, req.GetPrice())
+			if err != nil {
+				return biz.ProductPatch{}, err
+			}
+			patch.Price = &price
+		case "quantity":
+			patch.Quantity = proto.Int32(req.GetQuantity())
+		case "category_id":
+			patch.CategoryID = proto.String(req.GetCategoryId())
+		case "units":
+			units := toUnits(req.Units)
+			patch.Units = &units
+		case "attributes":
+			attrs, err := toAttributes(req.Attributes)
+			if err != nil {
+				return biz.ProductPatch{}, err
+			}
+			patch.Attributes = &attrs
+		case "allergens":
+			allergens, err := toAllergens(req.Allergens)
+			if err != nil {
+				return biz.ProductPatch{}, err
+			}
+			patch.Allergens = &allergens
+		case "nutrition":
+			nutrition := toNutrition(req.Nutrition)
+			patch.Nutrition = &nutrition
+		case "dietary_tags":
+			tags := toDietaryTags(req.DietaryTags)
+			patch.DietaryTags = &tags
+		case "barcodes":
+			barcodes := toBarcodes(req.Barcodes)
+			patch.Barcodes = &barcodes
+		default:
+			return biz.ProductPatch{}, biz.InvalidArgument("update_mask", "unknown field %q", path)
+		}
+	}
+	return patch, nil
+}
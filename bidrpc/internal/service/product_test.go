@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+		// fields are the BadRequest field violations, version tells whether
+		// a VERSION precondition failure is attached
+		fields  []string
+		version bool
+	}{
+		{biz.ErrProductNotFound, codes.NotFound, nil, false},
+		{fmt.Errorf("linking: %w", biz.ErrSupplierNotFound), codes.NotFound, nil, false},
+		{biz.InvalidArgument("name", "is required"), codes.InvalidArgument, []string{"name"}, false},
+		{biz.InvalidArguments(biz.InvalidArgument("name", "is required"), biz.InvalidArgument("price", "must not be negative")),
+			codes.InvalidArgument, []string{"name", "price"}, false},
+		{biz.ErrVersionConflict, codes.Aborted, nil, false},
+		{biz.ErrBarcodeInUse, codes.AlreadyExists, nil, false},
+		{biz.ErrSKUInUse, codes.AlreadyExists, nil, false},
+		{biz.ErrProductHasVariants, codes.FailedPrecondition, nil, false},
+		{biz.ErrReservationClosed, codes.FailedPrecondition, nil, false},
+		{biz.PreconditionFailed("p1", 3), codes.FailedPrecondition, nil, true},
+		{biz.ErrSearchUnavailable, codes.Unavailable, nil, false},
+		{context.Canceled, codes.Canceled, nil, false},
+		{fmt.Errorf("saving: %w", context.DeadlineExceeded), codes.DeadlineExceeded, nil, false},
+		{errors.New("disk full"), codes.Internal, nil, false},
+	}
+	for _, tt := range tests {
+		st := status.Convert(toStatus(tt.err))
+		if st.Code() != tt.code {
+			t.Errorf("toStatus(%v) = %v, want %v", tt.err, st.Code(), tt.code)
+		}
+		var fields []string
+		var version bool
+		for _, d := range st.Details() {
+			switch d := d.(type) {
+			case *errdetails.BadRequest:
+				for _, v := range d.GetFieldViolations() {
+					fields = append(fields, v.GetField())
+				}
+			case *errdetails.PreconditionFailure:
+				version = slices.ContainsFunc(d.GetViolations(), func(v *errdetails.PreconditionFailure_Violation) bool {
+					return v.GetType() == versionViolation
+				})
+			}
+		}
+		if !slices.Equal(fields, tt.fields) {
+			t.Errorf("toStatus(%v) has the field violations %v, want %v", tt.err, fields, tt.fields)
+		}
+		if version != tt.version {
+			t.Errorf("toStatus(%v) VERSION precondition failure = %v, want %v", tt.err, version, tt.version)
+		}
+	}
+}
+
+func TestToPatch(t *testing.T) {
+	// with a mask the listed fields are set, empty ones cleared
+	patch, err := toPatch(&pb.UpdateProductRequest{
+		Name:        proto.String("Brie"),
+		Description: proto.String("ignored, not in the mask"),
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"name", "category_id", "attributes"}},
+	})
+	if err != nil {
+		t.Fatalf("toPatch failed: %v", err)
+	}
+	if patch.Name == nil || *patch.Name != "Brie" || patch.Description != nil || patch.CategoryID == nil || *patch.CategoryID != "" ||
+		patch.Attributes == nil || len(*patch.Attributes) != 0 || patch.Price != nil || patch.Quantity != nil {
+		t.Errorf("unexpected patch %+v", patch)
+	}
+
+	// without one the fields present are set
+	patch, err = toPatch(&pb.UpdateProductRequest{Description: proto.String(""), Quantity: proto.Int32(4)})
+	if err != nil {
+		t.Fatalf("toPatch failed: %v", err)
+	}
+	if patch.Name != nil || patch.Description == nil || *patch.Description != "" || patch.Quantity == nil || *patch.Quantity != 4 {
+		t.Errorf("unexpected patch %+v", patch)
+	}
+
+	_, err = toPatch(&pb.UpdateProductRequest{UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "version"}}})
+	st := status.Convert(toStatus(err))
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("an unknown mask path should be InvalidArgument, got %v", st)
+	}
+	if v := biz.FieldViolations(err); len(v) != 1 || v[0].Field != "update_mask" {
+		t.Errorf("expected an update_mask violation, got %v", err)
+	}
+}
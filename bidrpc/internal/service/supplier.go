@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// SupplierService implements the gRPC SupplierService
+type SupplierService struct {
+	pb.UnimplementedSupplierServiceServer
+	uc *biz.SupplierUseCase
+}
+
+// NewSupplierService creates a new supplier service
+func NewSupplierService(uc *biz.SupplierUseCase) *SupplierService {
+	return &SupplierService{
+		uc: uc,
+	}
+}
+
+// CreateSupplier creates a new supplier
+func (s *SupplierService) CreateSupplier(ctx context.Context, req *pb.CreateSupplierRequest) (*pb.CreateSupplierResponse, error) {
+	supplier, err := s.uc.CreateSupplier(ctx, biz.SupplierInput{
+		Name:         req.Name,
+		ContactName:  req.ContactName,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		LeadTimeDays: req.LeadTimeDays,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CreateSupplierResponse{
+		Supplier: toSupplierProto(supplier),
+	}, nil
+}
+
+// GetSupplier retrieves a supplier by ID
+func (s *SupplierService) GetSupplier(ctx context.Context, req *pb.GetSupplierRequest) (*pb.GetSupplierResponse, error) {
+	supplier, err := s.uc.GetSupplier(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GetSupplierResponse{
+		Supplier: toSupplierProto(supplier),
+	}, nil
+}
+
+// ListSuppliers lists every supplier ordered by name
+func (s *SupplierService) ListSuppliers(ctx context.Context, req *pb.ListSuppliersRequest) (*pb.ListSuppliersResponse, error) {
+	suppliers, err := s.uc.ListSuppliers(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbSuppliers := make([]*pb.Supplier, len(suppliers))
+	for i, supplier := range suppliers {
+		pbSuppliers[i] = toSupplierProto(supplier)
+	}
+
+	return &pb.ListSuppliersResponse{
+		Suppliers: pbSuppliers,
+	}, nil
+}
+
+// UpdateSupplier replaces the details of a supplier
+func (s *SupplierService) UpdateSupplier(ctx context.Context, req *pb.UpdateSupplierRequest) (*pb.UpdateSupplierResponse, error) {
+	supplier, err := s.uc.UpdateSupplier(ctx, req.Id, biz.SupplierInput{
+		Name:         req.Name,
+		ContactName:  req.ContactName,
+		Email:        req.Email,
+		Phone:        req.Phone,
+		LeadTimeDays: req.LeadTimeDays,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.UpdateSupplierResponse{
+		Supplier: toSupplierProto(supplier),
+	}, nil
+}
+
+// DeleteSupplier deletes a supplier no product is linked to
+func (s *SupplierService) DeleteSupplier(ctx context.Context, req *pb.DeleteSupplierRequest) (*pb.DeleteSupplierResponse, error) {
+	if err := s.uc.DeleteSupplier(ctx, req.Id); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.DeleteSupplierResponse{
+		Success: true,
+	}, nil
+}
+
+// LinkProductSupplier links a product to a supplier
+func (s *SupplierService) LinkProductSupplier(ctx context.Context, req *pb.LinkProductSupplierRequest) (*pb.LinkProductSupplierResponse, error) {
+	cost, err := toMoney("cost_price", req.CostPriceMoney, req.CostPrice)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	link, err := s.uc.LinkProductSupplier(ctx, biz.ProductSupplierInput{
+		ProductID:   req.ProductId,
+		SupplierID:  req.SupplierId,
+		SupplierSKU: req.SupplierSku,
+		CostPrice:   cost,
+		Preferred:   req.Preferred,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.LinkProductSupplierResponse{
+		ProductSupplier: toProductSupplierProto(link, nil),
+	}, nil
+}
+
+// ListProductSuppliers lists the suppliers of a product with their details
+func (s *SupplierService) ListProductSuppliers(ctx context.Context, req *pb.ListProductSuppliersRequest) (*pb.ListProductSuppliersResponse, error) {
+	links, err := s.uc.ListProductSuppliers(ctx, req.ProductId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	suppliers, err := s.uc.ListSuppliers(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	byID := make(map[string]*biz.Supplier, len(suppliers))
+	for _, supplier := range suppliers {
+		byID[supplier.ID] = supplier
+	}
+
+	pbLinks := make([]*pb.ProductSupplier, len(links))
+	for i, link := range links {
+		pbLinks[i] = toProductSupplierProto(link, byID[link.SupplierID])
+	}
+
+	return &pb.ListProductSuppliersResponse{
+		ProductSuppliers: pbLinks,
+	}, nil
+}
+
+// UnlinkProductSupplier removes a supplier from the sources of a product
+func (s *SupplierService) UnlinkProductSupplier(ctx context.Context, req *pb.UnlinkProductSupplierRequest) (*pb.UnlinkProductSupplierResponse, error) {
+	if err := s.uc.UnlinkProductSupplier(ctx, req.ProductId, req.SupplierId); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.UnlinkProductSupplierResponse{
+		Success: true,
+	}, nil
+}
+
+// toSupplierProto converts a biz supplier to its protobuf form
+func toSupplierProto(s *biz.Supplier) *pb.Supplier {
+	return &pb.Supplier{
+		Id:           s.ID,
+		Name:         s.Name,
+		ContactName:  s.ContactName,
+		Email:        s.Email,
+		Phone:        s.Phone,
+		LeadTimeDays: s.LeadTimeDays,
+		CreatedAt:    s.CreatedAt.Unix(),
+		UpdatedAt:    s.UpdatedAt.Unix(),
+	}
+}
+
+// toProductSupplierProto converts a biz supplier link to its protobuf form,
+// supplier may be nil
+func toProductSupplierProto(l *biz.ProductSupplier, supplier *biz.Supplier) *pb.ProductSupplier {
+	out := &pb.ProductSupplier{
+		ProductId:      l.ProductID,
+		SupplierId:     l.SupplierID,
+		SupplierSku:    l.SupplierSKU,
+		CostPrice:      l.CostPrice.Float(),
+		CostPriceMoney: toMoneyProto(l.CostPrice),
+		Preferred:      l.Preferred,
+		CreatedAt:      l.CreatedAt.Unix(),
+		UpdatedAt:      l.UpdatedAt.Unix(),
+	}
+	if supplier != nil {
+		out.Supplier = toSupplierProto(supplier)
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/athxx/bidfood/bidrpc/bidrpcproto"
+	"github.com/athxx/bidfood/bidrpc/internal/biz"
+)
+
+// CreateWarehouse creates a new warehouse
+func (s *ProductService) CreateWarehouse(ctx context.Context, req *pb.CreateWarehouseRequest) (*pb.CreateWarehouseResponse, error) {
+	w, err := s.uc.CreateWarehouse(ctx, req.Name, req.Address)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.CreateWarehouseResponse{
+		Warehouse: toWarehouseProto(w),
+	}, nil
+}
+
+// GetWarehouse retrieves a warehouse by ID
+func (s *ProductService) GetWarehouse(ctx context.Context, req *pb.GetWarehouseRequest) (*pb.GetWarehouseResponse, error) {
+	w, err := s.uc.GetWarehouse(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.GetWarehouseResponse{
+		Warehouse: toWarehouseProto(w),
+	}, nil
+}
+
+// ListWarehouses lists every warehouse ordered by name
+func (s *ProductService) ListWarehouses(ctx context.Context, req *pb.ListWarehousesRequest) (*pb.ListWarehousesResponse, error) {
+	warehouses, err := s.uc.ListWarehouses(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	pbWarehouses := make([]*pb.Warehouse, len(warehouses))
+	for i, w := range warehouses {
+		pbWarehouses[i] = toWarehouseProto(w)
+	}
+
+	return &pb.ListWarehousesResponse{
+		Warehouses: pbWarehouses,
+	}, nil
+}
+
+// UpdateWarehouse replaces the name and address of a warehouse
+func (s *ProductService) UpdateWarehouse(ctx context.Context, req *pb.UpdateWarehouseRequest) (*pb.UpdateWarehouseResponse, error) {
+	w, err := s.uc.UpdateWarehouse(ctx, req.Id, req.Name, req.Address)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.UpdateWarehouseResponse{
+		Warehouse: toWarehouseProto(w),
+	}, nil
+}
+
+// DeleteWarehouse deletes a warehouse that holds no stock
+func (s *ProductService) DeleteWarehouse(ctx context.Context, req *pb.DeleteWarehouseRequest) (*pb.DeleteWarehouseResponse, error) {
+	if err := s.uc.DeleteWarehouse(ctx, req.Id); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.DeleteWarehouseResponse{
+		Success: true,
+	}, nil
+}
+
+// toWarehouseProto converts a biz warehouse to its protobuf form
+func toWarehouseProto(w *biz.Warehouse) *pb.Warehouse {
+	return &pb.Warehouse{
+		Id:        w.ID,
+		Name:      w.Name,
+		Address:   w.Address,
+		CreatedAt: w.CreatedAt.Unix(),
+		UpdatedAt: w.UpdatedAt.Unix(),
+	}
+}
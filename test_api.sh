@@ -22,8 +22,8 @@ curl "http://localhost:8080/products/$ID"
 
 echo -e "\n=== UpdateProduct  ==="
 curl "http://localhost:8080/products/$ID" \
--X POST \
--H 'content-type: application/json' \
+-X PATCH \
+-H 'content-type: application/merge-patch+json' \
 -d '{"price": 100}'
 
 echo -e "\n=== DeleteProduct  ==="
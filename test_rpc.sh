@@ -27,6 +27,10 @@ echo -e "\n=== UpdateProduct  ==="
 DATA='{"id":1,"name":"iPhone 15 Pro Max","description":"Updated desc","price":1099.99,"quantity":30}'
 grpcurl -plaintext -proto $PROTO -d "$DATA" $SVR $SVC/UpdateProduct
 
+echo -e "\n=== UpdateProduct, quantity and description only (cleared) ==="
+DATA='{"id":"6584f023-9cfd-4fe0-b613-2b2ecf00fa4c","quantity":25,"update_mask":"quantity,description"}'
+grpcurl -plaintext -proto $PROTO -d "$DATA" $SVR $SVC/UpdateProduct
+
 
 echo -e "\n=== DeleteProduct  ==="
 DATA='{"id":"6584f023-9cfd-4fe0-b613-2b2ecf00fa4c"}'